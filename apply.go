@@ -0,0 +1,679 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/agent"
+	"github.com/celloopa/ghosted/internal/model"
+	"github.com/celloopa/ghosted/internal/store"
+	"github.com/charmbracelet/x/term"
+)
+
+const applyUsage = "Usage: ghosted apply <posting-file | -> [--dry-run] [--auto-approve] [--auto-revise N] [--tone T] [--cover-style S] [--format F] [--reviewer-cv PATH] [--explain] [--preview-cover] [--force]\n" +
+	"       ghosted apply <posting-file> --resume [flags]\n" +
+	"       ghosted apply <posting-file> --parse-only\n" +
+	"       ghosted apply <posting-file> --emit-prompts [--tone T] [--cover-style S] [--format F] [--reviewer-cv PATH] [--attach-posting]\n" +
+	"       ghosted apply <posting-file> --json-output [flags]\n" +
+	"       ghosted apply --dir <folder> [--skip-existing] [--concurrency N] [flags]\n" +
+	"       ghosted apply --since-cv-change [--concurrency N] [flags]\n" +
+	"       ghosted apply --prune-state [--older-than DAYS]"
+
+// applyOptions holds the flags shared by single and batch apply runs
+type applyOptions struct {
+	dryRun      bool
+	autoApprove bool
+	autoRevise  int
+	tone        string
+	coverStyle  string
+	jsonOutput  bool
+	// format is the document format, typst or markdown; empty uses the
+	// config default
+	format string
+	// discardFailedState removes a failed run's state instead of keeping
+	// it for pipeline list
+	discardFailedState bool
+	// attachPosting includes the raw posting text in the generation prompts
+	attachPosting bool
+	// explain prints the reviewer's per-criterion score breakdown
+	explain bool
+	// force applies even past the per-company active application limit
+	force bool
+	// reviewerCV is the CV the reviewer verifies against instead of the
+	// generation CV
+	reviewerCV string
+	// previewCover prints the generated cover letter's text after the run
+	previewCover bool
+	// resume continues the posting's failed run from the step it failed at
+	// instead of starting over
+	resume bool
+}
+
+// trackedPosting is a posting skipped because it already has a tracker entry
+type trackedPosting struct {
+	Path string
+	App  *model.Application
+}
+
+// cmdApply runs the full pipeline on a job posting, or on every posting in a
+// folder with --dir
+func cmdApply(s *store.Store, args []string) {
+	if len(args) < 1 {
+		fmt.Fprintln(os.Stderr, applyUsage)
+		os.Exit(1)
+	}
+
+	// Parse arguments
+	var postingPath, dir string
+	var opts applyOptions
+	skipExisting := false
+	parseOnly := false
+	emitPrompts := false
+	concurrency := 0
+	pruneState := false
+	sinceCVChange := false
+	pruneAge := defaultPruneAge
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch arg {
+		case "--dry-run":
+			opts.dryRun = true
+		case "--auto-approve":
+			opts.autoApprove = true
+		case "--skip-existing":
+			skipExisting = true
+		case "--parse-only":
+			parseOnly = true
+		case "--emit-prompts":
+			emitPrompts = true
+		case "--json-output":
+			opts.jsonOutput = true
+		case "--keep-failed-state", "--keep-failed-state=true":
+			opts.discardFailedState = false
+		case "--keep-failed-state=false":
+			opts.discardFailedState = true
+		case "--attach-posting":
+			opts.attachPosting = true
+		case "--explain":
+			opts.explain = true
+		case "--preview-cover":
+			opts.previewCover = true
+		case "--force":
+			opts.force = true
+		case "--resume":
+			opts.resume = true
+		case "--prune-state":
+			pruneState = true
+		case "--since-cv-change":
+			sinceCVChange = true
+		case "--older-than":
+			if i+1 < len(args) {
+				days, err := strconv.Atoi(strings.TrimSuffix(args[i+1], "d"))
+				if err != nil || days < 0 {
+					fmt.Fprintf(os.Stderr, "Error: --older-than expects a number of days, got %q\n", args[i+1])
+					os.Exit(1)
+				}
+				pruneAge = time.Duration(days) * 24 * time.Hour
+				i++
+			}
+		case "--auto-revise":
+			if i+1 < len(args) {
+				n, err := strconv.Atoi(args[i+1])
+				if err != nil || n < 0 {
+					fmt.Fprintf(os.Stderr, "Error: --auto-revise expects a non-negative number, got %q\n", args[i+1])
+					os.Exit(1)
+				}
+				opts.autoRevise = n
+				i++
+			}
+		case "--tone":
+			if i+1 < len(args) {
+				if err := agent.ValidateTone(args[i+1]); err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+					os.Exit(1)
+				}
+				opts.tone = args[i+1]
+				i++
+			}
+		case "--cover-style":
+			if i+1 < len(args) {
+				if err := agent.ValidateCoverStyle(args[i+1]); err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+					os.Exit(1)
+				}
+				opts.coverStyle = args[i+1]
+				i++
+			}
+		case "--format":
+			if i+1 < len(args) {
+				if err := agent.ValidateDocumentFormat(args[i+1]); err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+					os.Exit(1)
+				}
+				opts.format = args[i+1]
+				i++
+			}
+		case "--reviewer-cv":
+			if i+1 < len(args) {
+				if !fileExists(args[i+1]) {
+					fmt.Fprintf(os.Stderr, "Error: reviewer CV not found: %s\n", args[i+1])
+					os.Exit(1)
+				}
+				opts.reviewerCV = args[i+1]
+				i++
+			}
+		case "--concurrency":
+			if i+1 < len(args) {
+				n, err := strconv.Atoi(args[i+1])
+				if err != nil || n < 1 {
+					fmt.Fprintf(os.Stderr, "Error: --concurrency expects a positive number, got %q\n", args[i+1])
+					os.Exit(1)
+				}
+				concurrency = n
+				i++
+			}
+		case "--dir":
+			if i+1 < len(args) {
+				dir = args[i+1]
+				i++
+			}
+		case stdinPosting:
+			if postingPath == "" {
+				postingPath = arg
+			}
+		default:
+			if postingPath == "" && !isFlag(arg) {
+				postingPath = arg
+			}
+		}
+	}
+
+	if pruneState {
+		if postingPath != "" || dir != "" {
+			fmt.Fprintln(os.Stderr, "Error: --prune-state runs on its own, without a posting or --dir")
+			os.Exit(1)
+		}
+		if err := pruneStates(os.Stdout, pruneAge); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := checkPipelineConfig(os.Stderr, pipelineConfigPath); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if parseOnly && dir != "" {
+		fmt.Fprintln(os.Stderr, "Error: --parse-only takes a single posting file, not --dir")
+		os.Exit(1)
+	}
+	if emitPrompts && dir != "" {
+		fmt.Fprintln(os.Stderr, "Error: --emit-prompts takes a single posting file, not --dir")
+		os.Exit(1)
+	}
+	if opts.jsonOutput && dir != "" {
+		fmt.Fprintln(os.Stderr, "Error: --json-output takes a single posting file, not --dir")
+		os.Exit(1)
+	}
+	if opts.resume && (dir != "" || sinceCVChange || parseOnly || emitPrompts) {
+		fmt.Fprintln(os.Stderr, "Error: --resume takes a single posting file, without --dir, --since-cv-change, or --parse-only/--emit-prompts")
+		os.Exit(1)
+	}
+	if opts.previewCover && (dir != "" || opts.jsonOutput) {
+		fmt.Fprintln(os.Stderr, "Error: --preview-cover takes a single posting file, without --dir or --json-output")
+		os.Exit(1)
+	}
+
+	if sinceCVChange {
+		if postingPath != "" || dir != "" || parseOnly || emitPrompts || opts.jsonOutput {
+			fmt.Fprintln(os.Stderr, "Error: --since-cv-change runs on its own, without a posting, --dir, or output modes")
+			os.Exit(1)
+		}
+		regenerateSinceCVChange(s, opts, max(concurrency, 1))
+		return
+	}
+
+	if concurrency > 0 && dir == "" {
+		fmt.Fprintln(os.Stderr, "Error: --concurrency only applies to --dir batches and --since-cv-change")
+		os.Exit(1)
+	}
+
+	if dir != "" {
+		applyDir(s, dir, opts, skipExisting, max(concurrency, 1))
+		return
+	}
+
+	if postingPath == "" {
+		fmt.Fprintln(os.Stderr, "Error: posting file is required")
+		fmt.Fprintln(os.Stderr, applyUsage)
+		os.Exit(1)
+	}
+
+	if postingPath == stdinPosting {
+		saved, err := savePostingFromStdin(os.Stdin, stdinPostingsDir, time.Now())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "Saved posting from stdin to %s\n", saved)
+		postingPath = saved
+	}
+
+	// Check if file exists
+	if _, err := os.Stat(postingPath); os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "Error: file not found: %s\n", postingPath)
+		os.Exit(1)
+	}
+
+	if parseOnly {
+		if err := printParsed(os.Stdout, pipelineConfigPath, postingPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if emitPrompts {
+		if err := printPrompts(os.Stdout, pipelineConfigPath, postingPath, opts); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if skipExisting {
+		if app := agent.FindTracked(s, postingPath); app != nil {
+			printSkipped(trackedPosting{Path: postingPath, App: app})
+			return
+		}
+	}
+
+	if err := applyPosting(s, postingPath, opts); err != nil {
+		os.Exit(1)
+	}
+}
+
+// applyDir runs the pipeline on every supported posting in a folder, up to
+// concurrency at a time. Failures are reported and the batch continues.
+func applyDir(s *store.Store, dir string, opts applyOptions, skipExisting bool, concurrency int) {
+	postings, err := collectPostings(dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading postings folder: %v\n", err)
+		os.Exit(1)
+	}
+	if len(postings) == 0 {
+		fmt.Printf("No postings found in %s\n", dir)
+		return
+	}
+
+	if skipExisting {
+		var skipped []trackedPosting
+		postings, skipped = filterTracked(s, postings)
+		for _, sp := range skipped {
+			printSkipped(sp)
+		}
+	}
+
+	if len(postings) == 0 {
+		fmt.Println("Nothing to apply.")
+		return
+	}
+
+	fmt.Printf("Applying %d posting(s) from %s with concurrency %d\n", len(postings), dir, concurrency)
+	if opts.dryRun {
+		fmt.Println("Mode: dry-run (documents are written, but no tracker entries are created)")
+	}
+
+	progress := newBatchProgress(os.Stdout, len(postings), term.IsTerminal(os.Stdout.Fd()))
+	failed := runBatch(postings, concurrency, progress, func(postingPath string) (string, error) {
+		return applyQuiet(s, postingPath, opts, progress)
+	})
+	progress.summary()
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// printRunSummary writes the pipeline run as a single JSON object
+func printRunSummary(w io.Writer, pipeline *agent.Pipeline) error {
+	output, err := json.MarshalIndent(pipeline.Summary(), "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(output))
+	return err
+}
+
+// pipelineConfigPath is the agent pipeline configuration file
+var pipelineConfigPath = filepath.Join("local", "document-generation", ".agent", "config.json")
+
+// checkPipelineConfig warns on w when the pipeline config at path is
+// missing, since apply then silently runs with the defaults, and returns an
+// error when the config exists but can't run a pipeline
+func checkPipelineConfig(w io.Writer, path string) error {
+	config, err := agent.LoadConfig(path)
+	if os.IsNotExist(err) {
+		fmt.Fprintf(w, "Warning: no pipeline config at %s, using the defaults. Run 'ghosted init' to create one you can edit.\n", path)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading pipeline config %s: %w", path, err)
+	}
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("invalid pipeline config %s: %w", path, err)
+	}
+	return nil
+}
+
+// newApplyPipeline creates the pipeline for one posting, configured from
+// the apply flags. Single and batch runs share it so every flag applies to
+// both.
+func newApplyPipeline(s *store.Store, opts applyOptions) (*agent.Pipeline, error) {
+	// For dry run, don't pass the store (prevents tracker entry)
+	var pipelineStore *store.Store
+	if !opts.dryRun {
+		pipelineStore = s
+	}
+
+	pipeline, err := agent.NewPipeline(pipelineConfigPath, pipelineStore)
+	if err != nil {
+		return nil, fmt.Errorf("creating pipeline: %w", err)
+	}
+	pipeline.AutoRevise = opts.autoRevise
+	pipeline.Tone = opts.tone
+	pipeline.CoverStyle = opts.coverStyle
+	pipeline.Format = opts.format
+	pipeline.CVPath = draftCVPath()
+	pipeline.ReviewerCVPath = opts.reviewerCV
+	pipeline.DiscardFailedState = opts.discardFailedState
+	pipeline.AttachPosting = opts.attachPosting
+	pipeline.LLM = agent.NewLLMClient(pipeline.Config)
+	if pipeline.Rubric, err = agent.LoadRubric(defaultRubricPath); err != nil {
+		return nil, err
+	}
+	return pipeline, nil
+}
+
+// runApply runs the pipeline on postingPath, or with --resume continues the
+// posting's failed run
+func runApply(pipeline *agent.Pipeline, postingPath string, opts applyOptions) error {
+	if opts.resume {
+		return pipeline.Resume(postingPath)
+	}
+	return pipeline.Run(postingPath)
+}
+
+// applyPosting runs the pipeline on a single posting and prints its status.
+// Errors are printed before being returned.
+func applyPosting(s *store.Store, postingPath string, opts applyOptions) error {
+	pipeline, err := newApplyPipeline(s, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return err
+	}
+
+	if !opts.dryRun {
+		if err := checkPostingCadence(s, pipeline, postingPath, opts.force); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return err
+		}
+	}
+
+	if opts.jsonOutput {
+		runErr := runApply(pipeline, postingPath, opts)
+		if err := printRunSummary(os.Stdout, pipeline); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing summary: %v\n", err)
+			return err
+		}
+		return runErr
+	}
+
+	if opts.resume {
+		fmt.Printf("Resuming pipeline on: %s\n", postingPath)
+	} else {
+		fmt.Printf("Running pipeline on: %s\n", postingPath)
+	}
+	if opts.dryRun {
+		fmt.Println("Mode: dry-run (documents are written, but no tracker entry is created)")
+	}
+	if opts.autoApprove {
+		fmt.Println("Mode: auto-approve (skipping review confirmation)")
+	}
+	if opts.autoRevise > 0 {
+		fmt.Printf("Mode: auto-revise (up to %d revision(s) on rejection)\n", opts.autoRevise)
+	}
+	if opts.tone != "" {
+		fmt.Printf("Cover letter tone: %s\n", opts.tone)
+	}
+	if opts.coverStyle != "" {
+		fmt.Printf("Cover letter style: %s\n", opts.coverStyle)
+	}
+	if opts.format != "" {
+		fmt.Printf("Document format: %s\n", opts.format)
+	}
+	if pipeline.LLM == nil {
+		fmt.Printf("Mode: drafting from the CV (set %s to generate with a model)\n", agent.APIKeyEnv(pipeline.Config.Provider))
+		if opts.autoRevise > 0 {
+			fmt.Println("Note: --auto-revise needs a model; a rejected draft from the CV ends the run")
+		}
+	}
+	if opts.reviewerCV != "" {
+		fmt.Printf("Reviewer CV: %s\n", opts.reviewerCV)
+	}
+	fmt.Println()
+
+	// Run pipeline
+	if err := runApply(pipeline, postingPath, opts); err != nil {
+		fmt.Fprintf(os.Stderr, "\nPipeline failed: %v\n", err)
+		fmt.Println("\n" + pipeline.GetStatus())
+		if opts.explain {
+			printScoreBreakdown(os.Stdout, pipeline.DetailedReview())
+		}
+		return err
+	}
+
+	if parsed := pipeline.ParsedPosting(); parsed != nil {
+		warnSalary(model.Application{
+			Company:         parsed.Company,
+			Position:        parsed.Position,
+			SalaryMin:       parsed.SalaryMin,
+			SalaryMax:       parsed.SalaryMax,
+			SalaryEstimated: parsed.SalaryEstimated,
+		}, minAcceptableSalary(pipeline.Config))
+		warnLanguage(parsed.Language)
+	}
+	if docs := pipeline.Documents(); docs != nil {
+		warnDocumentPages(docs)
+	}
+
+	// Output status
+	fmt.Println("\n" + pipeline.GetStatus())
+	if pipeline.Revisions > 0 {
+		fmt.Printf("Revisions: %d\n", pipeline.Revisions)
+	}
+	if opts.explain {
+		printScoreBreakdown(os.Stdout, pipeline.DetailedReview())
+	}
+
+	if opts.previewCover {
+		printCoverPreview(os.Stdout, pipeline.Documents())
+	}
+
+	if summary := pipeline.Summary(); summary.Status == "rejected" {
+		fmt.Printf("\nDocuments rejected by the reviewer, so no application was added. Feedback saved to: %s\n", summary.FeedbackPath)
+	} else if opts.dryRun {
+		if docs := pipeline.Documents(); docs != nil {
+			printDocuments(os.Stdout, docs)
+		}
+		fmt.Println("\nDry run complete. No application was added to tracker.")
+	} else {
+		fmt.Println("\nApplication added to tracker. Run 'ghosted list' to view.")
+	}
+	return nil
+}
+
+// printScoreBreakdown shows how each review criterion contributed to the
+// overall score. review may be nil.
+func printScoreBreakdown(w io.Writer, review *agent.DetailedReviewResult) {
+	if review == nil || review.ScoreBreakdown == nil {
+		fmt.Fprintln(w, "\nNo score breakdown: the review didn't include one.")
+		return
+	}
+	fmt.Fprintf(w, "\nScore breakdown (overall %d/100):\n", review.OverallScore)
+	for _, c := range review.ScoreBreakdown.Criteria() {
+		fmt.Fprintf(w, "  %-22s %3d/100 x %2d%% = %4.1f\n", c.Name, c.Score, c.Weight, float64(c.Score*c.Weight)/100)
+	}
+	fmt.Fprintf(w, "  %-22s %29d\n", "Weighted total", review.ScoreBreakdown.Weighted())
+}
+
+// defaultRubricPath is the reviewer rubric file; without one the reviewer
+// uses its built-in rubric
+var defaultRubricPath = filepath.Join("local", "reviewer-rubric.json")
+
+// draftCVPath returns the CV the pipeline drafts documents from, or "" when
+// there is none yet
+func draftCVPath() string {
+	if fileExists(defaultCVPath) {
+		return defaultCVPath
+	}
+	return ""
+}
+
+// printDocuments lists the documents a run wrote
+func printDocuments(w io.Writer, docs *agent.GeneratedDocuments) {
+	fmt.Fprintln(w, "\nDocuments:")
+	for _, path := range []string{docs.ResumePath, docs.ResumePDF, docs.CoverLetterPath, docs.CoverLetterPDF} {
+		if path != "" && fileExists(path) {
+			fmt.Fprintf(w, "  %s\n", path)
+		}
+	}
+}
+
+// printCoverPreview prints the generated cover letter as plain text, its
+// markup stripped, for a quick read without compiling. docs may be nil.
+func printCoverPreview(w io.Writer, docs *agent.GeneratedDocuments) {
+	if docs == nil || docs.CoverLetterPath == "" {
+		fmt.Fprintln(w, "\nNo cover letter to preview.")
+		return
+	}
+	content, err := os.ReadFile(docs.CoverLetterPath)
+	if err != nil {
+		fmt.Fprintf(w, "\nNo cover letter source to preview (%s); set output.keep_typst to keep it.\n", filepath.Base(docs.CoverLetterPath))
+		return
+	}
+	fmt.Fprintf(w, "\nCover letter preview (%s):\n\n%s\n", filepath.Base(docs.CoverLetterPath), agent.DocumentProse(string(content)))
+}
+
+// printParsed runs only the parser step on a posting and writes the
+// ParsedPosting as JSON. No documents or tracker entries are created.
+func printParsed(w io.Writer, configPath, postingPath string) error {
+	pipeline, err := agent.NewPipeline(configPath, nil)
+	if err != nil {
+		return fmt.Errorf("creating pipeline: %w", err)
+	}
+
+	parsed, err := pipeline.Parse(postingPath)
+	if err != nil {
+		return err
+	}
+
+	output, err := json.MarshalIndent(parsed, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(output))
+	return err
+}
+
+// printPrompts writes the prompts each downstream agent would receive for
+// a posting, for pasting into a chat model by hand. Nothing is generated
+// or tracked.
+func printPrompts(w io.Writer, configPath, postingPath string, opts applyOptions) error {
+	pipeline, err := agent.NewPipeline(configPath, nil)
+	if err != nil {
+		return fmt.Errorf("creating pipeline: %w", err)
+	}
+	pipeline.Tone = opts.tone
+	pipeline.CoverStyle = opts.coverStyle
+	pipeline.Format = opts.format
+	pipeline.CVPath = draftCVPath()
+	pipeline.ReviewerCVPath = opts.reviewerCV
+	pipeline.AttachPosting = opts.attachPosting
+	if pipeline.Rubric, err = agent.LoadRubric(defaultRubricPath); err != nil {
+		return err
+	}
+
+	prompts, err := pipeline.EmitPrompts(postingPath)
+	if err != nil {
+		return err
+	}
+	for i, prompt := range prompts {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "===== %s: system prompt =====\n\n%s\n\n", prompt.Agent, prompt.System)
+		fmt.Fprintf(w, "===== %s: user prompt =====\n\n%s\n", prompt.Agent, prompt.User)
+	}
+	return nil
+}
+
+// collectPostings returns the supported posting files in a folder, sorted by name
+func collectPostings(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := agent.NewParserAgent(nil)
+	var postings []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		if parser.IsSupported(path) {
+			postings = append(postings, path)
+		}
+	}
+	sort.Strings(postings)
+	return postings, nil
+}
+
+// filterTracked splits postings into those still to process and those that
+// already have a tracker entry
+func filterTracked(s *store.Store, postings []string) ([]string, []trackedPosting) {
+	var pending []string
+	var skipped []trackedPosting
+	for _, path := range postings {
+		if app := agent.FindTracked(s, path); app != nil {
+			skipped = append(skipped, trackedPosting{Path: path, App: app})
+		} else {
+			pending = append(pending, path)
+		}
+	}
+	return pending, skipped
+}
+
+// printSkipped logs a posting skipped by --skip-existing
+func printSkipped(sp trackedPosting) {
+	fmt.Printf("Skipping %s (already tracked: [%s] %s @ %s)\n",
+		sp.Path, shortID(sp.App.ID), sp.App.Position, sp.App.Company)
+}
+
+// shortID returns the first 8 characters of an application ID
+func shortID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
@@ -0,0 +1,393 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/celloopa/ghosted/internal/agent"
+	"github.com/celloopa/ghosted/internal/model"
+	"github.com/celloopa/ghosted/internal/store"
+)
+
+func TestCollectPostings(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"b-posting.md", "a-posting.txt", "notes.json", "shot.png"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("x"), 0644); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "processed"), 0755); err != nil {
+		t.Fatalf("Mkdir() error = %v", err)
+	}
+
+	got, err := collectPostings(dir)
+	if err != nil {
+		t.Fatalf("collectPostings() error = %v", err)
+	}
+
+	want := []string{
+		filepath.Join(dir, "a-posting.txt"),
+		filepath.Join(dir, "b-posting.md"),
+		filepath.Join(dir, "shot.png"),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("collectPostings() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("collectPostings()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestFilterTracked_SkipsExisting(t *testing.T) {
+	dir := t.TempDir()
+
+	s, err := store.New(filepath.Join(dir, "applications.json"))
+	if err != nil {
+		t.Fatalf("store.New() error = %v", err)
+	}
+
+	tracked := filepath.Join(dir, "acme-engineer-posting.md")
+	fresh := filepath.Join(dir, "newco-designer-posting.md")
+	for _, path := range []string{tracked, fresh} {
+		if err := os.WriteFile(path, []byte("# Posting\n"), 0644); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+
+	existing, err := s.Add(model.Application{Company: "Acme", Position: "Engineer", PostingPath: tracked})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	pending, skipped := filterTracked(s, []string{tracked, fresh})
+
+	if len(pending) != 1 || pending[0] != fresh {
+		t.Errorf("pending = %v, want only %s", pending, fresh)
+	}
+	if len(skipped) != 1 || skipped[0].Path != tracked || skipped[0].App.ID != existing.ID {
+		t.Errorf("skipped = %+v, want %s matched to %s", skipped, tracked, existing.ID)
+	}
+}
+
+func TestPrintParsed_ParseOnly(t *testing.T) {
+	dir := t.TempDir()
+	postingPath := filepath.Join(dir, "acme-swe-posting.md")
+	posting := `# Software Engineer
+
+Company: Acme Corp
+Position: Software Engineer
+
+## Requirements
+
+- 3+ years of Go
+- Experience with distributed systems
+`
+	if err := os.WriteFile(postingPath, []byte(posting), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	var out bytes.Buffer
+	configPath := filepath.Join(dir, ".agent", "config.json")
+	if err := printParsed(&out, configPath, postingPath); err != nil {
+		t.Fatalf("printParsed() error = %v", err)
+	}
+
+	var parsed agent.ParsedPosting
+	dec := json.NewDecoder(&out)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&parsed); err != nil {
+		t.Fatalf("output is not ParsedPosting JSON: %v\n%s", err, out.String())
+	}
+	if parsed.Company != "Acme Corp" || parsed.Position != "Software Engineer" {
+		t.Errorf("parsed = %q / %q, want Acme Corp / Software Engineer", parsed.Company, parsed.Position)
+	}
+	if len(parsed.Requirements) != 2 {
+		t.Errorf("Requirements = %v, want 2 entries", parsed.Requirements)
+	}
+
+	// Nothing besides the posting may be written: no state file, documents,
+	// or tracker data
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	if len(entries) != 1 {
+		var names []string
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		t.Errorf("parse-only wrote files: %v", names)
+	}
+}
+
+func TestPrintParsed_UnsupportedFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "posting.docx")
+	if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := printParsed(&out, filepath.Join(dir, "config.json"), path); err == nil {
+		t.Error("printParsed() on unsupported file should return error")
+	}
+	if out.Len() != 0 {
+		t.Errorf("printParsed() wrote output on error: %q", out.String())
+	}
+}
+
+func TestPrintRunSummary(t *testing.T) {
+	dir := t.TempDir()
+	postingPath := filepath.Join(dir, "acme-swe-posting.md")
+	if err := os.WriteFile(postingPath, []byte("# Software Engineer\n\nCompany: Acme\n"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	s, err := store.New(filepath.Join(dir, "applications.json"))
+	if err != nil {
+		t.Fatalf("store.New() error = %v", err)
+	}
+	pipeline, err := agent.NewPipeline(filepath.Join(dir, ".agent", "config.json"), s)
+	if err != nil {
+		t.Fatalf("NewPipeline() error = %v", err)
+	}
+	if err := pipeline.Run(postingPath); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := printRunSummary(&out, pipeline); err != nil {
+		t.Fatalf("printRunSummary() error = %v", err)
+	}
+
+	var got struct {
+		Status string `json:"status"`
+		Steps  []struct {
+			Agent  string `json:"agent"`
+			Status string `json:"status"`
+		} `json:"steps"`
+		ApplicationID string `json:"application_id"`
+		ReviewScore   int    `json:"review_score"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("output is not a JSON object: %v\n%s", err, out.String())
+	}
+	if got.Status != "completed" {
+		t.Errorf("status = %q, want completed", got.Status)
+	}
+	if len(got.Steps) != 5 || got.Steps[4].Agent != "tracker" || got.Steps[4].Status != "completed" {
+		t.Errorf("steps = %+v, want 5 completed steps ending with tracker", got.Steps)
+	}
+	if _, err := s.GetByID(got.ApplicationID); err != nil {
+		t.Errorf("application_id %q not in store: %v", got.ApplicationID, err)
+	}
+}
+
+func TestApplyPosting_DryRunWritesDocumentsOnly(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	if err := os.MkdirAll(filepath.Dir(defaultCVPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	cv := `{"basics": {"name": "Dry Runner", "email": "dry@example.com"},
+		"skills": [{"name": "Languages", "keywords": ["Go"]}],
+		"work": [{"name": "Initech", "position": "Engineer", "highlights": ["Built services in Go"]}]}`
+	if err := os.WriteFile(defaultCVPath, []byte(cv), 0644); err != nil {
+		t.Fatal(err)
+	}
+	postingPath := filepath.Join("local", "postings", "acme-swe-posting.md")
+	if err := os.MkdirAll(filepath.Dir(postingPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(postingPath, []byte("# Software Engineer\n\nCompany: Acme\n\n## Requirements\n\n- Go\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	storePath := filepath.Join(dir, "applications.json")
+	s, err := store.New(storePath)
+	if err != nil {
+		t.Fatalf("store.New() error = %v", err)
+	}
+	before := s.Total()
+
+	t.Setenv(agent.AnthropicAPIKeyEnv, "") // draft locally, never call the API
+	t.Setenv(agent.OpenAIAPIKeyEnv, "")
+	if err := applyPosting(s, postingPath, applyOptions{dryRun: true}); err != nil {
+		t.Fatalf("applyPosting() error = %v", err)
+	}
+
+	outputDir := agent.DefaultConfig().Paths.OutputDir
+	for _, pattern := range []string{"*resume.typ", "*cover.typ"} {
+		matches, _ := filepath.Glob(filepath.Join(outputDir, pattern))
+		if len(matches) != 1 {
+			t.Errorf("%s in %s: got %v, want one file", pattern, outputDir, matches)
+		}
+	}
+
+	reloaded, err := store.New(storePath)
+	if err != nil {
+		t.Fatalf("reloading store: %v", err)
+	}
+	if s.Total() != before || reloaded.Total() != before {
+		t.Errorf("store has %d (%d on disk) applications after dry run, want %d", s.Total(), reloaded.Total(), before)
+	}
+	if !fileExists(postingPath) {
+		t.Error("dry run moved the posting")
+	}
+}
+
+func TestPrintPrompts_AllAgents(t *testing.T) {
+	dir := t.TempDir()
+	postingPath := filepath.Join(dir, "zephyr-swe-posting.md")
+	posting := `# Backend Engineer
+
+Company: Zephyr Labs
+
+## Requirements
+
+- 5+ years of Go
+`
+	if err := os.WriteFile(postingPath, []byte(posting), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	var out bytes.Buffer
+	configPath := filepath.Join(dir, ".agent", "config.json")
+	if err := printPrompts(&out, configPath, postingPath, applyOptions{}); err != nil {
+		t.Fatalf("printPrompts() error = %v", err)
+	}
+
+	sections := strings.Split(out.String(), "===== ")
+	for _, agentType := range []agent.AgentType{agent.AgentResume, agent.AgentCover, agent.AgentReviewer, agent.AgentTracker} {
+		for _, kind := range []string{"system", "user"} {
+			header := fmt.Sprintf("%s: %s prompt =====", agentType, kind)
+			var section string
+			for _, s := range sections {
+				if strings.HasPrefix(s, header) {
+					section = s
+				}
+			}
+			if section == "" {
+				t.Errorf("missing %q section", header)
+				continue
+			}
+			if kind == "user" && !strings.Contains(section, "Zephyr Labs") {
+				t.Errorf("%s user prompt doesn't mention the company:\n%s", agentType, section)
+			}
+		}
+	}
+
+	// Only the posting is on disk: no state file or documents
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("emit-prompts wrote %d entries, want only the posting", len(entries))
+	}
+}
+
+func TestPrintScoreBreakdown(t *testing.T) {
+	review := &agent.DetailedReviewResult{
+		OverallScore: 85,
+		ScoreBreakdown: &agent.ScoreBreakdown{
+			RequirementsMatch:   90,
+			ExperienceRelevance: 85,
+			Communication:       80,
+			CulturalFit:         75,
+		},
+	}
+	var out bytes.Buffer
+	printScoreBreakdown(&out, review)
+	for _, want := range []string{"overall 85/100", "Requirements match", " 90/100 x 40% = 36.0", "Cultural fit", " 75/100 x 10% =  7.5", "Weighted total"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	printScoreBreakdown(&out, nil)
+	if !strings.Contains(out.String(), "No score breakdown") {
+		t.Errorf("nil review output = %q", out.String())
+	}
+}
+
+func TestPrintCoverPreview(t *testing.T) {
+	coverPath := filepath.Join(t.TempDir(), "acme-swe-cover.typ")
+	cover := "// Generated by ghosted on 2026-10-16\n" +
+		"#set page(margin: 2cm)\n#set text(size: 11pt)\n\n" +
+		"*Jane Doe*\\\njane\\@example.com\n\n" +
+		"Dear Hiring Team at Acme,\n\n" +
+		"As Engineer at Initech, I built _reliable_ services in Go.\n"
+	if err := os.WriteFile(coverPath, []byte(cover), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	printCoverPreview(&out, &agent.GeneratedDocuments{CoverLetterPath: coverPath})
+	want := "\nCover letter preview (acme-swe-cover.typ):\n\n" +
+		"Jane Doe\njane@example.com\n\n" +
+		"Dear Hiring Team at Acme,\n\n" +
+		"As Engineer at Initech, I built reliable services in Go.\n"
+	if out.String() != want {
+		t.Errorf("printCoverPreview() =\n%s\nwant\n%s", out.String(), want)
+	}
+	for _, markup := range []string{"#set", "Generated by", "*", "_", "\\"} {
+		if strings.Contains(out.String(), markup) {
+			t.Errorf("preview still has Typst markup %q", markup)
+		}
+	}
+
+	// Without the source (keep_typst off) there's nothing to read
+	out.Reset()
+	printCoverPreview(&out, &agent.GeneratedDocuments{CoverLetterPath: filepath.Join(t.TempDir(), "gone-cover.typ")})
+	if !strings.Contains(out.String(), "keep_typst") {
+		t.Errorf("missing source output = %q", out.String())
+	}
+}
+
+func TestCheckPipelineConfig_MissingWarns(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".agent", "config.json")
+
+	var out bytes.Buffer
+	if err := checkPipelineConfig(&out, configPath); err != nil {
+		t.Fatalf("checkPipelineConfig() error = %v", err)
+	}
+	if !strings.Contains(out.String(), configPath) || !strings.Contains(out.String(), "ghosted init") {
+		t.Errorf("warning = %q, want the expected path and a hint to run ghosted init", out.String())
+	}
+
+	// Once the config exists there is nothing to warn about
+	if err := agent.SaveConfig(agent.DefaultConfig(), configPath); err != nil {
+		t.Fatalf("SaveConfig() error = %v", err)
+	}
+	out.Reset()
+	if err := checkPipelineConfig(&out, configPath); err != nil {
+		t.Fatalf("checkPipelineConfig() error = %v", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("unexpected warning for an existing config: %q", out.String())
+	}
+}
+
+func TestCheckPipelineConfig_RejectsNoGenerators(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".agent", "config.json")
+	config := agent.DefaultConfig()
+	config.GetAgentConfig(agent.AgentResume).Enabled = false
+	config.GetAgentConfig(agent.AgentCover).Enabled = false
+	if err := agent.SaveConfig(config, configPath); err != nil {
+		t.Fatalf("SaveConfig() error = %v", err)
+	}
+
+	if err := checkPipelineConfig(io.Discard, configPath); err == nil {
+		t.Error("checkPipelineConfig() should reject a config with no generation agents")
+	}
+}
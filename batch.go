@@ -0,0 +1,181 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/celloopa/ghosted/internal/agent"
+	"github.com/celloopa/ghosted/internal/model"
+	"github.com/celloopa/ghosted/internal/store"
+)
+
+// progressBarWidth is the number of cells in the batch progress bar
+const progressBarWidth = 20
+
+// batchProgress reports batch apply progress. On a terminal a progress bar
+// is redrawn in place as pipeline steps finish; otherwise one line is
+// printed per completed posting. Safe for concurrent use.
+type batchProgress struct {
+	mu      sync.Mutex
+	w       io.Writer
+	inPlace bool
+	total   int
+	done    int
+	failed  int
+	running int
+	// lastStep describes the most recent finished step, e.g. "acme.md: parser"
+	lastStep string
+}
+
+// newBatchProgress creates a progress reporter for total postings. inPlace
+// redraws a single line and should only be set for terminals.
+func newBatchProgress(w io.Writer, total int, inPlace bool) *batchProgress {
+	return &batchProgress{
+		w:       w,
+		inPlace: inPlace,
+		total:   total,
+	}
+}
+
+// start marks a posting as in flight
+func (b *batchProgress) start(posting string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.running++
+	b.redraw()
+}
+
+// stepDone records a finished pipeline step; it is the pipeline's OnStep
+// observer
+func (b *batchProgress) stepDone(posting string, agentType agent.AgentType) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.lastStep = fmt.Sprintf("%s: %s", filepath.Base(posting), agentType)
+	b.redraw()
+}
+
+// finish records a completed posting. note is appended to the completion
+// line (e.g. a salary warning).
+func (b *batchProgress) finish(posting string, err error, note string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.running--
+	b.done++
+	if err != nil {
+		b.failed++
+	}
+
+	line := fmt.Sprintf("[%d/%d] %s: done", b.done, b.total, posting)
+	if err != nil {
+		line = fmt.Sprintf("[%d/%d] %s: failed: %v", b.done, b.total, posting, err)
+	}
+	if note != "" {
+		line += " (" + note + ")"
+	}
+
+	if !b.inPlace {
+		fmt.Fprintln(b.w, line)
+		return
+	}
+	// Failures and warnings stay on screen above the bar
+	if err != nil || note != "" {
+		fmt.Fprintf(b.w, "\r\033[K%s\n", line)
+	}
+	b.redraw()
+}
+
+// redraw rewrites the progress line in place; callers hold b.mu
+func (b *batchProgress) redraw() {
+	if !b.inPlace {
+		return
+	}
+	filled := 0
+	if b.total > 0 {
+		filled = b.done * progressBarWidth / b.total
+	}
+	bar := strings.Repeat("=", filled) + strings.Repeat(" ", progressBarWidth-filled)
+	line := fmt.Sprintf("[%s] %d/%d postings processed", bar, b.done, b.total)
+	if b.running > 0 {
+		line += fmt.Sprintf(" (%d running, last: %s)", b.running, b.lastStep)
+	}
+	fmt.Fprintf(b.w, "\r\033[K%s", line)
+}
+
+// summary prints the final tally
+func (b *batchProgress) summary() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.inPlace {
+		fmt.Fprintln(b.w)
+	}
+	fmt.Fprintf(b.w, "\nBatch complete: %d processed, %d failed\n", b.done-b.failed, b.failed)
+}
+
+// runBatch runs fn on every posting with at most concurrency in flight,
+// reporting each completion to progress. It returns the number of failures.
+func runBatch(postings []string, concurrency int, progress *batchProgress, fn func(posting string) (note string, err error)) int {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	jobs := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < min(concurrency, len(postings)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for posting := range jobs {
+				progress.start(posting)
+				note, err := fn(posting)
+				progress.finish(posting, err, note)
+			}
+		}()
+	}
+	for _, posting := range postings {
+		jobs <- posting
+	}
+	close(jobs)
+	wg.Wait()
+
+	return progress.failed
+}
+
+// applyQuiet runs the pipeline on one posting of a batch without printing,
+// feeding step completions to progress. It returns a salary warning, if any.
+func applyQuiet(s *store.Store, postingPath string, opts applyOptions, progress *batchProgress) (string, error) {
+	pipeline, err := newApplyPipeline(s, opts)
+	if err != nil {
+		return "", err
+	}
+	pipeline.OnStep = func(agentType agent.AgentType, _ agent.StepResult) {
+		progress.stepDone(postingPath, agentType)
+	}
+
+	if !opts.dryRun {
+		// Hold the company's lock from the limit check until the posting is
+		// tracked, so concurrent workers can't all pass the same limit
+		company := postingCompany(pipeline, postingPath)
+		defer lockCompany(company)()
+		if !opts.force && company != "" {
+			if err := checkCompanyCadence(s, company, companyLimit(pipeline.Config), false); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if err := pipeline.Run(postingPath); err != nil {
+		return "", err
+	}
+	if summary := pipeline.Summary(); summary.Status == "rejected" {
+		return "", fmt.Errorf("documents rejected, feedback saved to %s", summary.FeedbackPath)
+	}
+
+	if parsed := pipeline.ParsedPosting(); parsed != nil {
+		app := model.Application{SalaryMin: parsed.SalaryMin, SalaryMax: parsed.SalaryMax, SalaryEstimated: parsed.SalaryEstimated}
+		return app.SalaryWarning(minAcceptableSalary(pipeline.Config)), nil
+	}
+	return "", nil
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/agent"
+	"github.com/celloopa/ghosted/internal/store"
+)
+
+func TestRunBatch_RespectsConcurrency(t *testing.T) {
+	postings := make([]string, 12)
+	for i := range postings {
+		postings[i] = fmt.Sprintf("posting-%02d.md", i)
+	}
+
+	var inFlight, peak, calls int32
+	step := func(posting string) (string, error) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		return "", nil
+	}
+
+	var out bytes.Buffer
+	progress := newBatchProgress(&out, len(postings), false)
+	if failed := runBatch(postings, 3, progress, step); failed != 0 {
+		t.Errorf("runBatch() failed = %d, want 0", failed)
+	}
+	if peak > 3 {
+		t.Errorf("peak in-flight = %d, want at most 3", peak)
+	}
+	if calls != int32(len(postings)) {
+		t.Errorf("step called %d times, want %d", calls, len(postings))
+	}
+	// Non-TTY output is one line per completion
+	if lines := strings.Count(out.String(), "\n"); lines != len(postings) {
+		t.Errorf("got %d progress lines, want %d:\n%s", lines, len(postings), out.String())
+	}
+}
+
+func TestRunBatch_FinalTally(t *testing.T) {
+	postings := []string{"a.md", "b.md", "c.md", "d.md"}
+	step := func(posting string) (string, error) {
+		switch posting {
+		case "b.md":
+			return "", errors.New("parser failed")
+		case "c.md":
+			return "salary not disclosed", nil
+		}
+		return "", nil
+	}
+
+	var out bytes.Buffer
+	progress := newBatchProgress(&out, len(postings), false)
+	if failed := runBatch(postings, 2, progress, step); failed != 1 {
+		t.Errorf("runBatch() failed = %d, want 1", failed)
+	}
+	progress.summary()
+
+	got := out.String()
+	for _, want := range []string{
+		"b.md: failed: parser failed",
+		"c.md: done (salary not disclosed)",
+		"[4/4]",
+		"Batch complete: 3 processed, 1 failed",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestApplyQuiet_ConcurrentPipelines(t *testing.T) {
+	dir := t.TempDir()
+	saved := pipelineConfigPath
+	pipelineConfigPath = filepath.Join(dir, ".agent", "config.json")
+	defer func() { pipelineConfigPath = saved }()
+	t.Setenv(agent.AnthropicAPIKeyEnv, "") // draft locally, never call the API
+	t.Setenv(agent.OpenAIAPIKeyEnv, "")
+
+	s, err := store.New(filepath.Join(dir, "applications.json"))
+	if err != nil {
+		t.Fatalf("store.New() error = %v", err)
+	}
+	before := s.Total()
+
+	var postings []string
+	for _, name := range []string{"acme-swe-posting.md", "globex-swe-posting.md", "initech-swe-posting.md"} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("# Software Engineer\n"), 0644); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+		postings = append(postings, path)
+	}
+
+	var out bytes.Buffer
+	progress := newBatchProgress(&out, len(postings), true)
+	failed := runBatch(postings, 3, progress, func(posting string) (string, error) {
+		return applyQuiet(s, posting, applyOptions{}, progress)
+	})
+	if failed != 0 {
+		t.Fatalf("runBatch() failed = %d, want 0:\n%s", failed, out.String())
+	}
+	if got := s.Total() - before; got != len(postings) {
+		t.Errorf("tracker entries added = %d, want %d", got, len(postings))
+	}
+	// Step completions from the pipeline observer redraw the bar
+	if !strings.Contains(out.String(), ": tracker") {
+		t.Errorf("progress never showed a finished tracker step:\n%q", out.String())
+	}
+	if !strings.Contains(out.String(), "3/3 postings processed") {
+		t.Errorf("progress never reached 3/3:\n%q", out.String())
+	}
+}
+
+func TestApplyQuiet_ConcurrentCompanyLimit(t *testing.T) {
+	dir := t.TempDir()
+	saved := pipelineConfigPath
+	pipelineConfigPath = filepath.Join(dir, ".agent", "config.json")
+	defer func() { pipelineConfigPath = saved }()
+	t.Setenv(agent.AnthropicAPIKeyEnv, "") // draft locally, never call the API
+	t.Setenv(agent.OpenAIAPIKeyEnv, "")
+
+	s, err := store.New(filepath.Join(dir, "applications.json"))
+	if err != nil {
+		t.Fatalf("store.New() error = %v", err)
+	}
+
+	var postings []string
+	for _, role := range []string{"backend", "frontend", "platform", "data", "mobile"} {
+		path := filepath.Join(dir, "acme-"+role+"-posting.md")
+		if err := os.WriteFile(path, []byte("Company: Acme\n"), 0644); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+		postings = append(postings, path)
+	}
+
+	var out bytes.Buffer
+	progress := newBatchProgress(&out, len(postings), false)
+	failed := runBatch(postings, len(postings), progress, func(posting string) (string, error) {
+		return applyQuiet(s, posting, applyOptions{}, progress)
+	})
+
+	limit := companyLimit(nil)
+	if got := len(s.ByCompany("Acme")); got != limit {
+		t.Errorf("Acme applications = %d, want the limit of %d", got, limit)
+	}
+	if failed != len(postings)-limit {
+		t.Errorf("runBatch() failed = %d, want %d over the limit:\n%s", failed, len(postings)-limit, out.String())
+	}
+}
+
+func TestApplyQuiet_UsesApplyFlags(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	t.Setenv(agent.AnthropicAPIKeyEnv, "") // draft locally, never call the API
+	t.Setenv(agent.OpenAIAPIKeyEnv, "")
+
+	if err := os.MkdirAll(filepath.Dir(defaultCVPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(defaultCVPath, []byte(`{"basics": {"name": "Batch Runner", "email": "batch@example.com"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var postings []string
+	for _, name := range []string{"acme-swe-posting.md", "globex-swe-posting.md"} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("# Software Engineer\n\nCompany: "+strings.Split(name, "-")[0]+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		postings = append(postings, path)
+	}
+	s, err := store.New(filepath.Join(dir, "applications.json"))
+	if err != nil {
+		t.Fatalf("store.New() error = %v", err)
+	}
+
+	opts := applyOptions{dryRun: true, format: agent.FormatMarkdown}
+	var out bytes.Buffer
+	progress := newBatchProgress(&out, len(postings), false)
+	failed := runBatch(postings, 1, progress, func(posting string) (string, error) {
+		return applyQuiet(s, posting, opts, progress)
+	})
+	if failed != 0 {
+		t.Fatalf("runBatch() failed = %d:\n%s", failed, out.String())
+	}
+
+	outputDir := agent.DefaultConfig().Paths.OutputDir
+	markdown, _ := filepath.Glob(filepath.Join(outputDir, "*.md"))
+	typst, _ := filepath.Glob(filepath.Join(outputDir, "*.typ"))
+	if len(markdown) != 2*len(postings) || len(typst) != 0 {
+		t.Errorf("batch wrote markdown %v and typst %v, want only markdown documents", markdown, typst)
+	}
+}
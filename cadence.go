@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/celloopa/ghosted/internal/agent"
+	"github.com/celloopa/ghosted/internal/model"
+	"github.com/celloopa/ghosted/internal/store"
+)
+
+// companyLimit returns how many active applications at one company are
+// allowed before another needs --force. config may be nil.
+func companyLimit(config *agent.PipelineConfig) int {
+	if config != nil && config.MaxActivePerCompany > 0 {
+		return config.MaxActivePerCompany
+	}
+	return model.DefaultMaxActivePerCompany
+}
+
+// loadCompanyLimit reads the per-company limit without requiring a
+// pipeline config
+func loadCompanyLimit() int {
+	config, _ := agent.LoadConfig(pipelineConfigPath)
+	return companyLimit(config)
+}
+
+// checkCompanyCadence returns an error listing the existing positions when
+// company already has limit or more active (not rejected or withdrawn)
+// applications, unless force is set
+func checkCompanyCadence(s *store.Store, company string, limit int, force bool) error {
+	if force {
+		return nil
+	}
+	var active []model.Application
+	for _, app := range s.ByCompany(company) {
+		if !app.IsClosed() {
+			active = append(active, app)
+		}
+	}
+	if len(active) < limit {
+		return nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "already %d active application(s) at %s (limit %d):\n", len(active), company, limit)
+	for _, app := range active {
+		fmt.Fprintf(&b, "  - %s (%s)\n", app.Position, model.StatusLabel(app.Status))
+	}
+	b.WriteString("Use --force to add another anyway.")
+	return fmt.Errorf("%s", b.String())
+}
+
+// checkPostingCadence parses a posting and checks its company against the
+// per-company limit, as checkCompanyCadence. Postings that can't be parsed
+// pass; the pipeline run reports their errors.
+func checkPostingCadence(s *store.Store, pipeline *agent.Pipeline, postingPath string, force bool) error {
+	if force {
+		return nil
+	}
+	company := postingCompany(pipeline, postingPath)
+	if company == "" {
+		return nil
+	}
+	return checkCompanyCadence(s, company, companyLimit(pipeline.Config), false)
+}
+
+// postingCompany parses a posting for its company, or "" when it can't be
+// parsed or names none
+func postingCompany(pipeline *agent.Pipeline, postingPath string) string {
+	parsed, err := pipeline.Parse(postingPath)
+	if err != nil {
+		return ""
+	}
+	return parsed.Company
+}
+
+// companyRuns holds a lock per company (lowercased, as ByCompany matches)
+// for batch runs, so concurrent workers check the limit and run the
+// pipeline one posting per company at a time
+var companyRuns = struct {
+	sync.Mutex
+	locks map[string]*sync.Mutex
+}{locks: make(map[string]*sync.Mutex)}
+
+// lockCompany locks company's batch runs and returns the unlock function.
+// An empty company isn't locked.
+func lockCompany(company string) func() {
+	key := strings.ToLower(strings.TrimSpace(company))
+	if key == "" {
+		return func() {}
+	}
+	companyRuns.Lock()
+	mu, ok := companyRuns.locks[key]
+	if !ok {
+		mu = &sync.Mutex{}
+		companyRuns.locks[key] = mu
+	}
+	companyRuns.Unlock()
+	mu.Lock()
+	return mu.Unlock
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/celloopa/ghosted/internal/model"
+	"github.com/celloopa/ghosted/internal/store"
+)
+
+func newCadenceStore(t *testing.T, statuses ...string) *store.Store {
+	t.Helper()
+	s, err := store.New(filepath.Join(t.TempDir(), "applications.json"))
+	if err != nil {
+		t.Fatalf("store.New() error = %v", err)
+	}
+	for i, status := range statuses {
+		app := model.Application{Company: "Acme", Position: "Engineer " + string(rune('A'+i)), Status: status}
+		if _, err := s.Add(app); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+	if _, err := s.Add(model.Application{Company: "Other", Position: "Engineer", Status: model.StatusApplied}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	return s
+}
+
+func TestCheckCompanyCadence_AtThreshold(t *testing.T) {
+	s := newCadenceStore(t, model.StatusApplied, model.StatusScreening, model.StatusInterview)
+
+	err := checkCompanyCadence(s, " acme ", 3, false)
+	if err == nil {
+		t.Fatal("checkCompanyCadence() should fail with 3 active applications at the limit")
+	}
+	for _, want := range []string{"3 active application(s) at", "Engineer A (Applied)", "Engineer C (Interview)", "--force"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q should contain %q", err, want)
+		}
+	}
+}
+
+func TestCheckCompanyCadence_BelowThreshold(t *testing.T) {
+	s := newCadenceStore(t, model.StatusApplied, model.StatusScreening)
+
+	if err := checkCompanyCadence(s, "Acme", 3, false); err != nil {
+		t.Errorf("checkCompanyCadence() with 2 active = %v, want nil", err)
+	}
+}
+
+func TestCheckCompanyCadence_CountsOnlyActive(t *testing.T) {
+	s := newCadenceStore(t, model.StatusApplied, model.StatusRejected, model.StatusWithdrawn, model.StatusSaved)
+
+	if err := checkCompanyCadence(s, "Acme", 3, false); err != nil {
+		t.Errorf("rejected and withdrawn applications should not count: %v", err)
+	}
+}
+
+func TestCheckCompanyCadence_Force(t *testing.T) {
+	s := newCadenceStore(t, model.StatusApplied, model.StatusScreening, model.StatusInterview, model.StatusOffer)
+
+	if err := checkCompanyCadence(s, "Acme", 3, true); err != nil {
+		t.Errorf("checkCompanyCadence() with --force = %v, want nil", err)
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/celloopa/ghosted/internal/model"
+	"github.com/celloopa/ghosted/internal/store"
+)
+
+// cmdCompare shows two applications side by side, with how their salary
+// ranges relate
+func cmdCompare(s *store.Store, args []string) {
+	if len(args) != 2 {
+		fmt.Fprintln(os.Stderr, "Usage: ghosted compare <id> <id>")
+		os.Exit(1)
+	}
+
+	var apps [2]model.Application
+	for i, id := range args {
+		app := findAppByID(s, id)
+		if app == nil {
+			fmt.Fprintf(os.Stderr, "Error: application not found: %s\n", id)
+			os.Exit(1)
+		}
+		apps[i] = *app
+	}
+	printComparison(os.Stdout, apps[0], apps[1], terminalWidth())
+}
+
+// printComparison writes a side-by-side table of two applications followed
+// by their salary overlap and which has the higher ceiling
+func printComparison(w io.Writer, a, b model.Application, width int) {
+	row := func(label string, value func(model.Application) string) []string {
+		return []string{label, value(a), value(b)}
+	}
+	orDash := func(s string) string {
+		if s == "" {
+			return "—"
+		}
+		return s
+	}
+
+	rows := [][]string{
+		row("Company", func(app model.Application) string { return app.Company }),
+		row("Position", func(app model.Application) string { return app.Position }),
+		row("Status", func(app model.Application) string { return model.StatusLabel(app.Status) }),
+		row("Location", func(app model.Application) string {
+			if app.Remote {
+				return orDash(app.Location) + " (remote)"
+			}
+			return orDash(app.Location)
+		}),
+		row("Salary", func(app model.Application) string { return orDash(app.SalaryRange()) }),
+		row("Applied", func(app model.Application) string {
+			if app.DateApplied == nil {
+				return "—"
+			}
+			return app.DateApplied.Format("2006-01-02")
+		}),
+	}
+	fmt.Fprint(w, renderBoxTable([]string{"", shortID(a.ID), shortID(b.ID)}, rows, width))
+
+	fmt.Fprintf(w, "\nSalary overlap: %s\n", describeSalaryOverlap(a, b))
+	fmt.Fprintf(w, "Higher ceiling: %s\n", describeHigherCeiling(a, b))
+}
+
+// salaryOverlap returns the band where two salary ranges overlap. A range
+// with only a minimum is open-ended, and one with only a maximum starts at
+// zero; a high of 0 means the overlap has no upper bound. Applications
+// without any salary don't overlap anything.
+func salaryOverlap(a, b model.Application) (low, high int, overlaps bool) {
+	if (a.SalaryMin == 0 && a.SalaryMax == 0) || (b.SalaryMin == 0 && b.SalaryMax == 0) {
+		return 0, 0, false
+	}
+
+	low = max(a.SalaryMin, b.SalaryMin)
+	switch {
+	case a.SalaryMax == 0:
+		high = b.SalaryMax
+	case b.SalaryMax == 0:
+		high = a.SalaryMax
+	default:
+		high = min(a.SalaryMax, b.SalaryMax)
+	}
+
+	if high != 0 && low > high {
+		return 0, 0, false
+	}
+	return low, high, true
+}
+
+// describeSalaryOverlap explains how two salary ranges relate
+func describeSalaryOverlap(a, b model.Application) string {
+	for _, app := range []model.Application{a, b} {
+		if app.SalaryMin == 0 && app.SalaryMax == 0 {
+			return fmt.Sprintf("unknown (no salary for %s)", app.Company)
+		}
+	}
+
+	low, high, overlaps := salaryOverlap(a, b)
+	if !overlaps {
+		return "none, the ranges are disjoint"
+	}
+	switch {
+	case high == 0:
+		return model.FormatSalary(low) + "+"
+	case low == high:
+		return model.FormatSalary(low)
+	}
+	return model.FormatSalary(low) + " - " + model.FormatSalary(high)
+}
+
+// describeHigherCeiling names the application with the higher maximum
+// salary; open-ended or missing maximums can't be compared
+func describeHigherCeiling(a, b model.Application) string {
+	if a.SalaryMax == 0 || b.SalaryMax == 0 {
+		return "unknown (a salary maximum is missing)"
+	}
+	limit := model.FormatSalary(max(a.SalaryMax, b.SalaryMax))
+	switch {
+	case a.SalaryMax > b.SalaryMax:
+		return fmt.Sprintf("%s (%s)", a.Company, limit)
+	case b.SalaryMax > a.SalaryMax:
+		return fmt.Sprintf("%s (%s)", b.Company, limit)
+	}
+	return fmt.Sprintf("same (%s)", limit)
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+func TestSalaryOverlap(t *testing.T) {
+	tests := []struct {
+		name      string
+		a, b      model.Application
+		low, high int
+		overlaps  bool
+	}{
+		{
+			name: "partially overlapping",
+			a:    model.Application{SalaryMin: 100000, SalaryMax: 140000},
+			b:    model.Application{SalaryMin: 120000, SalaryMax: 160000},
+			low:  120000, high: 140000, overlaps: true,
+		},
+		{
+			name: "identical ranges",
+			a:    model.Application{SalaryMin: 100000, SalaryMax: 140000},
+			b:    model.Application{SalaryMin: 100000, SalaryMax: 140000},
+			low:  100000, high: 140000, overlaps: true,
+		},
+		{
+			name: "disjoint",
+			a:    model.Application{SalaryMin: 80000, SalaryMax: 100000},
+			b:    model.Application{SalaryMin: 120000, SalaryMax: 150000},
+		},
+		{
+			name: "one range within the other",
+			a:    model.Application{SalaryMin: 90000, SalaryMax: 200000},
+			b:    model.Application{SalaryMin: 120000, SalaryMax: 150000},
+			low:  120000, high: 150000, overlaps: true,
+		},
+		{
+			name: "only a minimum is open-ended",
+			a:    model.Application{SalaryMin: 130000},
+			b:    model.Application{SalaryMin: 100000, SalaryMax: 150000},
+			low:  130000, high: 150000, overlaps: true,
+		},
+		{
+			name: "only a maximum starts at zero",
+			a:    model.Application{SalaryMax: 110000},
+			b:    model.Application{SalaryMin: 100000, SalaryMax: 150000},
+			low:  100000, high: 110000, overlaps: true,
+		},
+		{
+			name: "two minimums overlap without an upper bound",
+			a:    model.Application{SalaryMin: 130000},
+			b:    model.Application{SalaryMin: 100000},
+			low:  130000, overlaps: true,
+		},
+		{
+			name: "minimum above the other maximum",
+			a:    model.Application{SalaryMin: 160000},
+			b:    model.Application{SalaryMin: 100000, SalaryMax: 150000},
+		},
+		{
+			name: "no salary",
+			a:    model.Application{},
+			b:    model.Application{SalaryMin: 100000, SalaryMax: 150000},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			low, high, overlaps := salaryOverlap(tt.a, tt.b)
+			if low != tt.low || high != tt.high || overlaps != tt.overlaps {
+				t.Errorf("salaryOverlap() = (%d, %d, %v), want (%d, %d, %v)", low, high, overlaps, tt.low, tt.high, tt.overlaps)
+			}
+			// Overlap doesn't depend on the order
+			low, high, overlaps = salaryOverlap(tt.b, tt.a)
+			if low != tt.low || high != tt.high || overlaps != tt.overlaps {
+				t.Errorf("salaryOverlap() swapped = (%d, %d, %v), want (%d, %d, %v)", low, high, overlaps, tt.low, tt.high, tt.overlaps)
+			}
+		})
+	}
+}
+
+func TestPrintComparison(t *testing.T) {
+	a := model.Application{ID: "aaaaaaaa-1", Company: "Acme", Position: "SWE", Status: model.StatusOffer, SalaryMin: 100000, SalaryMax: 140000}
+	b := model.Application{ID: "bbbbbbbb-2", Company: "Globex", Position: "Frontend", Status: model.StatusOffer, SalaryMin: 120000, SalaryMax: 160000, Remote: true}
+
+	var buf bytes.Buffer
+	printComparison(&buf, a, b, 100)
+	out := buf.String()
+
+	for _, want := range []string{"Acme", "Globex", "(remote)", "Salary overlap: $120k - $140k", "Higher ceiling: Globex ($160k)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
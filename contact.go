@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/model"
+	"github.com/celloopa/ghosted/internal/store"
+)
+
+const contactUsage = "Usage: ghosted contact add <id> --name <name> [--email E] [--role R] [--notes T] [--date YYYY-MM-DD]\n" +
+	"       ghosted contact list <id>"
+
+// cmdContact logs and lists the people involved in an application
+func cmdContact(s *store.Store, args []string) {
+	if len(args) < 2 {
+		fmt.Fprintln(os.Stderr, contactUsage)
+		os.Exit(1)
+	}
+
+	switch args[0] {
+	case "add":
+		cmdContactAdd(s, args[1:])
+	case "list":
+		app := findAppByID(s, args[1])
+		if app == nil {
+			fmt.Fprintf(os.Stderr, "Error: application not found: %s\n", args[1])
+			os.Exit(1)
+		}
+		printContacts(os.Stdout, *app)
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown contact command: %s\n", args[0])
+		fmt.Fprintln(os.Stderr, contactUsage)
+		os.Exit(1)
+	}
+}
+
+// cmdContactAdd parses the contact flags and appends the contact
+func cmdContactAdd(s *store.Store, args []string) {
+	id := args[0]
+	contact := model.Contact{LastContacted: time.Now()}
+
+	for i := 1; i < len(args); i++ {
+		if i+1 >= len(args) {
+			fmt.Fprintln(os.Stderr, contactUsage)
+			os.Exit(1)
+		}
+		switch args[i] {
+		case "--name":
+			contact.Name = args[i+1]
+		case "--email":
+			contact.Email = args[i+1]
+		case "--role":
+			contact.Role = args[i+1]
+		case "--notes":
+			contact.Notes = args[i+1]
+		case "--date":
+			t, err := time.ParseInLocation("2006-01-02", args[i+1], time.Local)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: --date expects YYYY-MM-DD, got %q\n", args[i+1])
+				os.Exit(1)
+			}
+			contact.LastContacted = t
+		default:
+			fmt.Fprintln(os.Stderr, contactUsage)
+			os.Exit(1)
+		}
+		i++
+	}
+	if contact.Name == "" {
+		fmt.Fprintln(os.Stderr, "Error: --name is required")
+		os.Exit(1)
+	}
+
+	app, err := addContact(s, id, contact)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Added contact %s to %s @ %s\n", contact.Name, app.Position, app.Company)
+}
+
+// addContact appends a contact to an application's contact log
+func addContact(s *store.Store, id string, contact model.Contact) (model.Application, error) {
+	app := findAppByID(s, id)
+	if app == nil {
+		return model.Application{}, fmt.Errorf("application not found: %s", id)
+	}
+	app.AddContact(contact)
+	if err := s.Update(*app); err != nil {
+		return model.Application{}, fmt.Errorf("updating application: %w", err)
+	}
+	return *app, nil
+}
+
+// printContacts lists an application's contacts, most recently contacted
+// first. Applications with only the legacy single contact show it.
+func printContacts(w io.Writer, app model.Application) {
+	contacts := app.ContactsByLastContacted()
+	if len(contacts) == 0 && (app.ContactName != "" || app.ContactEmail != "") {
+		contacts = []model.Contact{{Name: app.ContactName, Email: app.ContactEmail}}
+	}
+
+	fmt.Fprintf(w, "%s @ %s\n", app.Position, app.Company)
+	if len(contacts) == 0 {
+		fmt.Fprintln(w, "No contacts logged.")
+		return
+	}
+	for _, c := range contacts {
+		line := "  " + c.Name
+		if c.Role != "" {
+			line += " (" + c.Role + ")"
+		}
+		if c.Email != "" {
+			line += " <" + c.Email + ">"
+		}
+		if !c.LastContacted.IsZero() {
+			line += fmt.Sprintf(" - last contacted %s", c.LastContacted.Format("2006-01-02"))
+		}
+		fmt.Fprintln(w, line)
+		if c.Notes != "" {
+			fmt.Fprintf(w, "    %s\n", c.Notes)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/model"
+	"github.com/celloopa/ghosted/internal/store"
+)
+
+func TestAddContact(t *testing.T) {
+	s, err := store.New(filepath.Join(t.TempDir(), "applications.json"))
+	if err != nil {
+		t.Fatalf("store.New() error = %v", err)
+	}
+	app, err := s.Add(model.Application{Company: "Acme", Position: "Engineer"})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	if _, err := addContact(s, shortID(app.ID), model.Contact{Name: "Jane Doe", Email: "jane@acme.com"}); err != nil {
+		t.Fatalf("addContact() error = %v", err)
+	}
+	stored, _ := s.GetByID(app.ID)
+	if len(stored.Contacts) != 1 || stored.Contacts[0].Name != "Jane Doe" {
+		t.Errorf("Contacts = %+v, want Jane Doe", stored.Contacts)
+	}
+	if stored.ContactName != "Jane Doe" || stored.ContactEmail != "jane@acme.com" {
+		t.Errorf("legacy contact = %q <%q>, want Jane Doe", stored.ContactName, stored.ContactEmail)
+	}
+
+	if _, err := addContact(s, "nope", model.Contact{Name: "x"}); err == nil {
+		t.Error("addContact() for an unknown ID should error")
+	}
+}
+
+func TestPrintContacts(t *testing.T) {
+	app := model.Application{Company: "Acme", Position: "Engineer", Contacts: []model.Contact{
+		{Name: "Jane Doe", Role: "recruiter", LastContacted: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{Name: "Sam Lee", Email: "sam@acme.com", LastContacted: time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC), Notes: "Onsite debrief"},
+	}}
+
+	var out bytes.Buffer
+	printContacts(&out, app)
+	got := out.String()
+	if !strings.Contains(got, "Sam Lee <sam@acme.com> - last contacted 2026-03-08\n    Onsite debrief") {
+		t.Errorf("output missing Sam Lee's line:\n%s", got)
+	}
+	if strings.Index(got, "Sam Lee") > strings.Index(got, "Jane Doe (recruiter)") {
+		t.Errorf("contacts not most recent first:\n%s", got)
+	}
+
+	out.Reset()
+	printContacts(&out, model.Application{Company: "Acme", Position: "Engineer", ContactName: "Old Recruiter"})
+	if !strings.Contains(out.String(), "  Old Recruiter\n") {
+		t.Errorf("legacy contact not listed:\n%s", out.String())
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+// printCount writes the number of applications as a bare integer
+func printCount(w io.Writer, apps []model.Application) {
+	fmt.Fprintln(w, len(apps))
+}
+
+// printStatusCounts writes one "status: N" line per status that has
+// applications, in pipeline order. Statuses outside model.AllStatuses
+// follow in the order first seen.
+func printStatusCounts(w io.Writer, apps []model.Application) {
+	counts := make(map[string]int)
+	var unknown []string
+	for _, app := range apps {
+		if counts[app.Status] == 0 && !slices.Contains(model.AllStatuses(), app.Status) {
+			unknown = append(unknown, app.Status)
+		}
+		counts[app.Status]++
+	}
+
+	for _, status := range append(model.AllStatuses(), unknown...) {
+		if n := counts[status]; n > 0 {
+			fmt.Fprintf(w, "%s: %d\n", status, n)
+		}
+	}
+}
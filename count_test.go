@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/celloopa/ghosted/internal/model"
+	"github.com/celloopa/ghosted/internal/store"
+)
+
+var countApps = []model.Application{
+	{Company: "A", Status: model.StatusApplied},
+	{Company: "B", Status: model.StatusInterview},
+	{Company: "C", Status: model.StatusApplied},
+	{Company: "D", Status: model.StatusSaved},
+	{Company: "E", Status: model.StatusApplied, Remote: true},
+}
+
+func TestPrintCount_WithStatusFilter(t *testing.T) {
+	pred, err := store.ParseQuery("status:applied")
+	if err != nil {
+		t.Fatalf("ParseQuery() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	printCount(&buf, filterQuery(countApps, pred))
+	if got := buf.String(); got != "3\n" {
+		t.Errorf("count = %q, want %q", got, "3\n")
+	}
+
+	buf.Reset()
+	printCount(&buf, filterQuery(countApps, func(model.Application) bool { return false }))
+	if got := buf.String(); got != "0\n" {
+		t.Errorf("count with no matches = %q, want %q", got, "0\n")
+	}
+}
+
+func TestPrintStatusCounts(t *testing.T) {
+	apps := append(countApps, model.Application{Company: "F", Status: "archived"})
+
+	var buf bytes.Buffer
+	printStatusCounts(&buf, apps)
+
+	want := "saved: 1\napplied: 3\ninterview: 1\narchived: 1\n"
+	if got := buf.String(); got != want {
+		t.Errorf("grouped counts =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/model"
+	"github.com/celloopa/ghosted/internal/store"
+	"github.com/celloopa/ghosted/internal/timefmt"
+)
+
+// cmdDeadlines lists applications with an upcoming deadline, soonest first.
+// --all includes deadlines that have already passed.
+func cmdDeadlines(s *store.Store, args []string) {
+	jsonOutput := false
+	includePast := false
+	for _, arg := range args {
+		switch arg {
+		case "--json":
+			jsonOutput = true
+		case "--all":
+			includePast = true
+		default:
+			fmt.Fprintln(os.Stderr, "Usage: ghosted deadlines [--all] [--json]")
+			os.Exit(1)
+		}
+	}
+
+	now := time.Now()
+	apps := upcomingDeadlines(s.List(), now, includePast)
+
+	if jsonOutput {
+		output, _ := json.MarshalIndent(apps, "", "  ")
+		fmt.Println(string(output))
+		return
+	}
+
+	if len(apps) == 0 {
+		fmt.Println("No upcoming deadlines.")
+		return
+	}
+	for _, app := range apps {
+		fmt.Printf("%s  %-12s [%s] %s @ %s - %s\n",
+			app.Deadline.Format("2006-01-02"),
+			deadlineLabel(*app.Deadline, now),
+			shortID(app.ID),
+			app.Position,
+			app.Company,
+			model.StatusLabel(app.Status),
+		)
+	}
+}
+
+// upcomingDeadlines returns applications with a deadline, soonest first.
+// Deadlines before today are dropped unless includePast is set.
+func upcomingDeadlines(apps []model.Application, now time.Time, includePast bool) []model.Application {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	var result []model.Application
+	for _, app := range apps {
+		if app.Deadline == nil {
+			continue
+		}
+		if !includePast && app.Deadline.Before(today) {
+			continue
+		}
+		result = append(result, app)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Deadline.Before(*result[j].Deadline)
+	})
+	return result
+}
+
+// deadlineLabel describes a deadline date relative to now ("today", "in 3 days")
+func deadlineLabel(deadline, now time.Time) string {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if deadline.Equal(today) {
+		return "today"
+	}
+	return timefmt.HumanizeTime(deadline, today)
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+func TestUpcomingDeadlines_SoonestFirst(t *testing.T) {
+	date := func(s string) *time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return &d
+	}
+	now := time.Date(2024, 3, 10, 15, 0, 0, 0, time.UTC)
+	apps := []model.Application{
+		{ID: "later", Deadline: date("2024-04-01")},
+		{ID: "none"},
+		{ID: "past", Deadline: date("2024-03-01")},
+		{ID: "today", Deadline: date("2024-03-10")},
+		{ID: "soon", Deadline: date("2024-03-15")},
+	}
+
+	got := upcomingDeadlines(apps, now, false)
+	want := []string{"today", "soon", "later"}
+	if len(got) != len(want) {
+		t.Fatalf("got %d applications, want %d", len(got), len(want))
+	}
+	for i, id := range want {
+		if got[i].ID != id {
+			t.Errorf("position %d = %s, want %s", i, got[i].ID, id)
+		}
+	}
+
+	if all := upcomingDeadlines(apps, now, true); len(all) != 4 || all[0].ID != "past" {
+		t.Errorf("with includePast got %d applications starting with %v, want 4 starting with past", len(all), all)
+	}
+}
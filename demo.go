@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/celloopa/ghosted/internal/store"
+)
+
+// cmdDemo adds the sample applications, for exploring the TUI before
+// tracking real applications
+func cmdDemo(s *store.Store, args []string) {
+	if len(args) > 0 {
+		fmt.Fprintln(os.Stderr, "Usage: ghosted demo")
+		os.Exit(1)
+	}
+
+	added, err := s.LoadSampleData()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if added == 0 {
+		fmt.Println("Sample applications are already loaded.")
+		return
+	}
+	fmt.Printf("Added %d sample applications (IDs sample-001 to sample-%03d).\n", added, added)
+	fmt.Println("Run 'ghosted' to explore them; remove one with 'ghosted delete <id>'.")
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/celloopa/ghosted/internal/model"
+	"github.com/celloopa/ghosted/internal/store"
+)
+
+// findAppForDir returns the application for a documents folder: the one
+// linked to it, or failing that the one its {company}-{position} name matches
+func findAppForDir(s *store.Store, dir string) *model.Application {
+	if app, ok := s.FindByDocumentsDir(dir); ok {
+		return &app
+	}
+	return findAppByFolder(s, dir)
+}
+
+// linkCandidates returns applications whose company appears in the folder
+// name, for folders named differently from {company}-{position}
+func linkCandidates(s *store.Store, dir string) []model.Application {
+	base := sanitizeForMatch(filepath.Base(filepath.Clean(dir)))
+	return s.Find(func(a model.Application) bool {
+		company := sanitizeForMatch(a.Company)
+		return company != "" && strings.Contains(base, company)
+	})
+}
+
+// promptLink asks which candidate a folder belongs to, reading the answer
+// from r. It returns nil when the user skips.
+func promptLink(r io.Reader, w io.Writer, dir string, candidates []model.Application) *model.Application {
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	if len(candidates) == 1 {
+		app := candidates[0]
+		fmt.Fprintf(w, "No application is linked to %s.\nLink it to %s @ %s (%s)? [y/N] ", dir, app.Position, app.Company, shortID(app.ID))
+		answer := readAnswer(r)
+		if strings.EqualFold(answer, "y") || strings.EqualFold(answer, "yes") {
+			return &app
+		}
+		return nil
+	}
+
+	fmt.Fprintf(w, "No application is linked to %s. Possible matches:\n", dir)
+	for i, app := range candidates {
+		fmt.Fprintf(w, "  %d. %s @ %s (%s)\n", i+1, app.Position, app.Company, shortID(app.ID))
+	}
+	fmt.Fprintf(w, "Link to which? [1-%d, Enter to skip] ", len(candidates))
+	n, err := strconv.Atoi(readAnswer(r))
+	if err != nil || n < 1 || n > len(candidates) {
+		return nil
+	}
+	return &candidates[n-1]
+}
+
+// readAnswer reads one trimmed line of input
+func readAnswer(r io.Reader) string {
+	line, _ := bufio.NewReader(r).ReadString('\n')
+	return strings.TrimSpace(line)
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/celloopa/ghosted/internal/model"
+	"github.com/celloopa/ghosted/internal/store"
+)
+
+func TestFindAppForDir_PrefersLinkedFolder(t *testing.T) {
+	t.Chdir(t.TempDir())
+	s, err := store.New(filepath.Join(t.TempDir(), "applications.json"))
+	if err != nil {
+		t.Fatalf("store.New() error = %v", err)
+	}
+	// Matches acme-engineer by name, but the linked application wins
+	s.Add(model.Application{Company: "Acme", Position: "Engineer", Status: model.StatusApplied})
+	linked, _ := s.Add(model.Application{Company: "Acme", Position: "Staff Engineer", Status: model.StatusApplied})
+	if err := s.LinkDocuments(linked.ID, "local/applications/swe/acme-engineer/"); err != nil {
+		t.Fatal(err)
+	}
+
+	if app := findAppForDir(s, "local/applications/swe/acme-engineer"); app == nil || app.ID != linked.ID {
+		t.Errorf("findAppForDir() = %v, want the linked application", app)
+	}
+	if app := findAppForDir(s, "local/applications/swe/acme-engineer-2/"); app != nil {
+		t.Errorf("findAppForDir() = %+v, want nil for an unlinked, unmatched folder", app)
+	}
+}
+
+func TestLinkCandidates(t *testing.T) {
+	s, err := store.New(filepath.Join(t.TempDir(), "applications.json"))
+	if err != nil {
+		t.Fatalf("store.New() error = %v", err)
+	}
+	s.Add(model.Application{Company: "Acme Corp", Position: "Engineer", Status: model.StatusApplied})
+	s.Add(model.Application{Company: "Globex", Position: "Engineer", Status: model.StatusApplied})
+
+	got := linkCandidates(s, "local/applications/swe/acme-corp-take-2/")
+	if len(got) != 1 || got[0].Company != "Acme Corp" {
+		t.Errorf("linkCandidates() = %+v, want Acme Corp only", got)
+	}
+}
+
+func TestPromptLink(t *testing.T) {
+	one := []model.Application{{ID: "aaaaaaaa1", Company: "Acme", Position: "Engineer"}}
+	two := append(one, model.Application{ID: "bbbbbbbb2", Company: "Acme", Position: "Designer"})
+
+	cases := []struct {
+		name       string
+		candidates []model.Application
+		input      string
+		wantID     string
+	}{
+		{"no candidates", nil, "y\n", ""},
+		{"confirm single", one, "y\n", "aaaaaaaa1"},
+		{"decline single", one, "\n", ""},
+		{"pick second", two, "2\n", "bbbbbbbb2"},
+		{"skip choice", two, "\n", ""},
+		{"out of range", two, "3\n", ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := promptLink(strings.NewReader(tc.input), io.Discard, "acme-v2", tc.candidates)
+			gotID := ""
+			if app != nil {
+				gotID = app.ID
+			}
+			if gotID != tc.wantID {
+				t.Errorf("promptLink() = %q, want %q", gotID, tc.wantID)
+			}
+		})
+	}
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/model"
+	"github.com/celloopa/ghosted/internal/store"
+)
+
+const exportUsage = "Usage: ghosted export --pdf-bundle <out.zip> | --format csv|notion|html [--status-map file.json] [--output file]"
+
+// bundleEntry is a PDF to add to an export bundle under a descriptive name
+type bundleEntry struct {
+	Name string // Name inside the zip, e.g. acme-swe-resume.pdf
+	Path string // Source file on disk
+}
+
+// cmdExport exports application documents, or the tracker in another
+// tool's format
+func cmdExport(s *store.Store, args []string) {
+	var bundlePath, format, statusMapPath, outputPath string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--pdf-bundle":
+			if i+1 < len(args) {
+				bundlePath = args[i+1]
+				i++
+			}
+		case "--format":
+			if i+1 < len(args) {
+				format = args[i+1]
+				i++
+			}
+		case "--status-map":
+			if i+1 < len(args) {
+				statusMapPath = args[i+1]
+				i++
+			}
+		case "--output", "-o":
+			if i+1 < len(args) {
+				outputPath = args[i+1]
+				i++
+			}
+		}
+	}
+
+	if format != "" {
+		exportFormat(s, format, statusMapPath, outputPath)
+		return
+	}
+	if bundlePath == "" {
+		fmt.Fprintln(os.Stderr, exportUsage)
+		os.Exit(1)
+	}
+
+	entries, missing := collectPDFBundle(s.List(), "local/applications")
+	for _, app := range missing {
+		fmt.Fprintf(os.Stderr, "Warning: no compiled PDFs for %s - %s (%s), skipping\n", app.Company, app.Position, shortID(app.ID))
+	}
+
+	if len(entries) == 0 {
+		fmt.Fprintln(os.Stderr, "Error: no compiled PDFs found. Run 'ghosted compile <id>' first.")
+		os.Exit(1)
+	}
+
+	if err := writePDFBundle(bundlePath, entries); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing bundle: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Wrote %d PDF(s) to %s\n", len(entries), bundlePath)
+	for _, e := range entries {
+		fmt.Printf("  • %s\n", e.Name)
+	}
+}
+
+// exportFormat writes every application in an export profile's format, to
+// outputPath or stdout
+func exportFormat(s *store.Store, format, statusMapPath, outputPath string) {
+	switch format {
+	case "csv", "html":
+		if statusMapPath != "" {
+			fmt.Fprintln(os.Stderr, "Error: --status-map only applies to --format notion")
+			os.Exit(1)
+		}
+	case "notion":
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown export format %q (supported: csv, notion, html)\n", format)
+		os.Exit(1)
+	}
+
+	var statusMap map[string]string
+	if statusMapPath != "" {
+		var err error
+		if statusMap, err = loadStatusMap(statusMapPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	w := io.Writer(os.Stdout)
+	if outputPath != "" {
+		f, err := os.Create(outputPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	apps := s.List()
+	write := func() error { return writeNotionCSV(w, apps, statusMap) }
+	switch format {
+	case "csv":
+		write = func() error { return store.ExportCSV(w, apps) }
+	case "html":
+		write = func() error { return writeHTMLReport(w, apps, s.Stats(time.Now(), defaultStatsWeeks)) }
+	}
+	if err := write(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing %s export: %v\n", format, err)
+		os.Exit(1)
+	}
+	if outputPath != "" {
+		fmt.Printf("Wrote %d application(s) to %s\n", len(apps), outputPath)
+	}
+}
+
+// collectPDFBundle finds the compiled resume and cover letter PDFs for each
+// application. Paths recorded on the application are used first, then
+// resume.pdf and cover-letter.pdf in its folder under baseDir.
+// Applications with no PDFs are returned as missing.
+func collectPDFBundle(apps []model.Application, baseDir string) ([]bundleEntry, []model.Application) {
+	var entries []bundleEntry
+	var missing []model.Application
+	used := make(map[string]bool)
+
+	for i := range apps {
+		app := &apps[i]
+		folder := findAppFolderIn(baseDir, app)
+
+		name := appBaseName(app)
+		if used[name] {
+			// Same company and position applied to twice
+			name += "-" + shortID(app.ID)
+		}
+
+		found := false
+		for _, doc := range []struct {
+			recorded string
+			file     string
+			suffix   string
+		}{
+			{app.ResumeVersion, "resume.pdf", "resume"},
+			{app.CoverLetter, "cover-letter.pdf", "cover-letter"},
+		} {
+			path := existingPDF(doc.recorded, folder, doc.file)
+			if path == "" {
+				continue
+			}
+			entries = append(entries, bundleEntry{
+				Name: fmt.Sprintf("%s-%s.pdf", name, doc.suffix),
+				Path: path,
+			})
+			found = true
+		}
+
+		if found {
+			used[name] = true
+		} else {
+			missing = append(missing, *app)
+		}
+	}
+
+	return entries, missing
+}
+
+// existingPDF returns recorded if it is a PDF on disk, otherwise file inside
+// folder if that exists, otherwise ""
+func existingPDF(recorded, folder, file string) string {
+	if strings.EqualFold(filepath.Ext(recorded), ".pdf") && fileExists(recorded) {
+		return recorded
+	}
+	if folder != "" {
+		if path := filepath.Join(folder, file); fileExists(path) {
+			return path
+		}
+	}
+	return ""
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+// writePDFBundle writes the entries into a new zip archive at zipPath
+func writePDFBundle(zipPath string, entries []bundleEntry) error {
+	if dir := filepath.Dir(zipPath); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.Create(zipPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	for _, e := range entries {
+		if err := addZipFile(zw, e); err != nil {
+			zw.Close()
+			return fmt.Errorf("failed to add %s: %w", e.Path, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func addZipFile(zw *zip.Writer, e bundleEntry) error {
+	src, err := os.Open(e.Path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	info, err := src.Stat()
+	if err != nil {
+		return err
+	}
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return err
+	}
+	header.Name = e.Name
+	header.Method = zip.Deflate
+
+	w, err := zw.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, src)
+	return err
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+}
+
+func TestPDFBundle(t *testing.T) {
+	baseDir := t.TempDir()
+
+	// Found via the application folder
+	writeTestFile(t, filepath.Join(baseDir, "swe", "acme-software-engineer", "resume.pdf"), "acme resume")
+	writeTestFile(t, filepath.Join(baseDir, "swe", "acme-software-engineer", "cover-letter.pdf"), "acme cover")
+	// Found via the recorded path
+	recorded := filepath.Join(t.TempDir(), "custom-name.pdf")
+	writeTestFile(t, recorded, "globex resume")
+	// Only .typ sources, no compiled PDFs
+	writeTestFile(t, filepath.Join(baseDir, "initech-designer", "resume.typ"), "source")
+
+	apps := []model.Application{
+		{ID: "aaaaaaaa-1", Company: "Acme", Position: "Software Engineer"},
+		{ID: "bbbbbbbb-2", Company: "Globex Corp.", Position: "Staff/Principal Engineer", ResumeVersion: recorded},
+		{ID: "cccccccc-3", Company: "Initech", Position: "Designer"},
+	}
+
+	entries, missing := collectPDFBundle(apps, baseDir)
+
+	if len(missing) != 1 || missing[0].Company != "Initech" {
+		t.Errorf("missing = %+v, want only Initech", missing)
+	}
+
+	zipPath := filepath.Join(t.TempDir(), "out", "bundle.zip")
+	if err := writePDFBundle(zipPath, entries); err != nil {
+		t.Fatalf("writePDFBundle() error = %v", err)
+	}
+
+	zr, err := zip.OpenReader(zipPath)
+	if err != nil {
+		t.Fatalf("OpenReader() error = %v", err)
+	}
+	defer zr.Close()
+
+	var names []string
+	sizes := make(map[string]uint64)
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+		sizes[f.Name] = f.UncompressedSize64
+	}
+	sort.Strings(names)
+
+	want := []string{
+		"acme-software-engineer-cover-letter.pdf",
+		"acme-software-engineer-resume.pdf",
+		"globex-corp.-staffprincipal-engineer-resume.pdf",
+	}
+	if len(names) != len(want) {
+		t.Fatalf("zip entries = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("zip entry %d = %q, want %q", i, names[i], want[i])
+		}
+	}
+	if sizes["acme-software-engineer-resume.pdf"] != uint64(len("acme resume")) {
+		t.Errorf("resume entry size = %d, want %d", sizes["acme-software-engineer-resume.pdf"], len("acme resume"))
+	}
+}
+
+func TestPDFBundle_DuplicateNames(t *testing.T) {
+	baseDir := t.TempDir()
+	first := filepath.Join(t.TempDir(), "first.pdf")
+	second := filepath.Join(t.TempDir(), "second.pdf")
+	writeTestFile(t, first, "1")
+	writeTestFile(t, second, "2")
+
+	apps := []model.Application{
+		{ID: "11111111-a", Company: "Acme", Position: "SWE", ResumeVersion: first},
+		{ID: "22222222-b", Company: "Acme", Position: "SWE", ResumeVersion: second},
+	}
+
+	entries, _ := collectPDFBundle(apps, baseDir)
+	if len(entries) != 2 {
+		t.Fatalf("entries = %+v, want 2", entries)
+	}
+	if entries[0].Name == entries[1].Name {
+		t.Errorf("duplicate zip entry name %q", entries[0].Name)
+	}
+	if entries[1].Name != "acme-swe-22222222-resume.pdf" {
+		t.Errorf("second entry name = %q, want acme-swe-22222222-resume.pdf", entries[1].Name)
+	}
+}
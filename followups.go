@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/model"
+	"github.com/celloopa/ghosted/internal/store"
+)
+
+// upcomingFollowUpDays is how far ahead followups --upcoming looks
+const upcomingFollowUpDays = 7
+
+// followUp is an application with its follow-up date relative to today, as
+// printed by followups --json. DaysOverdue is negative for upcoming ones.
+type followUp struct {
+	model.Application
+	DaysOverdue int `json:"days_overdue"`
+}
+
+// cmdFollowUps lists applications whose follow-up is due, most overdue
+// first. --upcoming lists the ones due in the next week instead.
+func cmdFollowUps(s *store.Store, args []string) {
+	jsonOutput := false
+	upcoming := false
+	for _, arg := range args {
+		switch arg {
+		case "--json":
+			jsonOutput = true
+		case "--upcoming":
+			upcoming = true
+		default:
+			fmt.Fprintln(os.Stderr, "Usage: ghosted followups [--upcoming] [--json]")
+			os.Exit(1)
+		}
+	}
+
+	now := time.Now()
+	var apps []model.Application
+	if upcoming {
+		apps = s.UpcomingFollowUps(now, upcomingFollowUpDays)
+	} else {
+		apps = s.DueFollowUps(now)
+	}
+
+	if jsonOutput {
+		result := make([]followUp, len(apps))
+		for i, app := range apps {
+			result[i] = followUp{Application: app, DaysOverdue: daysOverdue(*app.NextFollowUp, now)}
+		}
+		output, _ := json.MarshalIndent(result, "", "  ")
+		fmt.Println(string(output))
+		return
+	}
+
+	if len(apps) == 0 {
+		if upcoming {
+			fmt.Printf("No follow-ups in the next %d days.\n", upcomingFollowUpDays)
+		} else {
+			fmt.Println("No follow-ups due.")
+		}
+		return
+	}
+	for _, app := range apps {
+		fmt.Printf("%s  %-15s [%s] %s @ %s - %s\n",
+			app.NextFollowUp.Format("2006-01-02"),
+			followUpLabel(daysOverdue(*app.NextFollowUp, now)),
+			shortID(app.ID),
+			app.Position,
+			app.Company,
+			model.StatusLabel(app.Status),
+		)
+	}
+}
+
+// daysOverdue counts whole days from a follow-up date to now's date; it's
+// negative for follow-ups still to come
+func daysOverdue(date, now time.Time) int {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	return int(today.Sub(day).Hours() / 24)
+}
+
+// followUpLabel describes a follow-up by its days overdue ("today",
+// "3 days overdue", "in 2 days")
+func followUpLabel(days int) string {
+	switch {
+	case days == 0:
+		return "today"
+	case days == 1:
+		return "1 day overdue"
+	case days > 1:
+		return fmt.Sprintf("%d days overdue", days)
+	case days == -1:
+		return "tomorrow"
+	default:
+		return fmt.Sprintf("in %d days", -days)
+	}
+}
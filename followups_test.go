@@ -0,0 +1,26 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFollowUpLabel(t *testing.T) {
+	now := time.Date(2026, 5, 12, 22, 30, 0, 0, time.UTC)
+	tests := []struct {
+		date string
+		want string
+	}{
+		{"2026-05-12", "today"},
+		{"2026-05-11", "1 day overdue"},
+		{"2026-05-02", "10 days overdue"},
+		{"2026-05-13", "tomorrow"},
+		{"2026-05-16", "in 4 days"},
+	}
+	for _, tt := range tests {
+		date, _ := time.Parse("2006-01-02", tt.date)
+		if got := followUpLabel(daysOverdue(date, now)); got != tt.want {
+			t.Errorf("followUpLabel(%s) = %q, want %q", tt.date, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/celloopa/ghosted/internal/agent"
+)
+
+const gapsUsage = "Usage: ghosted gaps <posting-file> [--cv path]"
+
+// cmdGaps prints what a posting asks for that the CV does and doesn't show,
+// without generating anything
+func cmdGaps(args []string) {
+	var postingPath string
+	cvPath := defaultCVPath
+
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--cv" && i+1 < len(args):
+			cvPath = args[i+1]
+			i++
+		case !isFlag(args[i]) && postingPath == "":
+			postingPath = args[i]
+		default:
+			fmt.Fprintln(os.Stderr, gapsUsage)
+			os.Exit(1)
+		}
+	}
+	if postingPath == "" {
+		fmt.Fprintln(os.Stderr, gapsUsage)
+		os.Exit(1)
+	}
+
+	cv, err := readCV(cvPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	pipeline, err := agent.NewPipeline(pipelineConfigPath, nil)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	posting, err := pipeline.Parse(postingPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	printGapReport(os.Stdout, posting, agent.AnalyzeGaps(cv, posting))
+}
+
+// printGapReport writes the met, missing, and bonus sections of a report
+func printGapReport(w io.Writer, posting *agent.ParsedPosting, report *agent.GapReport) {
+	fmt.Fprintf(w, "%s @ %s: %.1f%% match\n", posting.Position, posting.Company, report.MatchPercent)
+
+	if len(report.Met)+len(report.Missing)+len(report.Bonus) == 0 {
+		fmt.Fprintln(w, "\nNo requirements or skills found in the posting.")
+		return
+	}
+
+	section := func(title, symbol string, items []agent.GapItem, detail func(agent.GapItem) string) {
+		if len(items) == 0 {
+			return
+		}
+		fmt.Fprintf(w, "\n%s (%d):\n", title, len(items))
+		for _, item := range items {
+			fmt.Fprintf(w, "  %s %s\n", symbol, item.Requirement)
+			if d := detail(item); d != "" {
+				fmt.Fprintf(w, "      %s\n", d)
+			}
+		}
+	}
+	evidence := func(item agent.GapItem) string { return item.Evidence }
+
+	section("Met", "✓", report.Met, evidence)
+	section("Missing", "✗", report.Missing, func(item agent.GapItem) string {
+		if item.Suggestion == "" {
+			return ""
+		}
+		return "closest CV skill: " + item.Suggestion
+	})
+	section("Bonus", "+", report.Bonus, evidence)
+}
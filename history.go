@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultHistoryLimit is how many entries `ghosted history` shows by default
+const defaultHistoryLimit = 20
+
+// historyEntry is a single recorded command invocation
+type historyEntry struct {
+	Time    time.Time
+	Command string
+}
+
+// getHistoryPath returns the command history log, stored next to the data file
+func getHistoryPath() string {
+	return filepath.Join(filepath.Dir(getDataPath()), "history.log")
+}
+
+// recordHistory appends a command to the history log.
+// Best effort: errors are ignored so logging never fails the actual command.
+func recordHistory(args []string) {
+	if len(args) == 0 {
+		return
+	}
+	switch args[0] {
+	case "history", "help", "--help", "-h":
+		return
+	}
+	_ = appendHistory(getHistoryPath(), args, time.Now())
+}
+
+// appendHistory writes one tab-separated "timestamp<TAB>command" line to the log
+func appendHistory(path string, args []string, now time.Time) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintf(f, "%s\t%s\n", now.Format(time.RFC3339), formatHistoryArgs(args))
+	return err
+}
+
+// formatHistoryArgs joins arguments into a single line, quoting any that
+// contain whitespace or quotes so the command can be copied and re-run
+func formatHistoryArgs(args []string) string {
+	parts := make([]string, len(args))
+	for i, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t\n'\"") {
+			parts[i] = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		} else {
+			parts[i] = arg
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// readHistory returns up to limit entries from the log, newest first.
+// A missing log is treated as empty; malformed lines are skipped.
+func readHistory(path string, limit int) ([]historyEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []historyEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		stamp, command, ok := strings.Cut(scanner.Text(), "\t")
+		if !ok {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, stamp)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, historyEntry{Time: t, Command: command})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// Reverse so the most recent command comes first
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries, nil
+}
+
+// cmdHistory shows or clears the command history log
+func cmdHistory(args []string) {
+	path := getHistoryPath()
+	limit := defaultHistoryLimit
+
+	for _, arg := range args {
+		switch {
+		case arg == "--clear":
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				fmt.Fprintf(os.Stderr, "Error clearing history: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Println("History cleared.")
+			return
+		case !isFlag(arg):
+			n, err := strconv.Atoi(arg)
+			if err != nil || n <= 0 {
+				fmt.Fprintln(os.Stderr, "Usage: ghosted history [N] [--clear]")
+				os.Exit(1)
+			}
+			limit = n
+		}
+	}
+
+	entries, err := readHistory(path, limit)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading history: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("No history yet.")
+		return
+	}
+
+	for _, e := range entries {
+		fmt.Printf("%s  ghosted %s\n", e.Time.Local().Format("2006-01-02 15:04:05"), e.Command)
+	}
+}
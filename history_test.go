@@ -0,0 +1,103 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAppendHistory_Format(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "history.log")
+	now := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	if err := appendHistory(path, []string{"list", "--format", "table"}, now); err != nil {
+		t.Fatalf("appendHistory() error = %v", err)
+	}
+	if err := appendHistory(path, []string{"add", "--json", `{"company":"Acme Corp"}`}, now.Add(time.Minute)); err != nil {
+		t.Fatalf("appendHistory() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+
+	want := "2026-01-02T15:04:05Z\tlist --format table\n" +
+		"2026-01-02T15:05:05Z\tadd --json '{\"company\":\"Acme Corp\"}'\n"
+	if string(data) != want {
+		t.Errorf("history log =\n%q\nwant\n%q", string(data), want)
+	}
+}
+
+func TestFormatHistoryArgs(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"list"}, "list"},
+		{[]string{"get", "abc123", "--json"}, "get abc123 --json"},
+		{[]string{"apply", "my posting.md"}, "apply 'my posting.md'"},
+		{[]string{"update", "x", "--json", `{"notes":"it's"}`}, `update x --json '{"notes":"it'\''s"}'`},
+		{[]string{"fetch", ""}, "fetch ''"},
+	}
+
+	for _, tt := range tests {
+		if got := formatHistoryArgs(tt.args); got != tt.want {
+			t.Errorf("formatHistoryArgs(%q) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestReadHistory_LastNNewestFirst(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.log")
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	for i, cmd := range []string{"list", "get abc", "fetch example.com", "apply p.md", "context"} {
+		if err := appendHistory(path, strings.Fields(cmd), start.Add(time.Duration(i)*time.Hour)); err != nil {
+			t.Fatalf("appendHistory() error = %v", err)
+		}
+	}
+
+	entries, err := readHistory(path, 3)
+	if err != nil {
+		t.Fatalf("readHistory() error = %v", err)
+	}
+
+	want := []string{"context", "apply p.md", "fetch example.com"}
+	if len(entries) != len(want) {
+		t.Fatalf("readHistory() returned %d entries, want %d", len(entries), len(want))
+	}
+	for i, w := range want {
+		if entries[i].Command != w {
+			t.Errorf("entries[%d].Command = %q, want %q", i, entries[i].Command, w)
+		}
+	}
+	if !entries[0].Time.After(entries[1].Time) {
+		t.Error("entries should be in reverse-chronological order")
+	}
+}
+
+func TestReadHistory_MissingAndMalformed(t *testing.T) {
+	dir := t.TempDir()
+
+	entries, err := readHistory(filepath.Join(dir, "missing.log"), 10)
+	if err != nil || len(entries) != 0 {
+		t.Errorf("readHistory(missing) = %v, %v; want empty, nil", entries, err)
+	}
+
+	path := filepath.Join(dir, "history.log")
+	content := "garbage line\nnot-a-time\tlist\n2026-01-01T09:00:00Z\tlist\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	entries, err = readHistory(path, 10)
+	if err != nil {
+		t.Fatalf("readHistory() error = %v", err)
+	}
+	if len(entries) != 1 || entries[0].Command != "list" {
+		t.Errorf("readHistory() = %+v, want only the valid entry", entries)
+	}
+}
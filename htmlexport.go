@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+
+	"github.com/celloopa/ghosted/internal/model"
+	"github.com/celloopa/ghosted/internal/store"
+	"github.com/celloopa/ghosted/internal/tui"
+)
+
+// htmlReport is the data behind the HTML export
+type htmlReport struct {
+	Stats   store.Stats
+	Summary []htmlStat
+	// ByStatus counts applications per status, in pipeline order
+	ByStatus []htmlStatusCount
+	Rows     []htmlRow
+	// StatusCSS colors the status badges like the TUI
+	StatusCSS template.CSS
+}
+
+// htmlStat is one figure in the report's summary
+type htmlStat struct {
+	Label string
+	Value string
+}
+
+// htmlStatusCount is a status badge with its application count
+type htmlStatusCount struct {
+	Status string
+	Count  int
+}
+
+// htmlRow is one application in the report's table. The Sort fields give
+// the table's sort script comparable values.
+type htmlRow struct {
+	Company, Position, Location string
+	Status, StatusLabel         string
+	Applied, AppliedSort        string
+	Salary                      string
+	SalarySort                  int
+	Priority                    string
+	PrioritySort                int
+}
+
+// writeHTMLReport writes a self-contained HTML page summarizing the search:
+// stats at the top, then a sortable table of apps. Everything, styles and
+// the sort script included, is inline so the file can be shared on its own.
+// The page is well-formed XML as well as HTML.
+func writeHTMLReport(w io.Writer, apps []model.Application, stats store.Stats) error {
+	report := htmlReport{
+		Stats: stats,
+		Summary: []htmlStat{
+			{"Total", fmt.Sprint(stats.Total)},
+			{"Active", fmt.Sprint(stats.Active)},
+			{"Interviewing", fmt.Sprint(stats.Interviewing)},
+			{"Applied this week", fmt.Sprint(stats.ThisWeek)},
+			{"Response rate", fmt.Sprintf("%.1f%%", stats.ResponseRate)},
+			{"Ghost rate", fmt.Sprintf("%.1f%%", stats.GhostRate)},
+			{"Avg days to first interview", firstResponseSummary(stats)},
+			{"Response time", responseTimeSummary(stats)},
+		},
+		StatusCSS: statusBadgeCSS(),
+	}
+	for _, status := range sortedStatuses(stats.ByStatus) {
+		report.ByStatus = append(report.ByStatus, htmlStatusCount{status, stats.ByStatus[status]})
+	}
+	for _, app := range apps {
+		row := htmlRow{
+			Company:      app.Company,
+			Position:     app.Position,
+			Location:     app.Location,
+			Status:       app.Status,
+			StatusLabel:  model.StatusLabel(app.Status),
+			Applied:      "Not sent",
+			Salary:       app.SalaryRange(),
+			SalarySort:   max(app.SalaryMin, app.SalaryMax),
+			Priority:     model.PriorityStars(app.Priority),
+			PrioritySort: app.Priority,
+		}
+		if app.Remote {
+			row.Location = "Remote"
+			if app.Location != "" {
+				row.Location = app.Location + " (remote)"
+			}
+		}
+		if app.DateApplied != nil {
+			row.Applied = app.DateApplied.Format("2006-01-02")
+			row.AppliedSort = row.Applied
+		}
+		report.Rows = append(report.Rows, row)
+	}
+	return htmlReportTemplate.Execute(w, report)
+}
+
+// statusBadgeCSS renders a badge color rule per status, using the TUI's
+// status colors
+func statusBadgeCSS() template.CSS {
+	var b strings.Builder
+	for _, status := range model.AllStatuses() {
+		fmt.Fprintf(&b, ".status-%s { background: %s; }\n", status, tui.GetStatusColor(status))
+	}
+	return template.CSS(b.String())
+}
+
+// htmlReportTemplate is the export page. Void elements are self-closed and
+// the script avoids < and & so the page also parses as XML.
+var htmlReportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8"/>
+<meta name="viewport" content="width=device-width, initial-scale=1"/>
+<title>Job Search Report</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2rem; color: #1a1a2e; background: #fafafa; }
+h1 { color: #7d56f4; margin-bottom: 0.25rem; }
+.generated { color: #6c6c6c; margin-top: 0; }
+.summary { display: flex; flex-wrap: wrap; gap: 0.75rem; margin: 1.5rem 0; }
+.stat { background: #fff; border: 1px solid #e0e0e0; border-radius: 8px; padding: 0.75rem 1rem; min-width: 8rem; }
+.stat .label { color: #6c6c6c; font-size: 0.8rem; }
+.stat .value { font-size: 1.2rem; font-weight: 600; }
+.by-status { margin-bottom: 1.5rem; }
+table { border-collapse: collapse; width: 100%; background: #fff; }
+th, td { text-align: left; padding: 0.5rem 0.75rem; border-bottom: 1px solid #e0e0e0; }
+th { cursor: pointer; user-select: none; background: #252541; color: #fff; }
+th[data-dir="asc"]::after { content: " ▲"; }
+th[data-dir="desc"]::after { content: " ▼"; }
+.badge { display: inline-block; padding: 0.1rem 0.5rem; border-radius: 999px; color: #1a1a2e; font-size: 0.85rem; font-weight: 600; }
+{{.StatusCSS}}</style>
+</head>
+<body>
+<h1>Job Search Report</h1>
+<p class="generated">Generated {{.Stats.GeneratedAt.Format "January 2, 2006"}}</p>
+<div class="summary">
+{{- range .Summary}}
+<div class="stat"><div class="label">{{.Label}}</div><div class="value">{{.Value}}</div></div>
+{{- end}}
+</div>
+<div class="by-status">
+{{- range .ByStatus}}
+<span class="badge status-{{.Status}}">{{.Status}}: {{.Count}}</span>
+{{- end}}
+</div>
+<table>
+<thead>
+<tr><th>Company</th><th>Position</th><th>Status</th><th>Applied</th><th>Location</th><th data-type="number">Salary</th><th data-type="number">Priority</th></tr>
+</thead>
+<tbody>
+{{- range .Rows}}
+<tr class="app"><td data-sort="{{.Company}}">{{.Company}}</td><td data-sort="{{.Position}}">{{.Position}}</td><td data-sort="{{.Status}}"><span class="badge status-{{.Status}}">{{.StatusLabel}}</span></td><td data-sort="{{.AppliedSort}}">{{.Applied}}</td><td data-sort="{{.Location}}">{{.Location}}</td><td data-sort="{{.SalarySort}}">{{.Salary}}</td><td data-sort="{{.PrioritySort}}">{{.Priority}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+<script>
+document.querySelectorAll("th").forEach(function (th, col) {
+  th.addEventListener("click", function () {
+    var tbody = th.closest("table").tBodies[0];
+    var dir = th.dataset.dir === "asc" ? -1 : 1;
+    th.parentNode.querySelectorAll("th").forEach(function (h) { delete h.dataset.dir; });
+    th.dataset.dir = dir === 1 ? "asc" : "desc";
+    var numeric = th.dataset.type === "number";
+    Array.from(tbody.rows).sort(function (a, b) {
+      var x = a.cells[col].dataset.sort, y = b.cells[col].dataset.sort;
+      return dir * (numeric ? Number(x) - Number(y) : x.localeCompare(y));
+    }).forEach(function (row) { tbody.appendChild(row); });
+  });
+});
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/model"
+	"github.com/celloopa/ghosted/internal/store"
+)
+
+func TestWriteHTMLReport(t *testing.T) {
+	applied := time.Date(2026, 3, 9, 15, 4, 0, 0, time.UTC)
+	apps := []model.Application{
+		{ID: "1", Company: "Acme", Position: "Frontend Engineer", Status: model.StatusInterview,
+			DateApplied: &applied, Remote: true, SalaryMin: 140000, SalaryMax: 180000, Priority: 3},
+		{ID: "2", Company: "AT&T <Labs>", Position: "Designer", Status: model.StatusSaved},
+		{ID: "3", Company: "Initech", Position: "SRE", Status: model.StatusRejected, DateApplied: &applied},
+	}
+	stats := store.Stats{
+		GeneratedAt:  time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC),
+		Total:        3,
+		Active:       1,
+		Interviewing: 1,
+		ResponseRate: 100,
+		ByStatus:     map[string]int{model.StatusInterview: 1, model.StatusSaved: 1, model.StatusRejected: 1},
+	}
+
+	var buf bytes.Buffer
+	if err := writeHTMLReport(&buf, apps, stats); err != nil {
+		t.Fatalf("writeHTMLReport() error = %v", err)
+	}
+	page := buf.String()
+
+	// Well-formed: every element closes, in order, and text is escaped
+	decoder := xml.NewDecoder(strings.NewReader(page))
+	rows, cells := 0, 0
+	var elements []string
+	for {
+		tok, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("report isn't well-formed: %v\n%s", err, page)
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			elements = append(elements, start.Name.Local)
+			switch start.Name.Local {
+			case "tr":
+				for _, attr := range start.Attr {
+					if attr.Name.Local == "class" && attr.Value == "app" {
+						rows++
+					}
+				}
+			case "td":
+				cells++
+			}
+		}
+	}
+	if rows != len(apps) || cells != len(apps)*7 {
+		t.Errorf("table has %d rows and %d cells, want a 7-column row per application (%d)", rows, cells, len(apps))
+	}
+	if len(elements) == 0 || elements[0] != "html" {
+		t.Errorf("root element = %v, want html", elements)
+	}
+
+	for _, want := range []string{
+		"Generated March 15, 2026",
+		`<div class="label">Total</div><div class="value">3</div>`,
+		`<div class="label">Response rate</div><div class="value">100.0%</div>`,
+		`<span class="badge status-interview">interview: 1</span>`,
+		".status-interview { background: #A8E6CF; }",
+		"AT&amp;T &lt;Labs&gt;",
+		`<td data-sort="180000">$140k - $180k</td>`,
+		"<td data-sort=\"Remote\">Remote</td>",
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("report missing %q", want)
+		}
+	}
+	for _, external := range []string{"<link", "src=", "http://", "https://"} {
+		if strings.Contains(page, external) {
+			t.Errorf("report references an external asset (%q)", external)
+		}
+	}
+}
@@ -0,0 +1,288 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/model"
+	"github.com/celloopa/ghosted/internal/store"
+)
+
+const importUsage = "Usage: ghosted import <file.csv|file.json> [--skip-duplicates]"
+
+// importRecord is one row of an import file: the application it describes,
+// or why it can't be imported
+type importRecord struct {
+	Row int // 1-based data row (CSV rows after the header, JSON array items)
+	App model.Application
+	Err error
+}
+
+// importSkip is a row left out of an import, and why
+type importSkip struct {
+	Row    int
+	Reason string
+}
+
+// cmdImport adds the applications in a CSV or JSON file to the tracker.
+// CSV uses the columns written by export --format csv; JSON is an array of
+// applications as in the data file or list --json.
+func cmdImport(s *store.Store, args []string) {
+	path := ""
+	skipDuplicates := false
+	for _, arg := range args {
+		switch {
+		case arg == "--skip-duplicates":
+			skipDuplicates = true
+		case path == "" && !strings.HasPrefix(arg, "-"):
+			path = arg
+		default:
+			fmt.Fprintln(os.Stderr, importUsage)
+			os.Exit(1)
+		}
+	}
+	if path == "" {
+		fmt.Fprintln(os.Stderr, importUsage)
+		os.Exit(1)
+	}
+
+	records, err := readImportFile(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	imported, skipped, err := importApplications(s, records, skipDuplicates)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: import failed, nothing was added: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Imported %d application(s) from %s\n", imported, path)
+	if len(skipped) > 0 {
+		fmt.Printf("Skipped %d row(s):\n", len(skipped))
+		for _, skip := range skipped {
+			fmt.Printf("  row %d: %s\n", skip.Row, skip.Reason)
+		}
+	}
+}
+
+// readImportFile parses an import file, picking the format by extension
+func readImportFile(path string) ([]importRecord, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return parseImportCSV(f)
+	case ".json":
+		return parseImportJSON(f)
+	}
+	return nil, fmt.Errorf("unsupported import file %s (use .csv or .json)", path)
+}
+
+// parseImportCSV reads applications from CSV with a header row naming
+// store.CSVColumns. Columns may be missing or in any order; unknown ones
+// are ignored, as is id, since imported applications get new IDs.
+func parseImportCSV(r io.Reader) ([]importRecord, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading CSV header: %w", err)
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if slices.Contains(store.CSVColumns, name) {
+			columns[name] = i
+		}
+	}
+
+	var records []importRecord
+	for row := 1; ; row++ {
+		fields, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading CSV row %d: %w", row, err)
+		}
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(fields) {
+				return strings.TrimSpace(fields[i])
+			}
+			return ""
+		}
+		app, err := csvApplication(field)
+		records = append(records, importRecord{Row: row, App: app, Err: err})
+	}
+	return records, nil
+}
+
+// csvApplication builds an application from a CSV row's fields
+func csvApplication(field func(name string) string) (model.Application, error) {
+	app := model.Application{
+		Company:      field("company"),
+		Position:     field("position"),
+		Status:       field("status"),
+		Location:     field("location"),
+		JobURL:       field("job_url"),
+		ContactName:  field("contact_name"),
+		ContactEmail: field("contact_email"),
+		Notes:        field("notes"),
+	}
+
+	if v := field("date_applied"); v != "" {
+		t, err := parseImportDate(v)
+		if err != nil {
+			return app, fmt.Errorf("date_applied %q is not YYYY-MM-DD", v)
+		}
+		app.DateApplied = &t
+	}
+	if v := field("remote"); v != "" {
+		remote, err := parseImportBool(v)
+		if err != nil {
+			return app, fmt.Errorf("remote %q is not true or false", v)
+		}
+		app.Remote = remote
+	}
+	for _, salary := range []struct {
+		name string
+		dst  *int
+	}{{"salary_min", &app.SalaryMin}, {"salary_max", &app.SalaryMax}} {
+		v := strings.NewReplacer(",", "", "$", "").Replace(field(salary.name))
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return app, fmt.Errorf("%s %q is not a number", salary.name, field(salary.name))
+		}
+		*salary.dst = n
+	}
+	return app, nil
+}
+
+// parseImportDate accepts dates as export writes them (YYYY-MM-DD) or full
+// RFC 3339 timestamps
+func parseImportDate(v string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation("2006-01-02", v, time.Local)
+}
+
+// parseImportBool accepts true/false and the Yes/No of Notion exports
+func parseImportBool(v string) (bool, error) {
+	switch strings.ToLower(v) {
+	case "yes", "y":
+		return true, nil
+	case "no", "n":
+		return false, nil
+	}
+	return strconv.ParseBool(v)
+}
+
+// parseImportJSON reads a JSON array of applications. Each item is decoded
+// on its own so one malformed entry doesn't reject the rest.
+func parseImportJSON(r io.Reader) ([]importRecord, error) {
+	var items []json.RawMessage
+	if err := json.NewDecoder(r).Decode(&items); err != nil {
+		return nil, fmt.Errorf("parsing JSON: expected an array of applications: %w", err)
+	}
+
+	records := make([]importRecord, len(items))
+	for i, item := range items {
+		records[i].Row = i + 1
+		if err := json.Unmarshal(item, &records[i].App); err != nil {
+			records[i].Err = fmt.Errorf("invalid application: %w", err)
+		}
+	}
+	return records, nil
+}
+
+// errDuplicate marks a row matching an application already tracked
+var errDuplicate = errors.New("duplicate")
+
+// importApplications adds the valid records in one transaction and returns
+// how many were added and which rows were skipped. With skipDuplicates,
+// rows whose company and position match a tracked application (or an
+// earlier row) are skipped.
+func importApplications(s *store.Store, records []importRecord, skipDuplicates bool) (int, []importSkip, error) {
+	existing := make(map[string]bool)
+	for _, app := range s.List() {
+		existing[duplicateKey(app)] = true
+	}
+
+	var skipped []importSkip
+	imported := 0
+	err := s.Transaction(func(tx *store.Tx) error {
+		for _, rec := range records {
+			app := rec.App
+			err := rec.Err
+			if err == nil {
+				err = validateImport(&app)
+			}
+			if err == nil && skipDuplicates && existing[duplicateKey(app)] {
+				err = fmt.Errorf("%w of %s - %s", errDuplicate, app.Company, app.Position)
+			}
+			if err != nil {
+				skipped = append(skipped, importSkip{Row: rec.Row, Reason: err.Error()})
+				continue
+			}
+
+			app.Interviews = dedupeInterviews(app.Interviews)
+			tx.Add(app)
+			existing[duplicateKey(app)] = true
+			imported++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	return imported, skipped, nil
+}
+
+// validateImport checks an imported application has what the tracker
+// needs, normalizing its status. IDs and timestamps are reassigned by Add.
+func validateImport(app *model.Application) error {
+	app.Company = strings.TrimSpace(app.Company)
+	app.Position = strings.TrimSpace(app.Position)
+	switch {
+	case app.Company == "":
+		return errors.New("missing company")
+	case app.Position == "":
+		return errors.New("missing position")
+	}
+	if app.Status != "" {
+		app.Status = model.NormalizeStatus(app.Status)
+		if !slices.Contains(model.AllStatuses(), app.Status) {
+			return fmt.Errorf("unknown status %q", app.Status)
+		}
+	}
+	app.ID = ""
+	return nil
+}
+
+// duplicateKey identifies an application by company and position,
+// ignoring case and surrounding space
+func duplicateKey(app model.Application) string {
+	return strings.ToLower(strings.TrimSpace(app.Company)) + "\x00" + strings.ToLower(strings.TrimSpace(app.Position))
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/model"
+	"github.com/celloopa/ghosted/internal/store"
+)
+
+func newImportStore(t *testing.T) *store.Store {
+	t.Helper()
+	s, err := store.New(filepath.Join(t.TempDir(), "applications.json"))
+	if err != nil {
+		t.Fatalf("store.New() error = %v", err)
+	}
+	return s
+}
+
+func TestImport_CSV(t *testing.T) {
+	input := `company,position,status,date_applied,remote,salary_min,notes,favorite_color
+"Acme, Inc.",Backend Engineer,Interview,2026-03-09,true,"150,000","Referred by Dana
+Follow up Friday",blue
+Globex,SRE,,,,,,
+Initech,Platform Engineer,applied,March 9,,,,`
+
+	records, err := parseImportCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("parseImportCSV() error = %v", err)
+	}
+	s := newImportStore(t)
+	imported, skipped, err := importApplications(s, records, false)
+	if err != nil {
+		t.Fatalf("importApplications() error = %v", err)
+	}
+
+	if imported != 2 {
+		t.Errorf("imported = %d, want 2", imported)
+	}
+	if len(skipped) != 1 || skipped[0].Row != 3 || !strings.Contains(skipped[0].Reason, "date_applied") {
+		t.Errorf("skipped = %+v, want row 3 for its date", skipped)
+	}
+
+	acme := s.Find(func(a model.Application) bool { return a.Company == "Acme, Inc." })
+	if len(acme) != 1 {
+		t.Fatalf("Acme not imported")
+	}
+	got := acme[0]
+	if got.Status != model.StatusInterview || !got.Remote || got.SalaryMin != 150000 ||
+		got.DateApplied == nil || got.DateApplied.Format("2006-01-02") != "2026-03-09" ||
+		got.Notes != "Referred by Dana\nFollow up Friday" {
+		t.Errorf("imported Acme = %+v", got)
+	}
+}
+
+func TestImport_RoundTripsExport(t *testing.T) {
+	applied := time.Date(2026, 3, 9, 0, 0, 0, 0, time.Local)
+	var buf bytes.Buffer
+	err := store.ExportCSV(&buf, []model.Application{
+		{ID: "a1", Company: "Acme", Position: "SWE", Status: model.StatusOffer, DateApplied: &applied, SalaryMax: 200000, ContactEmail: "dana@acme.example"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := parseImportCSV(&buf)
+	if err != nil {
+		t.Fatalf("parseImportCSV() error = %v", err)
+	}
+	if len(records) != 1 || records[0].Err != nil {
+		t.Fatalf("records = %+v, want one valid row", records)
+	}
+	got := records[0].App
+	if got.Company != "Acme" || got.Status != model.StatusOffer || got.SalaryMax != 200000 ||
+		got.ContactEmail != "dana@acme.example" || !got.DateApplied.Equal(applied) {
+		t.Errorf("round-tripped application = %+v", got)
+	}
+}
+
+func TestImport_MissingCompany(t *testing.T) {
+	records, err := parseImportCSV(strings.NewReader("company,position\n,Backend Engineer\nAcme,\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := newImportStore(t)
+	imported, skipped, err := importApplications(s, records, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if imported != 0 || s.Total() != 0 {
+		t.Errorf("imported %d, store has %d, want nothing added", imported, s.Total())
+	}
+	want := []importSkip{{1, "missing company"}, {2, "missing position"}}
+	if len(skipped) != len(want) {
+		t.Fatalf("skipped = %+v, want %+v", skipped, want)
+	}
+	for i := range want {
+		if skipped[i] != want[i] {
+			t.Errorf("skipped[%d] = %+v, want %+v", i, skipped[i], want[i])
+		}
+	}
+}
+
+func TestImport_SkipDuplicates(t *testing.T) {
+	input := `[
+		{"company": "Acme", "position": "Backend Engineer", "status": "applied"},
+		{"company": "acme ", "position": "backend engineer"},
+		{"company": "Globex", "position": "SRE"},
+		{"company": "Globex", "position": "SRE"}
+	]`
+
+	tests := []struct {
+		name           string
+		skipDuplicates bool
+		wantImported   int
+		wantSkipped    []int
+	}{
+		{"kept without the flag", false, 4, nil},
+		{"skipped with the flag", true, 1, []int{1, 2, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newImportStore(t)
+			if _, err := s.Add(model.Application{Company: "Acme", Position: "Backend Engineer"}); err != nil {
+				t.Fatal(err)
+			}
+			records, err := parseImportJSON(strings.NewReader(input))
+			if err != nil {
+				t.Fatalf("parseImportJSON() error = %v", err)
+			}
+
+			imported, skipped, err := importApplications(s, records, tt.skipDuplicates)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if imported != tt.wantImported {
+				t.Errorf("imported = %d, want %d", imported, tt.wantImported)
+			}
+			var rows []int
+			for _, skip := range skipped {
+				if !strings.HasPrefix(skip.Reason, "duplicate") {
+					t.Errorf("row %d skipped for %q, want a duplicate", skip.Row, skip.Reason)
+				}
+				rows = append(rows, skip.Row)
+			}
+			if len(rows) != len(tt.wantSkipped) {
+				t.Fatalf("skipped rows %v, want %v", rows, tt.wantSkipped)
+			}
+			for i := range rows {
+				if rows[i] != tt.wantSkipped[i] {
+					t.Errorf("skipped rows %v, want %v", rows, tt.wantSkipped)
+				}
+			}
+		})
+	}
+}
+
+func TestImport_DedupesInterviews(t *testing.T) {
+	input := `[{"company": "Acme", "position": "Backend Engineer", "status": "interview", "interviews": [
+		{"date": "2026-03-12T10:00:00Z", "type": "phone"},
+		{"date": "2026-03-12T15:00:00Z", "type": "phone", "notes": "Talked about the on-call rotation"},
+		{"date": "2026-03-19T10:00:00Z", "type": "technical"}
+	]}]`
+
+	records, err := parseImportJSON(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("parseImportJSON() error = %v", err)
+	}
+	s := newImportStore(t)
+	if _, _, err := importApplications(s, records, false); err != nil {
+		t.Fatalf("importApplications() error = %v", err)
+	}
+
+	apps := s.List()
+	if len(apps) != 1 {
+		t.Fatalf("imported %d applications, want 1", len(apps))
+	}
+	got := apps[0].Interviews
+	if len(got) != 2 || got[0].Notes != "Talked about the on-call rotation" || got[1].Type != "technical" {
+		t.Errorf("Interviews = %+v, want the noted phone screen and the technical", got)
+	}
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/celloopa/ghosted/internal/agent"
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+// defaultCVPath is the master CV used by the agent pipeline
+var defaultCVPath = filepath.Join("local", "cv.json")
+
+// cvTemplate is a JSON Resume skeleton written by `ghosted init`. JSON has no
+// comments, so the placeholder values describe what goes in each field.
+const cvTemplate = `{
+  "$schema": "https://raw.githubusercontent.com/jsonresume/resume-schema/v1.0.0/schema.json",
+  "basics": {
+    "name": "Your Name",
+    "label": "Your headline, e.g. Senior Software Engineer",
+    "email": "you@example.com",
+    "phone": "",
+    "url": "https://your-site.example",
+    "summary": "Two or three sentences on what you do and what you're looking for",
+    "location": {
+      "city": "City",
+      "region": "State or region",
+      "countryCode": "US"
+    },
+    "profiles": [
+      {
+        "network": "GitHub",
+        "username": "your-username",
+        "url": "https://github.com/your-username"
+      }
+    ]
+  },
+  "work": [
+    {
+      "name": "Company name",
+      "position": "Your title",
+      "url": "https://company.example",
+      "startDate": "2022-01",
+      "endDate": "",
+      "summary": "One line on the team and your scope (leave endDate empty for your current role)",
+      "highlights": [
+        "An accomplishment with a measurable result",
+        "Another accomplishment"
+      ]
+    }
+  ],
+  "education": [
+    {
+      "institution": "University name",
+      "url": "",
+      "area": "Field of study",
+      "studyType": "Bachelor",
+      "startDate": "2014-09",
+      "endDate": "2018-06"
+    }
+  ],
+  "skills": [
+    {
+      "name": "Skill category, e.g. Languages",
+      "keywords": ["Go", "TypeScript"]
+    }
+  ],
+  "projects": [
+    {
+      "name": "Project name",
+      "description": "What it is and why it matters",
+      "highlights": ["What you built"],
+      "keywords": ["Tech used"],
+      "startDate": "2023-01",
+      "url": ""
+    }
+  ]
+}
+`
+
+// cmdInit scaffolds the local/ workspace: folders, a starter pipeline config,
+// and a CV template
+func cmdInit(args []string) {
+	force := false
+	for _, arg := range args {
+		switch arg {
+		case "--force":
+			force = true
+		default:
+			fmt.Fprintln(os.Stderr, "Usage: ghosted init [--force]")
+			os.Exit(1)
+		}
+	}
+
+	created, skipped, err := initWorkspace(".", force)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	for _, path := range created {
+		fmt.Printf("  created  %s\n", path)
+	}
+	for _, path := range skipped {
+		fmt.Printf("  exists   %s\n", path)
+	}
+	if len(created) == 0 {
+		fmt.Println("Workspace already initialized.")
+		return
+	}
+	fmt.Printf("\nNext: fill in %s, then fetch a posting with 'ghosted fetch <url>'\n", defaultCVPath)
+}
+
+// initWorkspace creates the local/ folder structure under baseDir along with
+// a starter config.json and cv.json. Existing files are left alone unless
+// force is set; existing directories are never touched. Paths are reported
+// relative to baseDir.
+func initWorkspace(baseDir string, force bool) (created, skipped []string, err error) {
+	config := agent.DefaultConfig()
+
+	dirs := []string{
+		config.Paths.PostingsDir,
+		config.Paths.ResumesDir,
+		config.Paths.CoverLettersDir,
+		config.Paths.TemplatesDir,
+		config.Paths.OutputDir,
+	}
+	for _, jobType := range []string{model.JobTypeFrontend, model.JobTypeSWE, model.JobTypeUXDesign, model.JobTypeProductDesign} {
+		dirs = append(dirs, filepath.Join("local", "applications", jobType))
+	}
+
+	for _, dir := range dirs {
+		path := filepath.Join(baseDir, dir)
+		if _, err := os.Stat(path); err == nil {
+			skipped = append(skipped, dir+"/")
+			continue
+		}
+		if err := os.MkdirAll(path, 0755); err != nil {
+			return created, skipped, fmt.Errorf("creating %s: %w", dir, err)
+		}
+		created = append(created, dir+"/")
+	}
+
+	// Starter files: written when missing, or replaced with --force
+	configFile := filepath.Join(baseDir, pipelineConfigPath)
+	if fileExists(configFile) && !force {
+		skipped = append(skipped, pipelineConfigPath)
+	} else {
+		if err := agent.SaveConfig(config, configFile); err != nil {
+			return created, skipped, fmt.Errorf("writing %s: %w", pipelineConfigPath, err)
+		}
+		created = append(created, pipelineConfigPath)
+	}
+
+	cvFile := filepath.Join(baseDir, defaultCVPath)
+	if fileExists(cvFile) && !force {
+		skipped = append(skipped, defaultCVPath)
+	} else {
+		if err := os.WriteFile(cvFile, []byte(cvTemplate), 0644); err != nil {
+			return created, skipped, fmt.Errorf("writing %s: %w", defaultCVPath, err)
+		}
+		created = append(created, defaultCVPath)
+	}
+
+	return created, skipped, nil
+}
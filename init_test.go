@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/celloopa/ghosted/internal/agent"
+)
+
+func TestInitWorkspace_CreatesScaffolding(t *testing.T) {
+	base := t.TempDir()
+
+	created, skipped, err := initWorkspace(base, false)
+	if err != nil {
+		t.Fatalf("initWorkspace() error = %v", err)
+	}
+	if len(skipped) != 0 {
+		t.Errorf("skipped = %v on a fresh workspace", skipped)
+	}
+	if len(created) == 0 {
+		t.Fatal("nothing created")
+	}
+
+	for _, dir := range []string{
+		"local/postings",
+		"local/resumes",
+		"local/cover-letters",
+		"local/applications/fe-dev",
+		"local/applications/swe",
+		"local/applications/ux-design",
+		"local/applications/product-design",
+		"local/document-generation/output",
+	} {
+		if info, err := os.Stat(filepath.Join(base, dir)); err != nil || !info.IsDir() {
+			t.Errorf("directory %s not created", dir)
+		}
+	}
+
+	config, err := agent.LoadConfig(filepath.Join(base, pipelineConfigPath))
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if len(config.Agents) != len(agent.DefaultConfig().Agents) {
+		t.Errorf("config has %d agents, want the defaults", len(config.Agents))
+	}
+
+	data, err := os.ReadFile(filepath.Join(base, defaultCVPath))
+	if err != nil {
+		t.Fatalf("ReadFile(cv.json) error = %v", err)
+	}
+	var cv agent.CVData
+	if err := json.Unmarshal(data, &cv); err != nil {
+		t.Fatalf("cv.json template is not valid JSON Resume: %v", err)
+	}
+	if cv.Basics.Name == "" || len(cv.Work) == 0 {
+		t.Errorf("cv.json template missing skeleton sections: %+v", cv)
+	}
+}
+
+func TestInitWorkspace_KeepsExistingCV(t *testing.T) {
+	base := t.TempDir()
+	if _, _, err := initWorkspace(base, false); err != nil {
+		t.Fatalf("initWorkspace() error = %v", err)
+	}
+
+	cvFile := filepath.Join(base, defaultCVPath)
+	mine := []byte(`{"basics":{"name":"Casey"}}`)
+	if err := os.WriteFile(cvFile, mine, 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	created, _, err := initWorkspace(base, false)
+	if err != nil {
+		t.Fatalf("second initWorkspace() error = %v", err)
+	}
+	if len(created) != 0 {
+		t.Errorf("re-run created %v, want nothing", created)
+	}
+	if data, _ := os.ReadFile(cvFile); string(data) != string(mine) {
+		t.Errorf("cv.json overwritten without --force: %s", data)
+	}
+
+	// --force replaces the starter files
+	if _, _, err := initWorkspace(base, true); err != nil {
+		t.Fatalf("forced initWorkspace() error = %v", err)
+	}
+	if data, _ := os.ReadFile(cvFile); string(data) != cvTemplate {
+		t.Error("cv.json not replaced with --force")
+	}
+}
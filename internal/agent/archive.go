@@ -0,0 +1,153 @@
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/atomicfile"
+	"github.com/celloopa/ghosted/internal/model"
+	"github.com/celloopa/ghosted/internal/store"
+)
+
+// ArchiveIndexFile is the index kept alongside archived postings
+const ArchiveIndexFile = "index.json"
+
+// archiveIndexMu serializes updates to archive indexes, so pipelines
+// archiving postings concurrently (apply --concurrency) don't lose entries
+var archiveIndexMu sync.Mutex
+
+// ArchiveEntry records where an archived posting came from
+type ArchiveEntry struct {
+	Company       string    `json:"company"`
+	Position      string    `json:"position"`
+	ApplicationID string    `json:"application_id,omitempty"`
+	ArchivedAt    time.Time `json:"archived_at"`
+}
+
+// ArchiveIndex maps archived posting filenames to their entries
+type ArchiveIndex map[string]ArchiveEntry
+
+// LoadArchiveIndex reads the archive index in processedDir.
+// A missing index is returned as empty.
+func LoadArchiveIndex(processedDir string) (ArchiveIndex, error) {
+	data, err := os.ReadFile(filepath.Join(processedDir, ArchiveIndexFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ArchiveIndex{}, nil
+		}
+		return nil, fmt.Errorf("failed to read archive index: %w", err)
+	}
+
+	index := ArchiveIndex{}
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse archive index: %w", err)
+	}
+	return index, nil
+}
+
+// saveArchiveIndex writes the archive index to processedDir
+func saveArchiveIndex(processedDir string, index ArchiveIndex) error {
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize archive index: %w", err)
+	}
+	return atomicfile.WriteFile(filepath.Join(processedDir, ArchiveIndexFile), data, 0644)
+}
+
+// appendArchiveIndex adds or replaces the index entry for an archived posting.
+// Without an application, company and position are guessed from the filename.
+func appendArchiveIndex(processedDir, filename string, app *model.Application, now time.Time) error {
+	archiveIndexMu.Lock()
+	defer archiveIndexMu.Unlock()
+
+	index, err := LoadArchiveIndex(processedDir)
+	if err != nil {
+		return err
+	}
+
+	entry := ArchiveEntry{ArchivedAt: now}
+	if app != nil {
+		entry.Company = app.Company
+		entry.Position = app.Position
+		entry.ApplicationID = app.ID
+	} else {
+		entry.Company, entry.Position = filenameCompanyPosition(filename)
+	}
+
+	index[filename] = entry
+	return saveArchiveIndex(processedDir, index)
+}
+
+// PostingInfo describes a pending or archived posting and its linked application
+type PostingInfo struct {
+	Path     string
+	Archived bool
+	// Entry holds the archive index entry; zero for pending postings and
+	// for archived files missing from the index
+	Entry ArchiveEntry
+	// App is the linked tracker entry, if any
+	App *model.Application
+}
+
+// ListPostings returns the pending postings in postingsDir followed by the
+// archived postings in its processed/ folder, each linked to its application
+// where one can be found. The store may be nil.
+func ListPostings(postingsDir string, s *store.Store) ([]PostingInfo, error) {
+	parser := NewParserAgent(nil)
+	var postings []PostingInfo
+
+	pending, err := supportedFiles(parser, postingsDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range pending {
+		postings = append(postings, PostingInfo{Path: path, App: FindTracked(s, path)})
+	}
+
+	processedDir := filepath.Join(postingsDir, "processed")
+	archived, err := supportedFiles(parser, processedDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	index, err := LoadArchiveIndex(processedDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range archived {
+		info := PostingInfo{Path: path, Archived: true, Entry: index[filepath.Base(path)]}
+		if info.Entry.ApplicationID != "" && s != nil {
+			if app, err := s.GetByID(info.Entry.ApplicationID); err == nil {
+				info.App = &app
+			}
+		}
+		postings = append(postings, info)
+	}
+
+	return postings, nil
+}
+
+// supportedFiles lists the posting files in dir, sorted by name
+func supportedFiles(parser *ParserAgent, dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		if parser.IsSupported(path) {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
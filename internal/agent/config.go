@@ -2,8 +2,13 @@ package agent
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/model"
 )
 
 // AgentType identifies the type of agent in the pipeline
@@ -39,6 +44,28 @@ type PipelineConfig struct {
 
 	// Output settings
 	Output OutputConfig `json:"output"`
+
+	// MinAcceptableSalary is the salary floor; postings paying less are
+	// flagged when applying. Zero disables the check.
+	MinAcceptableSalary int `json:"min_acceptable_salary,omitempty"`
+
+	// GhostedAfterDays is how many days without a response mark an
+	// application as ghosted; zero uses model.DefaultGhostedDays
+	GhostedAfterDays int `json:"ghosted_after_days,omitempty"`
+
+	// MaxActivePerCompany is how many active applications at one company
+	// are allowed before adding another needs --force; zero uses
+	// model.DefaultMaxActivePerCompany
+	MaxActivePerCompany int `json:"max_active_per_company,omitempty"`
+
+	// Provider is the LLM backend that generates documents: "anthropic"
+	// (the default) or "openai" for OpenAI and compatible servers. Agents
+	// pick their model with AgentConfig.Model.
+	Provider string `json:"provider,omitempty"`
+
+	// ProviderURL overrides the provider's API base URL, e.g.
+	// http://localhost:11434/v1 for Ollama
+	ProviderURL string `json:"provider_url,omitempty"`
 }
 
 // PathsConfig defines paths used by the pipeline
@@ -56,6 +83,30 @@ type OutputConfig struct {
 	PDFEngine    string `json:"pdf_engine"`    // "typst" or "pandoc"
 	KeepTypst    bool   `json:"keep_typst"`    // Keep .typ source files
 	Naming       string `json:"naming"`        // Output file naming pattern
+	CoverTone    string `json:"cover_tone,omitempty"` // Default cover letter tone (formal, casual, enthusiastic)
+	// Format is the document format the resume and cover letter are
+	// written in: "typst" (default) or "markdown"
+	Format string `json:"format,omitempty"`
+	// InitialStatus is the status new tracker entries get: "saved"
+	// (default) or "applied"
+	InitialStatus string `json:"initial_status,omitempty"`
+}
+
+// DefaultInitialStatus is the status new tracker entries get when
+// output.initial_status is unset. Generating documents doesn't submit them,
+// so entries start as saved until the user marks them applied.
+const DefaultInitialStatus = model.StatusSaved
+
+// ResolveInitialStatus validates a configured initial status, returning
+// DefaultInitialStatus when it is empty
+func ResolveInitialStatus(status string) (string, error) {
+	switch status {
+	case "":
+		return DefaultInitialStatus, nil
+	case model.StatusSaved, model.StatusApplied:
+		return status, nil
+	}
+	return "", fmt.Errorf("invalid initial status %q: must be %q or %q", status, model.StatusSaved, model.StatusApplied)
 }
 
 // PipelineState tracks the state of a pipeline run
@@ -86,11 +137,28 @@ type ParsedPosting struct {
 	SalaryMin     int      `json:"salary_min,omitempty"`
 	SalaryMax     int      `json:"salary_max,omitempty"`
 	JobURL        string   `json:"job_url,omitempty"`
+	// SalaryEstimated marks SalaryMin/SalaryMax as inferred from the role
+	// and location because the posting didn't state pay
+	SalaryEstimated bool `json:"salary_estimated,omitempty"`
+	// MinYearsExperience is the most years of experience any requirement
+	// asks for; 0 when the posting doesn't say
+	MinYearsExperience int `json:"min_years_experience,omitempty"`
+	// Language is the ISO 639-1 code of the posting's language, "en" when
+	// detection is inconclusive
+	Language string `json:"language,omitempty"`
+	// Deadline is the last day to apply; nil for rolling postings or when
+	// none is stated
+	Deadline      *time.Time `json:"deadline,omitempty"`
 	Requirements  []string `json:"requirements,omitempty"`
 	BonusSkills   []string `json:"bonus_skills,omitempty"`
 	Keywords      []string `json:"keywords,omitempty"`
 	TechStack     []string `json:"tech_stack,omitempty"`
 	CompanyValues []string `json:"company_values,omitempty"`
+	// CultureSummary is a short paragraph on the company's culture, drawn
+	// from CompanyValues and the description, for the cover letter
+	CultureSummary string `json:"culture_summary,omitempty"`
+	// Benefits are the perks the posting lists (health insurance, 401k, ...)
+	Benefits      []string `json:"benefits,omitempty"`
 	Description   string   `json:"description,omitempty"`
 	Notes         string   `json:"notes,omitempty"`
 }
@@ -101,6 +169,31 @@ type GeneratedDocuments struct {
 	CoverLetterPath string `json:"cover_letter_path,omitempty"`
 	ResumePDF       string `json:"resume_pdf,omitempty"`
 	CoverLetterPDF  string `json:"cover_letter_pdf,omitempty"`
+	CoverTone       string `json:"cover_tone,omitempty"`
+
+	CoverStyle string `json:"cover_style,omitempty"`
+}
+
+// ResumeArtifact is the resume file left after compiling: the PDF when
+// the .typ source was removed, otherwise the .typ
+func (d GeneratedDocuments) ResumeArtifact() string {
+	return remainingArtifact(d.ResumePath, d.ResumePDF)
+}
+
+// CoverLetterArtifact is the cover letter file left after compiling, as
+// for ResumeArtifact
+func (d GeneratedDocuments) CoverLetterArtifact() string {
+	return remainingArtifact(d.CoverLetterPath, d.CoverLetterPDF)
+}
+
+// remainingArtifact prefers the PDF only when the .typ source is gone
+func remainingArtifact(typstPath, pdfPath string) string {
+	if pdfPath != "" {
+		if _, err := os.Stat(typstPath); os.IsNotExist(err) {
+			return pdfPath
+		}
+	}
+	return typstPath
 }
 
 // ReviewResult holds the reviewer agent's feedback
@@ -208,6 +301,38 @@ func (c *PipelineConfig) GetAgentConfig(agentType AgentType) *AgentConfig {
 	return nil
 }
 
+// Validate checks that the config can run a pipeline: it needs agents of
+// known types, with the parser and at least one document generator enabled
+func (c *PipelineConfig) Validate() error {
+	if len(c.Agents) == 0 {
+		return fmt.Errorf("no agents configured")
+	}
+	for _, a := range c.Agents {
+		switch a.Type {
+		case AgentParser, AgentResume, AgentCover, AgentReviewer, AgentTracker:
+		default:
+			return fmt.Errorf("unknown agent type %q", a.Type)
+		}
+	}
+	switch c.Provider {
+	case "", ProviderAnthropic, ProviderOpenAI:
+	default:
+		return fmt.Errorf("unknown provider %q (want %s)", c.Provider, strings.Join(Providers, " or "))
+	}
+
+	enabled := func(agentType AgentType) bool {
+		a := c.GetAgentConfig(agentType)
+		return a != nil && a.Enabled
+	}
+	if !enabled(AgentParser) {
+		return fmt.Errorf("the parser agent must be enabled")
+	}
+	if !enabled(AgentResume) && !enabled(AgentCover) {
+		return fmt.Errorf("at least one generation agent (resume or cover) must be enabled")
+	}
+	return nil
+}
+
 // EnabledAgents returns a list of enabled agents in pipeline order
 func (c *PipelineConfig) EnabledAgents() []AgentConfig {
 	var enabled []AgentConfig
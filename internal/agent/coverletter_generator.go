@@ -13,6 +13,116 @@ import (
 type CoverLetterGeneratorAgent struct {
 	Config  *AgentConfig
 	BaseDir string
+	// Tone is one of CoverLetterTones; empty keeps the default balanced tone
+	Tone string
+	// Style is one of CoverLetterStyles; empty means StyleStandard without
+	// checking paragraph counts
+	Style string
+	// Format is one of DocumentFormats; empty means FormatTypst
+	Format string
+}
+
+// Cover letter tones
+const (
+	ToneFormal       = "formal"
+	ToneCasual       = "casual"
+	ToneEnthusiastic = "enthusiastic"
+)
+
+// CoverLetterTones lists the supported cover letter tones
+var CoverLetterTones = []string{ToneFormal, ToneCasual, ToneEnthusiastic}
+
+// toneDirectives holds the writing guidance injected for each tone
+var toneDirectives = map[string]string{
+	ToneFormal: `- Use a formal, polished register suited to enterprise and regulated industries
+- Avoid contractions, slang, and exclamation marks
+- Lead with credibility: scope, scale, and measurable outcomes`,
+	ToneCasual: `- Use a relaxed, conversational register suited to startups and small teams
+- Contractions are fine; write the way you would speak to a future teammate
+- Keep it warm and direct, skipping stock business phrases`,
+	ToneEnthusiastic: `- Use an energetic, upbeat register that shows genuine excitement
+- Open with what specifically excites you about the product or mission
+- Let enthusiasm come from specifics, not from superlatives or exclamation marks`,
+}
+
+// toneHints maps words in a posting's company values to a suggested tone
+var toneHints = map[string][]string{
+	ToneFormal:       {"integrity", "compliance", "excellence", "professionalism", "accountability", "trust"},
+	ToneCasual:       {"fun", "scrappy", "hustle", "ownership", "move fast", "no ego", "low ego", "autonomy"},
+	ToneEnthusiastic: {"passion", "mission", "impact", "curiosity", "bold", "innovation", "love"},
+}
+
+// Cover letter styles, from a short note to a full letter
+const (
+	StyleBrief    = "brief"
+	StyleStandard = "standard"
+	StyleDetailed = "detailed"
+)
+
+// CoverLetterStyles lists the supported cover letter styles
+var CoverLetterStyles = []string{StyleBrief, StyleStandard, StyleDetailed}
+
+// coverStyle is the length guidance and paragraph bounds for a style
+type coverStyle struct {
+	Sentences     string // sentences per paragraph
+	Length        string // length instruction for the prompts
+	MinParagraphs int
+	MaxParagraphs int
+}
+
+// coverStyles holds the length guidance for each style
+var coverStyles = map[string]coverStyle{
+	StyleBrief:    {Sentences: "2-3", Length: "2 short paragraphs maximum", MinParagraphs: 1, MaxParagraphs: 2},
+	StyleStandard: {Sentences: "3-5", Length: "3 concise paragraphs maximum", MinParagraphs: 2, MaxParagraphs: 3},
+	StyleDetailed: {Sentences: "3-5", Length: "4 full paragraphs", MinParagraphs: 3, MaxParagraphs: 4},
+}
+
+// minParagraphWords is the fewest words a block needs to count as a body
+// paragraph, so salutations and sign-offs are left out
+const minParagraphWords = 12
+
+// ValidateCoverStyle returns an error listing the valid options if style is
+// not supported. An empty style is valid and means the standard style.
+func ValidateCoverStyle(style string) error {
+	if style == "" {
+		return nil
+	}
+	if _, ok := coverStyles[style]; !ok {
+		return fmt.Errorf("invalid cover style %q (valid: %s)", style, strings.Join(CoverLetterStyles, ", "))
+	}
+	return nil
+}
+
+// style returns the guidance for the agent's style, defaulting to standard
+func (c *CoverLetterGeneratorAgent) style() coverStyle {
+	if style, ok := coverStyles[c.Style]; ok {
+		return style
+	}
+	return coverStyles[StyleStandard]
+}
+
+// ParagraphBounds returns the fewest and most body paragraphs the agent's
+// style allows
+func (c *CoverLetterGeneratorAgent) ParagraphBounds() (fewest, most int) {
+	style := c.style()
+	return style.MinParagraphs, style.MaxParagraphs
+}
+
+// countParagraphs counts the prose paragraphs in a Typst or Markdown cover
+// letter: blank-line separated blocks that aren't markup, code, or comments
+// and are long enough not to be a salutation or sign-off
+func countParagraphs(letter string) int {
+	count := 0
+	for _, block := range strings.Split(strings.ReplaceAll(letter, "\r\n", "\n"), "\n\n") {
+		block = strings.TrimSpace(block)
+		if block == "" || strings.ContainsAny(block[:1], "#/=)]}-+<") {
+			continue
+		}
+		if len(strings.Fields(block)) >= minParagraphWords {
+			count++
+		}
+	}
+	return count
 }
 
 // CoverLetterOutput represents the generated cover letter paths
@@ -99,7 +209,7 @@ func (c *CoverLetterGeneratorAgent) GetSystemPrompt() string {
 - Professional but personable - Not stiff, not overly casual
 - Confident but not arrogant - State achievements factually
 - Specific over generic - Use concrete examples
-- Concise - Each paragraph should be 3-5 sentences
+- Concise - Each paragraph should be ` + c.style().Sentences + ` sentences
 - Active voice - "I built" not "It was built by me"
 
 ## Content Rules
@@ -108,17 +218,78 @@ func (c *CoverLetterGeneratorAgent) GetSystemPrompt() string {
 2. Be specific - "I improved API response time by 40%" not "I improved performance"
 3. Connect to requirements - Reference skills from job posting naturally
 4. Show, don't tell - Demonstrate skills through examples
-5. Keep it to one page - 3 concise paragraphs maximum
+5. Keep it to one page - ` + c.style().Length + `
+
+` + outputFormatSection(c.Format, "cover letter") + `
+
+CRITICAL: Only reference real experience from the provided CV. Do not invent projects, metrics, or achievements.` + toneSection(c.Tone)
+}
+
+// toneSection returns the system prompt directive for a tone, or "" for the default
+func toneSection(tone string) string {
+	directive, ok := toneDirectives[tone]
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf(`
+
+## Requested Tone: %s
+
+This overrides the general tone guidelines above:
+%s`, tone, directive)
+}
+
+// ValidateTone returns an error listing the valid options if tone is not
+// supported. An empty tone is valid and means the default.
+func ValidateTone(tone string) error {
+	if tone == "" {
+		return nil
+	}
+	if _, ok := toneDirectives[tone]; !ok {
+		return fmt.Errorf("invalid tone %q (valid: %s)", tone, strings.Join(CoverLetterTones, ", "))
+	}
+	return nil
+}
 
-## Output Format
+// SuggestTone picks a tone from the posting's company values.
+// Returns "" when the values give no clear signal.
+func SuggestTone(posting *ParsedPosting) string {
+	if posting == nil {
+		return ""
+	}
 
-Generate a complete Typst file using @preview/modern-cv:0.9.0. Return ONLY the Typst content, no markdown formatting or explanations.
+	scores := make(map[string]int)
+	for _, value := range posting.CompanyValues {
+		value = strings.ToLower(value)
+		for tone, hints := range toneHints {
+			for _, hint := range hints {
+				if strings.Contains(value, hint) {
+					scores[tone]++
+				}
+			}
+		}
+	}
 
-CRITICAL: Only reference real experience from the provided CV. Do not invent projects, metrics, or achievements.`
+	best, bestScore, tied := "", 0, false
+	for _, tone := range CoverLetterTones {
+		switch {
+		case scores[tone] > bestScore:
+			best, bestScore, tied = tone, scores[tone], false
+		case scores[tone] == bestScore && bestScore > 0:
+			tied = true
+		}
+	}
+	if tied {
+		return ""
+	}
+	return best
 }
 
-// GetUserPrompt creates the user prompt with job posting, CV, and resume context
-func (c *CoverLetterGeneratorAgent) GetUserPrompt(posting *ParsedPosting, cv *CVData, resumeContent string) (string, error) {
+// GetUserPrompt creates the user prompt with job posting, CV, and resume context.
+// If feedback is non-nil, the reviewer's notes on the previous cover letter are
+// included so the regenerated draft can address them. A non-empty rawPosting
+// attaches the original posting text alongside the parsed data.
+func (c *CoverLetterGeneratorAgent) GetUserPrompt(posting *ParsedPosting, cv *CVData, resumeContent string, feedback *DetailedReviewResult, rawPosting string) (string, error) {
 	postingJSON, err := json.MarshalIndent(posting, "", "  ")
 	if err != nil {
 		return "", fmt.Errorf("failed to serialize posting: %w", err)
@@ -133,11 +304,19 @@ func (c *CoverLetterGeneratorAgent) GetUserPrompt(posting *ParsedPosting, cv *CV
 
 ## Job Posting Data
 
-%s
+%s%s
 
 ## Candidate CV
 
-%s`, postingJSON, cvJSON)
+%s`, postingJSON, rawPostingSection(rawPosting), cvJSON)
+
+	if culture := strings.TrimSpace(posting.CultureSummary); culture != "" {
+		prompt += fmt.Sprintf(`
+
+## Company Culture
+
+%s`, culture)
+	}
 
 	// Include resume content if available for consistency
 	if resumeContent != "" {
@@ -156,8 +335,22 @@ func (c *CoverLetterGeneratorAgent) GetUserPrompt(posting *ParsedPosting, cv *CV
 2. Reference 2-3 most relevant experiences that match job requirements
 3. Mirror language and terminology from the job posting
 4. Show genuine interest in the company and role
-5. Keep it to 3 paragraphs maximum
-6. Return ONLY the complete Typst file content`
+5. Keep it to ` + c.style().Length + `
+6. ` + outputFormatInstruction(c.Format)
+
+	step := 7
+	if c.Tone != "" {
+		prompt += fmt.Sprintf("\n%d. Write in a %s tone", step, c.Tone)
+		step++
+	}
+	if strings.TrimSpace(posting.CultureSummary) != "" {
+		prompt += fmt.Sprintf("\n%d. In the value paragraph, tie your strengths to specifics from the Company Culture section rather than generic praise", step)
+	}
+
+	// Include reviewer feedback when regenerating a rejected draft
+	if feedback != nil {
+		prompt += revisionFeedbackSection(feedback.CoverReview, nil)
+	}
 
 	return prompt, nil
 }
@@ -173,18 +366,20 @@ func (c *CoverLetterGeneratorAgent) GenerateOutputPath(posting *ParsedPosting, j
 	}
 
 	folderName := fmt.Sprintf("%s-%s", company, strings.ReplaceAll(position, "-", "_"))
-	return filepath.Join(outputDir, jobType, folderName, "cover-letter.typ")
+	return filepath.Join(outputDir, jobType, folderName, "cover-letter"+documentExt(c.Format))
 }
 
-// WriteTypst writes the generated Typst content to a file
-func (c *CoverLetterGeneratorAgent) WriteTypst(content, outputPath string) error {
+// WriteTypst writes the generated Typst content to a file. meta, if not
+// nil, is prepended as a comment header recording the posting and CV used;
+// markdown (.md) documents get it as HTML comments.
+func (c *CoverLetterGeneratorAgent) WriteTypst(content, outputPath string, meta *DocumentMetadata) error {
 	// Ensure directory exists
 	dir := filepath.Dir(outputPath)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 
-	if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
+	if err := os.WriteFile(outputPath, []byte(meta.withHeader(content, outputPath)), 0644); err != nil {
 		return fmt.Errorf("failed to write Typst file: %w", err)
 	}
 
@@ -276,14 +471,39 @@ func (c *CoverLetterGeneratorAgent) ExtractRelevantExperiences(cv *CVData, posti
 	return result
 }
 
+// ParseOutput validates and cleans AI-generated cover letter content in
+// the agent's format. Markdown skips the Typst template checks but is
+// still held to the style's length.
+func (c *CoverLetterGeneratorAgent) ParseOutput(output string) (string, error) {
+	if c.Format != FormatMarkdown {
+		return c.ParseTypstOutput(output)
+	}
+	output, err := parseMarkdownOutput(output)
+	if err != nil {
+		return "", err
+	}
+	if err := c.checkParagraphs(output); err != nil {
+		return "", fmt.Errorf("invalid Markdown output: %w", err)
+	}
+	return output, nil
+}
+
+// checkParagraphs holds a letter to the chosen style's length
+func (c *CoverLetterGeneratorAgent) checkParagraphs(letter string) error {
+	if c.Style == "" {
+		return nil
+	}
+	fewest, most := c.ParagraphBounds()
+	if n := countParagraphs(letter); n < fewest || n > most {
+		return fmt.Errorf("%d paragraphs, %s style expects %d-%d", n, c.Style, fewest, most)
+	}
+	return nil
+}
+
 // ParseTypstOutput validates and cleans the AI-generated Typst content
 func (c *CoverLetterGeneratorAgent) ParseTypstOutput(output string) (string, error) {
 	// Remove markdown code blocks if present
-	output = strings.TrimSpace(output)
-	output = strings.TrimPrefix(output, "```typst")
-	output = strings.TrimPrefix(output, "```")
-	output = strings.TrimSuffix(output, "```")
-	output = strings.TrimSpace(output)
+	output = cleanTypstOutput(output)
 
 	// Basic validation - check for required Typst elements
 	if !strings.Contains(output, "#import") {
@@ -293,6 +513,11 @@ func (c *CoverLetterGeneratorAgent) ParseTypstOutput(output string) (string, err
 		return "", fmt.Errorf("invalid Typst output: missing coverletter template reference")
 	}
 
+	// Hold the letter to the chosen style's length
+	if err := c.checkParagraphs(output); err != nil {
+		return "", fmt.Errorf("invalid Typst output: %w", err)
+	}
+
 	return output, nil
 }
 
@@ -307,8 +532,15 @@ func (c *CoverLetterGeneratorAgent) Generate(posting *ParsedPosting, cvPath, res
 	// Load resume for consistency (optional)
 	resumeContent, _ := c.LoadResume(resumePath)
 
+	// Let the posting suggest a tone when none was chosen, without
+	// carrying it over to the next posting this agent generates for
+	gen := *c
+	if gen.Tone == "" {
+		gen.Tone = SuggestTone(posting)
+	}
+
 	// Generate output path
-	outputPath := c.GenerateOutputPath(posting, jobType, outputDir)
+	outputPath := gen.GenerateOutputPath(posting, jobType, outputDir)
 
 	// The actual Typst content generation would be done by an AI model
 	// This method prepares all the inputs and returns the output structure
@@ -319,7 +551,7 @@ func (c *CoverLetterGeneratorAgent) Generate(posting *ParsedPosting, cvPath, res
 	}
 
 	// Store context for AI generation
-	_, err = c.GetUserPrompt(posting, cv, resumeContent)
+	_, err = gen.GetUserPrompt(posting, cv, resumeContent, nil, "")
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate prompt: %w", err)
 	}
@@ -3,6 +3,7 @@ package agent
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -124,7 +125,7 @@ func TestCoverLetterGeneratorAgent_WriteTypst(t *testing.T) {
 	outputPath := filepath.Join(tmpDir, "nested", "cover-letter.typ")
 	content := `#import "@preview/modern-cv:0.9.0": *`
 
-	err := agent.WriteTypst(content, outputPath)
+	err := agent.WriteTypst(content, outputPath, nil)
 	if err != nil {
 		t.Errorf("WriteTypst() error = %v", err)
 	}
@@ -226,6 +227,11 @@ func TestCoverLetterGeneratorAgent_ParseTypstOutput(t *testing.T) {
 			input: "```typst\n#import \"@preview/modern-cv:0.9.0\": *\n#show: coverletter.with()\n```",
 			wantErr: false,
 		},
+		{
+			name:    "leading metadata comment",
+			input:   "// Generated by ghosted on 2024-03-15 for Acme - Engineer\n// posting: local/postings/acme.md\n#import \"@preview/modern-cv:0.9.0\": *\n#show: coverletter.with()",
+			wantErr: false,
+		},
 		{
 			name:    "missing import",
 			input:   `#show: coverletter.with(author: ())`,
@@ -287,7 +293,7 @@ func TestCoverLetterGeneratorAgent_GetUserPrompt(t *testing.T) {
 	}
 
 	// Without resume content
-	prompt, err := agent.GetUserPrompt(posting, cv, "")
+	prompt, err := agent.GetUserPrompt(posting, cv, "", nil, "")
 	if err != nil {
 		t.Errorf("GetUserPrompt() error = %v", err)
 	}
@@ -314,7 +320,7 @@ func TestCoverLetterGeneratorAgent_GetUserPrompt_WithResume(t *testing.T) {
 
 	resumeContent := "#import modern-cv... resume content here"
 
-	prompt, err := agent.GetUserPrompt(posting, cv, resumeContent)
+	prompt, err := agent.GetUserPrompt(posting, cv, resumeContent, nil, "")
 	if err != nil {
 		t.Errorf("GetUserPrompt() error = %v", err)
 	}
@@ -325,8 +331,252 @@ func TestCoverLetterGeneratorAgent_GetUserPrompt_WithResume(t *testing.T) {
 	}
 }
 
+func TestCoverLetterGeneratorAgent_GetUserPrompt_CultureSummary(t *testing.T) {
+	agent := NewCoverLetterGeneratorAgent(&AgentConfig{Type: AgentCover}, "")
+	cv := &CVData{Basics: CVBasics{Name: "Test User"}}
+
+	posting := &ParsedPosting{Company: "Acme", Position: "Engineer", CompanyValues: []string{"ownership", "curiosity"}}
+	fillCultureSummary(posting)
+
+	prompt, err := agent.GetUserPrompt(posting, cv, "", nil, "")
+	if err != nil {
+		t.Fatalf("GetUserPrompt() error = %v", err)
+	}
+	if !strings.Contains(prompt, "## Company Culture\n\nAcme emphasizes ownership and curiosity.") {
+		t.Errorf("GetUserPrompt() missing culture summary section:\n%s", prompt)
+	}
+	if !strings.Contains(prompt, "7. In the value paragraph") {
+		t.Error("GetUserPrompt() missing instruction to use the culture summary")
+	}
+
+	prompt, err = agent.GetUserPrompt(&ParsedPosting{Company: "Acme", Position: "Engineer"}, cv, "", nil, "")
+	if err != nil {
+		t.Fatalf("GetUserPrompt() error = %v", err)
+	}
+	if strings.Contains(prompt, "Company Culture") {
+		t.Error("GetUserPrompt() included a culture section without a summary")
+	}
+}
+
 func TestCoverLetterGeneratorAgent_IsTypstAvailable(t *testing.T) {
 	agent := NewCoverLetterGeneratorAgent(&AgentConfig{Type: AgentCover}, "")
 	// Just test that it runs without error
 	_ = agent.IsTypstAvailable()
 }
+
+func TestCoverLetterGeneratorAgent_GetUserPrompt_WithFeedback(t *testing.T) {
+	agent := NewCoverLetterGeneratorAgent(&AgentConfig{Type: AgentCover}, "")
+
+	posting := &ParsedPosting{Company: "TechCorp", Position: "Software Engineer"}
+	cv := &CVData{Basics: CVBasics{Name: "Jane Doe"}}
+	feedback := &DetailedReviewResult{
+		ResumeReview: DocumentReview{
+			Suggestions: []string{"Resume-only suggestion"},
+		},
+		CoverReview: DocumentReview{
+			Weaknesses:  []string{"Opening is generic"},
+			Suggestions: []string{"Mention the developer tools product by name"},
+		},
+	}
+
+	prompt, err := agent.GetUserPrompt(posting, cv, "", feedback, "")
+	if err != nil {
+		t.Fatalf("GetUserPrompt() error = %v", err)
+	}
+
+	if !contains(prompt, "Opening is generic") {
+		t.Error("GetUserPrompt() missing cover letter weakness")
+	}
+	if !contains(prompt, "Mention the developer tools product by name") {
+		t.Error("GetUserPrompt() missing cover letter suggestion")
+	}
+	if contains(prompt, "Resume-only suggestion") {
+		t.Error("GetUserPrompt() should not include resume feedback in cover letter prompt")
+	}
+}
+
+func TestCoverLetterGeneratorAgent_Tone(t *testing.T) {
+	posting := &ParsedPosting{Company: "TechCorp", Position: "Software Engineer"}
+	cv := &CVData{Basics: CVBasics{Name: "Jane Doe"}}
+
+	for _, tone := range CoverLetterTones {
+		agent := NewCoverLetterGeneratorAgent(&AgentConfig{Type: AgentCover}, "")
+		agent.Tone = tone
+
+		if system := agent.GetSystemPrompt(); !contains(system, "Requested Tone: "+tone) {
+			t.Errorf("GetSystemPrompt() missing %s tone directive", tone)
+		}
+
+		prompt, err := agent.GetUserPrompt(posting, cv, "", nil, "")
+		if err != nil {
+			t.Fatalf("GetUserPrompt() error = %v", err)
+		}
+		if !contains(prompt, "Write in a "+tone+" tone") {
+			t.Errorf("GetUserPrompt() missing %s tone instruction", tone)
+		}
+	}
+}
+
+func TestCoverLetterGeneratorAgent_DefaultTone(t *testing.T) {
+	agent := NewCoverLetterGeneratorAgent(&AgentConfig{Type: AgentCover}, "")
+
+	if contains(agent.GetSystemPrompt(), "Requested Tone") {
+		t.Error("GetSystemPrompt() should not include a tone directive by default")
+	}
+}
+
+func TestValidateTone(t *testing.T) {
+	for _, tone := range append([]string{""}, CoverLetterTones...) {
+		if err := ValidateTone(tone); err != nil {
+			t.Errorf("ValidateTone(%q) error = %v", tone, err)
+		}
+	}
+
+	err := ValidateTone("sarcastic")
+	if err == nil {
+		t.Fatal("ValidateTone(\"sarcastic\") should return error")
+	}
+	for _, tone := range CoverLetterTones {
+		if !contains(err.Error(), tone) {
+			t.Errorf("ValidateTone() error %q should list valid option %q", err, tone)
+		}
+	}
+}
+
+func TestSuggestTone(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+		want   string
+	}{
+		{"formal", []string{"Integrity", "Regulatory compliance"}, ToneFormal},
+		{"casual", []string{"Move fast", "Have fun"}, ToneCasual},
+		{"enthusiastic", []string{"Passion for our mission"}, ToneEnthusiastic},
+		{"no signal", []string{"Teamwork"}, ""},
+		{"tie", []string{"Integrity", "Fun"}, ""},
+		{"empty", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SuggestTone(&ParsedPosting{CompanyValues: tt.values})
+			if got != tt.want {
+				t.Errorf("SuggestTone(%v) = %q, want %q", tt.values, got, tt.want)
+			}
+		})
+	}
+
+	if got := SuggestTone(nil); got != "" {
+		t.Errorf("SuggestTone(nil) = %q, want empty", got)
+	}
+}
+
+func TestCoverLetterGeneratorAgent_GenerateKeepsTone(t *testing.T) {
+	agent := NewCoverLetterGeneratorAgent(&AgentConfig{Type: AgentCover}, "")
+
+	cvPath := filepath.Join(t.TempDir(), "cv.json")
+	if err := os.WriteFile(cvPath, []byte(`{"basics": {"name": "Jane Smith"}}`), 0644); err != nil {
+		t.Fatalf("Failed to create test CV: %v", err)
+	}
+
+	posting := &ParsedPosting{Company: "Acme", Position: "Engineer", CompanyValues: []string{"Move fast", "Have fun"}}
+	if _, err := agent.Generate(posting, cvPath, "", t.TempDir(), "swe"); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// The suggested tone applies to this posting only
+	if agent.Tone != "" {
+		t.Errorf("Tone = %q after Generate, want it left empty", agent.Tone)
+	}
+}
+
+func TestCoverLetterGeneratorAgent_StylePrompts(t *testing.T) {
+	posting := &ParsedPosting{Company: "TechCorp", Position: "Software Engineer"}
+	cv := &CVData{Basics: CVBasics{Name: "Jane Doe"}}
+
+	tests := []struct {
+		style     string
+		system    string
+		user      string
+		sentences string
+	}{
+		{"", "3 concise paragraphs maximum", "Keep it to 3 concise paragraphs maximum", "3-5 sentences"},
+		{StyleBrief, "2 short paragraphs maximum", "Keep it to 2 short paragraphs maximum", "2-3 sentences"},
+		{StyleStandard, "3 concise paragraphs maximum", "Keep it to 3 concise paragraphs maximum", "3-5 sentences"},
+		{StyleDetailed, "4 full paragraphs", "Keep it to 4 full paragraphs", "3-5 sentences"},
+	}
+
+	for _, tt := range tests {
+		agent := NewCoverLetterGeneratorAgent(&AgentConfig{Type: AgentCover}, "")
+		agent.Style = tt.style
+
+		system := agent.GetSystemPrompt()
+		if !contains(system, tt.system) || !contains(system, "Each paragraph should be "+tt.sentences) {
+			t.Errorf("style %q: system prompt missing %q / %q", tt.style, tt.system, tt.sentences)
+		}
+		prompt, err := agent.GetUserPrompt(posting, cv, "", nil, "")
+		if err != nil {
+			t.Fatalf("GetUserPrompt() error = %v", err)
+		}
+		if !contains(prompt, tt.user) {
+			t.Errorf("style %q: user prompt missing %q", tt.style, tt.user)
+		}
+	}
+}
+
+func TestCoverLetterGeneratorAgent_StyleParagraphBounds(t *testing.T) {
+	paragraph := "I have spent five years building reliable backend services in Go for teams that ship every week."
+	letter := func(paragraphs int) string {
+		body := []string{
+			`#import "@preview/modern-cv:0.9.0": *`,
+			`#show: coverletter.with(author: (firstname: "Jane"))`,
+			"Dear Hiring Team,",
+		}
+		for i := 0; i < paragraphs; i++ {
+			body = append(body, paragraph)
+		}
+		body = append(body, "Sincerely,\\\nJane Doe")
+		return strings.Join(body, "\n\n")
+	}
+
+	tests := []struct {
+		style      string
+		fewest     int
+		most       int
+		paragraphs int
+		wantErr    bool
+	}{
+		{StyleBrief, 1, 2, 2, false},
+		{StyleBrief, 1, 2, 3, true},
+		{StyleStandard, 2, 3, 3, false},
+		{StyleStandard, 2, 3, 4, true},
+		{StyleDetailed, 3, 4, 4, false},
+		{StyleDetailed, 3, 4, 2, true},
+		// Without a style the paragraph count isn't checked
+		{"", 2, 3, 6, false},
+	}
+
+	for _, tt := range tests {
+		agent := NewCoverLetterGeneratorAgent(&AgentConfig{Type: AgentCover}, "")
+		agent.Style = tt.style
+
+		if fewest, most := agent.ParagraphBounds(); fewest != tt.fewest || most != tt.most {
+			t.Errorf("style %q: ParagraphBounds() = %d-%d, want %d-%d", tt.style, fewest, most, tt.fewest, tt.most)
+		}
+		_, err := agent.ParseTypstOutput(letter(tt.paragraphs))
+		if (err != nil) != tt.wantErr {
+			t.Errorf("style %q with %d paragraphs: ParseTypstOutput() error = %v, wantErr %v", tt.style, tt.paragraphs, err, tt.wantErr)
+		}
+	}
+}
+
+func TestValidateCoverStyle(t *testing.T) {
+	for _, style := range append([]string{""}, CoverLetterStyles...) {
+		if err := ValidateCoverStyle(style); err != nil {
+			t.Errorf("ValidateCoverStyle(%q) error = %v", style, err)
+		}
+	}
+	if err := ValidateCoverStyle("epic"); err == nil || !contains(err.Error(), StyleDetailed) {
+		t.Errorf("ValidateCoverStyle(\"epic\") error = %v, want one listing the styles", err)
+	}
+}
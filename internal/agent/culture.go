@@ -0,0 +1,101 @@
+package agent
+
+import (
+	"strings"
+)
+
+// valueHeadings are section titles whose bullet lists hold company values
+var valueHeadings = []string{"our values", "what we value", "core values", "our culture", "our principles", "how we work"}
+
+// isValueHeading reports whether a line's text names a company values section
+func isValueHeading(text string) bool {
+	heading := strings.ToLower(strings.Trim(text, "#*: "))
+	if len(heading) >= 60 {
+		return false
+	}
+	for _, h := range valueHeadings {
+		if strings.Contains(heading, h) {
+			return true
+		}
+	}
+	return false
+}
+
+// extractCompanyValues collects the items listed under a values or culture
+// heading, as extractBenefits does for benefits
+func extractCompanyValues(lines []string) []string {
+	return extractSectionList(lines, isValueHeading)
+}
+
+// cultureHints mark a description sentence as being about culture
+var cultureHints = []string{"culture", "mission", "we value", "we believe", "our values"}
+
+// summarizeCulture builds a culture summary from a posting's values: a
+// sentence joining them, followed by the first sentence of the description
+// that talks about culture or mission. Returns "" without values.
+func summarizeCulture(posting *ParsedPosting) string {
+	var values []string
+	for _, v := range posting.CompanyValues {
+		if v = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(v), ".")); v != "" {
+			values = append(values, v)
+		}
+	}
+	if len(values) == 0 {
+		return ""
+	}
+
+	company := posting.Company
+	if company == "" {
+		company = "The company"
+	}
+	summary := company + " emphasizes " + joinWithAnd(values) + "."
+	if sentence := cultureSentence(posting.Description); sentence != "" {
+		summary += " " + sentence
+	}
+	return summary
+}
+
+// fillCultureSummary sets the posting's culture summary from its values
+// when the parser didn't write one
+func fillCultureSummary(posting *ParsedPosting) {
+	if strings.TrimSpace(posting.CultureSummary) == "" {
+		posting.CultureSummary = summarizeCulture(posting)
+	}
+}
+
+// cultureSentence returns the first prose sentence of a description that
+// mentions culture or mission, or ""
+func cultureSentence(description string) string {
+	for _, line := range strings.Split(description, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		for _, sentence := range strings.SplitAfter(line, ". ") {
+			sentence = strings.TrimSpace(sentence)
+			if len(sentence) > 300 || !strings.HasSuffix(sentence, ".") {
+				continue
+			}
+			lower := strings.ToLower(sentence)
+			for _, hint := range cultureHints {
+				if strings.Contains(lower, hint) {
+					return sentence
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// joinWithAnd joins items as "a", "a and b", or "a, b, and c"
+func joinWithAnd(items []string) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	case 2:
+		return items[0] + " and " + items[1]
+	}
+	return strings.Join(items[:len(items)-1], ", ") + ", and " + items[len(items)-1]
+}
@@ -0,0 +1,90 @@
+package agent
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSummarizeCulture(t *testing.T) {
+	tests := []struct {
+		name    string
+		posting ParsedPosting
+		want    string
+	}{
+		{
+			name:    "no values",
+			posting: ParsedPosting{Company: "Acme", Description: "Our culture is collaborative."},
+			want:    "",
+		},
+		{
+			name:    "one value",
+			posting: ParsedPosting{Company: "Acme", CompanyValues: []string{"ownership"}},
+			want:    "Acme emphasizes ownership.",
+		},
+		{
+			name: "values and a culture sentence",
+			posting: ParsedPosting{
+				Company:       "Acme",
+				CompanyValues: []string{"Customer obsession.", " bias for action ", "ownership"},
+				Description:   "# Backend Engineer\n\nYou'll build payment APIs. Our mission is to make payments boring.\nWe ship weekly.",
+			},
+			want: "Acme emphasizes Customer obsession, bias for action, and ownership. Our mission is to make payments boring.",
+		},
+		{
+			name:    "no company",
+			posting: ParsedPosting{CompanyValues: []string{"craft", "kindness"}},
+			want:    "The company emphasizes craft and kindness.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := summarizeCulture(&tt.posting); got != tt.want {
+				t.Errorf("summarizeCulture() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractBasicInfo_CultureSummary(t *testing.T) {
+	content := `# Platform Engineer at Acme
+
+We build developer tools.
+
+## Our Values
+- Ownership
+- Curiosity
+- Kindness
+
+## Benefits
+- Health insurance`
+	parsed := extractBasicInfo(content, "acme-platform_engineer.md")
+
+	if len(parsed.CompanyValues) != 3 || parsed.CompanyValues[0] != "Ownership" {
+		t.Fatalf("CompanyValues = %q, want the three listed values", parsed.CompanyValues)
+	}
+	for _, value := range parsed.CompanyValues {
+		if !strings.Contains(parsed.CultureSummary, value) {
+			t.Errorf("CultureSummary = %q, missing value %q", parsed.CultureSummary, value)
+		}
+	}
+}
+
+func TestParserAgent_ParseJSON_KeepsCultureSummary(t *testing.T) {
+	parser := NewParserAgent(nil)
+
+	written, err := parser.ParseJSON(`{"company": "Acme", "position": "Engineer", "company_values": ["ownership"], "culture_summary": "Small, writing-heavy team."}`)
+	if err != nil {
+		t.Fatalf("ParseJSON() error = %v", err)
+	}
+	if written.CultureSummary != "Small, writing-heavy team." {
+		t.Errorf("CultureSummary = %q, want the parser's summary kept", written.CultureSummary)
+	}
+
+	filled, err := parser.ParseJSON(`{"company": "Acme", "position": "Engineer", "company_values": ["ownership"]}`)
+	if err != nil {
+		t.Fatalf("ParseJSON() error = %v", err)
+	}
+	if filled.CultureSummary != "Acme emphasizes ownership." {
+		t.Errorf("CultureSummary = %q, want one built from the values", filled.CultureSummary)
+	}
+}
@@ -0,0 +1,242 @@
+package agent
+
+import (
+	"net/url"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// minDuplicateSimilarity is how much of their vocabulary (0-1) two postings
+// without a shared source URL must share to count as the same job
+const minDuplicateSimilarity = 0.9
+
+// DuplicateGroup is a set of pending postings for the same job
+type DuplicateGroup struct {
+	// Keep is the newest posting in the group
+	Keep string
+	// Duplicates are the older copies, newest first
+	Duplicates []string
+	// Reason says how the postings matched: "source URL", "identical
+	// content", and/or "similar content", comma separated
+	Reason string
+}
+
+// postingFingerprint is what a posting is compared on
+type postingFingerprint struct {
+	path    string
+	source  string // normalized source URL from the front matter
+	hash    string // hash of the body, ignoring case and spacing
+	words   map[string]bool
+	fetched time.Time
+}
+
+// FindDuplicatePostings groups the pending postings in postingsDir that
+// are the same job: those fetched from the same source URL, or whose text
+// is identical or nearly so. Archived postings are left alone. Groups are
+// sorted by the posting kept.
+func FindDuplicatePostings(postingsDir string) ([]DuplicateGroup, error) {
+	parser := NewParserAgent(nil)
+	paths, err := supportedFiles(parser, postingsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	prints := make([]postingFingerprint, 0, len(paths))
+	for _, path := range paths {
+		fp, err := fingerprintPosting(parser, path)
+		if err != nil {
+			return nil, err
+		}
+		prints = append(prints, fp)
+	}
+
+	// Union postings that match
+	parent := make([]int, len(prints))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	// reasons[i] is why the group rooted at i was joined to another
+	reasons := make([]string, len(prints))
+	for i := range prints {
+		for j := i + 1; j < len(prints); j++ {
+			if reason := duplicateReason(prints[i], prints[j]); reason != "" && find(i) != find(j) {
+				reasons[find(j)] = reason
+				parent[find(j)] = find(i)
+			}
+		}
+	}
+
+	members := make(map[int][]int)
+	for i := range prints {
+		root := find(i)
+		members[root] = append(members[root], i)
+	}
+
+	var groups []DuplicateGroup
+	for _, indexes := range members {
+		if len(indexes) < 2 {
+			continue
+		}
+		group := make([]postingFingerprint, len(indexes))
+		var why []string
+		for k, i := range indexes {
+			group[k] = prints[i]
+			if reasons[i] != "" && !slices.Contains(why, reasons[i]) {
+				why = append(why, reasons[i])
+			}
+		}
+		sort.SliceStable(group, func(a, b int) bool {
+			if !group[a].fetched.Equal(group[b].fetched) {
+				return group[a].fetched.After(group[b].fetched)
+			}
+			return group[a].path > group[b].path
+		})
+		sort.Strings(why)
+		dup := DuplicateGroup{Keep: group[0].path, Reason: strings.Join(why, ", ")}
+		for _, fp := range group[1:] {
+			dup.Duplicates = append(dup.Duplicates, fp.path)
+		}
+		groups = append(groups, dup)
+	}
+	sort.Slice(groups, func(a, b int) bool { return groups[a].Keep < groups[b].Keep })
+	return groups, nil
+}
+
+// duplicateReason says why two postings are the same job, or "" if they
+// aren't
+func duplicateReason(a, b postingFingerprint) string {
+	switch {
+	case a.source != "" && a.source == b.source:
+		return "source URL"
+	case a.hash == b.hash:
+		return "identical content"
+	case len(a.words) > 0 && wordOverlap(a.words, b.words) >= minDuplicateSimilarity:
+		return "similar content"
+	}
+	return ""
+}
+
+// fingerprintPosting reads a posting for comparison. Images are compared
+// by their bytes alone.
+func fingerprintPosting(parser *ParserAgent, path string) (postingFingerprint, error) {
+	fp := postingFingerprint{path: path}
+	info, err := os.Stat(path)
+	if err != nil {
+		return fp, err
+	}
+	fp.fetched = info.ModTime()
+
+	if parser.IsImageFile(path) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fp, err
+		}
+		fp.hash = hashContent(data)
+		return fp, nil
+	}
+
+	content, err := parser.ReadPosting(path)
+	if err != nil {
+		return fp, err
+	}
+	fields, body := splitFrontMatter(content)
+	fp.source = normalizeSourceURL(fields["source"])
+	if fetched, err := time.ParseInLocation("2006-01-02 15:04:05", fields["fetched"], time.Local); err == nil {
+		fp.fetched = fetched
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(body), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	fp.hash = hashContent([]byte(strings.Join(words, " ")))
+	fp.words = make(map[string]bool, len(words))
+	for _, w := range words {
+		fp.words[w] = true
+	}
+	return fp, nil
+}
+
+// splitFrontMatter separates the "key: value" front matter written by
+// `ghosted fetch` from the posting body. Content without front matter is
+// all body.
+func splitFrontMatter(content string) (map[string]string, string) {
+	fields := make(map[string]string)
+	rest, ok := strings.CutPrefix(strings.TrimPrefix(content, "\ufeff"), "---\n")
+	if !ok {
+		return fields, content
+	}
+	header, body, ok := strings.Cut(rest, "\n---\n")
+	if !ok {
+		return fields, content
+	}
+	for _, line := range strings.Split(header, "\n") {
+		if key, value, ok := strings.Cut(line, ":"); ok {
+			fields[strings.ToLower(strings.TrimSpace(key))] = strings.TrimSpace(value)
+		}
+	}
+	return fields, body
+}
+
+// normalizeSourceURL reduces a posting URL to what identifies the job:
+// scheme and host case, a "www." prefix, trailing slashes, fragments, and
+// utm_ tracking parameters don't count
+func normalizeSourceURL(raw string) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+	query := u.Query()
+	for key := range query {
+		if strings.HasPrefix(strings.ToLower(key), "utm_") {
+			query.Del(key)
+		}
+	}
+	normalized := host + strings.TrimRight(u.Path, "/")
+	if encoded := query.Encode(); encoded != "" {
+		normalized += "?" + encoded
+	}
+	return normalized
+}
+
+// wordOverlap scores how alike two vocabularies are from 0 to 1: the words
+// they share over the words in either (Jaccard similarity)
+func wordOverlap(a, b map[string]bool) float64 {
+	shared := 0
+	for w := range a {
+		if b[w] {
+			shared++
+		}
+	}
+	union := len(a) + len(b) - shared
+	if union == 0 {
+		return 1
+	}
+	return float64(shared) / float64(union)
+}
+
+// RemoveDuplicatePostings deletes each group's duplicates, keeping the
+// newest posting, and returns the paths removed
+func RemoveDuplicatePostings(groups []DuplicateGroup) ([]string, error) {
+	var removed []string
+	for _, group := range groups {
+		for _, path := range group.Duplicates {
+			if err := os.Remove(path); err != nil {
+				return removed, err
+			}
+			removed = append(removed, path)
+		}
+	}
+	return removed, nil
+}
@@ -0,0 +1,107 @@
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writePostings(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestFindDuplicatePostings_SourceURL(t *testing.T) {
+	dir := t.TempDir()
+	writePostings(t, dir, map[string]string{
+		"acme-swe.md":     "---\nsource: https://jobs.acme.example/roles/123\nfetched: 2026-03-01 09:00:00\ncompany: Acme\n---\n\n# Software Engineer\n\nBuild the billing platform.\n",
+		"acme-backend.md": "---\nsource: https://www.jobs.acme.example/roles/123/?utm_source=linkedin\nfetched: 2026-03-08 09:00:00\ncompany: Acme\n---\n\n# Backend Engineer\n\nBuild the billing platform in Go, now with an updated description.\n",
+		"globex-sre.md":   "---\nsource: https://globex.example/careers/sre\nfetched: 2026-03-05 09:00:00\n---\n\n# SRE\n\nKeep the lights on.\n",
+	})
+
+	groups, err := FindDuplicatePostings(dir)
+	if err != nil {
+		t.Fatalf("FindDuplicatePostings() error = %v", err)
+	}
+	if len(groups) != 1 {
+		t.Fatalf("FindDuplicatePostings() = %+v, want one group", groups)
+	}
+	got := groups[0]
+	if filepath.Base(got.Keep) != "acme-backend.md" || got.Reason != "source URL" {
+		t.Errorf("group keeps %s by %q, want the newer acme-backend.md by source URL", got.Keep, got.Reason)
+	}
+	if len(got.Duplicates) != 1 || filepath.Base(got.Duplicates[0]) != "acme-swe.md" {
+		t.Errorf("duplicates = %v, want acme-swe.md", got.Duplicates)
+	}
+
+	removed, err := RemoveDuplicatePostings(groups)
+	if err != nil {
+		t.Fatalf("RemoveDuplicatePostings() error = %v", err)
+	}
+	if len(removed) != 1 {
+		t.Errorf("removed %v, want one posting", removed)
+	}
+	for name, want := range map[string]bool{"acme-swe.md": false, "acme-backend.md": true, "globex-sre.md": true} {
+		if _, err := os.Stat(filepath.Join(dir, name)); (err == nil) != want {
+			t.Errorf("%s exists = %v, want %v", name, err == nil, want)
+		}
+	}
+}
+
+func TestFindDuplicatePostings_Content(t *testing.T) {
+	body := "# Platform Engineer\n\nYou will run our Kubernetes clusters, own the deploy pipeline, and mentor two engineers. We use Go, Terraform, and AWS.\n"
+
+	tests := []struct {
+		name       string
+		files      map[string]string
+		wantReason string // "" means no duplicates
+	}{
+		{
+			name: "different postings",
+			files: map[string]string{
+				"acme-swe.md":   "# Software Engineer\n\nBuild the billing platform in Go and Postgres.\n",
+				"globex-pm.txt": "# Product Manager\n\nOwn the roadmap for our mobile apps and talk to customers weekly.\n",
+			},
+		},
+		{
+			name: "same text saved twice",
+			files: map[string]string{
+				"initech-platform.md":   body,
+				"initech-platform-2.md": "---\nfetched: 2026-03-02 10:00:00\n---\n\n" + body + "\n\n",
+			},
+			wantReason: "identical content",
+		},
+		{
+			name: "nearly the same text",
+			files: map[string]string{
+				"initech-platform.md":   body,
+				"initech-platform-2.md": body + "Apply today.\n",
+			},
+			wantReason: "similar content",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writePostings(t, dir, tt.files)
+
+			groups, err := FindDuplicatePostings(dir)
+			if err != nil {
+				t.Fatalf("FindDuplicatePostings() error = %v", err)
+			}
+			if tt.wantReason == "" {
+				if len(groups) != 0 {
+					t.Errorf("FindDuplicatePostings() = %+v, want no duplicates", groups)
+				}
+				return
+			}
+			if len(groups) != 1 || len(groups[0].Duplicates) != 1 || groups[0].Reason != tt.wantReason {
+				t.Errorf("FindDuplicatePostings() = %+v, want one pair matched by %s", groups, tt.wantReason)
+			}
+		})
+	}
+}
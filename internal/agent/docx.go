@@ -0,0 +1,81 @@
+package agent
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// docxBodyPath is the main document part inside a .docx archive
+const docxBodyPath = "word/document.xml"
+
+// readDocx extracts the text of a Word document as plain paragraphs, one
+// per line. Formatting is dropped.
+func readDocx(path string) (string, error) {
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		// Password-protected documents are encrypted containers, not zips
+		return "", fmt.Errorf("failed to open .docx (corrupt or password-protected?): %w", err)
+	}
+	defer archive.Close()
+
+	for _, file := range archive.File {
+		if file.Name != docxBodyPath {
+			continue
+		}
+		body, err := file.Open()
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s: %w", docxBodyPath, err)
+		}
+		defer body.Close()
+		return docxText(body)
+	}
+	return "", fmt.Errorf("not a Word document: %s missing", docxBodyPath)
+}
+
+// docxText walks WordprocessingML and returns its paragraphs (<w:p>) as
+// lines. Text runs (<w:t>) are concatenated; tabs and line breaks are kept.
+func docxText(r io.Reader) (string, error) {
+	decoder := xml.NewDecoder(r)
+	var paragraphs []string
+	var current strings.Builder
+	inText := false
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to parse %s: %w", docxBodyPath, err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "t":
+				inText = true
+			case "tab":
+				current.WriteString("\t")
+			case "br", "cr":
+				current.WriteString("\n")
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				paragraphs = append(paragraphs, strings.TrimRight(current.String(), " \t"))
+				current.Reset()
+			}
+		case xml.CharData:
+			if inText {
+				current.Write(t)
+			}
+		}
+	}
+
+	return strings.TrimSpace(strings.Join(paragraphs, "\n")), nil
+}
@@ -0,0 +1,72 @@
+package agent
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeDocx writes a minimal .docx: a zip holding word/document.xml
+func writeDocx(t *testing.T, path, documentXML string) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	zw := zip.NewWriter(f)
+	w, err := zw.Create("word/document.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte(documentXML)); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParserAgent_ReadPosting_Docx(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "acme-swe-posting.docx")
+	writeDocx(t, path, `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+  <w:body>
+    <w:p><w:pPr><w:pStyle w:val="Heading1"/></w:pPr><w:r><w:t>Software Engineer</w:t></w:r></w:p>
+    <w:p><w:r><w:t xml:space="preserve">Company: </w:t></w:r><w:r><w:rPr><w:b/></w:rPr><w:t>Acme &amp; Co</w:t></w:r></w:p>
+    <w:p></w:p>
+    <w:p><w:r><w:t>Requirements</w:t></w:r></w:p>
+    <w:p><w:r><w:t>3+ years of Go</w:t><w:br/><w:t>Location:</w:t><w:tab/><w:t>Remote</w:t></w:r></w:p>
+  </w:body>
+</w:document>`)
+
+	got, err := NewParserAgent(nil).ReadPosting(path)
+	if err != nil {
+		t.Fatalf("ReadPosting() error = %v", err)
+	}
+	want := "Software Engineer\nCompany: Acme & Co\n\nRequirements\n3+ years of Go\nLocation:\tRemote"
+	if got != want {
+		t.Errorf("ReadPosting() = %q, want %q", got, want)
+	}
+}
+
+func TestParserAgent_ReadPosting_DocxCorrupt(t *testing.T) {
+	dir := t.TempDir()
+	parser := NewParserAgent(nil)
+
+	// Encrypted Word files are OLE containers, not zips
+	encrypted := filepath.Join(dir, "protected.docx")
+	if err := os.WriteFile(encrypted, []byte("\xd0\xcf\x11\xe0\xa1\xb1\x1a\xe1not a zip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parser.ReadPosting(encrypted); err == nil {
+		t.Error("ReadPosting() on a non-zip .docx should fail")
+	}
+
+	broken := filepath.Join(dir, "broken.docx")
+	writeDocx(t, broken, `<w:document><w:body><w:p><w:t>unclosed`)
+	if _, err := parser.ReadPosting(broken); err == nil {
+		t.Error("ReadPosting() on malformed document.xml should fail")
+	}
+}
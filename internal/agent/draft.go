@@ -0,0 +1,192 @@
+package agent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Drafts are Typst or markdown documents rendered straight from the CV,
+// without an AI backend. They give the pipeline reviewable documents to
+// write and compile; the AI generators replace them with tailored versions.
+
+// draftSyntax is the markup a draft is written in. Both formats share
+// typstEscape (pandoc treats any backslash-escaped punctuation literally),
+// "- " bullets, _emphasis_, and trailing-backslash line breaks.
+type draftSyntax struct {
+	resumePreamble string
+	coverPreamble  string
+	heading        string // repeated once per heading level
+	bold           string // wraps bold text on both sides
+}
+
+var (
+	typstDraft = draftSyntax{
+		resumePreamble: "#set page(margin: 1.5cm)\n#set text(size: 10pt)\n\n",
+		coverPreamble:  "#set page(margin: 2cm)\n#set text(size: 11pt)\n\n",
+		heading:        "=",
+		bold:           "*",
+	}
+	markdownDraft = draftSyntax{heading: "#", bold: "**"}
+)
+
+// draftSyntaxFor returns the draft markup for a document format
+func draftSyntaxFor(format string) draftSyntax {
+	if format == FormatMarkdown {
+		return markdownDraft
+	}
+	return typstDraft
+}
+
+// h renders a heading at level
+func (d draftSyntax) h(level int, text string) string {
+	return strings.Repeat(d.heading, level) + " " + text
+}
+
+// b renders bold text
+func (d draftSyntax) b(text string) string {
+	return d.bold + text + d.bold
+}
+
+// DraftResume renders a Typst resume from the CV, listing the skills the
+// posting asks for first and the most relevant experience
+func DraftResume(cv *CVData, posting *ParsedPosting) string {
+	return draftResume(cv, posting, typstDraft)
+}
+
+// draftResume is DraftResume in the given markup
+func draftResume(cv *CVData, posting *ParsedPosting, d draftSyntax) string {
+	var b strings.Builder
+	b.WriteString(d.resumePreamble)
+
+	fmt.Fprintf(&b, "%s\n\n", d.h(1, typstEscape(cv.Basics.Name)))
+	if contact := draftContactLine(cv.Basics); contact != "" {
+		fmt.Fprintf(&b, "%s\n\n", contact)
+	}
+	if cv.Basics.Summary != "" {
+		fmt.Fprintf(&b, "%s\n\n%s\n\n", d.h(2, "Summary"), typstEscape(cv.Basics.Summary))
+	}
+
+	matching := NewResumeGeneratorAgent(nil, "").ExtractMatchingSkills(cv, posting)
+	if len(matching) > 0 || len(cv.Skills) > 0 {
+		fmt.Fprintf(&b, "%s\n\n", d.h(2, "Skills"))
+		if len(matching) > 0 {
+			fmt.Fprintf(&b, "- %s %s\n", d.b("Relevant:"), typstEscape(strings.Join(matching, ", ")))
+		}
+		for _, group := range cv.Skills {
+			fmt.Fprintf(&b, "- %s %s\n", d.b(typstEscape(group.Name)+":"), typstEscape(strings.Join(group.Keywords, ", ")))
+		}
+		b.WriteString("\n")
+	}
+
+	experiences := NewCoverLetterGeneratorAgent(nil, "").ExtractRelevantExperiences(cv, posting, len(cv.Work))
+	if len(experiences) > 0 {
+		fmt.Fprintf(&b, "%s\n\n", d.h(2, "Experience"))
+		for _, work := range experiences {
+			fmt.Fprintf(&b, "%s\n", d.h(3, typstEscape(work.Position)+", "+typstEscape(work.Name)))
+			if dates := draftDateRange(work.StartDate, work.EndDate); dates != "" {
+				fmt.Fprintf(&b, "_%s_\n\n", dates)
+			}
+			for _, highlight := range work.Highlights {
+				fmt.Fprintf(&b, "- %s\n", typstEscape(highlight))
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	if len(cv.Education) > 0 {
+		fmt.Fprintf(&b, "%s\n\n", d.h(2, "Education"))
+		for _, edu := range cv.Education {
+			fmt.Fprintf(&b, "- %s %s, %s\n", typstEscape(edu.StudyType), typstEscape(edu.Area), typstEscape(edu.Institution))
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// DraftCoverLetter renders a short Typst cover letter from the CV, citing
+// the most relevant role and the skills the posting asks for
+func DraftCoverLetter(cv *CVData, posting *ParsedPosting, now time.Time) string {
+	return draftCoverLetter(cv, posting, now, typstDraft)
+}
+
+// draftCoverLetter is DraftCoverLetter in the given markup
+func draftCoverLetter(cv *CVData, posting *ParsedPosting, now time.Time, d draftSyntax) string {
+	var b strings.Builder
+	b.WriteString(d.coverPreamble)
+
+	fmt.Fprintf(&b, "%s\\\n", d.b(typstEscape(cv.Basics.Name)))
+	if contact := draftContactLine(cv.Basics); contact != "" {
+		fmt.Fprintf(&b, "%s\n\n", contact)
+	}
+	fmt.Fprintf(&b, "%s\n\n", now.Format("January 2, 2006"))
+
+	company := posting.Company
+	if company == "" {
+		company = "your company"
+	}
+	fmt.Fprintf(&b, "Dear Hiring Team at %s,\n\n", typstEscape(company))
+	fmt.Fprintf(&b, "I am applying for the %s role.", typstEscape(posting.Position))
+	if cv.Basics.Summary != "" {
+		fmt.Fprintf(&b, " %s", typstEscape(cv.Basics.Summary))
+	}
+	b.WriteString("\n\n")
+
+	experiences := NewCoverLetterGeneratorAgent(nil, "").ExtractRelevantExperiences(cv, posting, 1)
+	if len(experiences) > 0 {
+		work := experiences[0]
+		fmt.Fprintf(&b, "As %s at %s", typstEscape(work.Position), typstEscape(work.Name))
+		if len(work.Highlights) > 0 {
+			fmt.Fprintf(&b, ", I %s", typstEscape(lowerFirst(strings.TrimSuffix(work.Highlights[0], "."))))
+		}
+		b.WriteString(".\n\n")
+	}
+
+	if matching := NewResumeGeneratorAgent(nil, "").ExtractMatchingSkills(cv, posting); len(matching) > 0 {
+		fmt.Fprintf(&b, "My experience with %s matches what the role calls for.\n\n", typstEscape(strings.Join(matching, ", ")))
+	}
+
+	fmt.Fprintf(&b, "Thank you for your consideration.\n\nSincerely,\\\n%s\n", typstEscape(cv.Basics.Name))
+	return b.String()
+}
+
+// draftContactLine joins the CV's contact details with separators
+func draftContactLine(basics CVBasics) string {
+	var parts []string
+	for _, part := range []string{basics.Email, basics.Phone, basics.URL} {
+		if part != "" {
+			parts = append(parts, typstEscape(part))
+		}
+	}
+	return strings.Join(parts, " · ")
+}
+
+// draftDateRange formats a CV date range, treating a missing end as present
+func draftDateRange(start, end string) string {
+	if start == "" {
+		return ""
+	}
+	if end == "" {
+		end = "Present"
+	}
+	return typstEscape(start + " – " + end)
+}
+
+// lowerFirst lowercases the first letter of s, so a highlight can continue a sentence
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+// typstMarkup are the characters with meaning in Typst markup
+var typstMarkup = strings.NewReplacer(
+	`\`, `\\`, `#`, `\#`, `$`, `\$`, `*`, `\*`, `_`, `\_`,
+	`@`, `\@`, `<`, `\<`, `>`, `\>`, `[`, `\[`, `]`, `\]`, "`", "\\`",
+)
+
+// typstEscape makes CV text safe to place in Typst markup
+func typstEscape(s string) string {
+	return typstMarkup.Replace(s)
+}
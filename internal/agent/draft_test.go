@@ -0,0 +1,55 @@
+package agent
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDraftResume(t *testing.T) {
+	cv := &CVData{
+		Basics: CVBasics{Name: "Ada #1", Email: "ada@example.com"},
+		Skills: []CVSkill{{Name: "Languages", Keywords: []string{"Go", "C*"}}},
+		Work: []CVWork{
+			{Name: "Initech", Position: "Engineer", StartDate: "2020", Highlights: []string{"Shipped $ savings"}},
+		},
+	}
+	posting := &ParsedPosting{TechStack: []string{"Go"}}
+
+	got := DraftResume(cv, posting)
+	for _, want := range []string{
+		`= Ada \#1`,
+		`ada\@example.com`,
+		"- *Relevant:* Go",
+		`- *Languages:* Go, C\*`,
+		"=== Engineer, Initech",
+		"_2020 – Present_",
+		`- Shipped \$ savings`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("DraftResume() missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestDraftCoverLetter(t *testing.T) {
+	cv := &CVData{
+		Basics: CVBasics{Name: "Ada"},
+		Skills: []CVSkill{{Name: "Languages", Keywords: []string{"Go"}}},
+		Work:   []CVWork{{Name: "Initech", Position: "Engineer", Highlights: []string{"Built the billing service."}}},
+	}
+	posting := &ParsedPosting{Company: "Acme", Position: "Backend Engineer", TechStack: []string{"Go"}}
+
+	got := DraftCoverLetter(cv, posting, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+	for _, want := range []string{
+		"March 1, 2026",
+		"Dear Hiring Team at Acme,",
+		"I am applying for the Backend Engineer role.",
+		"As Engineer at Initech, I built the billing service.",
+		"My experience with Go",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("DraftCoverLetter() missing %q:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package agent
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// Placeholders for documents the emitted prompts refer to but that only
+// exist once the earlier prompts have been answered
+const (
+	resumePlaceholder      = "[Paste the generated resume here]"
+	coverLetterPlaceholder = "[Paste the generated cover letter here]"
+)
+
+// AgentPrompt is the system and user prompt an agent would be given
+type AgentPrompt struct {
+	Agent  AgentType
+	System string
+	User   string
+}
+
+// EmitPrompts parses a posting and builds the prompts the resume, cover
+// letter, reviewer, and tracker agents would receive for it, without
+// generating, reviewing, or tracking anything. Documents that don't exist
+// yet appear as placeholders to paste the earlier agents' answers into.
+func (p *Pipeline) EmitPrompts(postingPath string) ([]AgentPrompt, error) {
+	parsed, err := p.Parse(postingPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var cv *CVData
+	if p.CVPath != "" {
+		cv, err = NewResumeGeneratorAgent(nil, "").LoadCV(p.CVPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rawPosting := ""
+	if p.AttachPosting {
+		rawPosting, err = postingText(postingPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	format, err := p.DocumentFormat()
+	if err != nil {
+		return nil, err
+	}
+
+	prompts := make([]AgentPrompt, 0, 4)
+
+	resume := NewResumeGeneratorAgent(p.Config.GetAgentConfig(AgentResume), p.BaseDir)
+	resume.Format = format
+	user, err := resume.GetUserPrompt(parsed, cv, "", nil, rawPosting)
+	if err != nil {
+		return nil, fmt.Errorf("resume prompt: %w", err)
+	}
+	prompts = append(prompts, AgentPrompt{Agent: AgentResume, System: resume.GetSystemPrompt(), User: user})
+
+	cover := NewCoverLetterGeneratorAgent(p.Config.GetAgentConfig(AgentCover), p.BaseDir)
+	cover.Format = format
+	if cover.Tone, err = p.resolveTone(parsed); err != nil {
+		return nil, err
+	}
+	if err := ValidateCoverStyle(p.CoverStyle); err != nil {
+		return nil, err
+	}
+	cover.Style = p.CoverStyle
+	user, err = cover.GetUserPrompt(parsed, cv, resumePlaceholder, nil, rawPosting)
+	if err != nil {
+		return nil, fmt.Errorf("cover letter prompt: %w", err)
+	}
+	prompts = append(prompts, AgentPrompt{Agent: AgentCover, System: cover.GetSystemPrompt(), User: user})
+
+	reviewerCV := cv
+	if p.ReviewerCVPath != "" {
+		reviewerCV, err = NewResumeGeneratorAgent(nil, "").LoadCV(p.ReviewerCVPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+	reviewer := p.newReviewer()
+	user, err = reviewer.GetUserPrompt(parsed, resumePlaceholder, coverLetterPlaceholder, reviewerCV)
+	if err != nil {
+		return nil, fmt.Errorf("reviewer prompt: %w", err)
+	}
+	prompts = append(prompts, AgentPrompt{Agent: AgentReviewer, System: reviewer.GetSystemPrompt(), User: user})
+
+	tracker := NewTrackerAgent(p.Config.GetAgentConfig(AgentTracker), nil, p.BaseDir)
+	jobType := tracker.DetermineJobType(parsed)
+	user, err = tracker.GetUserPrompt(&TrackerInput{
+		Posting: parsed,
+		Documents: &GeneratedDocuments{
+			ResumePath:      filepath.Join(p.Config.Paths.OutputDir, p.formatFilename(*parsed, "resume"+documentExt(format))),
+			CoverLetterPath: filepath.Join(p.Config.Paths.OutputDir, p.formatFilename(*parsed, "cover"+documentExt(format))),
+		},
+		ApplicationFolder: tracker.GenerateApplicationFolder(parsed, jobType),
+		JobType:           jobType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("tracker prompt: %w", err)
+	}
+	prompts = append(prompts, AgentPrompt{Agent: AgentTracker, System: tracker.GetSystemPrompt(), User: user})
+
+	return prompts, nil
+}
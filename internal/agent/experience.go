@@ -0,0 +1,59 @@
+package agent
+
+import (
+	"time"
+)
+
+// cvDateLayouts are the JSON Resume date formats, most specific first
+var cvDateLayouts = []string{"2006-01-02", "2006-01", "2006"}
+
+// parseCVDate parses a JSON Resume date; ok is false for empty or
+// unrecognised dates
+func parseCVDate(s string) (time.Time, bool) {
+	for _, layout := range cvDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// YearsExperience estimates the years of work the CV covers, from the
+// earliest start date to the latest end date (or now, for current roles).
+// Gaps between roles are counted, so this is an upper bound.
+func (cv *CVData) YearsExperience(now time.Time) int {
+	var earliest, latest time.Time
+	for _, work := range cv.Work {
+		start, ok := parseCVDate(work.StartDate)
+		if !ok {
+			continue
+		}
+		end, ok := parseCVDate(work.EndDate)
+		if !ok {
+			end = now
+		}
+		if earliest.IsZero() || start.Before(earliest) {
+			earliest = start
+		}
+		if end.After(latest) {
+			latest = end
+		}
+	}
+	if earliest.IsZero() || !latest.After(earliest) {
+		return 0
+	}
+	return int(latest.Sub(earliest).Hours() / 24 / 365.25)
+}
+
+// SeniorityFit scores how well have years of experience meet a posting's
+// required years, from 0 (none) to 1 (meets or exceeds). Postings that
+// don't state a requirement fit fully.
+func SeniorityFit(have, required int) float64 {
+	if required <= 0 || have >= required {
+		return 1
+	}
+	if have <= 0 {
+		return 0
+	}
+	return float64(have) / float64(required)
+}
@@ -0,0 +1,50 @@
+package agent
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCVData_YearsExperience(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name string
+		work []CVWork
+		want int
+	}{
+		{"no work", nil, 0},
+		{"current role", []CVWork{{StartDate: "2021-03-15"}}, 5},
+		{"earliest start to latest end", []CVWork{
+			{StartDate: "2016", EndDate: "2018-06"},
+			{StartDate: "2019-01", EndDate: "2024-01"},
+		}, 8},
+		{"unparseable start is skipped", []CVWork{{StartDate: "spring 2010"}, {StartDate: "2023-06"}}, 3},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cv := &CVData{Work: tc.work}
+			if got := cv.YearsExperience(now); got != tc.want {
+				t.Errorf("YearsExperience() = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSeniorityFit(t *testing.T) {
+	cases := []struct {
+		have, required int
+		want           float64
+	}{
+		{0, 0, 1},
+		{2, 0, 1},
+		{5, 5, 1},
+		{8, 5, 1},
+		{3, 6, 0.5},
+		{0, 4, 0},
+	}
+	for _, tc := range cases {
+		if got := SeniorityFit(tc.have, tc.required); got != tc.want {
+			t.Errorf("SeniorityFit(%d, %d) = %v, want %v", tc.have, tc.required, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Document formats the generators can write
+const (
+	FormatTypst    = "typst"
+	FormatMarkdown = "markdown"
+)
+
+// DocumentFormats lists the valid document formats
+var DocumentFormats = []string{FormatTypst, FormatMarkdown}
+
+// ValidateDocumentFormat returns an error listing the valid options if
+// format is not supported. An empty format is valid and means Typst.
+func ValidateDocumentFormat(format string) error {
+	switch format {
+	case "", FormatTypst, FormatMarkdown:
+		return nil
+	}
+	return fmt.Errorf("invalid document format %q (valid: %s)", format, strings.Join(DocumentFormats, ", "))
+}
+
+// documentExt is the file extension for documents in format
+func documentExt(format string) string {
+	if format == FormatMarkdown {
+		return ".md"
+	}
+	return ".typ"
+}
+
+// IsMarkdownDocument reports whether a generated document is markdown,
+// by its extension
+func IsMarkdownDocument(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".md")
+}
+
+// outputFormatSection is the system prompt's Output Format section for a
+// document ("resume" or "cover letter") in format
+func outputFormatSection(format, document string) string {
+	if format == FormatMarkdown {
+		return fmt.Sprintf(`## Output Format
+
+Generate the complete %s as Markdown that pandoc can convert to PDF: # for the name or title, ## for sections, **bold** for emphasis, and - for bullet lists. Return ONLY the Markdown content, with no code fences, Typst, HTML, or explanations.`, document)
+	}
+	return `## Output Format
+
+Generate a complete Typst file using @preview/modern-cv:0.9.0. Return ONLY the Typst content, no markdown formatting or explanations.`
+}
+
+// outputFormatInstruction is the user prompt's closing instruction on what
+// to return
+func outputFormatInstruction(format string) string {
+	if format == FormatMarkdown {
+		return "Return ONLY the complete Markdown document"
+	}
+	return "Return ONLY the complete Typst file content"
+}
+
+// parseMarkdownOutput cleans AI-generated markdown. Markdown has no
+// template to check, so only empty output is rejected.
+func parseMarkdownOutput(output string) (string, error) {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	var header []string
+	for len(lines) > 0 && strings.HasPrefix(strings.TrimSpace(lines[0]), "<!--") {
+		header = append(header, lines[0])
+		lines = lines[1:]
+	}
+
+	body := strings.TrimSpace(strings.Join(lines, "\n"))
+	for _, fence := range []string{"```markdown", "```md", "```"} {
+		if strings.HasPrefix(body, fence) {
+			body = strings.TrimPrefix(body, fence)
+			body = strings.TrimSuffix(strings.TrimSpace(body), "```")
+			break
+		}
+	}
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", errors.New("invalid Markdown output: empty document")
+	}
+
+	if len(header) == 0 {
+		return body, nil
+	}
+	return strings.Join(header, "\n") + "\n" + body, nil
+}
+
+// CompileMarkdownPDF converts a markdown document to PDF with pandoc
+func CompileMarkdownPDF(mdPath string) (string, error) {
+	pdfPath := strings.TrimSuffix(mdPath, filepath.Ext(mdPath)) + ".pdf"
+
+	cmd := exec.Command("pandoc", mdPath, "-o", pdfPath)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("pandoc failed: %w\nOutput: %s", err, string(output))
+	}
+
+	return pdfPath, nil
+}
+
+// IsPandocAvailable checks if the pandoc CLI is installed
+func IsPandocAvailable() bool {
+	_, err := exec.LookPath("pandoc")
+	return err == nil
+}
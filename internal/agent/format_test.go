@@ -0,0 +1,169 @@
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateDocumentFormat(t *testing.T) {
+	for _, format := range []string{"", FormatTypst, FormatMarkdown} {
+		if err := ValidateDocumentFormat(format); err != nil {
+			t.Errorf("ValidateDocumentFormat(%q) error = %v", format, err)
+		}
+	}
+	err := ValidateDocumentFormat("docx")
+	if err == nil || !strings.Contains(err.Error(), "typst, markdown") {
+		t.Errorf("ValidateDocumentFormat(docx) error = %v, want one listing the formats", err)
+	}
+}
+
+func TestMarkdownFormat_Prompts(t *testing.T) {
+	posting := &ParsedPosting{Company: "TechCorp", Position: "Software Engineer"}
+	cv := &CVData{Basics: CVBasics{Name: "Jane Doe"}}
+
+	resume := NewResumeGeneratorAgent(&AgentConfig{Type: AgentResume}, "")
+	resume.Format = FormatMarkdown
+	resumeUser, err := resume.GetUserPrompt(posting, cv, "", nil, "")
+	if err != nil {
+		t.Fatalf("resume GetUserPrompt() error = %v", err)
+	}
+
+	cover := NewCoverLetterGeneratorAgent(&AgentConfig{Type: AgentCover}, "")
+	cover.Format = FormatMarkdown
+	coverUser, err := cover.GetUserPrompt(posting, cv, "", nil, "")
+	if err != nil {
+		t.Fatalf("cover GetUserPrompt() error = %v", err)
+	}
+
+	prompts := map[string]string{
+		"resume system": resume.GetSystemPrompt(),
+		"resume user":   resumeUser,
+		"cover system":  cover.GetSystemPrompt(),
+		"cover user":    coverUser,
+	}
+	for name, prompt := range prompts {
+		if !strings.Contains(prompt, "Markdown") {
+			t.Errorf("%s prompt doesn't ask for Markdown", name)
+		}
+		for _, typst := range []string{"Typst file", "Typst content", "@preview/modern-cv"} {
+			if strings.Contains(prompt, typst) {
+				t.Errorf("%s prompt still asks for %q", name, typst)
+			}
+		}
+	}
+}
+
+func TestMarkdownFormat_ParseOutputSkipsTypstValidation(t *testing.T) {
+	resume := NewResumeGeneratorAgent(&AgentConfig{Type: AgentResume}, "")
+	resume.Format = FormatMarkdown
+	cover := NewCoverLetterGeneratorAgent(&AgentConfig{Type: AgentCover}, "")
+	cover.Format = FormatMarkdown
+	cover.Style = StyleBrief
+
+	letter := "Dear Hiring Team,\n\n" +
+		"I am applying for the Software Engineer role, where I would bring years of backend work in Go.\n\n" +
+		"At my last company I rebuilt the billing pipeline and cut its latency in half for customers.\n\n" +
+		"Sincerely,\\\nJane Doe"
+
+	tests := []struct {
+		name    string
+		parse   func(string) (string, error)
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"plain resume", resume.ParseOutput, "# Jane Doe\n\n## Skills\n\n- Go", "# Jane Doe\n\n## Skills\n\n- Go", false},
+		{"fenced resume", resume.ParseOutput, "```markdown\n# Jane Doe\n```", "# Jane Doe", false},
+		{"metadata header kept", resume.ParseOutput, "<!-- cv: 3f2a9c1b7d4e -->\n```md\n# Jane Doe\n```", "<!-- cv: 3f2a9c1b7d4e -->\n# Jane Doe", false},
+		{"empty resume", resume.ParseOutput, "```markdown\n```", "", true},
+		{"cover letter", cover.ParseOutput, letter, letter, false},
+		{"cover letter too long for its style", cover.ParseOutput, strings.Replace(letter, "Sincerely", letter, 1), "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.parse(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseOutput() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseOutput() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// The same markdown fails the Typst checks when the format is unset
+	resume.Format = ""
+	if _, err := resume.ParseOutput("# Jane Doe"); err == nil {
+		t.Error("ParseOutput() accepted markdown for a Typst resume")
+	}
+}
+
+func TestPipeline_MarkdownFormat(t *testing.T) {
+	tmpDir := t.TempDir()
+	cvPath := filepath.Join(tmpDir, "cv.json")
+	if err := os.WriteFile(cvPath, []byte(`{"basics": {"name": "Draft Writer", "email": "draft@example.com"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	postingPath := filepath.Join(tmpDir, "acme-swe-posting.md")
+	if err := os.WriteFile(postingPath, []byte("# Software Engineer\n\nCompany: Acme Corp\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	pipeline, err := NewPipeline(filepath.Join(tmpDir, ".agent", "config.json"), nil)
+	if err != nil {
+		t.Fatalf("NewPipeline() error = %v", err)
+	}
+	pipeline.Config.Paths.OutputDir = filepath.Join(tmpDir, "output")
+	pipeline.Config.Output.GeneratePDF = true
+	pipeline.Config.Output.KeepTypst = true
+	pipeline.CVPath = cvPath
+	pipeline.Format = FormatMarkdown
+	var compiled []string
+	pipeline.CompileFunc = func(path string) (string, error) {
+		compiled = append(compiled, path)
+		pdfPath := strings.TrimSuffix(path, ".md") + ".pdf"
+		return pdfPath, os.WriteFile(pdfPath, []byte("%PDF-1.7\n"), 0644)
+	}
+	if err := pipeline.Run(postingPath); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	docs := pipeline.Documents()
+	if docs == nil {
+		t.Fatal("Documents() = nil after a completed run")
+	}
+	for _, path := range []string{docs.ResumePath, docs.CoverLetterPath} {
+		if filepath.Ext(path) != ".md" {
+			t.Errorf("document %s is not a .md file", path)
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Errorf("draft not written: %v", err)
+			continue
+		}
+		if !strings.HasPrefix(string(content), markdownHeaderPrefix) || strings.Contains(string(content), "#set") {
+			t.Errorf("%s is not a markdown draft with a metadata header:\n%s", path, content)
+		}
+		meta, err := ReadDocumentMetadata(path)
+		if err != nil || meta == nil || meta.Company != "Acme Corp" {
+			t.Errorf("ReadDocumentMetadata(%s) = %+v, %v; want the Acme Corp header", path, meta, err)
+		}
+	}
+	if !strings.Contains(mustRead(t, docs.ResumePath), "# Draft Writer\n") {
+		t.Errorf("resume lacks a markdown heading for the CV name:\n%s", mustRead(t, docs.ResumePath))
+	}
+	if len(compiled) != 2 {
+		t.Errorf("compiled %v, want both documents", compiled)
+	}
+}
+
+func mustRead(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
@@ -0,0 +1,167 @@
+package agent
+
+import (
+	"strings"
+)
+
+// minSuggestionSimilarity is how close (0-1) a CV skill must be to a missing
+// requirement before it is suggested
+const minSuggestionSimilarity = 0.6
+
+// GapReport is a human-oriented breakdown of how a CV covers a posting
+type GapReport struct {
+	Met     []GapItem
+	Missing []GapItem
+	Bonus   []GapItem
+	// MatchPercent is the weighted coverage from AnalyzeRequirementMatch
+	MatchPercent float64
+}
+
+// GapItem is a single posting requirement or skill
+type GapItem struct {
+	Requirement string
+	// Evidence is where the CV demonstrates it; set for met and bonus items
+	Evidence string
+	// Suggestion is the closest CV skill by spelling; set for missing items
+	// when one is close enough
+	Suggestion string
+}
+
+// AnalyzeGaps reports which of the posting's tech stack and requirements
+// the CV covers, with the CV evidence for each, and suggests the nearest CV
+// skill for those it doesn't
+func AnalyzeGaps(cv *CVData, posting *ParsedPosting) *GapReport {
+	analysis := NewReviewerAgent(nil, "").AnalyzeRequirementMatch(cv, posting)
+	report := &GapReport{MatchPercent: analysis.MatchPercent}
+	skills := cvSkillKeywords(cv)
+
+	for _, tech := range analysis.RequirementsMet {
+		report.Met = append(report.Met, GapItem{Requirement: tech, Evidence: cvEvidence(cv, tech)})
+	}
+	for _, tech := range analysis.RequirementsMissing {
+		report.Missing = append(report.Missing, GapItem{Requirement: tech, Suggestion: nearestSkill(tech, skills)})
+	}
+
+	// Requirements are free text, met when they mention a CV skill
+	for _, req := range posting.Requirements {
+		if skill := firstMentioned(strings.ToLower(req), skills); skill != "" {
+			report.Met = append(report.Met, GapItem{Requirement: req, Evidence: cvEvidence(cv, skill)})
+		} else {
+			report.Missing = append(report.Missing, GapItem{Requirement: req, Suggestion: nearestSkill(req, skills)})
+		}
+	}
+
+	for _, skill := range analysis.BonusPointsHit {
+		report.Bonus = append(report.Bonus, GapItem{Requirement: skill, Evidence: cvEvidence(cv, skill)})
+	}
+
+	return report
+}
+
+// cvSkillKeywords returns the keywords from the CV's skill groups
+func cvSkillKeywords(cv *CVData) []string {
+	var keywords []string
+	for _, group := range cv.Skills {
+		keywords = append(keywords, group.Keywords...)
+	}
+	return keywords
+}
+
+// firstMentioned returns the first skill mentioned as a whole word in text
+// (already lowercased), or "" if none is
+func firstMentioned(text string, skills []string) string {
+	for _, skill := range skills {
+		if mentionsAny(text, []string{strings.ToLower(skill)}) {
+			return skill
+		}
+	}
+	return ""
+}
+
+// cvEvidence describes where the CV shows skill: a skill group, then a work
+// highlight, then a project. Returns "" when it can't be located.
+func cvEvidence(cv *CVData, skill string) string {
+	lower := strings.ToLower(skill)
+	for _, group := range cv.Skills {
+		for _, keyword := range group.Keywords {
+			k := strings.ToLower(keyword)
+			if k == lower || strings.Contains(k, lower) || strings.Contains(lower, k) {
+				return "skills: " + group.Name
+			}
+		}
+	}
+	for _, work := range cv.Work {
+		for _, highlight := range work.Highlights {
+			if mentionsAny(strings.ToLower(highlight), []string{lower}) {
+				return work.Name + ": " + highlight
+			}
+		}
+	}
+	for _, project := range cv.Projects {
+		for _, keyword := range project.Keywords {
+			if strings.EqualFold(keyword, skill) {
+				return "project: " + project.Name
+			}
+		}
+	}
+	return ""
+}
+
+// nearestSkill returns the CV skill most similar to text, comparing against
+// both the whole text and each of its words, or "" when nothing is at least
+// minSuggestionSimilarity alike
+func nearestSkill(text string, skills []string) string {
+	candidates := []string{strings.ToLower(text)}
+	for _, word := range strings.Fields(strings.ToLower(text)) {
+		word = strings.Trim(word, ".,;:()")
+		if len(word) > 1 {
+			candidates = append(candidates, word)
+		}
+	}
+
+	best, bestScore := "", 0.0
+	for _, skill := range skills {
+		lower := strings.ToLower(skill)
+		for _, candidate := range candidates {
+			if score := similarity(candidate, lower); score > bestScore {
+				best, bestScore = skill, score
+			}
+		}
+	}
+	if bestScore < minSuggestionSimilarity {
+		return ""
+	}
+	return best
+}
+
+// similarity scores two strings from 0 (nothing alike) to 1 (identical)
+// by edit distance relative to the longer one
+func similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+// levenshtein returns the edit distance between a and b
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
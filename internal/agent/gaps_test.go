@@ -0,0 +1,83 @@
+package agent
+
+import (
+	"testing"
+)
+
+func gapTestCV() *CVData {
+	return &CVData{
+		Basics: CVBasics{Name: "Test Candidate"},
+		Skills: []CVSkill{
+			{Name: "Languages", Keywords: []string{"Go", "TypeScript"}},
+			{Name: "Data", Keywords: []string{"Postgres", "Redis"}},
+		},
+		Work: []CVWork{
+			{Name: "Initech", Highlights: []string{"Deployed services with Docker and Terraform"}},
+		},
+	}
+}
+
+func TestAnalyzeGaps_MissingSuggestsNearestSkill(t *testing.T) {
+	posting := &ParsedPosting{
+		TechStack:   []string{"Go", "PostgreSQL"},
+		BonusSkills: []string{"Docker"},
+	}
+
+	report := AnalyzeGaps(gapTestCV(), posting)
+
+	if len(report.Missing) != 1 {
+		t.Fatalf("Missing = %+v, want one item", report.Missing)
+	}
+	if got := report.Missing[0]; got.Requirement != "PostgreSQL" || got.Suggestion != "Postgres" {
+		t.Errorf("Missing[0] = %+v, want PostgreSQL with suggestion Postgres", got)
+	}
+
+	if len(report.Met) != 1 || report.Met[0].Requirement != "Go" || report.Met[0].Evidence != "skills: Languages" {
+		t.Errorf("Met = %+v, want Go evidenced by skills: Languages", report.Met)
+	}
+	if len(report.Bonus) != 1 || report.Bonus[0].Evidence != "Initech: Deployed services with Docker and Terraform" {
+		t.Errorf("Bonus = %+v, want Docker evidenced by the Initech highlight", report.Bonus)
+	}
+}
+
+func TestAnalyzeGaps_FreeTextRequirements(t *testing.T) {
+	posting := &ParsedPosting{
+		Requirements: []string{
+			"3+ years building services in Go",
+			"Strong Javascript skills",
+			"Experience leading teams",
+		},
+	}
+
+	report := AnalyzeGaps(gapTestCV(), posting)
+
+	if len(report.Met) != 1 || report.Met[0].Evidence != "skills: Languages" {
+		t.Errorf("Met = %+v, want the Go requirement evidenced by skills: Languages", report.Met)
+	}
+	if len(report.Missing) != 2 {
+		t.Fatalf("Missing = %+v, want two items", report.Missing)
+	}
+	if got := report.Missing[0].Suggestion; got != "TypeScript" {
+		t.Errorf("Missing[0].Suggestion = %q, want TypeScript", got)
+	}
+	if got := report.Missing[1].Suggestion; got != "" {
+		t.Errorf("Missing[1].Suggestion = %q, want none for an unrelated requirement", got)
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"go", "go", 1},
+		{"", "", 1},
+		{"abc", "xyz", 0},
+		{"postgres", "postgresql", 0.8},
+	}
+	for _, tt := range tests {
+		if got := similarity(tt.a, tt.b); got != tt.want {
+			t.Errorf("similarity(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Generation with an LLMClient: when Pipeline.LLM is set, the resume, cover
+// letter, and reviewer steps send their agents' prompts to the model instead
+// of drafting from the CV or returning a placeholder review.
+
+// agentModel is the model configured for an agent, or "" for the client's
+// default
+func (p *Pipeline) agentModel(agentType AgentType) string {
+	if config := p.Config.GetAgentConfig(agentType); config != nil {
+		return config.Model
+	}
+	return ""
+}
+
+// generationCV loads the CV the documents are generated from. Generating
+// without one would leave the model nothing true to write about.
+func (p *Pipeline) generationCV() (*CVData, error) {
+	if p.CVPath == "" {
+		return nil, errors.New("generating documents needs a CV (local/cv.json)")
+	}
+	return NewResumeGeneratorAgent(nil, "").LoadCV(p.CVPath)
+}
+
+// generateResume asks the model for a resume tailored to parsed and
+// validates it, returning the document content
+func (p *Pipeline) generateResume(parsed *ParsedPosting, format string) (string, error) {
+	cv, err := p.generationCV()
+	if err != nil {
+		return "", err
+	}
+	rawPosting, err := p.PostingText()
+	if err != nil {
+		return "", err
+	}
+
+	resume := NewResumeGeneratorAgent(p.Config.GetAgentConfig(AgentResume), p.BaseDir)
+	resume.Format = format
+	user, err := resume.GetUserPrompt(parsed, cv, "", p.Feedback, rawPosting)
+	if err != nil {
+		return "", fmt.Errorf("resume prompt: %w", err)
+	}
+	output, err := p.LLM.Complete(resume.GetSystemPrompt(), user, p.agentModel(AgentResume))
+	if err != nil {
+		return "", fmt.Errorf("generating resume: %w", err)
+	}
+	return resume.ParseOutput(output)
+}
+
+// generateCoverLetter asks the model for a cover letter in the given tone,
+// consistent with the resume at resumePath when it is still on disk
+func (p *Pipeline) generateCoverLetter(parsed *ParsedPosting, format, tone, resumePath string) (string, error) {
+	cv, err := p.generationCV()
+	if err != nil {
+		return "", err
+	}
+	rawPosting, err := p.PostingText()
+	if err != nil {
+		return "", err
+	}
+
+	cover := NewCoverLetterGeneratorAgent(p.Config.GetAgentConfig(AgentCover), p.BaseDir)
+	cover.Format = format
+	cover.Tone = tone
+	cover.Style = p.CoverStyle
+	resumeContent, _ := readSource(resumePath)
+	user, err := cover.GetUserPrompt(parsed, cv, resumeContent, p.Feedback, rawPosting)
+	if err != nil {
+		return "", fmt.Errorf("cover letter prompt: %w", err)
+	}
+	output, err := p.LLM.Complete(cover.GetSystemPrompt(), user, p.agentModel(AgentCover))
+	if err != nil {
+		return "", fmt.Errorf("generating cover letter: %w", err)
+	}
+	return cover.ParseOutput(output)
+}
+
+// reviewWithLLM has the model review the generated documents against the
+// posting and the reviewer CV
+func (p *Pipeline) reviewWithLLM(docs *GeneratedDocuments) (*DetailedReviewResult, error) {
+	parsed := p.ParsedPosting()
+	if parsed == nil {
+		return nil, fmt.Errorf("no parsed posting to review against")
+	}
+	var cv *CVData
+	if path := p.reviewerCV(); path != "" {
+		var err error
+		if cv, err = NewResumeGeneratorAgent(nil, "").LoadCV(path); err != nil {
+			return nil, fmt.Errorf("reviewer CV: %w", err)
+		}
+	}
+
+	reviewer := p.newReviewer()
+	user, err := reviewer.GetUserPrompt(parsed, reviewSource(docs.ResumePath), reviewSource(docs.CoverLetterPath), cv)
+	if err != nil {
+		return nil, fmt.Errorf("reviewer prompt: %w", err)
+	}
+	output, err := p.LLM.Complete(reviewer.GetSystemPrompt(), user, p.agentModel(AgentReviewer))
+	if err != nil {
+		return nil, fmt.Errorf("reviewing documents: %w", err)
+	}
+	return reviewer.ParseReviewOutput(output)
+}
+
+// readSource reads a generated document's source without its metadata
+// header
+func readSource(path string) (string, error) {
+	if path == "" {
+		return "", os.ErrNotExist
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return stripMarkdownHeader(stripTypstHeader(string(data))), nil
+}
+
+// reviewSource is a document's source for the reviewer prompt, noting when
+// only the PDF was kept (output.keep_typst off)
+func reviewSource(path string) string {
+	content, err := readSource(path)
+	if err != nil {
+		return "(source not available; only the compiled PDF was kept)"
+	}
+	return strings.TrimSpace(content)
+}
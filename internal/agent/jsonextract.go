@@ -0,0 +1,76 @@
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// trimJSONNoise returns the JSON object inside AI output that wraps it in
+// prose. Output that is already valid JSON, or has no object to extract, is
+// returned unchanged so the caller reports its own parse error.
+func trimJSONNoise(output string) string {
+	if json.Valid([]byte(output)) {
+		return output
+	}
+	if obj, err := extractJSONObject(output); err == nil {
+		return obj
+	}
+	return output
+}
+
+// extractJSONObject returns the first balanced, valid {...} object in s,
+// skipping any preamble, trailing prose, or code fences around it. Braces
+// inside string literals don't count towards the balance.
+func extractJSONObject(s string) (string, error) {
+	for start := strings.IndexByte(s, '{'); start >= 0; {
+		if end := matchingBrace(s, start); end >= 0 {
+			candidate := s[start : end+1]
+			if json.Valid([]byte(candidate)) {
+				return candidate, nil
+			}
+		}
+
+		// Not an object (e.g. a brace in the preamble); try the next one
+		next := strings.IndexByte(s[start+1:], '{')
+		if next < 0 {
+			break
+		}
+		start += next + 1
+	}
+	return "", fmt.Errorf("no JSON object found")
+}
+
+// matchingBrace returns the index of the brace closing the one at start, or
+// -1 if it's never closed
+func matchingBrace(s string, start int) int {
+	depth := 0
+	inString, escaped := false, false
+	for i := start; i < len(s); i++ {
+		c := s[i]
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+
+		switch c {
+		case '"':
+			inString = true
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
@@ -0,0 +1,72 @@
+package agent
+
+import "testing"
+
+func TestExtractJSONObject(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "wrapped in prose",
+			input: "Here's the JSON:\n{\"approved\": true}\nLet me know if you need changes.",
+			want:  `{"approved": true}`,
+		},
+		{
+			name:  "nested braces",
+			input: "Sure! {\"a\": {\"b\": {\"c\": 1}}, \"d\": [{\"e\": 2}]} Hope that helps.",
+			want:  `{"a": {"b": {"c": 1}}, "d": [{"e": 2}]}`,
+		},
+		{
+			name:  "braces inside strings",
+			input: `Result: {"note": "use {braces} and \"}\" freely", "ok": true} done`,
+			want:  `{"note": "use {braces} and \"}\" freely", "ok": true}`,
+		},
+		{
+			name:  "brace in preamble",
+			input: "Output {as requested}: {\"ok\": true}",
+			want:  `{"ok": true}`,
+		},
+		{
+			name:  "code fence after preamble",
+			input: "Here you go:\n```json\n{\"ok\": true}\n```",
+			want:  `{"ok": true}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := extractJSONObject(tt.input)
+			if err != nil {
+				t.Fatalf("extractJSONObject() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("extractJSONObject() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractJSONObject_NoObject(t *testing.T) {
+	for _, input := range []string{"", "no json here", `{"unclosed": true`, "[1, 2, 3]"} {
+		if got, err := extractJSONObject(input); err == nil {
+			t.Errorf("extractJSONObject(%q) = %q, want error", input, got)
+		}
+	}
+}
+
+func TestParseReviewOutput_ToleratesProse(t *testing.T) {
+	reviewer := NewReviewerAgent(nil, "")
+	output := "Here's my review:\n\n" +
+		`{"approved": true, "overall_score": 82, "resume_review": {"score": 80}, "cover_review": {"score": 85}}` +
+		"\n\nOverall the documents look strong."
+
+	result, err := reviewer.ParseReviewOutput(output)
+	if err != nil {
+		t.Fatalf("ParseReviewOutput() error = %v", err)
+	}
+	if !result.Approved || result.OverallScore != 82 {
+		t.Errorf("ParseReviewOutput() = %+v, want approved with score 82", result)
+	}
+}
@@ -0,0 +1,79 @@
+package agent
+
+import (
+	"strings"
+	"unicode"
+)
+
+// LanguageEnglish is the language assumed when detection is inconclusive
+const LanguageEnglish = "en"
+
+// languageStopwords are frequent short words that are distinctive for each
+// language. Words shared between languages (e.g. "a", "de", "en") are left
+// out so they don't blur the counts.
+var languageStopwords = map[string][]string{
+	"en": {"the", "and", "with", "you", "our", "will", "for", "are", "this", "your", "have", "of", "to", "is", "we", "experience"},
+	"es": {"el", "los", "las", "del", "con", "para", "por", "una", "que", "nuestro", "experiencia", "como", "es", "y", "somos", "buscamos"},
+	"de": {"der", "die", "das", "und", "mit", "für", "wir", "sie", "ist", "ein", "eine", "bei", "auf", "zu", "erfahrung", "suchen"},
+	"fr": {"le", "les", "des", "et", "avec", "pour", "nous", "vous", "une", "est", "dans", "sur", "du", "au", "expérience", "recherchons"},
+	"pt": {"os", "as", "com", "para", "uma", "não", "você", "nosso", "da", "do", "dos", "experiência", "em", "é", "são", "buscamos"},
+}
+
+// languageNames are the display names of the detected languages
+var languageNames = map[string]string{
+	"en": "English",
+	"es": "Spanish",
+	"de": "German",
+	"fr": "French",
+	"pt": "Portuguese",
+}
+
+// minLanguageHits is how many stopwords a text needs before the detection
+// is trusted; shorter texts are assumed to be English
+const minLanguageHits = 5
+
+// DetectLanguage guesses the language of a posting from how often each
+// language's common words appear, returning an ISO 639-1 code. Short or
+// ambiguous text defaults to English.
+func DetectLanguage(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+
+	counts := make(map[string]int, len(languageStopwords))
+	for lang, stopwords := range languageStopwords {
+		set := make(map[string]bool, len(stopwords))
+		for _, w := range stopwords {
+			set[w] = true
+		}
+		for _, w := range words {
+			if set[w] {
+				counts[lang]++
+			}
+		}
+	}
+
+	best, bestCount, runnerUp := LanguageEnglish, counts[LanguageEnglish], 0
+	for lang, count := range counts {
+		if count > bestCount {
+			best, bestCount, runnerUp = lang, count, bestCount
+		} else if lang != best && count > runnerUp {
+			runnerUp = count
+		}
+	}
+
+	// Require a clear winner; ties and near-ties stay English
+	if bestCount < minLanguageHits || best == LanguageEnglish || bestCount < runnerUp*3/2 {
+		return LanguageEnglish
+	}
+	return best
+}
+
+// LanguageName returns the display name for a language code, or the code
+// itself when it's not one DetectLanguage returns
+func LanguageName(code string) string {
+	if name, ok := languageNames[code]; ok {
+		return name
+	}
+	return code
+}
@@ -0,0 +1,59 @@
+package agent
+
+import "testing"
+
+func TestDetectLanguage(t *testing.T) {
+	cases := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"english", `Senior Backend Engineer
+
+We are looking for an engineer with experience in Go and Kubernetes.
+You will work with our platform team and have ownership of the services
+that power this product. Your day-to-day will include design reviews.`, "en"},
+		{"spanish", `Ingeniero Backend Senior
+
+Buscamos un ingeniero con experiencia en Go y Kubernetes para unirse a
+nuestro equipo de plataforma. Trabajarás con los equipos de producto y serás
+responsable de las APIs del servicio. Ofrecemos trabajo remoto y un salario
+competitivo para el puesto.`, "es"},
+		{"german", `Senior Backend Engineer (m/w/d)
+
+Wir suchen eine erfahrene Entwicklerin oder einen Entwickler für unser
+Plattform-Team. Du arbeitest mit Go und Kubernetes und bist für die
+Weiterentwicklung der Dienste verantwortlich. Erfahrung mit verteilten
+Systemen ist ein Plus, die Stelle ist auf Wunsch remote.`, "de"},
+		{"short defaults to english", "Ingeniero Backend - Madrid", "en"},
+		{"empty defaults to english", "", "en"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := DetectLanguage(tc.text); got != tc.want {
+				t.Errorf("DetectLanguage() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestExtractBasicInfo_Language(t *testing.T) {
+	content := `Ingeniero Backend en Acme
+
+Buscamos una persona con experiencia en Go para el equipo de pagos. Serás
+responsable de los servicios que procesan las transacciones y trabajarás con
+nuestro equipo de producto para que la plataforma sea fiable.`
+	parsed := extractBasicInfo(content, "acme-ingeniero.md")
+	if parsed.Language != "es" {
+		t.Errorf("Language = %q, want es", parsed.Language)
+	}
+}
+
+func TestLanguageName(t *testing.T) {
+	if got := LanguageName("de"); got != "German" {
+		t.Errorf("LanguageName(de) = %q, want German", got)
+	}
+	if got := LanguageName("xx"); got != "xx" {
+		t.Errorf("LanguageName(xx) = %q, want xx", got)
+	}
+}
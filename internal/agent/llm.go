@@ -0,0 +1,196 @@
+package agent
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// LLMClient completes a prompt with a language model. The pipeline uses it
+// to generate and review documents; without one the steps fall back to
+// drafts and placeholder reviews.
+type LLMClient interface {
+	// Complete returns the model's reply to the user prompt under the
+	// system prompt. An empty model uses the client's default.
+	Complete(system, user, model string) (string, error)
+}
+
+// AnthropicAPIKeyEnv is the environment variable holding the Anthropic API key
+const AnthropicAPIKeyEnv = "ANTHROPIC_API_KEY"
+
+const (
+	anthropicBaseURL = "https://api.anthropic.com"
+	anthropicVersion = "2023-06-01"
+	// DefaultAnthropicModel is used when an agent has no model configured
+	DefaultAnthropicModel = "claude-sonnet-4-5"
+	// anthropicMaxTokens bounds a reply; a full resume fits comfortably
+	anthropicMaxTokens = 8192
+)
+
+// anthropicModelAliases expands the short model names used in agent configs
+var anthropicModelAliases = map[string]string{
+	"sonnet": "claude-sonnet-4-5",
+	"opus":   "claude-opus-4-1",
+	"haiku":  "claude-haiku-4-5",
+}
+
+// AnthropicClient calls the Anthropic Messages API
+type AnthropicClient struct {
+	APIKey string
+	// BaseURL defaults to the public API; tests point it at a local server
+	BaseURL string
+	Client  *http.Client
+}
+
+// NewAnthropicClient creates a client for the given API key
+func NewAnthropicClient(apiKey string) *AnthropicClient {
+	return &AnthropicClient{
+		APIKey:  apiKey,
+		BaseURL: anthropicBaseURL,
+		Client: &http.Client{
+			Timeout: 5 * time.Minute,
+		},
+	}
+}
+
+// LLM providers selectable with PipelineConfig.Provider
+const (
+	ProviderAnthropic = "anthropic"
+	ProviderOpenAI    = "openai"
+)
+
+// Providers lists the supported LLM providers
+var Providers = []string{ProviderAnthropic, ProviderOpenAI}
+
+// APIKeyEnv is the environment variable holding the API key for provider
+func APIKeyEnv(provider string) string {
+	if provider == ProviderOpenAI {
+		return OpenAIAPIKeyEnv
+	}
+	return AnthropicAPIKeyEnv
+}
+
+// NewLLMClient returns a client for the configured provider, or nil so the
+// pipeline keeps its placeholder behavior. A client needs the provider's API
+// key, except an OpenAI-compatible server at a custom provider_url, which
+// local servers such as Ollama serve without one.
+func NewLLMClient(config *PipelineConfig) LLMClient {
+	key := strings.TrimSpace(os.Getenv(APIKeyEnv(config.Provider)))
+
+	switch config.Provider {
+	case ProviderOpenAI:
+		if key == "" && config.ProviderURL == "" {
+			return nil
+		}
+		client := NewOpenAIClient(key)
+		if config.ProviderURL != "" {
+			client.BaseURL = config.ProviderURL
+		}
+		return client
+	default:
+		if key == "" {
+			return nil
+		}
+		client := NewAnthropicClient(key)
+		if config.ProviderURL != "" {
+			client.BaseURL = config.ProviderURL
+		}
+		return client
+	}
+}
+
+// anthropicRequest is the Messages API request body
+type anthropicRequest struct {
+	Model     string             `json:"model"`
+	MaxTokens int                `json:"max_tokens"`
+	System    string             `json:"system,omitempty"`
+	Messages  []anthropicMessage `json:"messages"`
+}
+
+type anthropicMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// anthropicResponse is the part of a Messages API response (or error) the
+// client reads
+type anthropicResponse struct {
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+	StopReason string `json:"stop_reason"`
+	Error      *struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Complete sends one user message and returns the text of the reply
+func (c *AnthropicClient) Complete(system, user, model string) (string, error) {
+	if model == "" {
+		model = DefaultAnthropicModel
+	}
+	if full, ok := anthropicModelAliases[strings.ToLower(model)]; ok {
+		model = full
+	}
+
+	body, err := json.Marshal(anthropicRequest{
+		Model:     model,
+		MaxTokens: anthropicMaxTokens,
+		System:    system,
+		Messages:  []anthropicMessage{{Role: "user", Content: user}},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, strings.TrimRight(c.BaseURL, "/")+"/v1/messages", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-api-key", c.APIKey)
+	req.Header.Set("anthropic-version", anthropicVersion)
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("anthropic request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read anthropic response: %w", err)
+	}
+	var parsed anthropicResponse
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return "", fmt.Errorf("anthropic returned HTTP %d with an unreadable body: %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK || parsed.Error != nil {
+		if parsed.Error != nil {
+			return "", fmt.Errorf("anthropic returned HTTP %d: %s: %s", resp.StatusCode, parsed.Error.Type, parsed.Error.Message)
+		}
+		return "", fmt.Errorf("anthropic returned HTTP %d", resp.StatusCode)
+	}
+
+	var text strings.Builder
+	for _, block := range parsed.Content {
+		if block.Type == "text" {
+			text.WriteString(block.Text)
+		}
+	}
+	if text.Len() == 0 {
+		return "", errors.New("anthropic returned no text")
+	}
+	if parsed.StopReason == "max_tokens" {
+		return "", fmt.Errorf("anthropic reply was cut off at %d tokens", anthropicMaxTokens)
+	}
+	return text.String(), nil
+}
@@ -0,0 +1,125 @@
+package agent
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// OpenAIAPIKeyEnv is the environment variable holding the OpenAI API key.
+// Local OpenAI-compatible servers usually don't need one.
+const OpenAIAPIKeyEnv = "OPENAI_API_KEY"
+
+const (
+	openAIBaseURL = "https://api.openai.com/v1"
+	// DefaultOpenAIModel is used when an agent has no model configured
+	DefaultOpenAIModel = "gpt-4o"
+)
+
+// OpenAIClient calls an OpenAI-compatible chat completions endpoint: the
+// OpenAI API, or local servers such as Ollama and LM Studio
+type OpenAIClient struct {
+	// APIKey is sent as a bearer token; empty sends none
+	APIKey string
+	// BaseURL is the API root including the version, e.g.
+	// http://localhost:11434/v1 for Ollama
+	BaseURL string
+	Client  *http.Client
+}
+
+// NewOpenAIClient creates a client for the OpenAI API with the given key
+func NewOpenAIClient(apiKey string) *OpenAIClient {
+	return &OpenAIClient{
+		APIKey:  apiKey,
+		BaseURL: openAIBaseURL,
+		Client: &http.Client{
+			Timeout: 5 * time.Minute,
+		},
+	}
+}
+
+// openAIRequest is the chat completions request body
+type openAIRequest struct {
+	Model    string          `json:"model"`
+	Messages []openAIMessage `json:"messages"`
+	Stream   bool            `json:"stream"`
+}
+
+type openAIMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// openAIResponse is the part of a chat completions response (or error) the
+// client reads
+type openAIResponse struct {
+	Choices []struct {
+		Message      openAIMessage `json:"message"`
+		FinishReason string        `json:"finish_reason"`
+	} `json:"choices"`
+	Error *struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Complete sends the system and user prompts as chat messages and returns
+// the assistant's reply
+func (c *OpenAIClient) Complete(system, user, model string) (string, error) {
+	if model == "" {
+		model = DefaultOpenAIModel
+	}
+
+	messages := make([]openAIMessage, 0, 2)
+	if system != "" {
+		messages = append(messages, openAIMessage{Role: "system", Content: system})
+	}
+	messages = append(messages, openAIMessage{Role: "user", Content: user})
+	body, err := json.Marshal(openAIRequest{Model: model, Messages: messages})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, strings.TrimRight(c.BaseURL, "/")+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.APIKey)
+	}
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("openai request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read openai response: %w", err)
+	}
+	var parsed openAIResponse
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return "", fmt.Errorf("openai returned HTTP %d with an unreadable body: %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK || parsed.Error != nil {
+		if parsed.Error != nil {
+			return "", fmt.Errorf("openai returned HTTP %d: %s", resp.StatusCode, parsed.Error.Message)
+		}
+		return "", fmt.Errorf("openai returned HTTP %d", resp.StatusCode)
+	}
+
+	if len(parsed.Choices) == 0 || parsed.Choices[0].Message.Content == "" {
+		return "", errors.New("openai returned no text")
+	}
+	if parsed.Choices[0].FinishReason == "length" {
+		return "", errors.New("openai reply was cut off at the token limit")
+	}
+	return parsed.Choices[0].Message.Content, nil
+}
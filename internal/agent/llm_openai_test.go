@@ -0,0 +1,79 @@
+package agent
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestOpenAIClient_Complete(t *testing.T) {
+	var got openAIRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" || r.Header.Get("Authorization") != "Bearer test-key" {
+			t.Errorf("request to %s with headers %v", r.URL.Path, r.Header)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		w.Write([]byte(`{"id": "chatcmpl-1", "object": "chat.completion",
+			"choices": [{"index": 0, "message": {"role": "assistant", "content": "Hello, Jane"}, "finish_reason": "stop"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewOpenAIClient("test-key")
+	client.BaseURL = server.URL + "/v1/"
+
+	reply, err := client.Complete("Be brief.", "Say hello", "gpt-4o-mini")
+	if err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	if reply != "Hello, Jane" {
+		t.Errorf("Complete() = %q, want the assistant message", reply)
+	}
+	want := []openAIMessage{{Role: "system", Content: "Be brief."}, {Role: "user", Content: "Say hello"}}
+	if got.Model != "gpt-4o-mini" || got.Stream || len(got.Messages) != 2 || got.Messages[0] != want[0] || got.Messages[1] != want[1] {
+		t.Errorf("request = %+v, want a single non-streaming completion of %v", got, want)
+	}
+
+	if _, err := client.Complete("", "hi", ""); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	if got.Model != DefaultOpenAIModel || len(got.Messages) != 1 || got.Messages[0].Role != "user" {
+		t.Errorf("request = %+v, want the default model and only the user message", got)
+	}
+}
+
+func TestOpenAIClient_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr string
+	}{
+		{"error status", http.StatusUnauthorized, `{"error": {"type": "invalid_request_error", "message": "Incorrect API key provided"}}`, "HTTP 401: Incorrect API key provided"},
+		{"error without body", http.StatusBadGateway, `{}`, "HTTP 502"},
+		{"no choices", http.StatusOK, `{"choices": []}`, "no text"},
+		{"cut off", http.StatusOK, `{"choices": [{"message": {"role": "assistant", "content": "Dear"}, "finish_reason": "length"}]}`, "token limit"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Authorization") != "" {
+					t.Errorf("Authorization sent without a key: %q", r.Header.Get("Authorization"))
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := NewOpenAIClient("")
+			client.BaseURL = server.URL
+			_, err := client.Complete("", "hi", "llama3")
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Complete() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
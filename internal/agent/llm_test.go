@@ -0,0 +1,200 @@
+package agent
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// mockLLM answers each agent's prompt with a canned reply, recording the
+// models it was asked for and the user prompts it was sent
+type mockLLM struct {
+	replies map[AgentType]string
+	err     error
+	models  []string
+	prompts []string
+}
+
+func (m *mockLLM) Complete(system, user, model string) (string, error) {
+	m.models = append(m.models, model)
+	m.prompts = append(m.prompts, user)
+	if m.err != nil {
+		return "", m.err
+	}
+	switch {
+	case strings.Contains(system, "resume tailoring"):
+		return m.replies[AgentResume], nil
+	case strings.Contains(system, "cover letter writing"):
+		return m.replies[AgentCover], nil
+	}
+	return m.replies[AgentReviewer], nil
+}
+
+const (
+	mockResume = "```typst\n#import \"@preview/modern-cv:0.9.0\": *\n#show: resume.with(author: (firstname: \"Jane\"))\n= Experience\n```"
+	mockCover  = "#import \"@preview/modern-cv:0.9.0\": *\n#show: coverletter.with(author: (firstname: \"Jane\"))\nDear Acme,"
+	mockReview = `{"approved": true, "overall_score": 88, "resume_review": {"score": 90}, "cover_letter_review": {"score": 85}}`
+)
+
+// newLLMPipeline sets up a pipeline with a CV and posting that generates
+// with llm
+func newLLMPipeline(t *testing.T, llm LLMClient) (*Pipeline, string) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	cvPath := filepath.Join(tmpDir, "cv.json")
+	if err := os.WriteFile(cvPath, []byte(`{"basics": {"name": "Jane Doe", "email": "jane@example.com"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	postingPath := filepath.Join(tmpDir, "acme-swe-posting.md")
+	if err := os.WriteFile(postingPath, []byte("# Software Engineer\n\nCompany: Acme Corp\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	pipeline, err := NewPipeline(filepath.Join(tmpDir, ".agent", "config.json"), nil)
+	if err != nil {
+		t.Fatalf("NewPipeline() error = %v", err)
+	}
+	pipeline.Config.Paths.OutputDir = filepath.Join(tmpDir, "output")
+	pipeline.Config.Output.GeneratePDF = false
+	pipeline.CVPath = cvPath
+	pipeline.LLM = llm
+	return pipeline, postingPath
+}
+
+func TestPipeline_LLMGeneratesDocuments(t *testing.T) {
+	llm := &mockLLM{replies: map[AgentType]string{
+		AgentResume:   mockResume,
+		AgentCover:    mockCover,
+		AgentReviewer: mockReview,
+	}}
+	pipeline, postingPath := newLLMPipeline(t, llm)
+	pipeline.Config.GetAgentConfig(AgentResume).Model = "opus"
+
+	if err := pipeline.Run(postingPath); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	docs := pipeline.Documents()
+	if docs == nil {
+		t.Fatal("Documents() = nil after a completed run")
+	}
+	resume, err := os.ReadFile(docs.ResumePath)
+	if err != nil {
+		t.Fatalf("resume not written: %v", err)
+	}
+	if !strings.HasPrefix(string(resume), typstHeaderPrefix) || !strings.Contains(string(resume), "#show: resume.with") || strings.Contains(string(resume), "```") {
+		t.Errorf("resume is not the model's cleaned Typst with a header:\n%s", resume)
+	}
+	if err := NewResumeGeneratorAgent(nil, "").ValidateTemplate(stripTypstHeader(string(resume))); err != nil {
+		t.Errorf("written resume fails validation: %v", err)
+	}
+	cover, err := os.ReadFile(docs.CoverLetterPath)
+	if err != nil || !strings.Contains(string(cover), "#show: coverletter.with") {
+		t.Errorf("cover letter = %q, %v; want the model's Typst", cover, err)
+	}
+
+	review := pipeline.DetailedReview()
+	if review == nil || review.OverallScore != 88 {
+		t.Errorf("DetailedReview() = %+v, want the model's review", review)
+	}
+	if len(llm.models) != 3 || llm.models[0] != "opus" {
+		t.Errorf("models requested = %v, want the resume agent's opus first, then one call each for cover and review", llm.models)
+	}
+}
+
+func TestPipeline_LLMErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		llm     *mockLLM
+		wantErr string
+	}{
+		{"model error", &mockLLM{err: errors.New("overloaded")}, "generating resume: overloaded"},
+		{"invalid typst", &mockLLM{replies: map[AgentType]string{AgentResume: "Here is your resume!"}}, "invalid Typst output"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipeline, postingPath := newLLMPipeline(t, tt.llm)
+			pipeline.DiscardFailedState = true
+
+			err := pipeline.Run(postingPath)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Run() error = %v, want %q", err, tt.wantErr)
+			}
+			entries, _ := os.ReadDir(pipeline.Config.Paths.OutputDir)
+			if len(entries) != 0 {
+				t.Errorf("output written despite the failure: %v", entries)
+			}
+		})
+	}
+}
+
+func TestAnthropicClient_Complete(t *testing.T) {
+	var got anthropicRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/messages" || r.Header.Get("x-api-key") != "test-key" || r.Header.Get("anthropic-version") == "" {
+			t.Errorf("request to %s with headers %v", r.URL.Path, r.Header)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		if got.Model == "broken" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"type": "error", "error": {"type": "invalid_request_error", "message": "model: broken"}}`))
+			return
+		}
+		w.Write([]byte(`{"content": [{"type": "text", "text": "Hello, "}, {"type": "text", "text": "Jane"}], "stop_reason": "end_turn"}`))
+	}))
+	defer server.Close()
+
+	client := NewAnthropicClient("test-key")
+	client.BaseURL = server.URL
+
+	reply, err := client.Complete("Be brief.", "Say hello", "sonnet")
+	if err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	if reply != "Hello, Jane" {
+		t.Errorf("Complete() = %q, want the joined text blocks", reply)
+	}
+	if got.Model != anthropicModelAliases["sonnet"] || got.System != "Be brief." || len(got.Messages) != 1 || got.Messages[0].Content != "Say hello" {
+		t.Errorf("request = %+v", got)
+	}
+
+	if _, err := client.Complete("", "hi", "broken"); err == nil || !strings.Contains(err.Error(), "model: broken") {
+		t.Errorf("Complete() error = %v, want the API's message", err)
+	}
+}
+
+func TestNewLLMClient(t *testing.T) {
+	t.Setenv(AnthropicAPIKeyEnv, "")
+	t.Setenv(OpenAIAPIKeyEnv, "")
+	config := DefaultConfig()
+	if client := NewLLMClient(config); client != nil {
+		t.Errorf("NewLLMClient() = %v without a key, want nil", client)
+	}
+	t.Setenv(AnthropicAPIKeyEnv, "sk-test")
+	if client, ok := NewLLMClient(config).(*AnthropicClient); !ok || client.APIKey != "sk-test" {
+		t.Errorf("NewLLMClient() = %v, want an Anthropic client with the key", client)
+	}
+
+	config.Provider = ProviderOpenAI
+	if client := NewLLMClient(config); client != nil {
+		t.Errorf("NewLLMClient(openai) = %v without a key, want nil", client)
+	}
+	t.Setenv(OpenAIAPIKeyEnv, "sk-openai")
+	if client, ok := NewLLMClient(config).(*OpenAIClient); !ok || client.APIKey != "sk-openai" || client.BaseURL != openAIBaseURL {
+		t.Errorf("NewLLMClient(openai) = %v, want an OpenAI client with the key", client)
+	}
+
+	// A local server needs no key
+	t.Setenv(OpenAIAPIKeyEnv, "")
+	config.ProviderURL = "http://localhost:11434/v1"
+	if client, ok := NewLLMClient(config).(*OpenAIClient); !ok || client.BaseURL != config.ProviderURL {
+		t.Errorf("NewLLMClient(openai, local) = %v, want a client for %s", client, config.ProviderURL)
+	}
+}
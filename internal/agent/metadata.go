@@ -0,0 +1,216 @@
+package agent
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// typstHeaderPrefix starts the comment header written into generated .typ files
+const typstHeaderPrefix = "// Generated by ghosted"
+
+// markdownHeaderPrefix starts the HTML comment header written into
+// generated .md files
+const markdownHeaderPrefix = "<!-- Generated by ghosted"
+
+// DocumentMetadata records what a generated document was built from. It is
+// written as a Typst (or, in markdown, HTML) comment header, which doesn't
+// appear in the PDF.
+type DocumentMetadata struct {
+	GeneratedAt time.Time
+	Company     string
+	Position    string
+	PostingPath string
+	// CVHash identifies the CV version used; see HashCV
+	CVHash string
+}
+
+// NewDocumentMetadata describes a document generated now for posting from
+// the CV at cvPath. An unreadable CV leaves CVHash empty.
+func NewDocumentMetadata(posting *ParsedPosting, postingPath, cvPath string) *DocumentMetadata {
+	meta := &DocumentMetadata{
+		GeneratedAt: time.Now(),
+		PostingPath: postingPath,
+	}
+	if posting != nil {
+		meta.Company = posting.Company
+		meta.Position = posting.Position
+	}
+	if cvPath != "" {
+		meta.CVHash, _ = HashCV(cvPath)
+	}
+	return meta
+}
+
+// HashCV returns a short content hash of a CV file, so documents can be
+// matched to the CV version that produced them
+func HashCV(cvPath string) (string, error) {
+	data, err := os.ReadFile(cvPath)
+	if err != nil {
+		return "", err
+	}
+	return hashContent(data), nil
+}
+
+// hashContent returns a short content hash, as used for CVs and postings
+func hashContent(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// TypstHeader renders the metadata as Typst line comments
+func (m *DocumentMetadata) TypstHeader() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s on %s for %s - %s\n", typstHeaderPrefix, m.GeneratedAt.Format("2006-01-02"), m.Company, m.Position)
+	if m.PostingPath != "" {
+		fmt.Fprintf(&b, "// posting: %s\n", m.PostingPath)
+	}
+	if m.CVHash != "" {
+		fmt.Fprintf(&b, "// cv: %s\n", m.CVHash)
+	}
+	return b.String()
+}
+
+// MarkdownHeader renders the metadata as HTML comments, one per TypstHeader
+// line
+func (m *DocumentMetadata) MarkdownHeader() string {
+	var b strings.Builder
+	for _, line := range strings.Split(strings.TrimSuffix(m.TypstHeader(), "\n"), "\n") {
+		fmt.Fprintf(&b, "<!-- %s -->\n", strings.TrimPrefix(line, "// "))
+	}
+	return b.String()
+}
+
+// ReadDocumentMetadata reads the metadata header of a generated .typ or .md file.
+// It returns nil without an error when the file has no header, e.g. a
+// document written by hand or before headers were added.
+func ReadDocumentMetadata(path string) (*DocumentMetadata, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	content := string(data)
+	if strings.HasPrefix(content, markdownHeaderPrefix+" on ") {
+		content = typstFromMarkdownHeader(content)
+	}
+	if !strings.HasPrefix(content, typstHeaderPrefix+" on ") {
+		return nil, nil
+	}
+
+	lines := strings.Split(content, "\n")
+	date, subject, _ := strings.Cut(strings.TrimPrefix(lines[0], typstHeaderPrefix+" on "), " for ")
+	generatedAt, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return nil, fmt.Errorf("malformed header in %s: %w", path, err)
+	}
+	meta := &DocumentMetadata{GeneratedAt: generatedAt}
+	meta.Company, meta.Position, _ = strings.Cut(subject, " - ")
+
+	for _, line := range lines[1:] {
+		if posting, ok := strings.CutPrefix(line, "// posting: "); ok {
+			meta.PostingPath = posting
+		} else if hash, ok := strings.CutPrefix(line, "// cv: "); ok {
+			meta.CVHash = hash
+		} else {
+			break
+		}
+	}
+	return meta, nil
+}
+
+// PredatesCV reports whether the document was generated from an older
+// version of the CV. The CV hash decides when both are known; otherwise a
+// document generated before the day the CV was modified counts as older.
+// Headers only record the day, so a same-day change counts too.
+func (m *DocumentMetadata) PredatesCV(cvHash string, cvModified time.Time) bool {
+	if m.CVHash != "" && cvHash != "" {
+		return m.CVHash != cvHash
+	}
+	y, mo, d := cvModified.Date()
+	return !m.GeneratedAt.After(time.Date(y, mo, d, 0, 0, 0, 0, time.UTC))
+}
+
+// withTypstHeader prepends the metadata header to content, replacing a
+// header left by an earlier generation. A nil m leaves content unchanged.
+func (m *DocumentMetadata) withTypstHeader(content string) string {
+	if m == nil {
+		return content
+	}
+	return m.TypstHeader() + stripTypstHeader(content)
+}
+
+// withHeader prepends the metadata header in the comment syntax of the
+// document at path: HTML comments for markdown, Typst comments otherwise
+func (m *DocumentMetadata) withHeader(content, path string) string {
+	if m == nil || !IsMarkdownDocument(path) {
+		return m.withTypstHeader(content)
+	}
+	return m.MarkdownHeader() + stripMarkdownHeader(content)
+}
+
+// stripMarkdownHeader removes a leading ghosted metadata header written as
+// HTML comments, if present
+func stripMarkdownHeader(content string) string {
+	if !strings.HasPrefix(content, markdownHeaderPrefix) {
+		return content
+	}
+	lines := strings.SplitAfter(content, "\n")
+	i := 1
+	for i < len(lines) && (strings.HasPrefix(lines[i], "<!-- posting: ") || strings.HasPrefix(lines[i], "<!-- cv: ")) {
+		i++
+	}
+	return strings.Join(lines[i:], "")
+}
+
+// typstFromMarkdownHeader rewrites a leading HTML comment header as Typst
+// line comments, so one parser reads both
+func typstFromMarkdownHeader(content string) string {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		inner, ok := strings.CutPrefix(line, "<!-- ")
+		if !ok || !strings.HasSuffix(inner, " -->") {
+			break
+		}
+		lines[i] = "// " + strings.TrimSuffix(inner, " -->")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// stripTypstHeader removes a leading ghosted metadata header, if present
+func stripTypstHeader(content string) string {
+	if !strings.HasPrefix(content, typstHeaderPrefix) {
+		return content
+	}
+	lines := strings.SplitAfter(content, "\n")
+	i := 1
+	for i < len(lines) && (strings.HasPrefix(lines[i], "// posting: ") || strings.HasPrefix(lines[i], "// cv: ")) {
+		i++
+	}
+	return strings.Join(lines[i:], "")
+}
+
+// cleanTypstOutput strips markdown code fences from model output. Leading
+// line comments (such as a metadata header) are kept, even when the fence
+// follows them.
+func cleanTypstOutput(output string) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	var header []string
+	for len(lines) > 0 && strings.HasPrefix(strings.TrimSpace(lines[0]), "//") {
+		header = append(header, lines[0])
+		lines = lines[1:]
+	}
+
+	body := strings.TrimSpace(strings.Join(lines, "\n"))
+	body = strings.TrimPrefix(body, "```typst")
+	body = strings.TrimPrefix(body, "```")
+	body = strings.TrimSuffix(body, "```")
+	body = strings.TrimSpace(body)
+
+	if len(header) == 0 {
+		return body
+	}
+	return strings.Join(header, "\n") + "\n" + body
+}
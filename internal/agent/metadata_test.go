@@ -0,0 +1,139 @@
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteTypst_MetadataHeader(t *testing.T) {
+	tmpDir := t.TempDir()
+	cvPath := filepath.Join(tmpDir, "cv.json")
+	if err := os.WriteFile(cvPath, []byte(`{"basics":{"name":"Test"}}`), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	posting := &ParsedPosting{Company: "Acme Corp", Position: "Software Engineer"}
+	meta := NewDocumentMetadata(posting, "local/postings/acme-swe.md", cvPath)
+	meta.GeneratedAt = time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC)
+
+	content := `#import "@preview/modern-cv:0.9.0": *`
+	outputPath := filepath.Join(tmpDir, "resume.typ")
+	agent := NewResumeGeneratorAgent(&AgentConfig{Type: AgentResume}, "")
+	if err := agent.WriteTypst(content, outputPath, meta); err != nil {
+		t.Fatalf("WriteTypst() error = %v", err)
+	}
+
+	data, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	got := string(data)
+	hash, _ := HashCV(cvPath)
+	for _, want := range []string{
+		"// Generated by ghosted on 2024-03-15 for Acme Corp - Software Engineer\n",
+		"// posting: local/postings/acme-swe.md\n",
+		"// cv: " + hash + "\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("written file missing %q:\n%s", want, got)
+		}
+	}
+	if !strings.HasSuffix(got, content) {
+		t.Errorf("document body not preserved:\n%s", got)
+	}
+
+	// Regenerating replaces the old header instead of stacking another
+	cover := NewCoverLetterGeneratorAgent(&AgentConfig{Type: AgentCover}, "")
+	if err := cover.WriteTypst(got, outputPath, meta); err != nil {
+		t.Fatalf("WriteTypst() error = %v", err)
+	}
+	data, _ = os.ReadFile(outputPath)
+	if n := strings.Count(string(data), typstHeaderPrefix); n != 1 {
+		t.Errorf("header appears %d times after rewrite, want 1", n)
+	}
+}
+
+func TestHashCV_ChangesWithContent(t *testing.T) {
+	cvPath := filepath.Join(t.TempDir(), "cv.json")
+	os.WriteFile(cvPath, []byte(`{"basics":{"name":"A"}}`), 0644)
+	first, err := HashCV(cvPath)
+	if err != nil {
+		t.Fatalf("HashCV() error = %v", err)
+	}
+	if len(first) != 12 {
+		t.Errorf("HashCV() = %q, want 12 hex characters", first)
+	}
+
+	os.WriteFile(cvPath, []byte(`{"basics":{"name":"B"}}`), 0644)
+	second, _ := HashCV(cvPath)
+	if first == second {
+		t.Error("HashCV() unchanged after the CV changed")
+	}
+}
+
+func TestCleanTypstOutput_KeepsLeadingComments(t *testing.T) {
+	input := "// Generated by ghosted on 2024-03-15 for Acme - Engineer\n```typst\n#import \"x\": *\n```"
+	want := "// Generated by ghosted on 2024-03-15 for Acme - Engineer\n#import \"x\": *"
+	if got := cleanTypstOutput(input); got != want {
+		t.Errorf("cleanTypstOutput() = %q, want %q", got, want)
+	}
+}
+
+func TestReadDocumentMetadata(t *testing.T) {
+	dir := t.TempDir()
+	meta := &DocumentMetadata{
+		GeneratedAt: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+		Company:     "Acme Corp",
+		Position:    "Software Engineer",
+		PostingPath: "local/postings/acme-swe.md",
+		CVHash:      "0123456789ab",
+	}
+	path := filepath.Join(dir, "resume.typ")
+	if err := os.WriteFile(path, []byte(meta.withTypstHeader("= Resume\n")), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadDocumentMetadata(path)
+	if err != nil {
+		t.Fatalf("ReadDocumentMetadata() error = %v", err)
+	}
+	if got == nil || *got != *meta {
+		t.Errorf("ReadDocumentMetadata() = %+v, want %+v", got, meta)
+	}
+
+	plain := filepath.Join(dir, "cover-letter.typ")
+	if err := os.WriteFile(plain, []byte("= Cover Letter\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := ReadDocumentMetadata(plain); got != nil || err != nil {
+		t.Errorf("ReadDocumentMetadata() without header = %+v, %v; want nil, nil", got, err)
+	}
+}
+
+func TestDocumentMetadata_PredatesCV(t *testing.T) {
+	generated := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		docHash    string
+		cvHash     string
+		cvModified time.Time
+		want       bool
+	}{
+		{"same hash despite a later edit", "abc", "abc", generated.AddDate(0, 0, 5), false},
+		{"different hash", "abc", "def", generated.AddDate(0, 0, -5), true},
+		{"no hash, CV edited later", "", "def", generated.AddDate(0, 0, 2), true},
+		{"no hash, CV edited the same day", "", "def", generated.Add(10 * time.Hour), true},
+		{"no hash, CV edited earlier", "", "def", generated.AddDate(0, 0, -2), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta := &DocumentMetadata{GeneratedAt: generated, CVHash: tt.docHash}
+			if got := meta.PredatesCV(tt.cvHash, tt.cvModified); got != tt.want {
+				t.Errorf("PredatesCV() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package agent
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// PostingQuality describes how well local parsing handled a single posting
+type PostingQuality struct {
+	Path         string
+	Company      string
+	Position     string
+	Requirements int
+	// CompanyFromContent and PositionFromContent are false when the value
+	// had to be guessed from the filename
+	CompanyFromContent  bool
+	PositionFromContent bool
+	Err                 error
+}
+
+// Usable reports whether local parsing found company, position and
+// requirements without relying on the filename
+func (q PostingQuality) Usable() bool {
+	return q.Err == nil && q.CompanyFromContent && q.PositionFromContent && q.Requirements > 0
+}
+
+// ParseQualityReport summarizes local parsing quality across postings
+type ParseQualityReport struct {
+	Postings []PostingQuality
+	Usable   int
+	Fallback int
+	Failed   int
+}
+
+// CheckPostingQuality runs the local extractor over a posting and records
+// where each field came from
+func CheckPostingQuality(content, postingPath string) PostingQuality {
+	parsed := extractBasicInfo(content, postingPath)
+	q := PostingQuality{
+		Path:         postingPath,
+		Company:      parsed.Company,
+		Position:     parsed.Position,
+		Requirements: len(parsed.Requirements),
+	}
+	// Report the values the posting states over the filename guesses
+	company, position := contentCompanyPosition(content)
+	if company != "" {
+		q.Company, q.CompanyFromContent = company, true
+	}
+	if position != "" {
+		q.Position, q.PositionFromContent = position, true
+	}
+	return q
+}
+
+// CheckPostingsQuality checks every supported posting in dir. Postings that
+// cannot be read as text (e.g. images) are counted as failed.
+func CheckPostingsQuality(dir string) (*ParseQualityReport, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read postings directory: %w", err)
+	}
+
+	parser := NewParserAgent(nil)
+	var paths []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		if parser.IsSupported(path) {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	report := &ParseQualityReport{}
+	for _, path := range paths {
+		var q PostingQuality
+		content, err := parser.ReadPosting(path)
+		if err == nil && parser.IsImageFile(path) {
+			err = fmt.Errorf("image postings require the AI parser")
+		}
+		if err != nil {
+			q = PostingQuality{Path: path, Err: err}
+		} else {
+			q = CheckPostingQuality(content, path)
+		}
+
+		switch {
+		case q.Err != nil:
+			report.Failed++
+		case q.Usable():
+			report.Usable++
+		default:
+			report.Fallback++
+		}
+		report.Postings = append(report.Postings, q)
+	}
+	return report, nil
+}
@@ -0,0 +1,112 @@
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const structuredPosting = `---
+source: https://jobs.lever.co/acme/123
+fetched: 2026-01-02 10:00:00
+company: Acme Corp
+position: Senior Backend Engineer
+---
+
+# Senior Backend Engineer
+
+**Company:** Acme Corp
+
+## Job Description
+
+We build developer tools.
+
+## Requirements
+
+- 5+ years of Go experience
+- Experience with PostgreSQL
+- Strong communication skills
+
+## Benefits
+
+- Health insurance
+`
+
+const filenameOnlyPosting = `We are hiring someone great.
+You will work on interesting problems with a friendly team.
+`
+
+func writePosting(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	return path
+}
+
+func TestCheckPostingQuality_Structured(t *testing.T) {
+	q := CheckPostingQuality(structuredPosting, "local/postings/acme-backend-posting.md")
+
+	if !q.Usable() {
+		t.Errorf("Usable() = false, want true: %+v", q)
+	}
+	if q.Company != "Acme Corp" {
+		t.Errorf("Company = %q, want %q", q.Company, "Acme Corp")
+	}
+	if q.Position != "Senior Backend Engineer" {
+		t.Errorf("Position = %q, want %q", q.Position, "Senior Backend Engineer")
+	}
+	if q.Requirements != 3 {
+		t.Errorf("Requirements = %d, want 3 (benefits must not be counted)", q.Requirements)
+	}
+}
+
+func TestCheckPostingQuality_FilenameOnly(t *testing.T) {
+	q := CheckPostingQuality(filenameOnlyPosting, "local/postings/globex-data_engineer-posting.md")
+
+	if q.Usable() {
+		t.Errorf("Usable() = true, want false: %+v", q)
+	}
+	if q.CompanyFromContent || q.PositionFromContent {
+		t.Errorf("expected company and position to come from the filename: %+v", q)
+	}
+	if q.Company != "Globex" || q.Position != "Data Engineer" {
+		t.Errorf("filename guess = %q/%q, want Globex/Data Engineer", q.Company, q.Position)
+	}
+	if q.Requirements != 0 {
+		t.Errorf("Requirements = %d, want 0", q.Requirements)
+	}
+}
+
+func TestCheckPostingsQuality_Tally(t *testing.T) {
+	dir := t.TempDir()
+	writePosting(t, dir, "acme-backend-posting.md", structuredPosting)
+	writePosting(t, dir, "globex-data_engineer-posting.md", filenameOnlyPosting)
+	writePosting(t, dir, "initech-screenshot.png", "not really an image")
+	writePosting(t, dir, "notes.json", "{}") // unsupported, ignored
+
+	report, err := CheckPostingsQuality(dir)
+	if err != nil {
+		t.Fatalf("CheckPostingsQuality() error = %v", err)
+	}
+
+	if len(report.Postings) != 3 {
+		t.Fatalf("Postings = %d, want 3", len(report.Postings))
+	}
+	if report.Usable != 1 {
+		t.Errorf("Usable = %d, want 1", report.Usable)
+	}
+	if report.Fallback != 1 {
+		t.Errorf("Fallback = %d, want 1", report.Fallback)
+	}
+	if report.Failed != 1 {
+		t.Errorf("Failed = %d, want 1 (image posting)", report.Failed)
+	}
+}
+
+func TestCheckPostingsQuality_MissingDir(t *testing.T) {
+	if _, err := CheckPostingsQuality(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("CheckPostingsQuality() on missing dir should return error")
+	}
+}
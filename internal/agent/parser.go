@@ -20,7 +20,7 @@ func NewParserAgent(config *AgentConfig) *ParserAgent {
 
 // SupportedExtensions returns file extensions the parser can handle
 func (p *ParserAgent) SupportedExtensions() []string {
-	return []string{".md", ".txt", ".png", ".jpg", ".jpeg"}
+	return []string{".md", ".txt", ".docx", ".png", ".jpg", ".jpeg"}
 }
 
 // IsSupported checks if the given file extension is supported
@@ -52,6 +52,10 @@ func (p *ParserAgent) ReadPosting(path string) (string, error) {
 		return fmt.Sprintf("[IMAGE_FILE: %s]", path), nil
 	}
 
+	if strings.EqualFold(filepath.Ext(path), ".docx") {
+		return readDocx(path)
+	}
+
 	content, err := os.ReadFile(path)
 	if err != nil {
 		return "", fmt.Errorf("failed to read file: %w", err)
@@ -75,6 +79,8 @@ Given a job posting (text, markdown, or image), extract the following informatio
   "salary_min": null or integer (annual salary in USD, e.g., 150000),
   "salary_max": null or integer (annual salary in USD, e.g., 200000),
   "job_url": "URL if provided",
+  "min_years_experience": null or integer (years of experience required, e.g., 5),
+  "deadline": null or "YYYY-MM-DDT00:00:00Z" (last day to apply, if stated),
   "requirements": [
     "List of required qualifications",
     "Each as a separate string"
@@ -96,6 +102,11 @@ Given a job posting (text, markdown, or image), extract the following informatio
   "company_values": [
     "Company culture keywords",
     "Values emphasized in posting"
+  ],
+  "culture_summary": "One paragraph on the company's culture, drawn from its values and the posting",
+  "benefits": [
+    "Benefits and perks offered",
+    "Each as a separate string"
   ]
 }
 
@@ -104,9 +115,13 @@ Guidelines:
 - Identify the team/department if explicitly mentioned
 - Set remote to true if remote or hybrid work is mentioned
 - Parse salary ranges if provided (convert to integers, e.g., "$150k-200k" -> salary_min: 150000, salary_max: 200000)
+- Set min_years_experience from phrases like "5+ years" or "at least 3 years"; for ranges like "7-10 years" use the lower bound
+- Set deadline from phrases like "Apply by March 15, 2024" or "Applications close 2024-03-15"; use null for rolling or "open until filled" postings
 - Separate required qualifications from nice-to-have/bonus qualifications
 - Extract technology stack mentions (languages, frameworks, cloud services, tools)
 - Identify company culture keywords and values from the about/culture sections
+- Write culture_summary as 2-3 sentences describing how the company works, grounded in the values and what the posting says about the team or mission; leave it empty if the posting says nothing about culture
+- List benefits and perks (from "Benefits", "Perks", or "What we offer" sections) one per entry
 - Keywords should capture domain-specific terms that indicate what the role is about
 - If information is not available, use null for optional fields or empty arrays for lists
 
@@ -149,6 +164,17 @@ func (p *ParserAgent) ParseJSON(jsonStr string) (*ParsedPosting, error) {
 	jsonStr = strings.TrimPrefix(jsonStr, "```")
 	jsonStr = strings.TrimSuffix(jsonStr, "```")
 	jsonStr = strings.TrimSpace(jsonStr)
+	jsonStr = trimJSONNoise(jsonStr)
+
+	// Validate against the output schema before building the struct so
+	// malformed AI output produces a precise, field-level error
+	var raw interface{}
+	if err := json.Unmarshal([]byte(jsonStr), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+	if err := validateJSONSchema(p.GetOutputSchema(), raw); err != nil {
+		return nil, fmt.Errorf("parser output does not match schema: %w", err)
+	}
 
 	var parsed ParsedPosting
 	if err := json.Unmarshal([]byte(jsonStr), &parsed); err != nil {
@@ -158,6 +184,7 @@ func (p *ParserAgent) ParseJSON(jsonStr string) (*ParsedPosting, error) {
 	if err := p.ValidateParsedPosting(&parsed); err != nil {
 		return nil, err
 	}
+	fillCultureSummary(&parsed)
 
 	return &parsed, nil
 }
@@ -201,6 +228,15 @@ func (p *ParserAgent) GetOutputSchema() string {
       "type": "string",
       "description": "URL to the job posting"
     },
+    "min_years_experience": {
+      "type": ["integer", "null"],
+      "description": "Years of experience required"
+    },
+    "deadline": {
+      "type": ["string", "null"],
+      "format": "date-time",
+      "description": "Last day to apply, if the posting states one"
+    },
     "requirements": {
       "type": "array",
       "items": {"type": "string"},
@@ -225,6 +261,15 @@ func (p *ParserAgent) GetOutputSchema() string {
       "type": "array",
       "items": {"type": "string"},
       "description": "Company culture keywords"
+    },
+    "culture_summary": {
+      "type": "string",
+      "description": "One-paragraph culture summary"
+    },
+    "benefits": {
+      "type": "array",
+      "items": {"type": "string"},
+      "description": "Benefits and perks offered"
     }
   }
 }`
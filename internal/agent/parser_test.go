@@ -19,7 +19,8 @@ func TestParserAgent_SupportedExtensions(t *testing.T) {
 		{"job-posting.jpg", true},
 		{"job-posting.jpeg", true},
 		{"job-posting.pdf", false},
-		{"job-posting.docx", false},
+		{"job-posting.docx", true},
+		{"job-posting.doc", false},
 	}
 
 	for _, tt := range tests {
@@ -108,6 +109,11 @@ func TestParserAgent_ParseJSON(t *testing.T) {
 			json: "```json\n{\"company\": \"Acme\", \"position\": \"Engineer\"}\n```",
 			wantErr: false,
 		},
+		{
+			name:    "JSON wrapped in prose",
+			json:    "Here's the JSON:\n```json\n{\"company\": \"Acme\", \"position\": \"Engineer\"}\n```\nLet me know if anything is missing.",
+			wantErr: false,
+		},
 		{
 			name:    "missing company",
 			json:    `{"position": "Engineer"}`,
@@ -139,6 +145,71 @@ func TestParserAgent_ParseJSON(t *testing.T) {
 	}
 }
 
+func TestParserAgent_ParseJSON_SchemaErrors(t *testing.T) {
+	agent := NewParserAgent(&AgentConfig{Type: AgentParser})
+
+	tests := []struct {
+		name    string
+		json    string
+		wantErr string
+	}{
+		{
+			name:    "salary as string",
+			json:    `{"company": "Acme", "position": "Engineer", "salary_min": "150k"}`,
+			wantErr: "salary_min: expected integer or null, got string",
+		},
+		{
+			name:    "fractional salary",
+			json:    `{"company": "Acme", "position": "Engineer", "salary_max": 150000.5}`,
+			wantErr: "salary_max: expected integer or null, got number",
+		},
+		{
+			name:    "missing required field",
+			json:    `{"position": "Engineer"}`,
+			wantErr: "company: required field missing",
+		},
+		{
+			name:    "wrong array item type",
+			json:    `{"company": "Acme", "position": "Engineer", "tech_stack": ["Go", 42]}`,
+			wantErr: "tech_stack[1]: expected string, got number",
+		},
+		{
+			name:    "remote as string",
+			json:    `{"company": "Acme", "position": "Engineer", "remote": "yes"}`,
+			wantErr: "remote: expected boolean, got string",
+		},
+		{
+			name:    "top-level array",
+			json:    `[{"company": "Acme"}]`,
+			wantErr: "(root): expected object, got array",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := agent.ParseJSON(tt.json)
+			if err == nil {
+				t.Fatalf("ParseJSON() error = nil, want %q", tt.wantErr)
+			}
+			if !contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseJSON() error = %q, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParserAgent_ParseJSON_NullSalary(t *testing.T) {
+	agent := NewParserAgent(&AgentConfig{Type: AgentParser})
+
+	parsed, err := agent.ParseJSON(`{"company": "Acme", "position": "Engineer", "salary_min": null, "salary_max": 180000}`)
+	if err != nil {
+		t.Fatalf("ParseJSON() error = %v", err)
+	}
+	if parsed.SalaryMin != 0 || parsed.SalaryMax != 180000 {
+		t.Errorf("salary = %d-%d, want 0-180000", parsed.SalaryMin, parsed.SalaryMax)
+	}
+}
+
 func TestParserAgent_GetSystemPrompt(t *testing.T) {
 	agent := NewParserAgent(&AgentConfig{Type: AgentParser})
 	prompt := agent.GetSystemPrompt()
@@ -188,6 +259,29 @@ This is a remote-friendly position.`
 	}
 }
 
+func TestExtractBasicInfo_CompanyPositionSources(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		path         string
+		wantCompany  string
+		wantPosition string
+	}{
+		{"heading does not replace the filename position", "# About Us\n\nRole: Hiring manager\n", "acme-swe-posting.md", "Acme", "Swe"},
+		{"labelled lines win over the filename", "Company: Acme Corp\nPosition: Staff Engineer\n", "acme-swe-posting.md", "Acme Corp", "Staff Engineer"},
+		{"front matter wins", "---\ncompany: Globex\nposition: SRE\n---\n\nCompany: Acme\n", "acme-swe-posting.md", "Globex", "SRE"},
+		{"heading fills a missing position", "# Platform Engineer\n", "acme.md", "Acme", "Platform Engineer"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed := extractBasicInfo(tt.content, tt.path)
+			if parsed.Company != tt.wantCompany || parsed.Position != tt.wantPosition {
+				t.Errorf("extractBasicInfo() = %q / %q, want %q / %q", parsed.Company, parsed.Position, tt.wantCompany, tt.wantPosition)
+			}
+		})
+	}
+}
+
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > 0 && containsHelper(s, substr))
 }
@@ -1,24 +1,87 @@
 package agent
 
 import (
+	"cmp"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/celloopa/ghosted/internal/atomicfile"
 	"github.com/celloopa/ghosted/internal/model"
 	"github.com/celloopa/ghosted/internal/store"
 )
 
 // Pipeline orchestrates the multi-agent document generation workflow
 type Pipeline struct {
-	Config    *PipelineConfig
-	Store     *store.Store
-	State     *PipelineState
-	BaseDir   string
+	Config  *PipelineConfig
+	Store   *store.Store
+	State   *PipelineState
+	BaseDir string
+	// StateFile is where the current run's state is saved; Run and
+	// LoadState set it to a file per posting under BaseDir, see
+	// runStatePath. It is removed once the run completes.
 	StateFile string
+
+	// AutoRevise is the maximum number of times a rejected draft is
+	// regenerated with reviewer feedback before giving up (0 disables).
+	// Revising needs LLM; drafts from the CV can't take feedback.
+	AutoRevise int
+	// Revisions counts the regenerations performed in the current run
+	Revisions int
+	// Feedback is the rejected review being addressed by the current
+	// regeneration; nil on the first draft
+	Feedback *DetailedReviewResult
+	// ReviewFunc scores generated documents. Defaults to the LLM review
+	// when LLM is set, otherwise to the reviewer checking against the
+	// reviewer CV, or a placeholder review when there is no CV.
+	ReviewFunc func(docs *GeneratedDocuments) (*DetailedReviewResult, error)
+	// Tone overrides the configured cover letter tone; when neither is set
+	// the tone is suggested from the posting's company values
+	Tone string
+	// CoverStyle is the cover letter length, one of CoverLetterStyles;
+	// empty means StyleStandard
+	CoverStyle string
+	// OnStep, if set, is called after each step finishes (completed or
+	// failed), so callers can report progress
+	OnStep func(agent AgentType, result StepResult)
+	// CVPath is the CV documents are drafted from. When set, the resume and
+	// cover steps write draft .typ or .md files (compiled to PDF when typst
+	// or pandoc is installed); when empty they only plan the output paths.
+	CVPath string
+	// ReviewerCVPath is the CV the reviewer verifies the documents against,
+	// such as the full CV when drafting from a trimmed one. Empty uses
+	// CVPath.
+	ReviewerCVPath string
+	// DiscardFailedState removes the state of a failed run instead of
+	// keeping it (under failed/ next to the state file) for resuming
+	DiscardFailedState bool
+	// AttachPosting adds the raw posting text to the resume and cover
+	// letter prompts, for nuances the parser dropped
+	AttachPosting bool
+	// LLM generates and reviews the documents. When nil, the resume and
+	// cover steps draft from the CV and the review is a placeholder.
+	LLM LLMClient
+	// Rubric is the reviewer's scoring rubric; nil uses DefaultRubric
+	Rubric *Rubric
+	// Format overrides the configured document format, one of
+	// DocumentFormats; when neither is set documents are Typst
+	Format string
+	// CompileFunc compiles a drafted document and returns the PDF path.
+	// Defaults to the typst CLI (pandoc for markdown), skipping
+	// compilation when it isn't installed.
+	CompileFunc func(typstPath string) (string, error)
+
+	// stateMu guards writes to State, so steps can update it while it is
+	// being saved
+	stateMu sync.Mutex
 }
 
 // NewPipeline creates a new pipeline instance
@@ -32,14 +95,16 @@ func NewPipeline(configPath string, store *store.Store) (*Pipeline, error) {
 			return nil, fmt.Errorf("failed to load config: %w", err)
 		}
 	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", configPath, err)
+	}
 
 	baseDir := filepath.Dir(configPath)
 
 	return &Pipeline{
-		Config:    config,
-		Store:     store,
-		BaseDir:   baseDir,
-		StateFile: filepath.Join(baseDir, "state.json"),
+		Config:  config,
+		Store:   store,
+		BaseDir: baseDir,
 	}, nil
 }
 
@@ -52,6 +117,7 @@ func (p *Pipeline) Run(postingPath string) error {
 		Status:      "running",
 		Results:     make(map[AgentType]StepResult),
 	}
+	p.StateFile = p.runStatePath(postingPath)
 
 	// Initialize all steps as pending
 	for _, agent := range p.Config.Agents {
@@ -63,32 +129,67 @@ func (p *Pipeline) Run(postingPath string) error {
 		return fmt.Errorf("failed to save state: %w", err)
 	}
 
+	p.Revisions = 0
+	p.Feedback = nil
+
 	// Run each enabled agent in sequence
 	var lastOutput json.RawMessage
 	for _, agent := range p.Config.EnabledAgents() {
-		p.State.CurrentStep = agent.Type
+		p.updateState(func(state *PipelineState) {
+			state.CurrentStep = agent.Type
+		})
 
 		result, err := p.runStep(agent, lastOutput, postingPath)
+		if err == nil && agent.Type == AgentReviewer && p.AutoRevise > 0 {
+			result, err = p.reviseUntilApproved(result, postingPath)
+		}
 		if err != nil {
-			p.State.Results[agent.Type] = StepResult{
-				Status: "failed",
-				Error:  err.Error(),
-			}
-			p.State.Status = "failed"
+			p.updateState(func(state *PipelineState) {
+				state.Results[agent.Type] = StepResult{
+					Status: "failed",
+					Error:  err.Error(),
+				}
+				state.Status = "failed"
+			})
 			p.saveState()
+			keepErr := p.keepFailedState()
+			p.notifyStep(agent.Type)
+			if keepErr != nil {
+				return fmt.Errorf("step %s failed: %w (keeping its state for resume also failed: %v)", agent.Type, err, keepErr)
+			}
 			return fmt.Errorf("step %s failed: %w", agent.Type, err)
 		}
 
-		p.State.Results[agent.Type] = result
+		p.setStepResult(agent.Type, result)
 		lastOutput = result.Output
+		p.notifyStep(agent.Type)
 
 		if err := p.saveState(); err != nil {
 			return fmt.Errorf("failed to save state: %w", err)
 		}
 	}
 
-	p.State.Status = "completed"
-	return p.saveState()
+	p.updateState(func(state *PipelineState) {
+		state.Status = "completed"
+	})
+	if err := p.clearFailedState(); err != nil {
+		return err
+	}
+	return p.clearRunState()
+}
+
+// Parse runs only the parser step and returns the structured posting.
+// Nothing is generated, tracked, or written to the state file.
+func (p *Pipeline) Parse(postingPath string) (*ParsedPosting, error) {
+	output, err := p.runParserStep(postingPath)
+	if err != nil {
+		return nil, err
+	}
+	var parsed ParsedPosting
+	if err := json.Unmarshal(output, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to decode parser output: %w", err)
+	}
+	return &parsed, nil
 }
 
 // runStep executes a single agent step
@@ -179,20 +280,22 @@ func extractBasicInfo(content string, postingPath string) ParsedPosting {
 
 	lines := strings.Split(content, "\n")
 
-	// Try to extract company and position from filename
-	// Format: company-position-posting.md
-	base := filepath.Base(postingPath)
-	base = strings.TrimSuffix(base, filepath.Ext(base))
-	base = strings.TrimSuffix(base, "-posting")
-	parts := strings.SplitN(base, "-", 2)
-	if len(parts) >= 1 {
-		parsed.Company = strings.ReplaceAll(parts[0], "_", " ")
-		parsed.Company = strings.Title(parsed.Company)
-	}
-	if len(parts) >= 2 {
-		parsed.Position = strings.ReplaceAll(parts[1], "_", " ")
-		parsed.Position = strings.Title(parsed.Position)
-	}
+	// Front matter from `ghosted fetch` and "Company:"/"Position:" lines
+	// state company and position outright. Otherwise they're guessed from
+	// the filename, and the posting's title is only used when the filename
+	// has no position, since a heading like "# About Us" isn't one.
+	fields, body := splitFrontMatter(content)
+	company, position, title := statedCompanyPosition(body)
+	fileCompany, filePosition := filenameCompanyPosition(postingPath)
+	parsed.Company = cmp.Or(fields["company"], company, fileCompany)
+	parsed.Position = cmp.Or(fields["position"], position, filePosition, title)
+
+	parsed.Requirements = extractRequirements(lines)
+	parsed.Benefits = extractBenefits(lines)
+	parsed.CompanyValues = extractCompanyValues(lines)
+	parsed.MinYearsExperience = extractYearsExperience(lines)
+	parsed.Deadline = extractDeadline(lines)
+	parsed.Language = DetectLanguage(content)
 
 	// Try to find location from content
 	for _, line := range lines {
@@ -221,28 +324,326 @@ func extractBasicInfo(content string, postingPath string) ParsedPosting {
 		parsed.Remote = true
 	}
 
+	estimateSalary(&parsed)
+	fillCultureSummary(&parsed)
+
 	return parsed
 }
 
+// filenameCompanyPosition guesses company and position from a posting filename
+// Format: company-position-posting.md
+func filenameCompanyPosition(postingPath string) (company, position string) {
+	base := filepath.Base(postingPath)
+	base = strings.TrimSuffix(base, filepath.Ext(base))
+	base = strings.TrimSuffix(base, "-posting")
+	parts := strings.SplitN(base, "-", 2)
+	if len(parts) >= 1 {
+		company = strings.Title(strings.ReplaceAll(parts[0], "_", " "))
+	}
+	if len(parts) >= 2 {
+		position = strings.Title(strings.ReplaceAll(parts[1], "_", " "))
+	}
+	return company, position
+}
+
+// contentCompanyPosition reads company and position from the posting text:
+// front matter written by `ghosted fetch`, "Company:"/"Position:" lines, or
+// a "Title:"/"Role:" line or leading "# Title" heading. Empty strings mean
+// nothing was found.
+func contentCompanyPosition(content string) (company, position string) {
+	fields, body := splitFrontMatter(content)
+	company, position, title := statedCompanyPosition(body)
+	return cmp.Or(fields["company"], company), cmp.Or(fields["position"], position, title)
+}
+
+// statedCompanyPosition reads a posting body's "Company:" and "Position:"
+// lines, and its title: a "Title:"/"Role:" line or a "# Title" heading on
+// the first line. Empty strings mean nothing was found.
+func statedCompanyPosition(body string) (company, position, title string) {
+	sawContent := false
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		// Strip markdown bold so "**Company:** Acme" reads as "Company: Acme"
+		plain := strings.ReplaceAll(line, "**", "")
+		if key, value, ok := strings.Cut(plain, ":"); ok {
+			value = strings.TrimSpace(value)
+			switch strings.ToLower(strings.TrimSpace(key)) {
+			case "company":
+				company = cmp.Or(company, value)
+			case "position":
+				position = cmp.Or(position, value)
+			case "title", "role", "job title":
+				title = cmp.Or(title, value)
+			}
+		}
+
+		if !sawContent && strings.HasPrefix(line, "# ") {
+			title = cmp.Or(title, strings.TrimSpace(strings.TrimPrefix(line, "# ")))
+		}
+		sawContent = true
+	}
+	return company, position, title
+}
+
+// requirementHeadings are section titles whose bullet lists hold requirements
+var requirementHeadings = []string{"requirement", "qualification", "what you'll need", "what you bring", "must have"}
+
+// extractRequirements collects bullet items listed under a requirements or
+// qualifications heading
+func extractRequirements(lines []string) []string {
+	var reqs []string
+	inSection := false
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		isBullet := strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "* ") || strings.HasPrefix(line, "• ")
+		if !isBullet {
+			// Any non-bullet line is treated as a potential section heading
+			heading := strings.ToLower(strings.Trim(line, "#*: "))
+			inSection = false
+			for _, h := range requirementHeadings {
+				if strings.Contains(heading, h) && len(heading) < 60 {
+					inSection = true
+					break
+				}
+			}
+			continue
+		}
+
+		if inSection {
+			if item := strings.TrimSpace(line[strings.Index(line, " ")+1:]); item != "" {
+				reqs = append(reqs, item)
+			}
+		}
+	}
+	return reqs
+}
+
+// benefitHeadings are section titles whose bullet lists hold benefits
+var benefitHeadings = []string{"benefit", "perk", "what we offer"}
+
+// isBenefitHeading reports whether a line's text names a benefits section
+func isBenefitHeading(text string) bool {
+	heading := strings.ToLower(strings.Trim(text, "#*: "))
+	if len(heading) >= 60 {
+		return false
+	}
+	for _, h := range benefitHeadings {
+		if strings.Contains(heading, h) {
+			return true
+		}
+	}
+	return false
+}
+
+// extractBenefits collects bullet items listed under a benefits or perks
+// heading, and comma-separated inline lists like "Benefits: health, 401k,
+// and unlimited PTO"
+func extractBenefits(lines []string) []string {
+	return extractSectionList(lines, isBenefitHeading)
+}
+
+// extractSectionList collects bullet items listed under headings matching
+// isHeading, and inline lists after such a heading and a colon
+func extractSectionList(lines []string, isHeading func(string) bool) []string {
+	var items []string
+	inSection := false
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		isBullet := strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "* ") || strings.HasPrefix(line, "• ")
+		if isBullet {
+			if inSection {
+				if item := strings.TrimSpace(line[strings.Index(line, " ")+1:]); item != "" {
+					items = append(items, item)
+				}
+			}
+			continue
+		}
+
+		// Any non-bullet line is treated as a potential section heading,
+		// unless it lists the items inline after a colon
+		plain := strings.ReplaceAll(line, "**", "")
+		if key, value, ok := strings.Cut(plain, ":"); ok && strings.TrimSpace(value) != "" && isHeading(key) {
+			items = append(items, splitInlineList(value)...)
+			inSection = false
+			continue
+		}
+		inSection = isHeading(plain)
+	}
+	return items
+}
+
+// splitInlineList splits "a, b; c and d" style lists into trimmed items
+func splitInlineList(list string) []string {
+	var items []string
+	for _, item := range strings.FieldsFunc(list, func(r rune) bool { return r == ',' || r == ';' }) {
+		item = strings.TrimSpace(item)
+		item = strings.TrimPrefix(item, "and ")
+		item = strings.TrimSpace(strings.TrimSuffix(item, "."))
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// yearsPattern matches "5+ years", "at least 3 years", "minimum of 4 years",
+// and ranges like "7-10 years" or "7 to 10 years"
+var yearsPattern = regexp.MustCompile(`(?i)\b(?:(at least|minimum of|a minimum of|min\.?)\s+)?(\d{1,2})\s*(\+|(?:-|–|to)\s*\d{1,2})?\+?\s+(?:years?|yrs?)\b`)
+
+// extractYearsExperience returns the most years of experience the posting
+// asks for, taking the lower bound of ranges. A bare "3 years" only counts
+// on a line that mentions experience, so "founded 10 years ago" is ignored.
+func extractYearsExperience(lines []string) int {
+	most := 0
+	for _, line := range lines {
+		mentionsExperience := strings.Contains(strings.ToLower(line), "experience")
+		for _, m := range yearsPattern.FindAllStringSubmatch(line, -1) {
+			qualified := m[1] != "" || m[3] != ""
+			if !qualified && !mentionsExperience {
+				continue
+			}
+			if n, err := strconv.Atoi(m[2]); err == nil && n > most {
+				most = n
+			}
+		}
+	}
+	return most
+}
+
+// deadlinePattern matches the phrases postings use to introduce an
+// application deadline
+var deadlinePattern = regexp.MustCompile(`(?i)\b(?:apply by|apply before|application deadline|deadline|applications? (?:close|closes|due)|closing date)\b`)
+
+// deadlineDatePattern matches the date formats seen after a deadline phrase:
+// "March 15, 2024", "15 March 2024", "2024-03-15", and "3/15/2024"
+var deadlineDatePattern = regexp.MustCompile(`(?i)\b(?:(?:jan|feb|mar|apr|may|jun|jul|aug|sep|sept|oct|nov|dec)[a-z]*\.?\s+\d{1,2}(?:st|nd|rd|th)?,?\s+\d{4}|\d{1,2}(?:st|nd|rd|th)?\s+(?:jan|feb|mar|apr|may|jun|jul|aug|sep|sept|oct|nov|dec)[a-z]*\.?,?\s+\d{4}|\d{4}-\d{2}-\d{2}|\d{1,2}/\d{1,2}/\d{4})\b`)
+
+// deadlineDateLayouts are tried in order on a normalized date match
+var deadlineDateLayouts = []string{
+	"January 2 2006",
+	"Jan 2 2006",
+	"2 January 2006",
+	"2 Jan 2006",
+	"2006-01-02",
+	"1/2/2006",
+}
+
+// extractDeadline finds an application deadline like "Apply by March 15,
+// 2024" or "Deadline: 2024-03-15". Rolling or "open until filled" postings
+// have no deadline, and neither do dates without a year.
+func extractDeadline(lines []string) *time.Time {
+	for i, line := range lines {
+		loc := deadlinePattern.FindStringIndex(line)
+		if loc == nil {
+			continue
+		}
+		rest := line[loc[1]:]
+		lower := strings.ToLower(rest)
+		if strings.Contains(lower, "rolling") || strings.Contains(lower, "until filled") {
+			return nil
+		}
+		// "Deadline:" on its own line, with the date below
+		if strings.Trim(rest, " \t:*_-#") == "" {
+			for _, next := range lines[i+1:] {
+				if strings.TrimSpace(next) != "" {
+					rest = next
+					break
+				}
+			}
+		}
+		if date := parseDeadlineDate(rest); date != nil {
+			return date
+		}
+	}
+	return nil
+}
+
+// parseDeadlineDate returns the first date in text, or nil
+func parseDeadlineDate(text string) *time.Time {
+	match := deadlineDatePattern.FindString(text)
+	if match == "" {
+		return nil
+	}
+
+	// Normalize "Sept. 15th, 2024" to "Sep 15 2024"
+	fields := strings.Fields(strings.NewReplacer(",", " ", ".", "").Replace(match))
+	for i, f := range fields {
+		if len(f) > 2 && f[0] >= '0' && f[0] <= '9' {
+			f = strings.TrimRight(f, "stndrh")
+		}
+		if strings.EqualFold(f, "sept") {
+			f = "Sep"
+		}
+		fields[i] = f
+	}
+	normalized := strings.Join(fields, " ")
+
+	for _, layout := range deadlineDateLayouts {
+		if t, err := time.Parse(layout, normalized); err == nil {
+			return &t
+		}
+	}
+	return nil
+}
+
 // runResumeStep generates a tailored resume
-// In production, this would invoke Claude Code with resume templates
 func (p *Pipeline) runResumeStep(input json.RawMessage) (json.RawMessage, error) {
 	var parsed ParsedPosting
 	if err := json.Unmarshal(input, &parsed); err != nil {
 		return nil, fmt.Errorf("invalid input: %w", err)
 	}
 
-	// Placeholder: In production, generates .typ file and compiles to PDF
+	format, err := p.DocumentFormat()
+	if err != nil {
+		return nil, err
+	}
 	docs := GeneratedDocuments{
-		ResumePath: filepath.Join(p.Config.Paths.OutputDir, p.formatFilename(parsed, "resume.typ")),
+		ResumePath: filepath.Join(p.Config.Paths.OutputDir, p.formatFilename(parsed, "resume"+documentExt(format))),
+	}
+
+	// The resume is generated by the model when there is one; without
+	// one, draft it from the CV
+	switch {
+	case p.LLM != nil:
+		content, err := p.generateResume(&parsed, format)
+		if err != nil {
+			return nil, err
+		}
+		if docs.ResumePDF, err = p.writeDraft(content, docs.ResumePath, &parsed); err != nil {
+			return nil, err
+		}
+	case p.CVPath != "":
+		cv, err := NewResumeGeneratorAgent(nil, "").LoadCV(p.CVPath)
+		if err != nil {
+			return nil, err
+		}
+		docs.ResumePDF, err = p.writeDraft(draftResume(cv, &parsed, draftSyntaxFor(format)), docs.ResumePath, &parsed)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return json.Marshal(docs)
 }
 
 // runCoverStep generates a cover letter
-// In production, this would invoke Claude Code with cover letter templates
 func (p *Pipeline) runCoverStep(input json.RawMessage) (json.RawMessage, error) {
+	format, err := p.DocumentFormat()
+	if err != nil {
+		return nil, err
+	}
 	var docs GeneratedDocuments
 	if err := json.Unmarshal(input, &docs); err != nil {
 		// Try parsing as ParsedPosting for backward compatibility
@@ -251,78 +652,436 @@ func (p *Pipeline) runCoverStep(input json.RawMessage) (json.RawMessage, error)
 			return nil, fmt.Errorf("invalid input: %w", err)
 		}
 		docs = GeneratedDocuments{
-			CoverLetterPath: filepath.Join(p.Config.Paths.OutputDir, p.formatFilename(parsed, "cover.typ")),
+			CoverLetterPath: filepath.Join(p.Config.Paths.OutputDir, p.formatFilename(parsed, "cover"+documentExt(format))),
+		}
+	}
+
+	tone, err := p.coverTone()
+	if err != nil {
+		return nil, err
+	}
+	docs.CoverTone = tone
+	if err := ValidateCoverStyle(p.CoverStyle); err != nil {
+		return nil, err
+	}
+	docs.CoverStyle = p.CoverStyle
+
+	// The cover letter is generated by the model when there is one;
+	// without one, draft it from the CV
+	if parsed := p.ParsedPosting(); parsed != nil {
+		if docs.CoverLetterPath == "" {
+			docs.CoverLetterPath = filepath.Join(p.Config.Paths.OutputDir, p.formatFilename(*parsed, "cover"+documentExt(format)))
+		}
+		if p.LLM != nil {
+			content, err := p.generateCoverLetter(parsed, format, tone, docs.ResumePath)
+			if err != nil {
+				return nil, err
+			}
+			if docs.CoverLetterPDF, err = p.writeDraft(content, docs.CoverLetterPath, parsed); err != nil {
+				return nil, err
+			}
+		} else if p.CVPath != "" {
+			cv, err := NewCoverLetterGeneratorAgent(nil, "").LoadCV(p.CVPath)
+			if err != nil {
+				return nil, err
+			}
+			docs.CoverLetterPDF, err = p.writeDraft(draftCoverLetter(cv, parsed, time.Now(), draftSyntaxFor(format)), docs.CoverLetterPath, parsed)
+			if err != nil {
+				return nil, err
+			}
 		}
 	}
 
-	// Placeholder: In production, generates .typ file and compiles to PDF
 	return json.Marshal(docs)
 }
 
+// PostingText returns the raw text of the run's posting for the generation
+// prompts, or "" unless AttachPosting is set. Image postings have no text
+// to attach.
+func (p *Pipeline) PostingText() (string, error) {
+	if !p.AttachPosting || p.State == nil || p.State.PostingPath == "" {
+		return "", nil
+	}
+	return postingText(p.State.PostingPath)
+}
+
+// postingText reads a posting's raw text; image postings have none
+func postingText(postingPath string) (string, error) {
+	parser := NewParserAgent(nil)
+	if parser.IsImageFile(postingPath) {
+		return "", nil
+	}
+	content, err := parser.ReadPosting(postingPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read posting: %w", err)
+	}
+	return content, nil
+}
+
+// Documents returns the documents produced by the current run's cover
+// step, which carries the resume paths along, or nil if it hasn't run
+func (p *Pipeline) Documents() *GeneratedDocuments {
+	if p.State == nil {
+		return nil
+	}
+	result, ok := p.State.Results[AgentCover]
+	if !ok || result.Status != "completed" {
+		return nil
+	}
+	var docs GeneratedDocuments
+	if err := json.Unmarshal(result.Output, &docs); err != nil {
+		return nil
+	}
+	return &docs
+}
+
+// writeDraft writes a drafted document with its metadata header and
+// compiles it when output.generate_pdf is set and typst (pandoc for
+// markdown) is installed, returning the PDF path ("" if not compiled).
+// Without output.keep_typst the source is removed once the PDF exists.
+func (p *Pipeline) writeDraft(content, typstPath string, parsed *ParsedPosting) (string, error) {
+	postingPath := ""
+	if p.State != nil {
+		postingPath = p.State.PostingPath
+	}
+	writer := NewResumeGeneratorAgent(nil, "")
+	meta := NewDocumentMetadata(parsed, postingPath, p.CVPath)
+	if err := writer.WriteTypst(content, typstPath, meta); err != nil {
+		return "", err
+	}
+	if !p.Config.Output.GeneratePDF {
+		return "", nil
+	}
+
+	compile := p.CompileFunc
+	if compile == nil {
+		switch {
+		case IsMarkdownDocument(typstPath):
+			if !IsPandocAvailable() {
+				return "", nil
+			}
+			compile = CompileMarkdownPDF
+		case !writer.IsTypstAvailable():
+			return "", nil
+		default:
+			compile = writer.CompilePDF
+		}
+	}
+	pdfPath, err := compile(typstPath)
+	if err != nil {
+		return "", err
+	}
+	if !p.Config.Output.KeepTypst {
+		if err := os.Remove(typstPath); err != nil {
+			return "", fmt.Errorf("removing %s: %w", typstPath, err)
+		}
+	}
+	return pdfPath, nil
+}
+
+// DocumentFormat resolves the document format: the explicit override,
+// then the config default, then Typst
+func (p *Pipeline) DocumentFormat() (string, error) {
+	format := p.Format
+	if format == "" {
+		format = p.Config.Output.Format
+	}
+	if err := ValidateDocumentFormat(format); err != nil {
+		return "", err
+	}
+	if format == "" {
+		return FormatTypst, nil
+	}
+	return format, nil
+}
+
+// coverTone resolves the cover letter tone: the explicit override, then the
+// config default, then a suggestion from the parsed posting
+func (p *Pipeline) coverTone() (string, error) {
+	return p.resolveTone(p.ParsedPosting())
+}
+
+// resolveTone is coverTone for a given posting, which may be nil
+func (p *Pipeline) resolveTone(parsed *ParsedPosting) (string, error) {
+	tone := p.Tone
+	if tone == "" {
+		tone = p.Config.Output.CoverTone
+	}
+	if err := ValidateTone(tone); err != nil {
+		return "", err
+	}
+	if tone != "" {
+		return tone, nil
+	}
+
+	if parsed != nil {
+		return SuggestTone(parsed), nil
+	}
+	return "", nil
+}
+
+// ParsedPosting returns the parser step's output from the current run, or
+// nil if the posting has not been parsed
+func (p *Pipeline) ParsedPosting() *ParsedPosting {
+	if p.State == nil {
+		return nil
+	}
+	result, ok := p.State.Results[AgentParser]
+	if !ok || result.Output == nil {
+		return nil
+	}
+	var parsed ParsedPosting
+	if err := json.Unmarshal(result.Output, &parsed); err != nil {
+		return nil
+	}
+	return &parsed
+}
+
+// DetailedReview returns the reviewer step's detailed review from the
+// current run, or nil when the review had no details (such as the
+// placeholder review)
+func (p *Pipeline) DetailedReview() *DetailedReviewResult {
+	if p.State == nil {
+		return nil
+	}
+	result, ok := p.State.Results[AgentReviewer]
+	if !ok || result.Output == nil {
+		return nil
+	}
+	var review struct {
+		DetailedReview *DetailedReviewResult `json:"detailed_review"`
+	}
+	if err := json.Unmarshal(result.Output, &review); err != nil {
+		return nil
+	}
+	return review.DetailedReview
+}
+
 // runReviewerStep reviews generated documents
 // In production, this would invoke Claude Code to review from hiring manager perspective
-func (p *Pipeline) runReviewerStep(_ json.RawMessage) (json.RawMessage, error) {
-	// TODO: Use input (GeneratedDocuments) to read and review the documents
-	// Placeholder review result
-	review := ReviewResult{
-		Approved: true,
-		Score:    8,
-		Feedback: []string{
-			"Resume highlights relevant experience",
-			"Cover letter is personalized to the role",
-		},
+func (p *Pipeline) runReviewerStep(input json.RawMessage) (json.RawMessage, error) {
+	if p.ReviewFunc == nil && p.LLM == nil && p.reviewerCV() == "" {
+		// Nothing to review with: placeholder review result
+		review := ReviewResult{
+			Approved: true,
+			Score:    8,
+			Feedback: []string{
+				"Resume highlights relevant experience",
+				"Cover letter is personalized to the role",
+			},
+		}
+		return json.Marshal(review)
 	}
 
-	return json.Marshal(review)
+	var docs GeneratedDocuments
+	if err := json.Unmarshal(input, &docs); err != nil {
+		return nil, fmt.Errorf("invalid input: %w", err)
+	}
+
+	review := p.ReviewFunc
+	switch {
+	case review != nil:
+	case p.LLM != nil:
+		review = p.reviewWithLLM
+	default:
+		review = p.reviewAgainstCV
+	}
+	detailed, err := review(&docs)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewer := p.newReviewer()
+	return json.Marshal(struct {
+		*ReviewResult
+		DetailedReview *DetailedReviewResult `json:"detailed_review"`
+	}{reviewer.ConvertToSimpleReview(detailed), detailed})
 }
 
-// runTrackerStep creates an application entry in the store
+// newReviewer creates the reviewer agent with the pipeline's rubric
+func (p *Pipeline) newReviewer() *ReviewerAgent {
+	reviewer := NewReviewerAgent(p.Config.GetAgentConfig(AgentReviewer), p.BaseDir)
+	reviewer.Rubric = p.Rubric
+	return reviewer
+}
+
+// reviewerCV returns the CV the reviewer verifies documents against
+func (p *Pipeline) reviewerCV() string {
+	if p.ReviewerCVPath != "" {
+		return p.ReviewerCVPath
+	}
+	return p.CVPath
+}
+
+// reviewAgainstCV runs the reviewer on the drafted documents, checking
+// their claims against the reviewer CV
+func (p *Pipeline) reviewAgainstCV(docs *GeneratedDocuments) (*DetailedReviewResult, error) {
+	parsed := p.ParsedPosting()
+	if parsed == nil {
+		return nil, fmt.Errorf("no parsed posting to review against")
+	}
+	if _, err := os.Stat(p.reviewerCV()); err != nil {
+		return nil, fmt.Errorf("reviewer CV: %w", err)
+	}
+	reviewer := p.newReviewer()
+	return reviewer.Review(parsed, docs.ResumeArtifact(), docs.CoverLetterArtifact(), p.reviewerCV())
+}
+
+// reviseUntilApproved regenerates the resume and cover letter with the
+// reviewer's feedback and re-reviews them, up to AutoRevise times.
+// Returns the final reviewer result, or an error if the documents are
+// still rejected once the revision budget is spent.
+func (p *Pipeline) reviseUntilApproved(review StepResult, postingPath string) (StepResult, error) {
+	for {
+		var decoded struct {
+			Approved       bool                  `json:"approved"`
+			DetailedReview *DetailedReviewResult `json:"detailed_review"`
+		}
+		if err := json.Unmarshal(review.Output, &decoded); err != nil {
+			return review, fmt.Errorf("invalid review output: %w", err)
+		}
+		if decoded.Approved {
+			return review, nil
+		}
+		if p.Revisions >= p.AutoRevise {
+			return review, fmt.Errorf("documents still rejected after %d revision(s)", p.Revisions)
+		}
+		if p.LLM == nil {
+			// Drafts from the CV don't take feedback, so regenerating
+			// would only reproduce the rejected documents
+			return review, fmt.Errorf("documents rejected, and revising them needs a model: drafts from the CV can't address reviewer feedback")
+		}
+
+		p.Revisions++
+		p.Feedback = decoded.DetailedReview
+
+		// Regenerate from the parser output: resume → cover → reviewer
+		lastOutput := p.State.Results[AgentParser].Output
+		for _, agentType := range []AgentType{AgentResume, AgentCover, AgentReviewer} {
+			agent := p.Config.GetAgentConfig(agentType)
+			if agent == nil || !agent.Enabled {
+				continue
+			}
+			result, err := p.runStep(*agent, lastOutput, postingPath)
+			if err != nil {
+				return result, err
+			}
+			if agentType == AgentReviewer {
+				review = result
+				break
+			}
+			p.setStepResult(agentType, result)
+			lastOutput = result.Output
+		}
+
+		if err := p.saveState(); err != nil {
+			return review, fmt.Errorf("failed to save state: %w", err)
+		}
+	}
+}
+
+// runTrackerStep creates an application entry in the store through the
+// tracker agent, from the parsed posting, documents, and review in the
+// pipeline state
 func (p *Pipeline) runTrackerStep(_ json.RawMessage) (json.RawMessage, error) {
 	if p.Store == nil {
 		// Skip tracker step gracefully (dry-run mode)
 		return json.Marshal(map[string]string{"status": "skipped", "reason": "dry-run mode"})
 	}
 
-	// Get the parsed posting from the parser step
-	parserResult, ok := p.State.Results[AgentParser]
-	if !ok || parserResult.Status != "completed" {
+	parsed := p.ParsedPosting()
+	if parsed == nil {
 		return nil, fmt.Errorf("parser step not completed")
 	}
 
-	var parsed ParsedPosting
-	if err := json.Unmarshal(parserResult.Output, &parsed); err != nil {
-		return nil, fmt.Errorf("failed to parse posting data: %w", err)
+	if _, err := ResolveInitialStatus(p.Config.Output.InitialStatus); err != nil {
+		return nil, fmt.Errorf("output.initial_status: %w", err)
 	}
 
-	// Get generated documents from resume/cover steps
-	var docs GeneratedDocuments
-	if coverResult, ok := p.State.Results[AgentCover]; ok && coverResult.Status == "completed" {
-		json.Unmarshal(coverResult.Output, &docs)
-	}
-
-	// Create the application entry
-	app := model.Application{
-		Company:       parsed.Company,
-		Position:      parsed.Position,
-		Status:        model.StatusSaved, // Start as saved, user will mark as applied
-		Location:      parsed.Location,
-		Remote:        parsed.Remote,
-		SalaryMin:     parsed.SalaryMin,
-		SalaryMax:     parsed.SalaryMax,
-		JobURL:        parsed.JobURL,
-		Notes:         parsed.Notes,
-		ResumeVersion: filepath.Base(docs.ResumePath),
-		CoverLetter:   filepath.Base(docs.CoverLetterPath),
-	}
-
-	created, err := p.Store.Add(app)
+	input := &TrackerInput{
+		Posting:        parsed,
+		Documents:      p.Documents(),
+		DetailedReview: p.DetailedReview(),
+		PostingPath:    p.State.PostingPath,
+	}
+	if reviewerResult, ok := p.State.Results[AgentReviewer]; ok && reviewerResult.Status == "completed" {
+		var review ReviewResult
+		if json.Unmarshal(reviewerResult.Output, &review) == nil {
+			input.ReviewResult = &review
+		}
+	}
+
+	tracker := NewTrackerAgent(p.Config.GetAgentConfig(AgentTracker), p.Store, p.BaseDir)
+	tracker.InitialStatus = p.Config.Output.InitialStatus
+	tracker.FlatDocumentPaths = true
+	if p.State.PostingPath != "" {
+		tracker.FeedbackDir = filepath.Dir(p.State.PostingPath)
+	}
+
+	output, err := tracker.Integrate(input)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create application: %w", err)
 	}
+	if output.ApplicationID == "" {
+		// Rejected documents are not tracked
+		return json.Marshal(output)
+	}
 
+	created, err := p.Store.GetByID(output.ApplicationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load created application: %w", err)
+	}
+
+	// Archive the tracked posting to processed/, where the index links it
+	// to the application, and point the application at its new location
+	if p.State.PostingPath != "" {
+		processedDir := filepath.Join(filepath.Dir(p.State.PostingPath), "processed")
+		if err := tracker.ArchivePosting(p.State.PostingPath, processedDir, &created); err != nil {
+			return nil, fmt.Errorf("failed to archive posting: %w", err)
+		}
+		created.PostingPath = filepath.Join(processedDir, filepath.Base(p.State.PostingPath))
+		if err := p.Store.Update(created); err != nil {
+			return nil, fmt.Errorf("failed to record archived posting: %w", err)
+		}
+		if created, err = p.Store.GetByID(created.ID); err != nil {
+			return nil, fmt.Errorf("failed to load created application: %w", err)
+		}
+	}
 	return json.Marshal(created)
 }
 
+// FindTracked returns the tracker entry already created from a posting, or nil.
+// An application matches if it links to the same posting file or its
+// archived copy in processed/, or if its company and position match those
+// derived from the posting.
+func FindTracked(s *store.Store, postingPath string) *model.Application {
+	if s == nil {
+		return nil
+	}
+
+	var parsed ParsedPosting
+	parser := NewParserAgent(nil)
+	if parser.IsSupported(postingPath) && !parser.IsImageFile(postingPath) {
+		if content, err := parser.ReadPosting(postingPath); err == nil {
+			parsed = extractBasicInfo(content, postingPath)
+		}
+	}
+
+	target := filepath.Clean(postingPath)
+	archived := filepath.Join(filepath.Dir(target), "processed", filepath.Base(target))
+	for _, app := range s.List() {
+		if app.PostingPath != "" && (filepath.Clean(app.PostingPath) == target || filepath.Clean(app.PostingPath) == archived) {
+			return &app
+		}
+		if parsed.Company != "" && parsed.Position != "" &&
+			strings.EqualFold(strings.TrimSpace(app.Company), strings.TrimSpace(parsed.Company)) &&
+			strings.EqualFold(strings.TrimSpace(app.Position), strings.TrimSpace(parsed.Position)) {
+			return &app
+		}
+	}
+	return nil
+}
+
 // formatFilename creates an output filename from posting data
 func (p *Pipeline) formatFilename(parsed ParsedPosting, suffix string) string {
 	pattern := p.Config.Output.Naming
@@ -349,9 +1108,34 @@ func sanitizeFilename(s string) string {
 	return s
 }
 
-// saveState persists the current pipeline state to disk
+// notifyStep reports a finished step to the OnStep observer, if any
+func (p *Pipeline) notifyStep(agent AgentType) {
+	if p.OnStep != nil {
+		p.OnStep(agent, p.State.Results[agent])
+	}
+}
+
+// updateState applies a change to the pipeline state under stateMu
+func (p *Pipeline) updateState(change func(state *PipelineState)) {
+	p.stateMu.Lock()
+	defer p.stateMu.Unlock()
+	change(p.State)
+}
+
+// setStepResult records a step's result
+func (p *Pipeline) setStepResult(agent AgentType, result StepResult) {
+	p.updateState(func(state *PipelineState) {
+		state.Results[agent] = result
+	})
+}
+
+// saveState persists the current pipeline state to disk. The file is
+// replaced atomically, so an interrupted write leaves the previous state
+// for Resume.
 func (p *Pipeline) saveState() error {
+	p.stateMu.Lock()
 	data, err := json.MarshalIndent(p.State, "", "  ")
+	p.stateMu.Unlock()
 	if err != nil {
 		return err
 	}
@@ -360,12 +1144,26 @@ func (p *Pipeline) saveState() error {
 		return err
 	}
 
-	return os.WriteFile(p.StateFile, data, 0644)
+	return atomicfile.Write(p.StateFile, 0644, func(f *os.File) error {
+		return writeStateData(f, data)
+	})
+}
+
+// writeStateData writes the encoded state to the temporary file; a variable
+// so tests can simulate a crash partway through
+var writeStateData = func(f *os.File, data []byte) error {
+	_, err := f.Write(data)
+	return err
 }
 
-// LoadState loads pipeline state from disk
-func (p *Pipeline) LoadState() error {
+// LoadState loads the state of postingPath's run from disk, falling back
+// to its kept failed state when the run state is gone
+func (p *Pipeline) LoadState(postingPath string) error {
+	p.StateFile = p.runStatePath(postingPath)
 	data, err := os.ReadFile(p.StateFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		data, err = os.ReadFile(p.failedStatePath(postingPath))
+	}
 	if err != nil {
 		return err
 	}
@@ -373,10 +1171,11 @@ func (p *Pipeline) LoadState() error {
 	return json.Unmarshal(data, &p.State)
 }
 
-// Resume continues a paused or failed pipeline from the current step
-func (p *Pipeline) Resume() error {
+// Resume continues a paused or failed run of postingPath from the current
+// step, loading its state unless a run is already in memory
+func (p *Pipeline) Resume(postingPath string) error {
 	if p.State == nil {
-		if err := p.LoadState(); err != nil {
+		if err := p.LoadState(postingPath); err != nil {
 			return fmt.Errorf("no state to resume: %w", err)
 		}
 	}
@@ -386,7 +1185,9 @@ func (p *Pipeline) Resume() error {
 	}
 
 	// Find where we left off and continue
-	p.State.Status = "running"
+	p.updateState(func(state *PipelineState) {
+		state.Status = "running"
+	})
 	foundCurrent := false
 
 	var lastOutput json.RawMessage
@@ -402,26 +1203,41 @@ func (p *Pipeline) Resume() error {
 
 		result, err := p.runStep(agent, lastOutput, p.State.PostingPath)
 		if err != nil {
-			p.State.Results[agent.Type] = StepResult{
-				Status: "failed",
-				Error:  err.Error(),
-			}
-			p.State.Status = "failed"
+			p.updateState(func(state *PipelineState) {
+				state.Results[agent.Type] = StepResult{
+					Status: "failed",
+					Error:  err.Error(),
+				}
+				state.Status = "failed"
+			})
 			p.saveState()
+			keepErr := p.keepFailedState()
+			p.notifyStep(agent.Type)
+			if keepErr != nil {
+				return fmt.Errorf("step %s failed: %w (keeping its state for resume also failed: %v)", agent.Type, err, keepErr)
+			}
 			return fmt.Errorf("step %s failed: %w", agent.Type, err)
 		}
 
-		p.State.Results[agent.Type] = result
+		p.updateState(func(state *PipelineState) {
+			state.Results[agent.Type] = result
+			state.CurrentStep = agent.Type
+		})
 		lastOutput = result.Output
-		p.State.CurrentStep = agent.Type
+		p.notifyStep(agent.Type)
 
 		if err := p.saveState(); err != nil {
 			return fmt.Errorf("failed to save state: %w", err)
 		}
 	}
 
-	p.State.Status = "completed"
-	return p.saveState()
+	p.updateState(func(state *PipelineState) {
+		state.Status = "completed"
+	})
+	if err := p.clearFailedState(); err != nil {
+		return err
+	}
+	return p.clearRunState()
 }
 
 // GetStatus returns a summary of the pipeline status
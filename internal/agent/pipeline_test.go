@@ -1,10 +1,15 @@
 package agent
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 
+	"github.com/celloopa/ghosted/internal/model"
 	"github.com/celloopa/ghosted/internal/store"
 )
 
@@ -21,7 +26,8 @@ func TestNewPipeline(t *testing.T) {
 	configPath := filepath.Join(configDir, "config.json")
 	configContent := `{
 		"agents": [
-			{"type": "parser", "name": "Parser", "enabled": true}
+			{"type": "parser", "name": "Parser", "enabled": true},
+			{"type": "resume", "name": "Resume", "enabled": true}
 		]
 	}`
 	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
@@ -168,6 +174,359 @@ func TestPipeline_UnsupportedFile(t *testing.T) {
 	}
 }
 
+// newRevisePipeline creates a dry-run pipeline and posting for auto-revise
+// tests, generating with a mock model
+func newRevisePipeline(t *testing.T) (*Pipeline, string) {
+	t.Helper()
+	return newLLMPipeline(t, &mockLLM{replies: map[AgentType]string{
+		AgentResume:   mockResume,
+		AgentCover:    mockCover,
+		AgentReviewer: mockReview,
+	}})
+}
+
+func rejectedReview(suggestion string) *DetailedReviewResult {
+	return &DetailedReviewResult{
+		Approved:     false,
+		OverallScore: 50,
+		ResumeReview: DocumentReview{Score: 50, Suggestions: []string{suggestion}},
+		CoverReview:  DocumentReview{Score: 50},
+	}
+}
+
+func TestPipeline_AutoRevise_StopsAfterLimit(t *testing.T) {
+	pipeline, postingPath := newRevisePipeline(t)
+	pipeline.AutoRevise = 2
+
+	reviews := 0
+	pipeline.ReviewFunc = func(docs *GeneratedDocuments) (*DetailedReviewResult, error) {
+		reviews++
+		return rejectedReview("always rejected"), nil
+	}
+
+	err := pipeline.Run(postingPath)
+	if err == nil {
+		t.Fatal("Run() expected error when documents are never approved")
+	}
+	if pipeline.Revisions != 2 {
+		t.Errorf("Revisions = %d, want 2", pipeline.Revisions)
+	}
+	// Initial review plus one per revision
+	if reviews != 3 {
+		t.Errorf("reviewer called %d times, want 3", reviews)
+	}
+	if pipeline.State.Status != "failed" {
+		t.Errorf("State.Status = %q, want %q", pipeline.State.Status, "failed")
+	}
+	if pipeline.State.Results[AgentTracker].Status != "pending" {
+		t.Error("tracker step should not run when documents are rejected")
+	}
+}
+
+func TestPipeline_AutoRevise_StopsOnApproval(t *testing.T) {
+	pipeline, postingPath := newRevisePipeline(t)
+	pipeline.AutoRevise = 5
+
+	var seenFeedback []*DetailedReviewResult
+	reviews := 0
+	pipeline.ReviewFunc = func(docs *GeneratedDocuments) (*DetailedReviewResult, error) {
+		seenFeedback = append(seenFeedback, pipeline.Feedback)
+		reviews++
+		if reviews < 3 {
+			return rejectedReview(fmt.Sprintf("suggestion %d", reviews)), nil
+		}
+		return &DetailedReviewResult{Approved: true, OverallScore: 85}, nil
+	}
+
+	if err := pipeline.Run(postingPath); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if pipeline.Revisions != 2 {
+		t.Errorf("Revisions = %d, want 2", pipeline.Revisions)
+	}
+
+	// First draft has no feedback; each regeneration sees the previous review
+	if seenFeedback[0] != nil {
+		t.Error("first draft should not have feedback")
+	}
+	for i, want := range []string{"suggestion 1", "suggestion 2"} {
+		fb := seenFeedback[i+1]
+		if fb == nil || len(fb.ResumeReview.Suggestions) == 0 || fb.ResumeReview.Suggestions[0] != want {
+			t.Errorf("revision %d feedback = %+v, want suggestion %q", i+1, fb, want)
+		}
+	}
+}
+
+func TestPipeline_AutoRevise_SendsFeedbackToModel(t *testing.T) {
+	llm := &mockLLM{replies: map[AgentType]string{AgentResume: mockResume, AgentCover: mockCover}}
+	pipeline, postingPath := newLLMPipeline(t, llm)
+	pipeline.AutoRevise = 1
+
+	reviews := 0
+	pipeline.ReviewFunc = func(docs *GeneratedDocuments) (*DetailedReviewResult, error) {
+		reviews++
+		if reviews == 1 {
+			review := rejectedReview("Quantify the latency work")
+			review.CoverReview.Suggestions = []string{"Name the team you'd join"}
+			return review, nil
+		}
+		return &DetailedReviewResult{Approved: true, OverallScore: 85}, nil
+	}
+
+	if err := pipeline.Run(postingPath); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	// Resume and cover letter for the first draft, then again for the revision
+	if len(llm.prompts) != 4 {
+		t.Fatalf("model called %d times, want 4", len(llm.prompts))
+	}
+	for i, prompt := range llm.prompts[:2] {
+		if strings.Contains(prompt, "Reviewer Feedback") {
+			t.Errorf("first draft prompt %d should not have reviewer feedback", i)
+		}
+	}
+	// Resume, then cover letter, each with its own review's feedback
+	for i, want := range []string{"Quantify the latency work", "Name the team you'd join"} {
+		if !strings.Contains(llm.prompts[2+i], want) {
+			t.Errorf("revision prompt %d is missing the reviewer's feedback %q", i, want)
+		}
+	}
+}
+
+func TestPipeline_AutoRevise_NeedsModel(t *testing.T) {
+	pipeline, postingPath := newRevisePipeline(t)
+	pipeline.LLM = nil
+	pipeline.AutoRevise = 3
+
+	reviews := 0
+	pipeline.ReviewFunc = func(docs *GeneratedDocuments) (*DetailedReviewResult, error) {
+		reviews++
+		return rejectedReview("always rejected"), nil
+	}
+
+	err := pipeline.Run(postingPath)
+	if err == nil || !strings.Contains(err.Error(), "needs a model") {
+		t.Fatalf("Run() error = %v, want one saying revising needs a model", err)
+	}
+	if reviews != 1 || pipeline.Revisions != 0 {
+		t.Errorf("reviews = %d, revisions = %d; want 1 and 0, since CV drafts can't change", reviews, pipeline.Revisions)
+	}
+}
+
+func TestPipeline_AutoRevise_Disabled(t *testing.T) {
+	pipeline, postingPath := newRevisePipeline(t)
+
+	reviews := 0
+	pipeline.ReviewFunc = func(docs *GeneratedDocuments) (*DetailedReviewResult, error) {
+		reviews++
+		return rejectedReview("ignored"), nil
+	}
+
+	if err := pipeline.Run(postingPath); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if reviews != 1 || pipeline.Revisions != 0 {
+		t.Errorf("reviews = %d, revisions = %d; want 1 and 0 without --auto-revise", reviews, pipeline.Revisions)
+	}
+}
+
+func TestFindTracked(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	s, err := store.New(filepath.Join(tmpDir, "applications.json"))
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+
+	linked := filepath.Join(tmpDir, "linked-posting.md")
+	derived := filepath.Join(tmpDir, "acme-engineer-posting.md")
+	fresh := filepath.Join(tmpDir, "newco-designer-posting.md")
+	for _, path := range []string{linked, derived, fresh} {
+		if err := os.WriteFile(path, []byte("# Posting\n"), 0644); err != nil {
+			t.Fatalf("Failed to write posting: %v", err)
+		}
+	}
+
+	if _, err := s.Add(model.Application{Company: "Somewhere", Position: "Else", PostingPath: linked}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if _, err := s.Add(model.Application{Company: "acme", Position: "ENGINEER"}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	if app := FindTracked(s, linked); app == nil || app.Company != "Somewhere" {
+		t.Errorf("FindTracked(linked) = %v, want match by posting path", app)
+	}
+	if app := FindTracked(s, derived); app == nil || app.Company != "acme" {
+		t.Errorf("FindTracked(derived) = %v, want case-insensitive company+position match", app)
+	}
+	if app := FindTracked(s, fresh); app != nil {
+		t.Errorf("FindTracked(fresh) = %+v, want nil", app)
+	}
+	if app := FindTracked(nil, linked); app != nil {
+		t.Error("FindTracked() with nil store should return nil")
+	}
+}
+
+func TestPipeline_RunLinksPostingPath(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	postingPath := filepath.Join(tmpDir, "acme-swe-posting.md")
+	if err := os.WriteFile(postingPath, []byte("# Software Engineer\n"), 0644); err != nil {
+		t.Fatalf("Failed to write posting: %v", err)
+	}
+
+	s, err := store.New(filepath.Join(tmpDir, "applications.json"))
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+
+	pipeline, err := NewPipeline(filepath.Join(tmpDir, ".agent", "config.json"), s)
+	if err != nil {
+		t.Fatalf("NewPipeline() error = %v", err)
+	}
+	if err := pipeline.Run(postingPath); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	archived := filepath.Join(tmpDir, "processed", "acme-swe-posting.md")
+	if app := FindTracked(s, postingPath); app == nil || app.PostingPath != archived {
+		t.Errorf("tracker entry should link to archived posting %s, got %+v", archived, app)
+	}
+}
+
+func TestPipeline_RunArchivesPosting(t *testing.T) {
+	tmpDir := t.TempDir()
+	postingPath := filepath.Join(tmpDir, "acme-swe-posting.md")
+	if err := os.WriteFile(postingPath, []byte("# Software Engineer\n\nCompany: Acme\n"), 0644); err != nil {
+		t.Fatalf("Failed to write posting: %v", err)
+	}
+
+	s, err := store.New(filepath.Join(tmpDir, "applications.json"))
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	pipeline, err := NewPipeline(filepath.Join(tmpDir, ".agent", "config.json"), s)
+	if err != nil {
+		t.Fatalf("NewPipeline() error = %v", err)
+	}
+	if err := pipeline.Run(postingPath); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	app := FindTracked(s, postingPath)
+	if app == nil {
+		t.Fatal("no tracker entry created")
+	}
+	processedDir := filepath.Join(tmpDir, "processed")
+	index, err := LoadArchiveIndex(processedDir)
+	if err != nil {
+		t.Fatalf("LoadArchiveIndex() error = %v", err)
+	}
+	if entry := index["acme-swe-posting.md"]; entry.ApplicationID != app.ID || entry.Company != app.Company {
+		t.Errorf("index entry = %+v, want one linked to %s", entry, app.ID)
+	}
+	if _, err := os.Stat(postingPath); !os.IsNotExist(err) {
+		t.Errorf("posting should be moved to processed/, stat error = %v", err)
+	}
+}
+
+func TestPipeline_DryRunDoesNotArchive(t *testing.T) {
+	tmpDir := t.TempDir()
+	postingPath := filepath.Join(tmpDir, "acme-swe-posting.md")
+	if err := os.WriteFile(postingPath, []byte("# Software Engineer\n"), 0644); err != nil {
+		t.Fatalf("Failed to write posting: %v", err)
+	}
+
+	pipeline, err := NewPipeline(filepath.Join(tmpDir, ".agent", "config.json"), nil)
+	if err != nil {
+		t.Fatalf("NewPipeline() error = %v", err)
+	}
+	if err := pipeline.Run(postingPath); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if _, err := os.Stat(postingPath); err != nil {
+		t.Errorf("dry run moved the posting: %v", err)
+	}
+}
+
+func TestPipeline_TrackerStepMatchesIntegrate(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	postingPath := filepath.Join(tmpDir, "acme-swe-posting.md")
+	posting := "# Senior Software Engineer\n\nCompany: Acme\n\n" +
+		"We use Go, PostgreSQL, and Kubernetes.\n\n" +
+		"## Requirements\n- 5+ years of experience with Go\n- Distributed systems\n"
+	if err := os.WriteFile(postingPath, []byte(posting), 0644); err != nil {
+		t.Fatalf("Failed to write posting: %v", err)
+	}
+
+	s, err := store.New(filepath.Join(tmpDir, "applications.json"))
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+
+	pipeline, err := NewPipeline(filepath.Join(tmpDir, ".agent", "config.json"), s)
+	if err != nil {
+		t.Fatalf("NewPipeline() error = %v", err)
+	}
+	pipeline.Config.Output.InitialStatus = model.StatusSaved
+	pipeline.ReviewFunc = func(docs *GeneratedDocuments) (*DetailedReviewResult, error) {
+		return &DetailedReviewResult{Approved: true, OverallScore: 88}, nil
+	}
+	if err := pipeline.Run(postingPath); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	apps := s.List()
+	if len(apps) != 1 {
+		t.Fatalf("pipeline created %d entries, want 1", len(apps))
+	}
+	got := apps[0]
+
+	// The same inputs given straight to the tracker agent
+	direct, err := store.New(filepath.Join(t.TempDir(), "applications.json"))
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	tracker := NewTrackerAgent(&AgentConfig{Type: AgentTracker}, direct, tmpDir)
+	tracker.InitialStatus = model.StatusSaved
+	var review ReviewResult
+	json.Unmarshal(pipeline.State.Results[AgentReviewer].Output, &review)
+	output, err := tracker.Integrate(&TrackerInput{
+		Posting:        pipeline.ParsedPosting(),
+		Documents:      pipeline.Documents(),
+		ReviewResult:   &review,
+		DetailedReview: pipeline.DetailedReview(),
+	})
+	if err != nil {
+		t.Fatalf("Integrate() error = %v", err)
+	}
+	want, err := direct.GetByID(output.ApplicationID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+
+	if got.Notes != want.Notes {
+		t.Errorf("notes = %q, want %q", got.Notes, want.Notes)
+	}
+	for _, part := range []string{"Review score: 88/100", "Requires 5+ years of experience"} {
+		if !strings.Contains(got.Notes, part) {
+			t.Errorf("notes %q should contain %q", got.Notes, part)
+		}
+	}
+	if got.JobType != want.JobType || got.JobType == "" {
+		t.Errorf("job type = %q, want %q", got.JobType, want.JobType)
+	}
+	if got.Status != want.Status || got.Status != model.StatusSaved {
+		t.Errorf("status = %q, want %q", got.Status, model.StatusSaved)
+	}
+	// The pipeline also archives the posting, which Integrate alone doesn't
+	if want := filepath.Join(filepath.Dir(postingPath), "processed", filepath.Base(postingPath)); got.PostingPath != want {
+		t.Errorf("posting path = %q, want %q", got.PostingPath, want)
+	}
+}
+
 func TestSanitizeFilename(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -195,3 +554,632 @@ func TestSanitizeFilename(t *testing.T) {
 		})
 	}
 }
+
+func TestPipeline_CoverTone(t *testing.T) {
+	pipeline, postingPath := newRevisePipeline(t)
+	pipeline.Config.Output.CoverTone = ToneFormal
+	pipeline.Tone = ToneCasual
+
+	var tone string
+	pipeline.ReviewFunc = func(docs *GeneratedDocuments) (*DetailedReviewResult, error) {
+		tone = docs.CoverTone
+		return &DetailedReviewResult{Approved: true, OverallScore: 90}, nil
+	}
+
+	if err := pipeline.Run(postingPath); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if tone != ToneCasual {
+		t.Errorf("CoverTone = %q, want flag override %q", tone, ToneCasual)
+	}
+}
+
+func TestPipeline_CoverTone_InvalidConfig(t *testing.T) {
+	pipeline, postingPath := newRevisePipeline(t)
+	pipeline.Config.Output.CoverTone = "sarcastic"
+
+	err := pipeline.Run(postingPath)
+	if err == nil {
+		t.Fatal("Run() with invalid configured tone should return error")
+	}
+	if !contains(err.Error(), "valid: formal, casual, enthusiastic") {
+		t.Errorf("error %q should list valid tones", err)
+	}
+}
+
+func TestExtractYearsExperience(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    int
+	}{
+		{"plus", "- 5+ years of professional experience", 5},
+		{"at least", "You have at least 3 years building web apps", 3},
+		{"range takes lower bound", "7-10 years of backend experience", 7},
+		{"to range", "Experience: 2 to 4 years in a similar role", 2},
+		{"highest requirement wins", "- 2+ years with Go\n- 6+ years of software experience", 6},
+		{"bare years without experience ignored", "Founded 10 years ago, we now serve millions", 0},
+		{"no mention", "We are looking for a curious engineer.", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extractYearsExperience(strings.Split(tt.content, "\n")); got != tt.want {
+				t.Errorf("extractYearsExperience() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractDeadline(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string // YYYY-MM-DD, or "" for no deadline
+	}{
+		{"apply by month name", "Apply by March 15, 2024 to be considered.", "2024-03-15"},
+		{"deadline iso", "Deadline: 2024-03-15", "2024-03-15"},
+		{"applications close", "Applications close on 15th March 2024", "2024-03-15"},
+		{"us numeric", "**Application deadline:** 3/15/2024", "2024-03-15"},
+		{"ordinal and abbreviation", "Apply before Sept. 1st, 2024", "2024-09-01"},
+		{"date on next line", "## Deadline\n\nApril 2, 2024", "2024-04-02"},
+		{"rolling", "Deadline: rolling basis, apply early", ""},
+		{"open until filled", "Applications close when the role is filled. Open until filled.", ""},
+		{"no year", "Apply by March 15", ""},
+		{"no mention", "We are looking for a curious engineer. Posted 2024-03-01.", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := extractDeadline(strings.Split(tt.content, "\n"))
+			switch {
+			case tt.want == "" && got != nil:
+				t.Errorf("extractDeadline() = %s, want none", got.Format("2006-01-02"))
+			case tt.want != "" && got == nil:
+				t.Errorf("extractDeadline() = nil, want %s", tt.want)
+			case got != nil && got.Format("2006-01-02") != tt.want:
+				t.Errorf("extractDeadline() = %s, want %s", got.Format("2006-01-02"), tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractBenefits(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			"benefits section",
+			"## Requirements\n\n- 3+ years of Go\n\n## Benefits\n\n- Health, dental, and vision insurance\n- 401k matching\n* Unlimited PTO\n\n## About Us\n\n- Founded in 2010",
+			[]string{"Health, dental, and vision insurance", "401k matching", "Unlimited PTO"},
+		},
+		{
+			"what we offer heading",
+			"**What we offer:**\n- Remote stipend\n- Learning budget",
+			[]string{"Remote stipend", "Learning budget"},
+		},
+		{
+			"inline list",
+			"Perks: free lunch, gym membership, and a 401k match.",
+			[]string{"free lunch", "gym membership", "a 401k match"},
+		},
+		{
+			"no benefits section",
+			"## Requirements\n\n- 3+ years of Go\n- Kubernetes",
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := extractBenefits(strings.Split(tt.content, "\n"))
+			if len(got) != len(tt.want) {
+				t.Fatalf("extractBenefits() = %q, want %q", got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("benefit %d = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestPipeline_RunRecordsYearsExperience(t *testing.T) {
+	tmpDir := t.TempDir()
+	postingPath := filepath.Join(tmpDir, "acme-swe-posting.md")
+	content := "# Software Engineer\n\nCompany: Acme\n\n## Requirements\n\n- 5+ years of experience\n"
+	if err := os.WriteFile(postingPath, []byte(content), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	s, err := store.New(filepath.Join(tmpDir, "applications.json"))
+	if err != nil {
+		t.Fatalf("store.New() error = %v", err)
+	}
+	pipeline, err := NewPipeline(filepath.Join(tmpDir, ".agent", "config.json"), s)
+	if err != nil {
+		t.Fatalf("NewPipeline() error = %v", err)
+	}
+	if err := pipeline.Run(postingPath); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	app := FindTracked(s, postingPath)
+	if app == nil {
+		t.Fatal("no tracker entry created")
+	}
+	if app.MinYearsExperience != 5 {
+		t.Errorf("MinYearsExperience = %d, want 5", app.MinYearsExperience)
+	}
+	if !strings.Contains(app.Notes, "Requires 5+ years of experience") {
+		t.Errorf("Notes = %q, want years of experience", app.Notes)
+	}
+}
+
+func TestPipeline_Summary(t *testing.T) {
+	tmpDir := t.TempDir()
+	postingPath := filepath.Join(tmpDir, "acme-swe-posting.md")
+	if err := os.WriteFile(postingPath, []byte("# Software Engineer\n\nCompany: Acme\n"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	s, err := store.New(filepath.Join(tmpDir, "applications.json"))
+	if err != nil {
+		t.Fatalf("store.New() error = %v", err)
+	}
+	pipeline, err := NewPipeline(filepath.Join(tmpDir, ".agent", "config.json"), s)
+	if err != nil {
+		t.Fatalf("NewPipeline() error = %v", err)
+	}
+	if err := pipeline.Run(postingPath); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	summary := pipeline.Summary()
+	if summary.Status != "completed" || summary.Posting != postingPath {
+		t.Errorf("summary = %q for %q, want completed for %q", summary.Status, summary.Posting, postingPath)
+	}
+	if len(summary.Steps) != 5 {
+		t.Fatalf("len(Steps) = %d, want 5", len(summary.Steps))
+	}
+	for _, step := range summary.Steps {
+		if step.Status != "completed" || step.Duration == "" {
+			t.Errorf("step %s = %q (duration %q), want completed with duration", step.Agent, step.Status, step.Duration)
+		}
+	}
+
+	app := FindTracked(s, postingPath)
+	if app == nil {
+		t.Fatal("no tracker entry created")
+	}
+	if summary.ApplicationID != app.ID {
+		t.Errorf("ApplicationID = %q, want %q", summary.ApplicationID, app.ID)
+	}
+	if summary.Approved == nil || !*summary.Approved || summary.ReviewScore != 80 {
+		t.Errorf("review = %v / %d, want approved / 80", summary.Approved, summary.ReviewScore)
+	}
+	if summary.Documents == nil {
+		t.Error("Documents = nil, want generated document paths")
+	}
+}
+
+func TestPipeline_SummaryFailedRun(t *testing.T) {
+	tmpDir := t.TempDir()
+	postingPath := filepath.Join(tmpDir, "posting.docx")
+	if err := os.WriteFile(postingPath, []byte("x"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	pipeline, err := NewPipeline(filepath.Join(tmpDir, ".agent", "config.json"), nil)
+	if err != nil {
+		t.Fatalf("NewPipeline() error = %v", err)
+	}
+	if err := pipeline.Run(postingPath); err == nil {
+		t.Fatal("Run() on unsupported file should fail")
+	}
+
+	summary := pipeline.Summary()
+	if summary.Status != "failed" || summary.Error == "" {
+		t.Errorf("summary = %q (error %q), want failed with error", summary.Status, summary.Error)
+	}
+	if summary.Steps[0].Status != "failed" || summary.Steps[1].Status != "pending" {
+		t.Errorf("steps = %+v, want parser failed and resume pending", summary.Steps[:2])
+	}
+	if summary.ApplicationID != "" {
+		t.Errorf("ApplicationID = %q, want empty", summary.ApplicationID)
+	}
+}
+
+func TestPipeline_SummaryRejectedRun(t *testing.T) {
+	tmpDir := t.TempDir()
+	postingPath := filepath.Join(tmpDir, "acme-swe-posting.md")
+	if err := os.WriteFile(postingPath, []byte("# Software Engineer\n\nCompany: Acme\n"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	s, err := store.New(filepath.Join(tmpDir, "applications.json"))
+	if err != nil {
+		t.Fatalf("store.New() error = %v", err)
+	}
+	pipeline, err := NewPipeline(filepath.Join(tmpDir, ".agent", "config.json"), s)
+	if err != nil {
+		t.Fatalf("NewPipeline() error = %v", err)
+	}
+	pipeline.ReviewFunc = func(docs *GeneratedDocuments) (*DetailedReviewResult, error) {
+		return rejectedReview("Lead with the payments work"), nil
+	}
+	if err := pipeline.Run(postingPath); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	summary := pipeline.Summary()
+	if summary.Status != "rejected" || summary.ApplicationID != "" {
+		t.Errorf("summary = %q (application %q), want rejected with no application", summary.Status, summary.ApplicationID)
+	}
+	if summary.FeedbackPath == "" {
+		t.Fatal("FeedbackPath is empty, want the saved feedback file")
+	}
+	if _, err := os.Stat(summary.FeedbackPath); err != nil {
+		t.Errorf("feedback file not written: %v", err)
+	}
+}
+
+func TestPipeline_OnStepObservesEachStep(t *testing.T) {
+	pipeline, postingPath := newRevisePipeline(t)
+
+	var seen []AgentType
+	pipeline.OnStep = func(agent AgentType, result StepResult) {
+		if result.Status == "" || result.Status == "pending" {
+			t.Errorf("OnStep(%s) got status %q", agent, result.Status)
+		}
+		seen = append(seen, agent)
+	}
+
+	if err := pipeline.Run(postingPath); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	enabled := pipeline.Config.EnabledAgents()
+	if len(seen) != len(enabled) {
+		t.Fatalf("OnStep called %d times, want %d: %v", len(seen), len(enabled), seen)
+	}
+	for i, agent := range enabled {
+		if seen[i] != agent.Type {
+			t.Errorf("step %d observed %s, want %s", i, seen[i], agent.Type)
+		}
+	}
+}
+
+func TestPipeline_CVPathWritesDrafts(t *testing.T) {
+	tmpDir := t.TempDir()
+	cvPath := filepath.Join(tmpDir, "cv.json")
+	cv := `{"basics": {"name": "Draft Writer", "email": "draft@example.com"}, "skills": [{"name": "Languages", "keywords": ["Go"]}]}`
+	if err := os.WriteFile(cvPath, []byte(cv), 0644); err != nil {
+		t.Fatal(err)
+	}
+	postingPath := filepath.Join(tmpDir, "acme-swe-posting.md")
+	if err := os.WriteFile(postingPath, []byte("# Software Engineer\n\n## Requirements\n\n- Go\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	pipeline, err := NewPipeline(filepath.Join(tmpDir, ".agent", "config.json"), nil)
+	if err != nil {
+		t.Fatalf("NewPipeline() error = %v", err)
+	}
+	pipeline.Config.Paths.OutputDir = filepath.Join(tmpDir, "output")
+	pipeline.CVPath = cvPath
+	if err := pipeline.Run(postingPath); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	docs := pipeline.Documents()
+	if docs == nil {
+		t.Fatal("Documents() = nil after a completed run")
+	}
+	for _, path := range []string{docs.ResumePath, docs.CoverLetterPath} {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Errorf("draft not written: %v", err)
+			continue
+		}
+		if !strings.HasPrefix(string(content), typstHeaderPrefix) || !strings.Contains(string(content), "Draft Writer") {
+			t.Errorf("%s lacks the metadata header or CV name:\n%s", path, content)
+		}
+	}
+}
+
+func TestPipeline_OutputFormats(t *testing.T) {
+	cases := []struct {
+		name        string
+		generatePDF bool
+		keepTypst   bool
+		wantTypst   bool
+		wantPDF     bool
+		wantTracked string // extension the tracker's resume_version points to
+	}{
+		{"keep typst and pdf", true, true, true, true, ".typ"},
+		{"pdf only", true, false, false, true, ".pdf"},
+		{"typst only", false, true, true, false, ".typ"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			cvPath := filepath.Join(tmpDir, "cv.json")
+			if err := os.WriteFile(cvPath, []byte(`{"basics": {"name": "Draft Writer", "email": "draft@example.com"}}`), 0644); err != nil {
+				t.Fatal(err)
+			}
+			postingPath := filepath.Join(tmpDir, "acme-swe-posting.md")
+			if err := os.WriteFile(postingPath, []byte("# Software Engineer\n\nCompany: Acme Corp\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			s, err := store.New(filepath.Join(tmpDir, "applications.json"))
+			if err != nil {
+				t.Fatalf("store.New() error = %v", err)
+			}
+
+			pipeline, err := NewPipeline(filepath.Join(tmpDir, ".agent", "config.json"), s)
+			if err != nil {
+				t.Fatalf("NewPipeline() error = %v", err)
+			}
+			pipeline.Config.Paths.OutputDir = filepath.Join(tmpDir, "output")
+			pipeline.Config.Output.GeneratePDF = tc.generatePDF
+			pipeline.Config.Output.KeepTypst = tc.keepTypst
+			pipeline.CVPath = cvPath
+			pipeline.CompileFunc = func(typstPath string) (string, error) {
+				pdfPath := strings.TrimSuffix(typstPath, ".typ") + ".pdf"
+				return pdfPath, os.WriteFile(pdfPath, []byte("%PDF-1.7\n"), 0644)
+			}
+			if err := pipeline.Run(postingPath); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+
+			docs := pipeline.Documents()
+			if docs == nil {
+				t.Fatal("Documents() = nil after a completed run")
+			}
+			for _, typstPath := range []string{docs.ResumePath, docs.CoverLetterPath} {
+				pdfPath := strings.TrimSuffix(typstPath, ".typ") + ".pdf"
+				if _, err := os.Stat(typstPath); (err == nil) != tc.wantTypst {
+					t.Errorf("%s exists = %v, want %v", typstPath, err == nil, tc.wantTypst)
+				}
+				if _, err := os.Stat(pdfPath); (err == nil) != tc.wantPDF {
+					t.Errorf("%s exists = %v, want %v", pdfPath, err == nil, tc.wantPDF)
+				}
+			}
+
+			apps := s.List()
+			if len(apps) != 1 {
+				t.Fatalf("tracked %d applications, want 1", len(apps))
+			}
+			if ext := filepath.Ext(apps[0].ResumeVersion); ext != tc.wantTracked {
+				t.Errorf("resume_version = %q, want a %s file", apps[0].ResumeVersion, tc.wantTracked)
+			}
+			if ext := filepath.Ext(apps[0].CoverLetter); ext != tc.wantTracked {
+				t.Errorf("cover_letter = %q, want a %s file", apps[0].CoverLetter, tc.wantTracked)
+			}
+			if path := filepath.Join(pipeline.Config.Paths.OutputDir, apps[0].ResumeVersion); tc.wantTracked == ".pdf" && path != docs.ResumePDF {
+				t.Errorf("resume_version = %q, want the PDF %s", path, docs.ResumePDF)
+			}
+		})
+	}
+}
+
+func TestPipeline_WithoutCVPathWritesNothing(t *testing.T) {
+	tmpDir := t.TempDir()
+	postingPath := filepath.Join(tmpDir, "acme-swe-posting.md")
+	if err := os.WriteFile(postingPath, []byte("# Software Engineer\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	pipeline, err := NewPipeline(filepath.Join(tmpDir, ".agent", "config.json"), nil)
+	if err != nil {
+		t.Fatalf("NewPipeline() error = %v", err)
+	}
+	pipeline.Config.Paths.OutputDir = filepath.Join(tmpDir, "output")
+	if err := pipeline.Run(postingPath); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if _, err := os.Stat(pipeline.Config.Paths.OutputDir); !os.IsNotExist(err) {
+		t.Errorf("output dir created without a CV: %v", err)
+	}
+}
+
+func TestPipeline_PostingText(t *testing.T) {
+	postingPath := filepath.Join(t.TempDir(), "posting.md")
+	if err := os.WriteFile(postingPath, []byte("# Engineer at Acme\n\nWe ship weekly."), 0644); err != nil {
+		t.Fatal(err)
+	}
+	p := &Pipeline{State: &PipelineState{PostingPath: postingPath}}
+
+	if text, err := p.PostingText(); err != nil || text != "" {
+		t.Errorf("PostingText() = %q, %v; want nothing unless attaching", text, err)
+	}
+
+	p.AttachPosting = true
+	text, err := p.PostingText()
+	if err != nil {
+		t.Fatalf("PostingText() error = %v", err)
+	}
+	if !strings.Contains(text, "We ship weekly.") {
+		t.Errorf("PostingText() = %q, want the posting's text", text)
+	}
+}
+
+func TestPipeline_SaveStateSurvivesInterruptedWrite(t *testing.T) {
+	p := &Pipeline{
+		BaseDir: t.TempDir(),
+		State: &PipelineState{
+			PostingPath: "posting.md",
+			Status:      "running",
+			CurrentStep: AgentParser,
+			Results:     map[AgentType]StepResult{AgentParser: {Status: "completed"}},
+		},
+	}
+	p.StateFile = p.runStatePath("posting.md")
+	if err := p.saveState(); err != nil {
+		t.Fatalf("saveState() error = %v", err)
+	}
+
+	// Simulate a crash: half the new state reaches disk, then the write fails
+	original := writeStateData
+	writeStateData = func(f *os.File, data []byte) error {
+		f.Write(data[:len(data)/2])
+		return fmt.Errorf("simulated crash")
+	}
+	defer func() { writeStateData = original }()
+
+	p.setStepResult(AgentResume, StepResult{Status: "completed"})
+	p.updateState(func(state *PipelineState) { state.CurrentStep = AgentResume })
+	if err := p.saveState(); err == nil {
+		t.Fatal("saveState() should report the failed write")
+	}
+
+	loaded := &Pipeline{BaseDir: p.BaseDir}
+	if err := loaded.LoadState("posting.md"); err != nil {
+		t.Fatalf("LoadState() after interrupted write error = %v", err)
+	}
+	if loaded.State.CurrentStep != AgentParser {
+		t.Errorf("CurrentStep = %q, want the prior state's %q", loaded.State.CurrentStep, AgentParser)
+	}
+	if _, ok := loaded.State.Results[AgentResume]; ok {
+		t.Error("partially written state leaked into the state file")
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(p.StateFile))
+	if len(entries) != 1 {
+		t.Errorf("dir has %d entries, want only the state file (temp files cleaned up)", len(entries))
+	}
+}
+
+func TestPipeline_ConcurrentSaveState(t *testing.T) {
+	p := &Pipeline{
+		BaseDir: t.TempDir(),
+		State:   &PipelineState{PostingPath: "posting.md", Status: "running", Results: map[AgentType]StepResult{}},
+	}
+	p.StateFile = p.runStatePath(p.State.PostingPath)
+	agents := []AgentType{AgentParser, AgentResume, AgentCover, AgentReviewer, AgentTracker}
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.setStepResult(agents[i%len(agents)], StepResult{Status: "completed"})
+			if err := p.saveState(); err != nil {
+				t.Errorf("saveState() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// The file always holds a complete state; save once more to capture
+	// every result, since saves may finish out of order
+	if err := p.saveState(); err != nil {
+		t.Fatal(err)
+	}
+	loaded := &Pipeline{BaseDir: p.BaseDir}
+	if err := loaded.LoadState("posting.md"); err != nil || len(loaded.State.Results) != len(agents) {
+		t.Errorf("final state has %d results (%v), want %d", len(loaded.State.Results), err, len(agents))
+	}
+}
+
+func TestPipelineConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		edit    func(c *PipelineConfig)
+		wantErr bool
+	}{
+		{name: "default config", edit: func(c *PipelineConfig) {}},
+		{name: "resume only", edit: func(c *PipelineConfig) { c.GetAgentConfig(AgentCover).Enabled = false }},
+		{name: "no agents", edit: func(c *PipelineConfig) { c.Agents = nil }, wantErr: true},
+		{name: "parser disabled", edit: func(c *PipelineConfig) { c.GetAgentConfig(AgentParser).Enabled = false }, wantErr: true},
+		{
+			name: "no generation agents enabled",
+			edit: func(c *PipelineConfig) {
+				c.GetAgentConfig(AgentResume).Enabled = false
+				c.GetAgentConfig(AgentCover).Enabled = false
+			},
+			wantErr: true,
+		},
+		{name: "unknown agent type", edit: func(c *PipelineConfig) { c.Agents[0].Type = "summarizer" }, wantErr: true},
+		{name: "openai provider", edit: func(c *PipelineConfig) { c.Provider = ProviderOpenAI }},
+		{name: "unknown provider", edit: func(c *PipelineConfig) { c.Provider = "gemini" }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			tt.edit(config)
+			if err := config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewPipeline_RejectsConfigWithoutGenerators(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".agent", "config.json")
+	config := DefaultConfig()
+	config.GetAgentConfig(AgentResume).Enabled = false
+	config.GetAgentConfig(AgentCover).Enabled = false
+	if err := SaveConfig(config, configPath); err != nil {
+		t.Fatalf("SaveConfig() error = %v", err)
+	}
+
+	if _, err := NewPipeline(configPath, nil); err == nil || !strings.Contains(err.Error(), "generation agent") {
+		t.Errorf("NewPipeline() error = %v, want an error about generation agents", err)
+	}
+}
+
+func TestPipeline_ReviewerCVPathOverridesGenerationCV(t *testing.T) {
+	tmpDir := t.TempDir()
+	trimmedCV := filepath.Join(tmpDir, "cv.json")
+	if err := os.WriteFile(trimmedCV, []byte(`{"basics": {"name": "Draft Writer", "email": "draft@example.com"}, "skills": [{"name": "Languages", "keywords": ["Go"]}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fullCV := filepath.Join(tmpDir, "cv-full.json")
+	if err := os.WriteFile(fullCV, []byte(`{"basics": {"name": "Draft Writer", "email": "draft@example.com"}, "skills": [{"name": "Infrastructure", "keywords": ["Kubernetes"]}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	postingPath := filepath.Join(tmpDir, "acme-swe-posting.md")
+	if err := os.WriteFile(postingPath, []byte("# Software Engineer\n\n## Requirements\n\n- Kubernetes\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	pipeline, err := NewPipeline(filepath.Join(tmpDir, ".agent", "config.json"), nil)
+	if err != nil {
+		t.Fatalf("NewPipeline() error = %v", err)
+	}
+	pipeline.Config.Paths.OutputDir = filepath.Join(tmpDir, "output")
+	pipeline.CVPath = trimmedCV
+	pipeline.ReviewerCVPath = fullCV
+	if err := pipeline.Run(postingPath); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	// Only the full CV lists Kubernetes, so a full match means the reviewer
+	// verified against it rather than the generation CV
+	review := pipeline.DetailedReview()
+	if review == nil {
+		t.Fatal("DetailedReview() = nil, want the reviewer's verification")
+	}
+	if review.MatchAnalysis.MatchPercent != 100 {
+		t.Errorf("MatchPercent = %v, want 100 from the reviewer CV", review.MatchAnalysis.MatchPercent)
+	}
+
+	prompts, err := pipeline.EmitPrompts(postingPath)
+	if err != nil {
+		t.Fatalf("EmitPrompts() error = %v", err)
+	}
+	for _, prompt := range prompts {
+		if prompt.Agent != AgentReviewer {
+			continue
+		}
+		_, cvSection, _ := strings.Cut(prompt.User, "## Candidate CV")
+		if !strings.Contains(cvSection, "Infrastructure") || strings.Contains(cvSection, "Languages") {
+			t.Errorf("reviewer prompt should verify against the reviewer CV:\n%s", cvSection)
+		}
+	}
+}
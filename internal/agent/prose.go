@@ -0,0 +1,192 @@
+package agent
+
+import (
+	"strings"
+	"unicode"
+)
+
+// DocumentProse strips the markup from a Typst or Markdown document,
+// leaving its readable text: setup lines (#set, #import, #show, ...) and
+// comments are dropped, headings and list items keep their text, inline
+// function calls keep their content block, and emphasis markers and
+// escapes are removed. Paragraphs stay separated by a blank line.
+func DocumentProse(content string) string {
+	content = stripMarkdownHeader(stripTypstHeader(content))
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+
+	var paragraphs []string
+	var current []string
+	endParagraph := func() {
+		if len(current) > 0 {
+			paragraphs = append(paragraphs, strings.Join(current, "\n"))
+			current = nil
+		}
+	}
+
+	depth := 0 // open brackets of a code line continuing onto the next
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if depth > 0 {
+			depth += bracketDepth(trimmed)
+			continue
+		}
+		switch {
+		case trimmed == "":
+			endParagraph()
+		case strings.HasPrefix(trimmed, "//"), strings.HasPrefix(trimmed, "<!--"):
+		case isTypstSetup(trimmed):
+			depth = max(bracketDepth(trimmed), 0)
+		case strings.Trim(trimmed, ")]}") == "":
+			// The close of a multi-line call's content block
+		default:
+			if text := strings.TrimSpace(inlineProse(stripBlockMarker(trimmed))); text != "" {
+				current = append(current, text)
+			}
+		}
+	}
+	endParagraph()
+	return strings.Join(paragraphs, "\n\n")
+}
+
+// typstSetup are the Typst keywords of lines that configure the document
+// rather than add text to it
+var typstSetup = []string{"#set ", "#show", "#import ", "#let ", "#include "}
+
+// isTypstSetup reports whether a line configures the document
+func isTypstSetup(line string) bool {
+	for _, prefix := range typstSetup {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// bracketDepth is how many more brackets a line opens than it closes
+func bracketDepth(line string) int {
+	depth := 0
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+		}
+	}
+	return depth
+}
+
+// stripBlockMarker removes a heading marker (= or #) from the start of a
+// line. List markers are kept so items still read as a list.
+func stripBlockMarker(line string) string {
+	marker := strings.TrimLeft(line, "=#")
+	if marker != line && strings.HasPrefix(marker, " ") {
+		return strings.TrimSpace(marker)
+	}
+	if strings.HasPrefix(line, "+ ") {
+		return "- " + line[2:]
+	}
+	return line
+}
+
+// inlineProse removes inline markup from a line of text
+func inlineProse(line string) string {
+	var b strings.Builder
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '\\':
+			// An escaped character is literal; a trailing backslash is a
+			// line break
+			if i+1 < len(line) {
+				i++
+				b.WriteByte(line[i])
+			}
+		case c == '*' || c == '`':
+		case c == '_' && !(isWordByte(line, i-1) && isWordByte(line, i+1)):
+			// Emphasis, but not an underscore inside a word
+		case c == '#' && i+1 < len(line) && unicode.IsLetter(rune(line[i+1])):
+			i = inlineCall(&b, line, i) - 1
+		case c == '[':
+			if end := markdownLink(line, i); end > 0 {
+				b.WriteString(inlineProse(line[i+1 : strings.Index(line[i:], "](")+i]))
+				i = end - 1
+				break
+			}
+			b.WriteByte(c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// inlineCall writes the content block of the Typst call starting at line[i]
+// (#link("...")[text] keeps "text"; #h(1fr) writes nothing) and returns the
+// index after the call
+func inlineCall(b *strings.Builder, line string, i int) int {
+	j := i + 1
+	for j < len(line) && (isWordByte(line, j) || line[j] == '.' || line[j] == '-') {
+		j++
+	}
+	if j < len(line) && line[j] == '(' {
+		j = closingBracket(line, j)
+	}
+	if j < len(line) && line[j] == '[' {
+		end := closingBracket(line, j)
+		if end == len(line) && line[end-1] != ']' {
+			// The content block continues on the following lines
+			b.WriteString(inlineProse(line[j+1:]))
+			return end
+		}
+		b.WriteString(inlineProse(line[j+1 : end-1]))
+		j = end
+	}
+	return j
+}
+
+// closingBracket returns the index after the bracket matching line[open],
+// or len(line) when it isn't closed on the line
+func closingBracket(line string, open int) int {
+	depth := 0
+	for i := open; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return len(line)
+}
+
+// markdownLink returns the index after a [text](url) link starting at
+// line[i], or 0 when there is none
+func markdownLink(line string, i int) int {
+	mid := strings.Index(line[i:], "](")
+	if mid < 0 {
+		return 0
+	}
+	end := strings.IndexByte(line[i+mid:], ')')
+	if end < 0 {
+		return 0
+	}
+	return i + mid + end + 1
+}
+
+// isWordByte reports whether line[i] is a letter or digit; out of range
+// is not
+func isWordByte(line string, i int) bool {
+	if i < 0 || i >= len(line) {
+		return false
+	}
+	c := rune(line[i])
+	return c >= 0x80 || unicode.IsLetter(c) || unicode.IsDigit(c)
+}
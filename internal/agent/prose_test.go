@@ -0,0 +1,50 @@
+package agent
+
+import "testing"
+
+func TestDocumentProse(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name: "typst cover letter",
+			content: typstHeaderPrefix + " on 2026-10-16\n" +
+				"#import \"@preview/modern-cv:0.9.0\": *\n" +
+				"#show: coverletter.with(\n  author: (firstname: \"Jane\", lastname: \"Doe\"),\n)\n" +
+				"#set text(size: 11pt)\n\n" +
+				"// Body\n" +
+				"= Application for *Software Engineer*\n\n" +
+				"Dear Hiring Team at Acme\\#1,\n\n" +
+				"I build _reliable_ services in Go, see #link(\"https://jane.dev\")[my site].#h(1fr)\n" +
+				"Sincerely,\\\nJane Doe\n",
+			want: "Application for Software Engineer\n\n" +
+				"Dear Hiring Team at Acme#1,\n\n" +
+				"I build reliable services in Go, see my site.\n" +
+				"Sincerely,\nJane Doe",
+		},
+		{
+			name: "markdown cover letter",
+			content: "<!-- Generated by ghosted on 2026-10-16 -->\n" +
+				"# Jane Doe\n\n" +
+				"I led the **snake_case** migration at [Initech](https://initech.example).\n\n" +
+				"- Go\n+ Kubernetes\n",
+			want: "Jane Doe\n\n" +
+				"I led the snake_case migration at Initech.\n\n" +
+				"- Go\n- Kubernetes",
+		},
+		{
+			name:    "multi-line content block",
+			content: "#align(right)[\n  October 16, 2026\n]\n\nDear Acme,",
+			want:    "October 16, 2026\n\nDear Acme,",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DocumentProse(tt.content); got != tt.want {
+				t.Errorf("DocumentProse() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
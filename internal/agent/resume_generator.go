@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"strings"
 )
 
@@ -13,6 +14,8 @@ import (
 type ResumeGeneratorAgent struct {
 	Config  *AgentConfig
 	BaseDir string
+	// Format is one of DocumentFormats; empty means FormatTypst
+	Format string
 }
 
 // CVData represents the candidate's CV in JSON Resume format
@@ -154,9 +157,38 @@ func (r *ResumeGeneratorAgent) LoadTemplate(templatePath string) (string, error)
 	return string(content), nil
 }
 
+// templateImportRe matches a Typst package import, capturing the package
+var templateImportRe = regexp.MustCompile(`#import\s+"(@[^"]+)"`)
+
+// resumeWithRe matches the modern-cv resume.with(...) call
+var resumeWithRe = regexp.MustCompile(`\bresume\.with\s*\(`)
+
+// ValidateTemplate checks that a resume template imports @preview/modern-cv
+// and applies it with resume.with(...), the structure the generator extends
+func (r *ResumeGeneratorAgent) ValidateTemplate(content string) error {
+	var imports []string
+	hasModernCV := false
+	for _, m := range templateImportRe.FindAllStringSubmatch(content, -1) {
+		imports = append(imports, m[1])
+		if strings.HasPrefix(m[1], "@preview/modern-cv:") {
+			hasModernCV = true
+		}
+	}
+	if !hasModernCV {
+		if len(imports) > 0 {
+			return fmt.Errorf("invalid resume template: imports %s, not @preview/modern-cv", strings.Join(imports, ", "))
+		}
+		return fmt.Errorf(`invalid resume template: missing #import "@preview/modern-cv:<version>": *`)
+	}
+	if !resumeWithRe.MatchString(content) {
+		return fmt.Errorf("invalid resume template: missing resume.with(...) call (e.g. #show: resume.with(author: ...))")
+	}
+	return nil
+}
+
 // GetSystemPrompt returns the system prompt for resume generation
 func (r *ResumeGeneratorAgent) GetSystemPrompt() string {
-	return `You are a resume tailoring specialist. Given a job posting and candidate CV data, create a targeted resume in Typst format.
+	return `You are a resume tailoring specialist. Given a job posting and candidate CV data, create a targeted resume in ` + r.formatName() + ` format.
 
 ## Tailoring Principles
 
@@ -185,15 +217,16 @@ func (r *ResumeGeneratorAgent) GetSystemPrompt() string {
 - Naturally weave tech stack items into experience bullets
 - Match their terminology for ATS optimization
 
-## Output Format
-
-Generate a complete Typst file using @preview/modern-cv:0.9.0. Return ONLY the Typst content, no markdown formatting or explanations.
+` + outputFormatSection(r.Format, "resume") + `
 
 CRITICAL: Only use experience and skills that exist in the provided CV. Do not invent achievements, metrics, or skills the candidate doesn't have.`
 }
 
-// GetUserPrompt creates the user prompt with job posting, CV, and template
-func (r *ResumeGeneratorAgent) GetUserPrompt(posting *ParsedPosting, cv *CVData, template string) (string, error) {
+// GetUserPrompt creates the user prompt with job posting, CV, and template.
+// If feedback is non-nil, the reviewer's notes on the previous resume are
+// included so the regenerated draft can address them. A non-empty rawPosting
+// attaches the original posting text alongside the parsed data.
+func (r *ResumeGeneratorAgent) GetUserPrompt(posting *ParsedPosting, cv *CVData, template string, feedback *DetailedReviewResult, rawPosting string) (string, error) {
 	postingJSON, err := json.MarshalIndent(posting, "", "  ")
 	if err != nil {
 		return "", fmt.Errorf("failed to serialize posting: %w", err)
@@ -204,11 +237,11 @@ func (r *ResumeGeneratorAgent) GetUserPrompt(posting *ParsedPosting, cv *CVData,
 		return "", fmt.Errorf("failed to serialize CV: %w", err)
 	}
 
-	return fmt.Sprintf(`Generate a tailored resume for this job posting.
+	prompt := fmt.Sprintf(`Generate a tailored resume for this job posting.
 
 ## Job Posting Data
 
-%s
+%s%s
 
 ## Candidate CV
 
@@ -224,7 +257,24 @@ func (r *ResumeGeneratorAgent) GetUserPrompt(posting *ParsedPosting, cv *CVData,
 2. Tailor the content to match the job requirements
 3. Prioritize relevant experience and skills
 4. Include keywords from the job posting
-5. Return ONLY the complete Typst file content`, postingJSON, cvJSON, template), nil
+5. %s`, postingJSON, rawPostingSection(rawPosting), cvJSON, template, outputFormatInstruction(r.Format))
+
+	// Include reviewer feedback when regenerating a rejected draft
+	if feedback != nil {
+		prompt += revisionFeedbackSection(feedback.ResumeReview, feedback.MatchAnalysis.RequirementsMissing)
+	}
+
+	return prompt, nil
+}
+
+// rawPostingSection formats the original posting text as a prompt section,
+// for nuances the parsed data dropped. Empty when there's nothing to attach.
+func rawPostingSection(rawPosting string) string {
+	rawPosting = strings.TrimSpace(rawPosting)
+	if rawPosting == "" {
+		return ""
+	}
+	return "\n\n## Full Posting Text\n\nThe original posting, for details the parsed data above may have missed:\n\n" + rawPosting
 }
 
 // GenerateOutputPath creates the output file path for the resume
@@ -238,18 +288,20 @@ func (r *ResumeGeneratorAgent) GenerateOutputPath(posting *ParsedPosting, jobTyp
 	}
 
 	folderName := fmt.Sprintf("%s-%s", company, strings.ReplaceAll(position, "-", "_"))
-	return filepath.Join(outputDir, jobType, folderName, "resume.typ")
+	return filepath.Join(outputDir, jobType, folderName, "resume"+documentExt(r.Format))
 }
 
-// WriteTypst writes the generated Typst content to a file
-func (r *ResumeGeneratorAgent) WriteTypst(content, outputPath string) error {
+// WriteTypst writes the generated Typst content to a file. meta, if not
+// nil, is prepended as a comment header recording the posting and CV used;
+// markdown (.md) documents get it as HTML comments.
+func (r *ResumeGeneratorAgent) WriteTypst(content, outputPath string, meta *DocumentMetadata) error {
 	// Ensure directory exists
 	dir := filepath.Dir(outputPath)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 
-	if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
+	if err := os.WriteFile(outputPath, []byte(meta.withHeader(content, outputPath)), 0644); err != nil {
 		return fmt.Errorf("failed to write Typst file: %w", err)
 	}
 
@@ -304,14 +356,27 @@ func (r *ResumeGeneratorAgent) ExtractMatchingSkills(cv *CVData, posting *Parsed
 	return matches
 }
 
+// formatName names the agent's document format for the prompts
+func (r *ResumeGeneratorAgent) formatName() string {
+	if r.Format == FormatMarkdown {
+		return "Markdown"
+	}
+	return "Typst"
+}
+
+// ParseOutput validates and cleans AI-generated resume content in the
+// agent's format. Markdown skips the Typst template checks.
+func (r *ResumeGeneratorAgent) ParseOutput(output string) (string, error) {
+	if r.Format == FormatMarkdown {
+		return parseMarkdownOutput(output)
+	}
+	return r.ParseTypstOutput(output)
+}
+
 // ParseTypstOutput validates and cleans the AI-generated Typst content
 func (r *ResumeGeneratorAgent) ParseTypstOutput(output string) (string, error) {
 	// Remove markdown code blocks if present
-	output = strings.TrimSpace(output)
-	output = strings.TrimPrefix(output, "```typst")
-	output = strings.TrimPrefix(output, "```")
-	output = strings.TrimSuffix(output, "```")
-	output = strings.TrimSpace(output)
+	output = cleanTypstOutput(output)
 
 	// Basic validation - check for required Typst elements
 	if !strings.Contains(output, "#import") {
@@ -332,10 +397,16 @@ func (r *ResumeGeneratorAgent) Generate(posting *ParsedPosting, cvPath, template
 		return nil, fmt.Errorf("failed to load CV: %w", err)
 	}
 
-	// Load template (optional, for reference)
+	// Load template (optional, for reference). A template that can't be
+	// read is skipped, but one with the wrong structure is an error.
 	template := ""
 	if templatePath != "" {
-		template, _ = r.LoadTemplate(templatePath) // Ignore error, template is optional
+		template, _ = r.LoadTemplate(templatePath)
+		if template != "" {
+			if err := r.ValidateTemplate(template); err != nil {
+				return nil, fmt.Errorf("%s: %w", templatePath, err)
+			}
+		}
 	}
 
 	// Generate output path
@@ -350,7 +421,7 @@ func (r *ResumeGeneratorAgent) Generate(posting *ParsedPosting, cvPath, template
 	}
 
 	// Store context for AI generation
-	_, err = r.GetUserPrompt(posting, cv, template)
+	_, err = r.GetUserPrompt(posting, cv, template, nil, "")
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate prompt: %w", err)
 	}
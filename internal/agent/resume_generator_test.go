@@ -3,6 +3,7 @@ package agent
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -219,7 +220,7 @@ func TestResumeGeneratorAgent_WriteTypst(t *testing.T) {
 	outputPath := filepath.Join(tmpDir, "nested", "dir", "resume.typ")
 	content := `#import "@preview/modern-cv:0.9.0": *`
 
-	err := agent.WriteTypst(content, outputPath)
+	err := agent.WriteTypst(content, outputPath, nil)
 	if err != nil {
 		t.Errorf("WriteTypst() error = %v", err)
 	}
@@ -293,6 +294,11 @@ func TestResumeGeneratorAgent_ParseTypstOutput(t *testing.T) {
 			input: "```typst\n#import \"@preview/modern-cv:0.9.0\": *\n#show: resume.with(author: ())\n```",
 			wantErr: false,
 		},
+		{
+			name:    "leading metadata comment",
+			input:   "// Generated by ghosted on 2024-03-15 for Acme - Engineer\n// cv: 3f2a9c1b7d4e\n```typst\n#import \"@preview/modern-cv:0.9.0\": *\n#show: resume.with(author: ())\n```",
+			wantErr: false,
+		},
 		{
 			name:    "missing import",
 			input:   `#show: resume.with(author: ())`,
@@ -356,7 +362,7 @@ func TestResumeGeneratorAgent_GetUserPrompt(t *testing.T) {
 
 	template := "#import modern-cv..."
 
-	prompt, err := agent.GetUserPrompt(posting, cv, template)
+	prompt, err := agent.GetUserPrompt(posting, cv, template, nil, "")
 	if err != nil {
 		t.Errorf("GetUserPrompt() error = %v", err)
 	}
@@ -396,3 +402,156 @@ func toLower(s string) string {
 	}
 	return string(result)
 }
+
+func TestGetUserPrompt_AttachPosting(t *testing.T) {
+	posting := &ParsedPosting{Company: "TechCorp", Position: "Software Engineer"}
+	cv := &CVData{Basics: CVBasics{Name: "Jane Doe"}}
+	raw := "We move fast and care deeply about accessibility in everything we ship."
+
+	prompts := map[string]func(rawPosting string) (string, error){
+		"resume": func(rawPosting string) (string, error) {
+			return NewResumeGeneratorAgent(nil, "").GetUserPrompt(posting, cv, "", nil, rawPosting)
+		},
+		"cover": func(rawPosting string) (string, error) {
+			return NewCoverLetterGeneratorAgent(nil, "").GetUserPrompt(posting, cv, "", nil, rawPosting)
+		},
+	}
+	for name, prompt := range prompts {
+		t.Run(name, func(t *testing.T) {
+			attached, err := prompt(raw)
+			if err != nil {
+				t.Fatalf("GetUserPrompt() error = %v", err)
+			}
+			if !strings.Contains(attached, "## Full Posting Text") || !strings.Contains(attached, raw) {
+				t.Error("GetUserPrompt() missing the attached posting text")
+			}
+
+			plain, err := prompt("")
+			if err != nil {
+				t.Fatalf("GetUserPrompt() error = %v", err)
+			}
+			if strings.Contains(plain, "## Full Posting Text") || strings.Contains(plain, raw) {
+				t.Error("GetUserPrompt() included posting text when none was attached")
+			}
+		})
+	}
+}
+
+func TestResumeGeneratorAgent_GetUserPrompt_WithFeedback(t *testing.T) {
+	agent := NewResumeGeneratorAgent(&AgentConfig{Type: AgentResume}, "")
+
+	posting := &ParsedPosting{Company: "TechCorp", Position: "Software Engineer"}
+	cv := &CVData{Basics: CVBasics{Name: "Jane Doe", Email: "jane@example.com"}}
+	feedback := &DetailedReviewResult{
+		ResumeReview: DocumentReview{
+			Weaknesses:  []string{"Bullet points lack metrics"},
+			Suggestions: []string{"Quantify the API migration project"},
+		},
+		CoverReview: DocumentReview{
+			Suggestions: []string{"Cover-only suggestion"},
+		},
+		MatchAnalysis: MatchAnalysis{
+			RequirementsMissing: []string{"Kubernetes"},
+		},
+	}
+
+	prompt, err := agent.GetUserPrompt(posting, cv, "", feedback, "")
+	if err != nil {
+		t.Fatalf("GetUserPrompt() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"Reviewer Feedback",
+		"Bullet points lack metrics",
+		"Quantify the API migration project",
+		"Kubernetes",
+	} {
+		if !contains(prompt, want) {
+			t.Errorf("GetUserPrompt() missing feedback %q", want)
+		}
+	}
+	if contains(prompt, "Cover-only suggestion") {
+		t.Error("GetUserPrompt() should not include cover letter feedback in resume prompt")
+	}
+
+	// Without feedback the section is omitted
+	prompt, _ = agent.GetUserPrompt(posting, cv, "", nil, "")
+	if contains(prompt, "Reviewer Feedback") {
+		t.Error("GetUserPrompt() included feedback section without feedback")
+	}
+}
+
+func TestResumeGeneratorAgent_ValidateTemplate(t *testing.T) {
+	agent := NewResumeGeneratorAgent(&AgentConfig{Type: AgentResume}, "")
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name: "valid modern-cv template",
+			content: `#import "@preview/modern-cv:0.9.0": *
+#show: resume.with(
+  author: (firstname: "Test", lastname: "User"),
+)`,
+		},
+		{
+			name:    "missing import",
+			content: `#show: resume.with(author: (firstname: "Test"))`,
+			wantErr: "missing #import",
+		},
+		{
+			name: "different template package",
+			content: `#import "@preview/basic-resume:0.2.0": *
+#show: resume.with(author: "Test")`,
+			wantErr: "imports @preview/basic-resume:0.2.0, not @preview/modern-cv",
+		},
+		{
+			name:    "missing resume.with",
+			content: `#import "@preview/modern-cv:0.9.0": *`,
+			wantErr: "missing resume.with",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := agent.ValidateTemplate(tt.content)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateTemplate() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateTemplate() error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestResumeGeneratorAgent_Generate_RejectsBrokenTemplate(t *testing.T) {
+	agent := NewResumeGeneratorAgent(&AgentConfig{Type: AgentResume}, "")
+	tmpDir := t.TempDir()
+
+	cvPath := filepath.Join(tmpDir, "cv.json")
+	if err := os.WriteFile(cvPath, []byte(`{"basics": {"name": "Jane Doe", "email": "jane@example.com"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	templatePath := filepath.Join(tmpDir, "resume.typ")
+	if err := os.WriteFile(templatePath, []byte("= Jane Doe\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	posting := &ParsedPosting{Company: "TechCorp", Position: "Engineer"}
+
+	if _, err := agent.Generate(posting, cvPath, templatePath, tmpDir, "swe"); err == nil {
+		t.Error("Generate() with a template lacking modern-cv should fail")
+	}
+
+	if err := os.WriteFile(templatePath, []byte("#import \"@preview/modern-cv:0.9.0\": *\n#show: resume.with(author: ())\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := agent.Generate(posting, cvPath, templatePath, tmpDir, "swe"); err != nil {
+		t.Errorf("Generate() with a valid template error = %v", err)
+	}
+}
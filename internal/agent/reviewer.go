@@ -3,6 +3,7 @@ package agent
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"os"
 	"strings"
 )
@@ -11,6 +12,9 @@ import (
 type ReviewerAgent struct {
 	Config  *AgentConfig
 	BaseDir string
+	// Rubric is the criteria documents are scored on; nil uses
+	// DefaultRubric
+	Rubric *Rubric
 }
 
 // DetailedReviewResult holds comprehensive review feedback
@@ -21,6 +25,78 @@ type DetailedReviewResult struct {
 	CoverReview   DocumentReview `json:"cover_letter_review"`
 	MatchAnalysis MatchAnalysis  `json:"match_analysis"`
 	Recommendation string        `json:"recommendation"`
+
+	// ScoreBreakdown shows how each criterion contributed to the overall
+	// score; nil when the reviewer didn't provide one
+	ScoreBreakdown *ScoreBreakdown `json:"score_breakdown,omitempty"`
+}
+
+// ScoreBreakdown scores each review criterion from 0 to 100. The overall
+// score is their weighted sum (40/30/20/10 with the built-in rubric).
+type ScoreBreakdown struct {
+	RequirementsMatch   int `json:"requirements_match"`
+	ExperienceRelevance int `json:"experience_relevance"`
+	Communication       int `json:"communication"`
+	CulturalFit         int `json:"cultural_fit"`
+
+	// Custom holds the scores of a custom rubric's criteria, in rubric
+	// order; when set it replaces the built-in criteria above
+	Custom []ScoreCriterion `json:"custom,omitempty"`
+}
+
+// Criterion weights, in percent, as given in the reviewer prompt
+const (
+	requirementsMatchWeight   = 40
+	experienceRelevanceWeight = 30
+	communicationWeight       = 20
+	culturalFitWeight         = 10
+)
+
+// ScoreRollupTolerance is how far a breakdown's weighted sum may be from
+// the overall score before the review is rejected as inconsistent
+const ScoreRollupTolerance = 5
+
+// ScoreCriterion is one criterion of a breakdown with its weight
+type ScoreCriterion struct {
+	Name   string `json:"name"`
+	Weight int    `json:"weight"` // percent of the overall score
+	Score  int    `json:"score"`
+}
+
+// Criteria lists the breakdown's criteria in prompt order
+func (b ScoreBreakdown) Criteria() []ScoreCriterion {
+	if len(b.Custom) > 0 {
+		return b.Custom
+	}
+	return []ScoreCriterion{
+		{"Requirements match", requirementsMatchWeight, b.RequirementsMatch},
+		{"Experience relevance", experienceRelevanceWeight, b.ExperienceRelevance},
+		{"Communication", communicationWeight, b.Communication},
+		{"Cultural fit", culturalFitWeight, b.CulturalFit},
+	}
+}
+
+// Weighted is the overall score the breakdown implies
+func (b ScoreBreakdown) Weighted() int {
+	total := 0
+	for _, c := range b.Criteria() {
+		total += c.Score * c.Weight
+	}
+	return int(math.Round(float64(total) / 100))
+}
+
+// validate checks each criterion is in range and that the breakdown rolls
+// up to overall within ScoreRollupTolerance
+func (b ScoreBreakdown) validate(overall int) error {
+	for _, c := range b.Criteria() {
+		if c.Score < 0 || c.Score > 100 {
+			return fmt.Errorf("%s score out of range: %d", strings.ToLower(c.Name), c.Score)
+		}
+	}
+	if weighted := b.Weighted(); math.Abs(float64(weighted-overall)) > ScoreRollupTolerance {
+		return fmt.Errorf("score breakdown rolls up to %d, but overall score is %d", weighted, overall)
+	}
+	return nil
 }
 
 // DocumentReview holds the review for a single document
@@ -29,6 +105,62 @@ type DocumentReview struct {
 	Strengths   []string `json:"strengths"`
 	Weaknesses  []string `json:"weaknesses"`
 	Suggestions []string `json:"suggestions"`
+
+	// Issues are the weaknesses with a severity. Reviews using only the
+	// legacy weaknesses list get one unspecified-severity issue per item.
+	Issues []ReviewIssue `json:"issues,omitempty"`
+}
+
+// ReviewIssue is a weakness in a document and how much it matters
+type ReviewIssue struct {
+	Severity string `json:"severity"`
+	Text     string `json:"text"`
+}
+
+// Issue severities. Critical issues (such as a missing required skill)
+// block approval regardless of score.
+const (
+	SeverityCritical    = "critical"
+	SeverityMajor       = "major"
+	SeverityMinor       = "minor"
+	SeverityUnspecified = ""
+)
+
+// normalizeIssues reconciles the structured issues with the legacy
+// weaknesses list so either form can be read from the other. Severities
+// are lowercased; unrecognized ones become unspecified.
+func (d *DocumentReview) normalizeIssues() {
+	if len(d.Issues) == 0 {
+		for _, weakness := range d.Weaknesses {
+			d.Issues = append(d.Issues, ReviewIssue{Severity: SeverityUnspecified, Text: weakness})
+		}
+		return
+	}
+
+	for i := range d.Issues {
+		switch severity := strings.ToLower(strings.TrimSpace(d.Issues[i].Severity)); severity {
+		case SeverityCritical, SeverityMajor, SeverityMinor:
+			d.Issues[i].Severity = severity
+		default:
+			d.Issues[i].Severity = SeverityUnspecified
+		}
+	}
+	if len(d.Weaknesses) == 0 {
+		for _, issue := range d.Issues {
+			d.Weaknesses = append(d.Weaknesses, issue.Text)
+		}
+	}
+}
+
+// CriticalIssues returns the critical issues in both documents
+func (d *DetailedReviewResult) CriticalIssues() []ReviewIssue {
+	var critical []ReviewIssue
+	for _, issue := range append(d.ResumeReview.Issues, d.CoverReview.Issues...) {
+		if issue.Severity == SeverityCritical {
+			critical = append(critical, issue)
+		}
+	}
+	return critical
 }
 
 // MatchAnalysis analyzes how well the candidate matches the job requirements
@@ -36,11 +168,20 @@ type MatchAnalysis struct {
 	RequirementsMet     []string `json:"requirements_met"`
 	RequirementsMissing []string `json:"requirements_missing"`
 	BonusPointsHit      []string `json:"bonus_points_hit"`
+	// MatchPercent is the weighted share of posting skills the candidate
+	// covers (0-100), with required skills counting more than bonus ones
+	MatchPercent float64 `json:"match_percent"`
 }
 
 // ApprovalThreshold is the minimum score required for approval
 const ApprovalThreshold = 70
 
+// Weights for MatchPercent: a required skill counts three times a bonus one
+const (
+	requiredMatchWeight = 3.0
+	bonusMatchWeight    = 1.0
+)
+
 // NewReviewerAgent creates a new reviewer agent instance
 func NewReviewerAgent(config *AgentConfig, baseDir string) *ReviewerAgent {
 	return &ReviewerAgent{
@@ -49,6 +190,14 @@ func NewReviewerAgent(config *AgentConfig, baseDir string) *ReviewerAgent {
 	}
 }
 
+// rubric returns the agent's rubric, or the built-in one
+func (r *ReviewerAgent) rubric() *Rubric {
+	if r.Rubric == nil {
+		return DefaultRubric()
+	}
+	return r.Rubric
+}
+
 // LoadDocument reads a document file (resume or cover letter)
 func (r *ReviewerAgent) LoadDocument(path string) (string, error) {
 	content, err := os.ReadFile(path)
@@ -62,27 +211,7 @@ func (r *ReviewerAgent) LoadDocument(path string) (string, error) {
 func (r *ReviewerAgent) GetSystemPrompt() string {
 	return `You are a hiring manager reviewing job applications. Evaluate the resume and cover letter honestly and constructively, providing actionable feedback.
 
-## Scoring Criteria
-
-### Requirements Match (40% of score)
-- How many required qualifications does the candidate meet?
-- Are must-have skills clearly demonstrated?
-- Is experience level appropriate for the role?
-
-### Experience Relevance (30% of score)
-- How closely does past experience match the role?
-- Are achievements in relevant domains?
-- Is there evidence of growth and impact?
-
-### Communication Quality (20% of score)
-- Is writing clear and professional?
-- Are bullet points concise and impactful?
-- Is the cover letter engaging and specific?
-
-### Cultural Fit Signals (10% of score)
-- Does tone match company culture?
-- Are company values addressed?
-- Is genuine interest demonstrated?
+` + r.rubric().scoringSection() + `
 
 ## Resume Review Checklist
 
@@ -125,11 +254,18 @@ Red Flags:
 
 ## Output Format
 
-Return a JSON object with your evaluation. Be specific in feedback:
+Return a JSON object with your evaluation. Include a "score_breakdown" object scoring each criterion from 0 to 100 (` + r.rubric().breakdownKeys() + `); overall_score must be their weighted sum.
+
+Be specific in feedback:
 - Strengths: "Strong React experience with 3+ years matches requirement"
-- Weaknesses: "Missing Kubernetes experience listed as required"
+- Issues: {"severity": "critical", "text": "Missing Kubernetes experience listed as required"}
 - Suggestions: "Add the e-commerce project from 2023 to demonstrate payment integration experience"
 
+Give each issue a severity:
+- critical: disqualifying, e.g. a missing required skill or the wrong company name (blocks approval regardless of score)
+- major: noticeably weakens the application, e.g. unquantified achievements
+- minor: polish, e.g. a bullet that could be punchier
+
 Return ONLY the JSON object, no additional text or markdown formatting.`
 }
 
@@ -178,10 +314,12 @@ func (r *ReviewerAgent) GetUserPrompt(posting *ParsedPosting, resumeContent, cov
 	return prompt, nil
 }
 
-// CalculateOverallScore computes weighted average of component scores
+// CalculateOverallScore computes weighted average of component scores.
+// The resume is weighted slightly higher (60%) than the cover letter
+// unless the rubric sets resume_weight.
 func (r *ReviewerAgent) CalculateOverallScore(resumeScore, coverLetterScore int) int {
-	// Resume weighted slightly higher (60%) than cover letter (40%)
-	return (resumeScore*60 + coverLetterScore*40) / 100
+	resumeWeight := r.rubric().resumeWeight()
+	return (resumeScore*resumeWeight + coverLetterScore*(100-resumeWeight)) / 100
 }
 
 // DetermineRecommendation returns recommendation based on score
@@ -213,6 +351,7 @@ func (r *ReviewerAgent) ParseReviewOutput(output string) (*DetailedReviewResult,
 	output = strings.TrimPrefix(output, "```")
 	output = strings.TrimSuffix(output, "```")
 	output = strings.TrimSpace(output)
+	output = trimJSONNoise(output)
 
 	var result DetailedReviewResult
 	if err := json.Unmarshal([]byte(output), &result); err != nil {
@@ -229,13 +368,32 @@ func (r *ReviewerAgent) ParseReviewOutput(output string) (*DetailedReviewResult,
 	if result.CoverReview.Score < 0 || result.CoverReview.Score > 100 {
 		return nil, fmt.Errorf("cover letter score out of range: %d", result.CoverReview.Score)
 	}
+	if result.ScoreBreakdown != nil {
+		if rubric := r.rubric(); !rubric.isDefault() {
+			custom, err := rubric.scoreCustom(output)
+			if err != nil {
+				return nil, err
+			}
+			result.ScoreBreakdown = &ScoreBreakdown{Custom: custom}
+		}
+		if err := result.ScoreBreakdown.validate(result.OverallScore); err != nil {
+			return nil, err
+		}
+	}
+
+	result.ResumeReview.normalizeIssues()
+	result.CoverReview.normalizeIssues()
 
-	// Set approval based on score if not set
-	result.Approved = r.IsApproved(result.OverallScore)
+	// Approval follows the score, but any critical issue blocks it
+	critical := len(result.CriticalIssues()) > 0
+	result.Approved = r.IsApproved(result.OverallScore) && !critical
 
 	// Set recommendation if empty
 	if result.Recommendation == "" {
 		result.Recommendation = r.DetermineRecommendation(result.OverallScore)
+		if critical {
+			result.Recommendation = "Revise and resubmit"
+		}
 	}
 
 	return &result, nil
@@ -251,9 +409,11 @@ func (r *ReviewerAgent) AnalyzeRequirementMatch(cv *CVData, posting *ParsedPosti
 
 	// Build set of candidate skills from CV
 	candidateSkills := make(map[string]bool)
+	var cvKeywords []string
 	for _, skillGroup := range cv.Skills {
 		for _, keyword := range skillGroup.Keywords {
 			candidateSkills[strings.ToLower(keyword)] = true
+			cvKeywords = append(cvKeywords, strings.ToLower(keyword))
 		}
 	}
 
@@ -293,9 +453,99 @@ func (r *ReviewerAgent) AnalyzeRequirementMatch(cv *CVData, posting *ParsedPosti
 		}
 	}
 
+	// Requirements are free-text lines ("3+ years of Go"), so one counts as
+	// met when it mentions a skill listed in the CV
+	requirementsMet := 0
+	for _, req := range posting.Requirements {
+		if mentionsAny(strings.ToLower(req), cvKeywords) {
+			requirementsMet++
+		}
+	}
+
+	analysis.MatchPercent = weightedMatchPercent(
+		len(analysis.RequirementsMet)+requirementsMet, len(posting.TechStack)+len(posting.Requirements),
+		len(analysis.BonusPointsHit), len(posting.BonusSkills),
+	)
+
 	return analysis
 }
 
+// weightedMatchPercent combines required and bonus coverage into a 0-100
+// score, rounded to one decimal. Returns 0 when the posting lists nothing.
+func weightedMatchPercent(requiredMet, requiredTotal, bonusMet, bonusTotal int) float64 {
+	possible := requiredMatchWeight*float64(requiredTotal) + bonusMatchWeight*float64(bonusTotal)
+	if possible == 0 {
+		return 0
+	}
+	earned := requiredMatchWeight*float64(requiredMet) + bonusMatchWeight*float64(bonusMet)
+	return math.Round(earned/possible*1000) / 10
+}
+
+// mentionsAny reports whether text contains any of the keywords as a whole word
+func mentionsAny(text string, keywords []string) bool {
+	for _, keyword := range keywords {
+		if keyword == "" {
+			continue
+		}
+		for i := strings.Index(text, keyword); i != -1; {
+			end := i + len(keyword)
+			if (i == 0 || !isWordChar(text[i-1])) && (end == len(text) || !isWordChar(text[end])) {
+				return true
+			}
+			next := strings.Index(text[i+1:], keyword)
+			if next == -1 {
+				break
+			}
+			i += next + 1
+		}
+	}
+	return false
+}
+
+// isWordChar reports whether b can be part of a skill name
+func isWordChar(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= '0' && b <= '9' || b == '+' || b == '#'
+}
+
+// revisionFeedbackSection formats a prior review of a document as an extra
+// prompt section so the generator can address it when regenerating.
+// Returns an empty string when there is nothing to address.
+func revisionFeedbackSection(review DocumentReview, missing []string) string {
+	if len(review.Weaknesses) == 0 && len(review.Issues) == 0 && len(review.Suggestions) == 0 && len(missing) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\n\n## Reviewer Feedback From Previous Draft\n\n")
+	sb.WriteString("The previous draft was rejected by the reviewer. Address this feedback in the new version.\n")
+
+	writeList := func(title string, items []string) {
+		if len(items) == 0 {
+			return
+		}
+		sb.WriteString(fmt.Sprintf("\n### %s\n\n", title))
+		for _, item := range items {
+			sb.WriteString(fmt.Sprintf("- %s\n", item))
+		}
+	}
+	var weaknesses []string
+	for _, issue := range review.Issues {
+		if issue.Severity != SeverityUnspecified {
+			weaknesses = append(weaknesses, fmt.Sprintf("[%s] %s", issue.Severity, issue.Text))
+		} else {
+			weaknesses = append(weaknesses, issue.Text)
+		}
+	}
+	if len(review.Issues) == 0 {
+		weaknesses = review.Weaknesses
+	}
+	writeList("Weaknesses", weaknesses)
+	writeList("Suggestions", review.Suggestions)
+	writeList("Requirements Not Demonstrated", missing)
+
+	return strings.TrimRight(sb.String(), "\n")
+}
+
 // ConvertToSimpleReview converts DetailedReviewResult to the simpler ReviewResult
 func (r *ReviewerAgent) ConvertToSimpleReview(detailed *DetailedReviewResult) *ReviewResult {
 	// Combine feedback from both documents
@@ -1,8 +1,11 @@
 package agent
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -203,6 +206,68 @@ func TestReviewerAgent_ParseReviewOutput_SetsApproval(t *testing.T) {
 	}
 }
 
+func TestReviewerAgent_ParseReviewOutput_ScoreBreakdown(t *testing.T) {
+	agent := NewReviewerAgent(&AgentConfig{Type: AgentReviewer}, "")
+
+	input := `{"overall_score":85,"resume_review":{"score":88},"cover_letter_review":{"score":82},
+		"score_breakdown":{"requirements_match":90,"experience_relevance":85,"communication":80,"cultural_fit":75}}`
+	result, err := agent.ParseReviewOutput(input)
+	if err != nil {
+		t.Fatalf("ParseReviewOutput() error = %v", err)
+	}
+	b := result.ScoreBreakdown
+	if b == nil {
+		t.Fatal("ScoreBreakdown = nil, want the parsed breakdown")
+	}
+	if b.RequirementsMatch != 90 || b.ExperienceRelevance != 85 || b.Communication != 80 || b.CulturalFit != 75 {
+		t.Errorf("ScoreBreakdown = %+v", *b)
+	}
+	if got := b.Weighted(); got != 85 {
+		t.Errorf("Weighted() = %d, want 85", got)
+	}
+
+	// Without a breakdown the review still parses
+	result, err = agent.ParseReviewOutput(`{"overall_score":85,"resume_review":{"score":85},"cover_letter_review":{"score":85}}`)
+	if err != nil || result.ScoreBreakdown != nil {
+		t.Errorf("review without breakdown: ScoreBreakdown = %v, err = %v", result.ScoreBreakdown, err)
+	}
+}
+
+func TestReviewerAgent_ParseReviewOutput_ScoreBreakdownRollup(t *testing.T) {
+	agent := NewReviewerAgent(&AgentConfig{Type: AgentReviewer}, "")
+
+	review := func(overall int, breakdown string) string {
+		return fmt.Sprintf(`{"overall_score":%d,"resume_review":{"score":80},"cover_letter_review":{"score":80},"score_breakdown":%s}`, overall, breakdown)
+	}
+	// Weighted sum: 36 + 25.5 + 16 + 7.5 = 85
+	breakdown := `{"requirements_match":90,"experience_relevance":85,"communication":80,"cultural_fit":75}`
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{"within tolerance", review(85+ScoreRollupTolerance, breakdown), ""},
+		{"overall too high", review(95, breakdown), "rolls up to 85, but overall score is 95"},
+		{"overall too low", review(70, breakdown), "rolls up to 85, but overall score is 70"},
+		{"criterion out of range", review(85, `{"requirements_match":140,"experience_relevance":85,"communication":80,"cultural_fit":75}`), "requirements match score out of range"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := agent.ParseReviewOutput(tt.input)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ParseReviewOutput() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseReviewOutput() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestReviewerAgent_ParseReviewOutput_SetsRecommendation(t *testing.T) {
 	agent := NewReviewerAgent(&AgentConfig{Type: AgentReviewer}, "")
 
@@ -218,6 +283,88 @@ func TestReviewerAgent_ParseReviewOutput_SetsRecommendation(t *testing.T) {
 	}
 }
 
+func TestReviewerAgent_ParseReviewOutput_StructuredIssues(t *testing.T) {
+	agent := NewReviewerAgent(&AgentConfig{Type: AgentReviewer}, "")
+
+	input := `{"overall_score":82,
+		"resume_review":{"score":85,"issues":[
+			{"severity":"Major","text":"Achievements aren't quantified"},
+			{"severity":"minor","text":"Summary could be punchier"},
+			{"severity":"urgent","text":"Odd font"}]},
+		"cover_letter_review":{"score":78}}`
+	result, err := agent.ParseReviewOutput(input)
+	if err != nil {
+		t.Fatalf("ParseReviewOutput() error = %v", err)
+	}
+
+	want := []ReviewIssue{
+		{Severity: SeverityMajor, Text: "Achievements aren't quantified"},
+		{Severity: SeverityMinor, Text: "Summary could be punchier"},
+		{Severity: SeverityUnspecified, Text: "Odd font"},
+	}
+	if !reflect.DeepEqual(result.ResumeReview.Issues, want) {
+		t.Errorf("Issues = %+v, want %+v", result.ResumeReview.Issues, want)
+	}
+	// Weaknesses are filled from the issues for older consumers
+	if len(result.ResumeReview.Weaknesses) != 3 || result.ResumeReview.Weaknesses[0] != "Achievements aren't quantified" {
+		t.Errorf("Weaknesses = %v, want the issue texts", result.ResumeReview.Weaknesses)
+	}
+	if !result.Approved {
+		t.Error("Approved = false, want true with no critical issues")
+	}
+}
+
+func TestReviewerAgent_ParseReviewOutput_LegacyWeaknesses(t *testing.T) {
+	agent := NewReviewerAgent(&AgentConfig{Type: AgentReviewer}, "")
+
+	input := `{"overall_score":85,"resume_review":{"score":85,"weaknesses":["Missing AWS"]},"cover_letter_review":{"score":85}}`
+	result, err := agent.ParseReviewOutput(input)
+	if err != nil {
+		t.Fatalf("ParseReviewOutput() error = %v", err)
+	}
+
+	want := []ReviewIssue{{Severity: SeverityUnspecified, Text: "Missing AWS"}}
+	if !reflect.DeepEqual(result.ResumeReview.Issues, want) {
+		t.Errorf("Issues = %+v, want %+v", result.ResumeReview.Issues, want)
+	}
+	if !result.Approved {
+		t.Error("Approved = false, want true: legacy weaknesses don't block approval")
+	}
+}
+
+func TestReviewerAgent_ParseReviewOutput_CriticalBlocksApproval(t *testing.T) {
+	agent := NewReviewerAgent(&AgentConfig{Type: AgentReviewer}, "")
+
+	input := `{"overall_score":90,"resume_review":{"score":92},
+		"cover_letter_review":{"score":88,"issues":[{"severity":"critical","text":"Addressed to the wrong company"}]}}`
+	result, err := agent.ParseReviewOutput(input)
+	if err != nil {
+		t.Fatalf("ParseReviewOutput() error = %v", err)
+	}
+
+	if result.Approved {
+		t.Error("Approved = true, want a critical issue to block approval despite a score of 90")
+	}
+	if got := result.CriticalIssues(); len(got) != 1 || got[0].Text != "Addressed to the wrong company" {
+		t.Errorf("CriticalIssues() = %+v, want the wrong-company issue", got)
+	}
+	if result.Recommendation != "Revise and resubmit" {
+		t.Errorf("Recommendation = %q, want %q", result.Recommendation, "Revise and resubmit")
+	}
+}
+
+func TestRevisionFeedbackSection_ShowsSeverity(t *testing.T) {
+	review := DocumentReview{Issues: []ReviewIssue{
+		{Severity: SeverityCritical, Text: "Missing Go"},
+		{Text: "Too long"},
+	}}
+
+	section := revisionFeedbackSection(review, nil)
+	if !strings.Contains(section, "- [critical] Missing Go") || !strings.Contains(section, "- Too long") {
+		t.Errorf("section = %q, want severity-tagged and plain weaknesses", section)
+	}
+}
+
 func TestReviewerAgent_AnalyzeRequirementMatch(t *testing.T) {
 	agent := NewReviewerAgent(&AgentConfig{Type: AgentReviewer}, "")
 
@@ -279,6 +426,65 @@ func TestReviewerAgent_AnalyzeRequirementMatch(t *testing.T) {
 	}
 }
 
+func TestReviewerAgent_AnalyzeRequirementMatch_WeightsRequired(t *testing.T) {
+	agent := NewReviewerAgent(&AgentConfig{Type: AgentReviewer}, "")
+
+	tests := []struct {
+		name string
+		cv   []string
+		want float64
+	}{
+		// 2 required (weight 3) and 2 bonus (weight 1): 6 of 8 points
+		{"all required, no bonus", []string{"Go", "PostgreSQL"}, 75},
+		// 2 of 8 points
+		{"all bonus, no required", []string{"Rust", "Terraform"}, 25},
+		{"everything", []string{"Go", "PostgreSQL", "Rust", "Terraform"}, 100},
+		{"nothing", []string{"COBOL"}, 0},
+	}
+
+	posting := &ParsedPosting{
+		TechStack:    []string{"Go"},
+		Requirements: []string{"3+ years with PostgreSQL in production"},
+		BonusSkills:  []string{"Rust", "Terraform"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cv := &CVData{Skills: []CVSkill{{Name: "Skills", Keywords: tt.cv}}}
+			got := agent.AnalyzeRequirementMatch(cv, posting).MatchPercent
+			if got != tt.want {
+				t.Errorf("MatchPercent = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMentionsAny_WholeWords(t *testing.T) {
+	tests := []struct {
+		text     string
+		keywords []string
+		want     bool
+	}{
+		{"experience with go and docker", []string{"go"}, true},
+		{"strong background in algorithms", []string{"go"}, false},
+		{"c++ or rust", []string{"c"}, false},
+		{"c++ or rust", []string{"c++"}, true},
+		{"anything", []string{""}, false},
+	}
+
+	for _, tt := range tests {
+		if got := mentionsAny(tt.text, tt.keywords); got != tt.want {
+			t.Errorf("mentionsAny(%q, %v) = %v, want %v", tt.text, tt.keywords, got, tt.want)
+		}
+	}
+}
+
+func TestWeightedMatchPercent_EmptyPosting(t *testing.T) {
+	if got := weightedMatchPercent(0, 0, 0, 0); got != 0 {
+		t.Errorf("weightedMatchPercent() = %v, want 0 for a posting with no skills", got)
+	}
+}
+
 func TestReviewerAgent_ConvertToSimpleReview(t *testing.T) {
 	agent := NewReviewerAgent(&AgentConfig{Type: AgentReviewer}, "")
 
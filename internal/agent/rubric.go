@@ -0,0 +1,206 @@
+package agent
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// Rubric is the set of criteria the reviewer scores documents on. The
+// built-in rubric (DefaultRubric) suits engineering roles; a rubric file
+// can weight, say, portfolio and communication higher for design roles.
+type Rubric struct {
+	Criteria []RubricCriterion `json:"criteria"`
+	// ResumeWeight is the resume's share, in percent, of the score blended
+	// from the resume and cover letter scores; the cover letter gets the
+	// rest. Zero uses DefaultResumeWeight.
+	ResumeWeight int `json:"resume_weight,omitempty"`
+}
+
+// RubricCriterion is one scored criterion of a rubric
+type RubricCriterion struct {
+	Name string `json:"name"`
+	// Key names the criterion in the review's score_breakdown; empty
+	// derives it from Name ("Portfolio Quality" → "portfolio_quality")
+	Key         string `json:"key,omitempty"`
+	Weight      int    `json:"weight"` // percent of the overall score
+	Description string `json:"description"`
+}
+
+// DefaultResumeWeight is the resume's share of the blended document score
+const DefaultResumeWeight = 60
+
+// DefaultRubric returns the built-in reviewer rubric
+func DefaultRubric() *Rubric {
+	return &Rubric{
+		Criteria: []RubricCriterion{
+			{
+				Name:   "Requirements Match",
+				Key:    "requirements_match",
+				Weight: requirementsMatchWeight,
+				Description: "- How many required qualifications does the candidate meet?\n" +
+					"- Are must-have skills clearly demonstrated?\n" +
+					"- Is experience level appropriate for the role?",
+			},
+			{
+				Name:   "Experience Relevance",
+				Key:    "experience_relevance",
+				Weight: experienceRelevanceWeight,
+				Description: "- How closely does past experience match the role?\n" +
+					"- Are achievements in relevant domains?\n" +
+					"- Is there evidence of growth and impact?",
+			},
+			{
+				Name:   "Communication Quality",
+				Key:    "communication",
+				Weight: communicationWeight,
+				Description: "- Is writing clear and professional?\n" +
+					"- Are bullet points concise and impactful?\n" +
+					"- Is the cover letter engaging and specific?",
+			},
+			{
+				Name:   "Cultural Fit Signals",
+				Key:    "cultural_fit",
+				Weight: culturalFitWeight,
+				Description: "- Does tone match company culture?\n" +
+					"- Are company values addressed?\n" +
+					"- Is genuine interest demonstrated?",
+			},
+		},
+		ResumeWeight: DefaultResumeWeight,
+	}
+}
+
+// LoadRubric reads a rubric file, falling back to DefaultRubric when the
+// file doesn't exist. A rubric that fails Validate is an error.
+func LoadRubric(path string) (*Rubric, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return DefaultRubric(), nil
+		}
+		return nil, fmt.Errorf("failed to read rubric: %w", err)
+	}
+
+	var rubric Rubric
+	if err := json.Unmarshal(data, &rubric); err != nil {
+		return nil, fmt.Errorf("failed to parse rubric %s: %w", path, err)
+	}
+	for i := range rubric.Criteria {
+		if rubric.Criteria[i].Key == "" {
+			rubric.Criteria[i].Key = criterionKey(rubric.Criteria[i].Name)
+		}
+	}
+	if err := rubric.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid rubric %s: %w", path, err)
+	}
+	return &rubric, nil
+}
+
+// Validate checks the rubric has named criteria with distinct keys and
+// positive weights summing to 100, and a resume weight within 0-100
+func (r *Rubric) Validate() error {
+	if len(r.Criteria) == 0 {
+		return errors.New("no criteria")
+	}
+	seen := make(map[string]bool)
+	total := 0
+	for _, c := range r.Criteria {
+		switch {
+		case strings.TrimSpace(c.Name) == "":
+			return errors.New("criterion without a name")
+		case c.Key == "":
+			return fmt.Errorf("criterion %q has no usable key", c.Name)
+		case seen[c.Key]:
+			return fmt.Errorf("duplicate criterion %q", c.Key)
+		case c.Weight <= 0:
+			return fmt.Errorf("criterion %q weight must be positive, got %d", c.Name, c.Weight)
+		}
+		seen[c.Key] = true
+		total += c.Weight
+	}
+	if total != 100 {
+		return fmt.Errorf("criterion weights sum to %d, want 100", total)
+	}
+	if r.ResumeWeight < 0 || r.ResumeWeight > 100 {
+		return fmt.Errorf("resume_weight must be between 0 and 100, got %d", r.ResumeWeight)
+	}
+	return nil
+}
+
+// isDefault reports whether the rubric scores the built-in criteria, so
+// reviews can use ScoreBreakdown's fixed fields
+func (r *Rubric) isDefault() bool {
+	builtin := DefaultRubric().Criteria
+	if len(r.Criteria) != len(builtin) {
+		return false
+	}
+	for i, c := range r.Criteria {
+		if c.Key != builtin[i].Key || c.Weight != builtin[i].Weight {
+			return false
+		}
+	}
+	return true
+}
+
+// resumeWeight is the resume's share of the blended document score
+func (r *Rubric) resumeWeight() int {
+	if r.ResumeWeight == 0 {
+		return DefaultResumeWeight
+	}
+	return r.ResumeWeight
+}
+
+// criterionKey derives a score_breakdown key from a criterion name
+func criterionKey(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, "_")
+}
+
+// scoringSection renders the rubric as the reviewer prompt's scoring
+// criteria
+func (r *Rubric) scoringSection() string {
+	var b strings.Builder
+	b.WriteString("## Scoring Criteria")
+	for _, c := range r.Criteria {
+		fmt.Fprintf(&b, "\n\n### %s (%d%% of score)", c.Name, c.Weight)
+		if desc := strings.TrimSpace(c.Description); desc != "" {
+			b.WriteString("\n" + desc)
+		}
+	}
+	return b.String()
+}
+
+// breakdownKeys lists the score_breakdown keys for the prompt, quoted
+func (r *Rubric) breakdownKeys() string {
+	keys := make([]string, len(r.Criteria))
+	for i, c := range r.Criteria {
+		keys[i] = fmt.Sprintf("%q", c.Key)
+	}
+	return strings.Join(keys, ", ")
+}
+
+// scoreCustom reads a review's score_breakdown against the rubric's
+// criteria. Every criterion must be scored.
+func (r *Rubric) scoreCustom(review string) ([]ScoreCriterion, error) {
+	var raw struct {
+		ScoreBreakdown map[string]int `json:"score_breakdown"`
+	}
+	if err := json.Unmarshal([]byte(review), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse score breakdown: %w", err)
+	}
+	scores := make([]ScoreCriterion, len(r.Criteria))
+	for i, c := range r.Criteria {
+		score, ok := raw.ScoreBreakdown[c.Key]
+		if !ok {
+			return nil, fmt.Errorf("score breakdown is missing %q", c.Key)
+		}
+		scores[i] = ScoreCriterion{Name: c.Name, Weight: c.Weight, Score: score}
+	}
+	return scores, nil
+}
@@ -0,0 +1,141 @@
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const designRubric = `{
+	"criteria": [
+		{"name": "Portfolio Quality", "weight": 50, "description": "- Does the portfolio show shipped work?"},
+		{"name": "Communication", "key": "comms", "weight": 30, "description": "- Are design decisions explained?"},
+		{"name": "Requirements Match", "weight": 20, "description": "- Are the listed tools covered?"}
+	],
+	"resume_weight": 40
+}`
+
+func writeRubric(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "reviewer-rubric.json")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadRubric(t *testing.T) {
+	rubric, err := LoadRubric(writeRubric(t, designRubric))
+	if err != nil {
+		t.Fatalf("LoadRubric() error = %v", err)
+	}
+	wantKeys := []string{"portfolio_quality", "comms", "requirements_match"}
+	if len(rubric.Criteria) != len(wantKeys) {
+		t.Fatalf("LoadRubric() criteria = %+v", rubric.Criteria)
+	}
+	total := 0
+	for i, c := range rubric.Criteria {
+		if c.Key != wantKeys[i] {
+			t.Errorf("criterion %d key = %q, want %q", i, c.Key, wantKeys[i])
+		}
+		total += c.Weight
+	}
+	if total != 100 {
+		t.Errorf("weights sum to %d, want 100", total)
+	}
+
+	// A missing file falls back to the built-in rubric
+	rubric, err = LoadRubric(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil || !rubric.isDefault() {
+		t.Errorf("LoadRubric(missing) = %+v, %v; want the default rubric", rubric, err)
+	}
+}
+
+func TestLoadRubric_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"weights short of 100", `{"criteria": [{"name": "A", "weight": 50}, {"name": "B", "weight": 40}]}`, "sum to 90"},
+		{"weights over 100", `{"criteria": [{"name": "A", "weight": 70}, {"name": "B", "weight": 40}]}`, "sum to 110"},
+		{"no criteria", `{"criteria": []}`, "no criteria"},
+		{"duplicate keys", `{"criteria": [{"name": "Fit", "weight": 50}, {"name": "fit!", "weight": 50}]}`, "duplicate"},
+		{"bad resume weight", `{"criteria": [{"name": "A", "weight": 100}], "resume_weight": 120}`, "resume_weight"},
+		{"not json", `criteria: A`, "failed to parse"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadRubric(writeRubric(t, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadRubric() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReviewerAgent_CustomRubricPrompt(t *testing.T) {
+	rubric, err := LoadRubric(writeRubric(t, designRubric))
+	if err != nil {
+		t.Fatal(err)
+	}
+	reviewer := NewReviewerAgent(&AgentConfig{Type: AgentReviewer}, "")
+	reviewer.Rubric = rubric
+	prompt := reviewer.GetSystemPrompt()
+
+	for _, want := range []string{
+		"### Portfolio Quality (50% of score)\n- Does the portfolio show shipped work?",
+		"### Communication (30% of score)",
+		`("portfolio_quality", "comms", "requirements_match")`,
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("GetSystemPrompt() missing %q", want)
+		}
+	}
+	for _, builtin := range []string{"Cultural Fit Signals", `"experience_relevance"`} {
+		if strings.Contains(prompt, builtin) {
+			t.Errorf("GetSystemPrompt() still has built-in criterion %q", builtin)
+		}
+	}
+
+	// The built-in rubric renders the original criteria
+	builtin := NewReviewerAgent(&AgentConfig{Type: AgentReviewer}, "").GetSystemPrompt()
+	if !strings.Contains(builtin, "### Cultural Fit Signals (10% of score)\n- Does tone match company culture?") {
+		t.Error("default GetSystemPrompt() lost the built-in criteria")
+	}
+}
+
+func TestReviewerAgent_CustomRubricScoring(t *testing.T) {
+	rubric, err := LoadRubric(writeRubric(t, designRubric))
+	if err != nil {
+		t.Fatal(err)
+	}
+	reviewer := NewReviewerAgent(&AgentConfig{Type: AgentReviewer}, "")
+	reviewer.Rubric = rubric
+
+	// Resume weighted 40%, cover letter 60%
+	if got := reviewer.CalculateOverallScore(90, 70); got != 78 {
+		t.Errorf("CalculateOverallScore(90, 70) = %d, want 78", got)
+	}
+
+	// Weighted sum: 45 + 24 + 14 = 83
+	result, err := reviewer.ParseReviewOutput(`{"overall_score": 83, "resume_review": {"score": 85}, "cover_letter_review": {"score": 80},
+		"score_breakdown": {"portfolio_quality": 90, "comms": 80, "requirements_match": 70}}`)
+	if err != nil {
+		t.Fatalf("ParseReviewOutput() error = %v", err)
+	}
+	criteria := result.ScoreBreakdown.Criteria()
+	if len(criteria) != 3 || criteria[0].Name != "Portfolio Quality" || criteria[0].Weight != 50 || criteria[0].Score != 90 {
+		t.Errorf("Criteria() = %+v, want the rubric's criteria", criteria)
+	}
+	if got := result.ScoreBreakdown.Weighted(); got != 83 {
+		t.Errorf("Weighted() = %d, want 83", got)
+	}
+
+	_, err = reviewer.ParseReviewOutput(`{"overall_score": 83, "resume_review": {"score": 85}, "cover_letter_review": {"score": 80},
+		"score_breakdown": {"requirements_match": 70}}`)
+	if err == nil || !strings.Contains(err.Error(), "portfolio_quality") {
+		t.Errorf("ParseReviewOutput() error = %v, want the missing criterion", err)
+	}
+}
@@ -0,0 +1,137 @@
+package agent
+
+import (
+	"math"
+	"strings"
+)
+
+// Salary estimates fill in a band for postings that don't disclose pay.
+// They are rough by design: a base band for the role, scaled for seniority
+// and for the metro's cost of labor. Figures are annual USD and were set
+// from public US salary surveys; update them here when they drift.
+
+// roleBand is the typical mid-level band for a role, matched by keyword
+type roleBand struct {
+	keyword  string
+	min, max int
+}
+
+// roleBands are checked in order, so more specific roles come first
+var roleBands = []roleBand{
+	{"machine learning", 135000, 185000},
+	{"site reliability", 125000, 165000},
+	{"devops", 120000, 160000},
+	{"data scientist", 120000, 165000},
+	{"data engineer", 120000, 160000},
+	{"product manager", 125000, 170000},
+	{"product designer", 105000, 145000},
+	{"ux", 100000, 135000},
+	{"frontend", 105000, 145000},
+	{"front-end", 105000, 145000},
+	{"front end", 105000, 145000},
+	{"backend", 120000, 160000},
+	{"back-end", 120000, 160000},
+	{"full stack", 115000, 155000},
+	{"fullstack", 115000, 155000},
+	{"software engineer", 115000, 155000},
+	{"software developer", 110000, 150000},
+}
+
+// seniorityMultipliers scale the band for the level in the title; the
+// first match wins
+var seniorityMultipliers = []struct {
+	keyword    string
+	multiplier float64
+}{
+	{"principal", 1.45},
+	{"staff", 1.4},
+	{"lead", 1.25},
+	{"senior", 1.2},
+	{"sr.", 1.2},
+	{"junior", 0.75},
+	{"jr.", 0.75},
+	{"entry", 0.7},
+}
+
+// metroMultipliers scale the band for where the job is; remote roles use
+// the national band
+var metroMultipliers = []struct {
+	keyword    string
+	multiplier float64
+}{
+	{"san francisco", 1.25},
+	{"bay area", 1.25},
+	{"palo alto", 1.25},
+	{"mountain view", 1.25},
+	{"san jose", 1.2},
+	{"new york", 1.2},
+	{"nyc", 1.2},
+	{"seattle", 1.15},
+	{"boston", 1.1},
+	{"los angeles", 1.1},
+	{"washington, dc", 1.05},
+	{"austin", 1.0},
+	{"denver", 1.0},
+	{"chicago", 1.0},
+	{"atlanta", 0.95},
+	{"remote", 1.0},
+}
+
+// inferSalaryBand estimates a salary band from a posting's position and
+// location. ok is false when the role or the location isn't in the tables.
+func inferSalaryBand(position, location string) (min, max int, ok bool) {
+	title := strings.ToLower(position)
+	var band *roleBand
+	for i := range roleBands {
+		if strings.Contains(title, roleBands[i].keyword) {
+			band = &roleBands[i]
+			break
+		}
+	}
+	if band == nil {
+		return 0, 0, false
+	}
+
+	place := strings.ToLower(location)
+	metro := 0.0
+	for _, m := range metroMultipliers {
+		if strings.Contains(place, m.keyword) {
+			metro = m.multiplier
+			break
+		}
+	}
+	if metro == 0 {
+		return 0, 0, false
+	}
+
+	scale := metro
+	for _, s := range seniorityMultipliers {
+		if strings.Contains(title, s.keyword) {
+			scale *= s.multiplier
+			break
+		}
+	}
+	return roundSalary(float64(band.min) * scale), roundSalary(float64(band.max) * scale), true
+}
+
+// roundSalary rounds an estimate to the nearest $5k, so it doesn't look
+// more precise than it is
+func roundSalary(amount float64) int {
+	return int(math.Round(amount/5000)) * 5000
+}
+
+// estimateSalary fills in an estimated band on a posting that doesn't
+// state one
+func estimateSalary(parsed *ParsedPosting) {
+	if parsed.SalaryMin > 0 || parsed.SalaryMax > 0 {
+		return
+	}
+	location := parsed.Location
+	if location == "" && parsed.Remote {
+		location = "Remote"
+	}
+	if min, max, ok := inferSalaryBand(parsed.Position, location); ok {
+		parsed.SalaryMin, parsed.SalaryMax = min, max
+		parsed.SalaryEstimated = true
+	}
+}
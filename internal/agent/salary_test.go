@@ -0,0 +1,56 @@
+package agent
+
+import "testing"
+
+func TestInferSalaryBand(t *testing.T) {
+	tests := []struct {
+		position, location string
+		wantMin, wantMax   int
+		wantOK             bool
+	}{
+		{"Software Engineer", "Austin, TX", 115000, 155000, true},
+		// Metro multiplier 1.25
+		{"Software Engineer", "San Francisco, CA", 145000, 195000, true},
+		// Seniority and metro combine: 1.2 * 1.2
+		{"Senior Frontend Engineer", "New York, NY", 150000, 210000, true},
+		{"Staff Software Engineer", "Remote", 160000, 215000, true},
+		// Unknown role or location
+		{"Chief Vibes Officer", "Austin, TX", 0, 0, false},
+		{"Software Engineer", "Lisbon, Portugal", 0, 0, false},
+		{"Software Engineer", "", 0, 0, false},
+	}
+	for _, tt := range tests {
+		min, max, ok := inferSalaryBand(tt.position, tt.location)
+		if min != tt.wantMin || max != tt.wantMax || ok != tt.wantOK {
+			t.Errorf("inferSalaryBand(%q, %q) = %d, %d, %v; want %d, %d, %v",
+				tt.position, tt.location, min, max, ok, tt.wantMin, tt.wantMax, tt.wantOK)
+		}
+	}
+}
+
+func TestExtractBasicInfo_EstimatesMissingSalary(t *testing.T) {
+	content := "# Backend Engineer at Acme\n\nLocation: Seattle, WA\n\nBuild APIs in Go."
+	parsed := extractBasicInfo(content, "acme-backend-posting.md")
+
+	if !parsed.SalaryEstimated {
+		t.Fatal("SalaryEstimated = false for a posting without pay")
+	}
+	if parsed.SalaryMin != 140000 || parsed.SalaryMax != 185000 {
+		t.Errorf("estimate = %d-%d, want 140000-185000", parsed.SalaryMin, parsed.SalaryMax)
+	}
+}
+
+func TestEstimateSalary_KeepsStatedSalary(t *testing.T) {
+	parsed := ParsedPosting{Position: "Software Engineer", Location: "Austin, TX", SalaryMin: 90000}
+	estimateSalary(&parsed)
+	if parsed.SalaryEstimated || parsed.SalaryMin != 90000 || parsed.SalaryMax != 0 {
+		t.Errorf("estimateSalary() changed a stated salary: %+v", parsed)
+	}
+
+	// A remote posting with no location uses the national band
+	parsed = ParsedPosting{Position: "Product Designer", Remote: true}
+	estimateSalary(&parsed)
+	if !parsed.SalaryEstimated || parsed.SalaryMin != 105000 {
+		t.Errorf("estimateSalary(remote) = %+v, want the national band", parsed)
+	}
+}
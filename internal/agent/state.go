@@ -0,0 +1,171 @@
+package agent
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/atomicfile"
+)
+
+// runStatesDir, under the pipeline's base directory, holds the state of each
+// run, one file per posting, so pipelines running concurrently (apply
+// --batch) don't overwrite or discard each other's state
+const runStatesDir = "runs"
+
+// failedStatesDir, next to runStatesDir, keeps the state of each failed run
+// (one file per posting) so a later run of the posting doesn't lose it, and
+// Resume can continue it even once the run state is gone
+const failedStatesDir = "failed"
+
+// ResumableState is a failed run whose state was kept for resuming
+type ResumableState struct {
+	Path        string    `json:"path"`
+	PostingPath string    `json:"posting_path"`
+	FailedStep  AgentType `json:"failed_step"`
+	Error       string    `json:"error,omitempty"`
+	StartedAt   string    `json:"started_at"`
+	// ModTime is when the run failed
+	ModTime time.Time `json:"modified"`
+}
+
+// postingStateName names a posting's state files after the posting file
+func postingStateName(postingPath string) string {
+	base := filepath.Base(postingPath)
+	name := sanitizeFilename(strings.TrimSuffix(base, filepath.Ext(base)))
+	if name == "" {
+		name = "posting"
+	}
+	return name
+}
+
+// postingStateFile names a posting's state files after the posting file,
+// with a hash of the posting's path, so postings sharing a file name in
+// different directories get their own state
+func postingStateFile(postingPath string) string {
+	key := postingPath
+	if abs, err := filepath.Abs(postingPath); err == nil {
+		key = abs
+	}
+	sum := sha256.Sum256([]byte(key))
+	return postingStateName(postingPath) + "-" + hex.EncodeToString(sum[:4]) + ".json"
+}
+
+// runStatePath is where a run of postingPath saves its state
+func (p *Pipeline) runStatePath(postingPath string) string {
+	return filepath.Join(p.BaseDir, runStatesDir, postingStateFile(postingPath))
+}
+
+// failedStatePath is where a failed run of postingPath is kept
+func (p *Pipeline) failedStatePath(postingPath string) string {
+	return filepath.Join(p.BaseDir, failedStatesDir, postingStateFile(postingPath))
+}
+
+// keepFailedState records a failed run for resuming, or with
+// DiscardFailedState removes its state entirely
+func (p *Pipeline) keepFailedState() error {
+	if p.DiscardFailedState {
+		return p.clearRunState()
+	}
+
+	p.stateMu.Lock()
+	data, err := json.MarshalIndent(p.State, "", "  ")
+	p.stateMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	path := p.failedStatePath(p.State.PostingPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return atomicfile.WriteFile(path, data, 0644)
+}
+
+// clearRunState removes the current run's state file, once the run has
+// completed or when its failed state is discarded
+func (p *Pipeline) clearRunState() error {
+	err := os.Remove(p.StateFile)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// clearFailedState removes a kept failed state once its posting has run
+// to completion
+func (p *Pipeline) clearFailedState() error {
+	err := os.Remove(p.failedStatePath(p.State.PostingPath))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// ListResumableStates returns the failed runs kept under stateDir (the
+// pipeline's base directory), most recent first
+func ListResumableStates(stateDir string) ([]ResumableState, error) {
+	paths, err := filepath.Glob(filepath.Join(stateDir, failedStatesDir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var states []ResumableState
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var state PipelineState
+		if err := json.Unmarshal(data, &state); err != nil {
+			// Leave unreadable files for the user to inspect
+			continue
+		}
+		states = append(states, ResumableState{
+			Path:        path,
+			PostingPath: state.PostingPath,
+			FailedStep:  state.CurrentStep,
+			Error:       state.Results[state.CurrentStep].Error,
+			StartedAt:   state.StartedAt,
+			ModTime:     info.ModTime(),
+		})
+	}
+
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].ModTime.After(states[j].ModTime)
+	})
+	return states, nil
+}
+
+// PruneFailedStates removes kept failed states last written more than
+// olderThan before now, and returns the ones removed
+func PruneFailedStates(stateDir string, olderThan time.Duration, now time.Time) ([]ResumableState, error) {
+	states, err := ListResumableStates(stateDir)
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := now.Add(-olderThan)
+	var pruned []ResumableState
+	for _, state := range states {
+		if !state.ModTime.Before(cutoff) {
+			continue
+		}
+		if err := os.Remove(state.Path); err != nil {
+			return pruned, err
+		}
+		pruned = append(pruned, state)
+	}
+	return pruned, nil
+}
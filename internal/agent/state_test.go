@@ -0,0 +1,275 @@
+package agent
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// failingPipeline returns a pipeline whose parser step fails on its posting
+func failingPipeline(t *testing.T) (*Pipeline, string) {
+	t.Helper()
+	dir := t.TempDir()
+	postingPath := filepath.Join(dir, "acme-swe.xyz")
+	if err := os.WriteFile(postingPath, []byte("unsupported"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	p, err := NewPipeline(filepath.Join(dir, ".agent", "config.json"), nil)
+	if err != nil {
+		t.Fatalf("NewPipeline() error = %v", err)
+	}
+	return p, postingPath
+}
+
+func TestPipeline_KeepsFailedState(t *testing.T) {
+	p, postingPath := failingPipeline(t)
+	if err := p.Run(postingPath); err == nil {
+		t.Fatal("Run() should fail on an unsupported posting")
+	}
+
+	states, err := ListResumableStates(p.BaseDir)
+	if err != nil {
+		t.Fatalf("ListResumableStates() error = %v", err)
+	}
+	if len(states) != 1 {
+		t.Fatalf("ListResumableStates() = %+v, want the failed run", states)
+	}
+	state := states[0]
+	if state.PostingPath != postingPath || state.FailedStep != AgentParser || state.Error == "" {
+		t.Errorf("state = %+v, want %s failed at the parser with an error", state, postingPath)
+	}
+	if _, err := os.Stat(p.StateFile); err != nil {
+		t.Errorf("run state should be kept: %v", err)
+	}
+}
+
+func TestPipeline_DiscardFailedState(t *testing.T) {
+	p, postingPath := failingPipeline(t)
+	p.DiscardFailedState = true
+	if err := p.Run(postingPath); err == nil {
+		t.Fatal("Run() should fail on an unsupported posting")
+	}
+
+	if _, err := os.Stat(p.StateFile); !os.IsNotExist(err) {
+		t.Errorf("run state should be removed, stat error = %v", err)
+	}
+	if states, _ := ListResumableStates(p.BaseDir); len(states) != 0 {
+		t.Errorf("ListResumableStates() = %+v, want none", states)
+	}
+}
+
+func TestPipeline_RunStatePerPosting(t *testing.T) {
+	kept, keptPosting := failingPipeline(t)
+	if err := kept.Run(keptPosting); err == nil {
+		t.Fatal("Run() should fail on an unsupported posting")
+	}
+
+	// A second pipeline sharing the base directory, as in apply --batch,
+	// discarding its own failed state
+	discarded, err := NewPipeline(filepath.Join(kept.BaseDir, "config.json"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	discarded.DiscardFailedState = true
+	otherPosting := filepath.Join(t.TempDir(), "globex-swe.xyz")
+	if err := os.WriteFile(otherPosting, []byte("unsupported"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := discarded.Run(otherPosting); err == nil {
+		t.Fatal("Run() should fail on an unsupported posting")
+	}
+
+	if kept.StateFile == discarded.StateFile {
+		t.Fatalf("both runs saved state to %s", kept.StateFile)
+	}
+	if _, err := os.Stat(kept.StateFile); err != nil {
+		t.Errorf("the other run's discard removed this run's state: %v", err)
+	}
+}
+
+func TestPipeline_ResumeFromNewPipeline(t *testing.T) {
+	failed, postingPath := newLLMPipeline(t, &mockLLM{err: errors.New("overloaded")})
+	if err := failed.Run(postingPath); err == nil {
+		t.Fatal("Run() should fail when the model errors")
+	}
+
+	// A later invocation starts from a new pipeline with no state in memory
+	resumed, err := NewPipeline(filepath.Join(failed.BaseDir, "config.json"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resumed.Config = failed.Config
+	resumed.CVPath = failed.CVPath
+	llm := &mockLLM{replies: map[AgentType]string{
+		AgentResume:   mockResume,
+		AgentCover:    mockCover,
+		AgentReviewer: mockReview,
+	}}
+	resumed.LLM = llm
+	if err := resumed.Resume(postingPath); err != nil {
+		t.Fatalf("Resume() error = %v", err)
+	}
+
+	if resumed.State.Status != "completed" {
+		t.Errorf("Status = %q, want completed", resumed.State.Status)
+	}
+	if len(llm.models) != 3 {
+		t.Errorf("model calls = %d, want resume, cover, and review only", len(llm.models))
+	}
+	if _, err := os.Stat(resumed.StateFile); !os.IsNotExist(err) {
+		t.Errorf("completed run's state should be removed, stat error = %v", err)
+	}
+	if states, _ := ListResumableStates(resumed.BaseDir); len(states) != 0 {
+		t.Errorf("ListResumableStates() = %+v, want the failure cleared", states)
+	}
+}
+
+func TestPipeline_CompletedRunRemovesState(t *testing.T) {
+	p, postingPath := newLLMPipeline(t, &mockLLM{replies: map[AgentType]string{
+		AgentResume:   mockResume,
+		AgentCover:    mockCover,
+		AgentReviewer: mockReview,
+	}})
+	if err := p.Run(postingPath); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	entries, _ := os.ReadDir(filepath.Join(p.BaseDir, runStatesDir))
+	if len(entries) != 0 {
+		t.Errorf("runs/ has %d file(s) after a completed run, want none", len(entries))
+	}
+}
+
+func TestPipeline_FailedStatePerPostingPath(t *testing.T) {
+	first, firstPosting := failingPipeline(t)
+	if err := first.Run(firstPosting); err == nil {
+		t.Fatal("Run() should fail on an unsupported posting")
+	}
+
+	// The same posting file name in another folder, completing its run
+	second, err := NewPipeline(filepath.Join(first.BaseDir, "config.json"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondPosting := filepath.Join(t.TempDir(), filepath.Base(firstPosting))
+	second.State = &PipelineState{PostingPath: secondPosting}
+	if err := second.clearFailedState(); err != nil {
+		t.Fatal(err)
+	}
+
+	if first.failedStatePath(firstPosting) == second.failedStatePath(secondPosting) {
+		t.Fatalf("both postings keep their failure at %s", first.failedStatePath(firstPosting))
+	}
+	if states, _ := ListResumableStates(first.BaseDir); len(states) != 1 || states[0].PostingPath != firstPosting {
+		t.Errorf("ListResumableStates() = %+v, want %s's failure kept", states, firstPosting)
+	}
+}
+
+func TestPipeline_ReportsKeepFailedStateError(t *testing.T) {
+	p, postingPath := failingPipeline(t)
+	// A file where the failed/ folder goes makes keeping the state fail
+	if err := os.MkdirAll(p.BaseDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(p.BaseDir, failedStatesDir), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := p.Run(postingPath)
+	if err == nil || !strings.Contains(err.Error(), "keeping its state for resume also failed") {
+		t.Errorf("Run() error = %v, want the failure to keep its state reported", err)
+	}
+}
+
+func TestPipeline_ResumeFromFailedState(t *testing.T) {
+	failed, postingPath := newLLMPipeline(t, &mockLLM{err: errors.New("overloaded")})
+	if err := failed.Run(postingPath); err == nil {
+		t.Fatal("Run() should fail when the model errors")
+	}
+	// Only the kept failure is left to resume from
+	if err := os.Remove(failed.StateFile); err != nil {
+		t.Fatal(err)
+	}
+
+	failed.State = nil
+	failed.LLM = &mockLLM{replies: map[AgentType]string{
+		AgentResume:   mockResume,
+		AgentCover:    mockCover,
+		AgentReviewer: mockReview,
+	}}
+	if err := failed.Resume(postingPath); err != nil {
+		t.Fatalf("Resume() error = %v", err)
+	}
+	if failed.State.Status != "completed" {
+		t.Errorf("Status = %q, want completed", failed.State.Status)
+	}
+	if states, _ := ListResumableStates(failed.BaseDir); len(states) != 0 {
+		t.Errorf("ListResumableStates() = %+v, want the failure cleared", states)
+	}
+}
+
+// writeFailedState writes a kept failed state last modified at modTime
+func writeFailedState(t *testing.T, dir, name string, modTime time.Time) {
+	t.Helper()
+	path := filepath.Join(dir, failedStatesDir, name+".json")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	state := `{"posting_path": "local/postings/` + name + `.md", "status": "failed", "current_step": "resume",
+		"results": {"resume": {"status": "failed", "error": "boom"}}}`
+	if err := os.WriteFile(path, []byte(state), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListResumableStates(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	writeFailedState(t, dir, "older", now.Add(-48*time.Hour))
+	writeFailedState(t, dir, "newer", now.Add(-time.Hour))
+
+	states, err := ListResumableStates(dir)
+	if err != nil {
+		t.Fatalf("ListResumableStates() error = %v", err)
+	}
+	if len(states) != 2 {
+		t.Fatalf("ListResumableStates() = %+v, want 2", states)
+	}
+	if states[0].PostingPath != "local/postings/newer.md" {
+		t.Errorf("first state = %s, want the most recent failure first", states[0].PostingPath)
+	}
+	if states[0].FailedStep != AgentResume || states[0].Error != "boom" {
+		t.Errorf("state = %+v, want resume step failing with boom", states[0])
+	}
+
+	// No kept states at all is not an error
+	if states, err := ListResumableStates(t.TempDir()); err != nil || len(states) != 0 {
+		t.Errorf("ListResumableStates(empty) = %+v, %v", states, err)
+	}
+}
+
+func TestPruneFailedStates(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	writeFailedState(t, dir, "stale", now.Add(-10*24*time.Hour))
+	writeFailedState(t, dir, "recent", now.Add(-2*24*time.Hour))
+
+	pruned, err := PruneFailedStates(dir, 7*24*time.Hour, now)
+	if err != nil {
+		t.Fatalf("PruneFailedStates() error = %v", err)
+	}
+	if len(pruned) != 1 || pruned[0].PostingPath != "local/postings/stale.md" {
+		t.Errorf("pruned = %+v, want only the stale state", pruned)
+	}
+
+	states, _ := ListResumableStates(dir)
+	if len(states) != 1 || states[0].PostingPath != "local/postings/recent.md" {
+		t.Errorf("remaining = %+v, want only the recent state", states)
+	}
+}
@@ -0,0 +1,112 @@
+package agent
+
+import (
+	"encoding/json"
+)
+
+// RunSummary is the machine-readable result of a pipeline run, as printed
+// by `ghosted apply --json-output`. Its shape is stable for scripts and CI.
+type RunSummary struct {
+	Posting string        `json:"posting"`
+	Status  string        `json:"status"` // "completed", "rejected", "failed", or "not started"
+	Error   string        `json:"error,omitempty"`
+	Steps   []StepSummary `json:"steps"`
+
+	Documents *GeneratedDocuments `json:"documents,omitempty"`
+
+	// Review outcome; Score is out of 100
+	ReviewScore int      `json:"review_score,omitempty"`
+	Approved    *bool    `json:"approved,omitempty"`
+	Feedback    []string `json:"feedback,omitempty"`
+	Revisions   int      `json:"revisions,omitempty"`
+	// FeedbackPath is where the review was saved when the tracker step
+	// rejected the documents; the run's status is then "rejected"
+	FeedbackPath string `json:"feedback_path,omitempty"`
+
+	// ApplicationID is the tracker entry created by the run (empty on dry run)
+	ApplicationID string `json:"application_id,omitempty"`
+}
+
+// StepSummary is the outcome of a single pipeline step
+type StepSummary struct {
+	Agent    AgentType `json:"agent"`
+	Status   string    `json:"status"`
+	Duration string    `json:"duration,omitempty"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// Summary condenses the pipeline state into a RunSummary. Steps are listed in
+// configuration order, including disabled ones as "pending".
+func (p *Pipeline) Summary() *RunSummary {
+	if p.State == nil {
+		return &RunSummary{Status: "not started", Steps: []StepSummary{}}
+	}
+
+	summary := &RunSummary{
+		Posting:   p.State.PostingPath,
+		Status:    p.State.Status,
+		Steps:     make([]StepSummary, 0, len(p.Config.Agents)),
+		Revisions: p.Revisions,
+	}
+
+	for _, agent := range p.Config.Agents {
+		result := p.State.Results[agent.Type]
+		status := result.Status
+		if status == "" {
+			status = "pending"
+		}
+		summary.Steps = append(summary.Steps, StepSummary{
+			Agent:    agent.Type,
+			Status:   status,
+			Duration: result.Duration,
+			Error:    result.Error,
+		})
+		if result.Error != "" && summary.Error == "" {
+			summary.Error = result.Error
+		}
+	}
+
+	if result, ok := p.State.Results[AgentCover]; ok && result.Status == "completed" {
+		var docs GeneratedDocuments
+		if json.Unmarshal(result.Output, &docs) == nil {
+			summary.Documents = &docs
+		}
+	}
+
+	if result, ok := p.State.Results[AgentReviewer]; ok && result.Output != nil {
+		var review struct {
+			ReviewResult
+			DetailedReview *DetailedReviewResult `json:"detailed_review"`
+		}
+		if json.Unmarshal(result.Output, &review) == nil {
+			approved := review.Approved
+			summary.Approved = &approved
+			summary.ReviewScore = review.Score * 10
+			if review.DetailedReview != nil {
+				summary.ReviewScore = review.DetailedReview.OverallScore
+			}
+			if !approved {
+				summary.Feedback = append(review.Issues, review.Feedback...)
+			}
+		}
+	}
+
+	if result, ok := p.State.Results[AgentTracker]; ok && result.Status == "completed" {
+		// The created application, or the tracker's output when it
+		// rejected the documents
+		var created struct {
+			ID           string `json:"id"`
+			Status       string `json:"status"`
+			FeedbackPath string `json:"feedback_path"`
+		}
+		if json.Unmarshal(result.Output, &created) == nil {
+			summary.ApplicationID = created.ID
+			if created.ID == "" && created.Status == "rejected" {
+				summary.Status = "rejected"
+				summary.FeedbackPath = created.FeedbackPath
+			}
+		}
+	}
+
+	return summary
+}
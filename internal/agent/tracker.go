@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/celloopa/ghosted/internal/model"
 	"github.com/celloopa/ghosted/internal/store"
@@ -16,6 +17,17 @@ type TrackerAgent struct {
 	Config  *AgentConfig
 	Store   *store.Store
 	BaseDir string
+	// InitialStatus is the status of created entries; empty means
+	// DefaultInitialStatus, as in the pipeline's tracker step
+	InitialStatus string
+
+	// FeedbackDir is where rejection feedback is saved; empty means
+	// BaseDir/local/postings
+	FeedbackDir string
+	// FlatDocumentPaths records document file names without the
+	// applications/<folder>/ prefix, for documents left where the pipeline
+	// wrote them
+	FlatDocumentPaths bool
 }
 
 // TrackerInput holds the data needed to create a tracker entry
@@ -26,6 +38,8 @@ type TrackerInput struct {
 	DetailedReview   *DetailedReviewResult `json:"detailed_review,omitempty"`
 	ApplicationFolder string             `json:"application_folder"`
 	JobType          string              `json:"job_type"`
+
+	PostingPath string `json:"posting_path,omitempty"`
 }
 
 // TrackerOutput holds the result of tracker integration
@@ -36,6 +50,9 @@ type TrackerOutput struct {
 	CoverLetter     string `json:"cover_letter"`
 	PostingArchived bool   `json:"posting_archived"`
 	Notes           string `json:"notes"`
+	// FeedbackPath is where the review was saved when the documents were
+	// rejected
+	FeedbackPath string `json:"feedback_path,omitempty"`
 }
 
 // RejectionFeedback holds feedback when documents are rejected
@@ -70,31 +87,47 @@ func (t *TrackerAgent) CreateApplication(input *TrackerInput) (*model.Applicatio
 		return nil, err
 	}
 
+	status, err := ResolveInitialStatus(t.InitialStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	jobType := input.JobType
+	if jobType == "" {
+		jobType = t.DetermineJobType(input.Posting)
+	}
+
 	// Build the application model
 	app := model.Application{
-		Company:   input.Posting.Company,
-		Position:  input.Posting.Position,
-		Status:    model.StatusApplied,
-		Location:  input.Posting.Location,
-		Remote:    input.Posting.Remote,
-		SalaryMin: input.Posting.SalaryMin,
-		SalaryMax: input.Posting.SalaryMax,
-		JobURL:    input.Posting.JobURL,
-		Notes:     t.GenerateNotes(input),
-	}
-
-	// Set document paths if available
+		Company:     input.Posting.Company,
+		Position:    input.Posting.Position,
+		Status:      status,
+		Location:    input.Posting.Location,
+		Remote:      input.Posting.Remote,
+		SalaryMin:   input.Posting.SalaryMin,
+		SalaryMax:   input.Posting.SalaryMax,
+		JobURL:      input.Posting.JobURL,
+		Notes:       t.GenerateNotes(input),
+		PostingPath: input.PostingPath,
+
+		JobType:            jobType,
+		MinYearsExperience: input.Posting.MinYearsExperience,
+		Deadline:           input.Posting.Deadline,
+		SalaryEstimated:    input.Posting.SalaryEstimated,
+	}
+
+	// Set document paths if available. The Typst source is recorded while
+	// it's kept; the PDF once it's the only file left.
 	if input.Documents != nil {
-		if input.Documents.ResumePDF != "" {
-			app.ResumeVersion = t.FormatDocumentPath(input.Documents.ResumePDF, input.ApplicationFolder)
-		} else if input.Documents.ResumePath != "" {
-			app.ResumeVersion = t.FormatDocumentPath(input.Documents.ResumePath, input.ApplicationFolder)
+		folder := input.ApplicationFolder
+		if t.FlatDocumentPaths {
+			folder = ""
 		}
-
-		if input.Documents.CoverLetterPDF != "" {
-			app.CoverLetter = t.FormatDocumentPath(input.Documents.CoverLetterPDF, input.ApplicationFolder)
-		} else if input.Documents.CoverLetterPath != "" {
-			app.CoverLetter = t.FormatDocumentPath(input.Documents.CoverLetterPath, input.ApplicationFolder)
+		if resume := input.Documents.ResumeArtifact(); resume != "" {
+			app.ResumeVersion = t.FormatDocumentPath(resume, folder)
+		}
+		if cover := input.Documents.CoverLetterArtifact(); cover != "" {
+			app.CoverLetter = t.FormatDocumentPath(cover, folder)
 		}
 	}
 
@@ -148,6 +181,16 @@ func (t *TrackerAgent) GenerateNotes(input *TrackerInput) string {
 		notes.WriteString("\n")
 	}
 
+	if input.Posting.MinYearsExperience > 0 {
+		notes.WriteString(fmt.Sprintf("Requires %d+ years of experience\n", input.Posting.MinYearsExperience))
+	}
+
+	if len(input.Posting.Benefits) > 0 {
+		notes.WriteString("Benefits: ")
+		notes.WriteString(strings.Join(input.Posting.Benefits, ", "))
+		notes.WriteString("\n")
+	}
+
 	// Original notes from posting
 	if input.Posting.Notes != "" {
 		notes.WriteString("\n")
@@ -175,8 +218,10 @@ func (t *TrackerAgent) FormatDocumentPath(fullPath, applicationFolder string) st
 	return filename
 }
 
-// ArchivePosting moves the original posting to the processed folder
-func (t *TrackerAgent) ArchivePosting(postingPath, processedDir string) error {
+// ArchivePosting moves the original posting to the processed folder and
+// records it in the folder's archive index. If app is non-nil the index entry
+// links the posting to that application.
+func (t *TrackerAgent) ArchivePosting(postingPath, processedDir string, app *model.Application) error {
 	if postingPath == "" {
 		return fmt.Errorf("posting path is required")
 	}
@@ -208,6 +253,10 @@ func (t *TrackerAgent) ArchivePosting(postingPath, processedDir string) error {
 		os.Remove(postingPath) // Best effort delete
 	}
 
+	if err := appendArchiveIndex(processedDir, filename, app, time.Now()); err != nil {
+		return fmt.Errorf("posting archived but index not updated: %w", err)
+	}
+
 	return nil
 }
 
@@ -264,34 +313,7 @@ func (t *TrackerAgent) SaveRejectionFeedback(input *TrackerInput, outputDir stri
 
 // DetermineJobType infers the job type from posting data
 func (t *TrackerAgent) DetermineJobType(posting *ParsedPosting) string {
-	positionLower := strings.ToLower(posting.Position)
-
-	// Check for frontend keywords
-	frontendKeywords := []string{"frontend", "front-end", "front end", "react", "vue", "angular", "ui"}
-	for _, keyword := range frontendKeywords {
-		if strings.Contains(positionLower, keyword) {
-			return "fe-dev"
-		}
-	}
-
-	// Check for UX/UI keywords
-	uxKeywords := []string{"ux", "ui design", "user experience", "user interface"}
-	for _, keyword := range uxKeywords {
-		if strings.Contains(positionLower, keyword) {
-			return "ux-design"
-		}
-	}
-
-	// Check for product design keywords
-	pdKeywords := []string{"product design", "product designer"}
-	for _, keyword := range pdKeywords {
-		if strings.Contains(positionLower, keyword) {
-			return "product-design"
-		}
-	}
-
-	// Default to general SWE
-	return "swe"
+	return model.InferJobType(posting.Position)
 }
 
 // GenerateApplicationFolder creates the folder name for an application
@@ -345,6 +367,8 @@ func (t *TrackerAgent) GetSystemPrompt() string {
 | parsed.salary_min | salary_min |
 | parsed.salary_max | salary_max |
 | parsed.job_url | job_url |
+| parsed.min_years_experience | min_years_experience |
+| parsed.deadline | deadline |
 | documents.resume_pdf | resume_version |
 | documents.cover_pdf | cover_letter |
 | (generated) | notes |
@@ -355,6 +379,7 @@ Compile useful information into the notes field:
 - Tech stack
 - Review score
 - Key requirements (first 3)
+- Years of experience required, if stated
 
 ## Output
 
@@ -407,18 +432,20 @@ func (t *TrackerAgent) Integrate(input *TrackerInput) (*TrackerOutput, error) {
 		approved = input.ReviewResult.Approved
 	}
 
-	output := &TrackerOutput{
-		Status: "applied",
-	}
+	output := &TrackerOutput{}
 
 	if !approved {
 		// Save rejection feedback
-		feedbackDir := filepath.Join(t.BaseDir, "local", "postings")
+		feedbackDir := t.FeedbackDir
+		if feedbackDir == "" {
+			feedbackDir = filepath.Join(t.BaseDir, "local", "postings")
+		}
 		feedbackPath, err := t.SaveRejectionFeedback(input, feedbackDir)
 		if err != nil {
 			return nil, fmt.Errorf("failed to save rejection feedback: %w", err)
 		}
 		output.Status = "rejected"
+		output.FeedbackPath = feedbackPath
 		output.Notes = fmt.Sprintf("Feedback saved to: %s", feedbackPath)
 		return output, nil
 	}
@@ -430,6 +457,7 @@ func (t *TrackerAgent) Integrate(input *TrackerInput) (*TrackerOutput, error) {
 	}
 
 	output.ApplicationID = app.ID
+	output.Status = app.Status
 	output.ResumeVersion = app.ResumeVersion
 	output.CoverLetter = app.CoverLetter
 
@@ -2,11 +2,15 @@ package agent
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/celloopa/ghosted/internal/model"
 	"github.com/celloopa/ghosted/internal/store"
 )
 
@@ -139,6 +143,7 @@ func TestTrackerAgent_GenerateNotes(t *testing.T) {
 		Posting: &ParsedPosting{
 			TechStack:    []string{"Go", "React", "PostgreSQL"},
 			Requirements: []string{"5+ years experience", "Distributed systems", "AWS", "Docker"},
+			Benefits:     []string{"401k matching", "Unlimited PTO"},
 			Notes:        "Great company culture",
 		},
 		DetailedReview: &DetailedReviewResult{
@@ -157,6 +162,9 @@ func TestTrackerAgent_GenerateNotes(t *testing.T) {
 	if !strings.Contains(notes, "Key requirements:") {
 		t.Error("Notes should contain key requirements")
 	}
+	if !strings.Contains(notes, "Benefits: 401k matching, Unlimited PTO") {
+		t.Error("Notes should contain benefits")
+	}
 	if !strings.Contains(notes, "Great company culture") {
 		t.Error("Notes should contain original posting notes")
 	}
@@ -279,7 +287,7 @@ func TestTrackerAgent_ArchivePosting(t *testing.T) {
 		t.Fatalf("Failed to create test posting: %v", err)
 	}
 
-	err := agent.ArchivePosting(postingPath, processedDir)
+	err := agent.ArchivePosting(postingPath, processedDir, nil)
 	if err != nil {
 		t.Errorf("ArchivePosting() error = %v", err)
 	}
@@ -300,7 +308,7 @@ func TestTrackerAgent_ArchivePosting_MissingFile(t *testing.T) {
 	agent := NewTrackerAgent(&AgentConfig{Type: AgentTracker}, nil, "")
 
 	tmpDir := t.TempDir()
-	err := agent.ArchivePosting("/nonexistent/posting.md", tmpDir)
+	err := agent.ArchivePosting("/nonexistent/posting.md", tmpDir, nil)
 	if err == nil {
 		t.Error("ArchivePosting() expected error for missing file")
 	}
@@ -309,7 +317,7 @@ func TestTrackerAgent_ArchivePosting_MissingFile(t *testing.T) {
 func TestTrackerAgent_ArchivePosting_EmptyPath(t *testing.T) {
 	agent := NewTrackerAgent(&AgentConfig{Type: AgentTracker}, nil, "")
 
-	err := agent.ArchivePosting("", "/some/dir")
+	err := agent.ArchivePosting("", "/some/dir", nil)
 	if err == nil {
 		t.Error("ArchivePosting() expected error for empty path")
 	}
@@ -480,8 +488,8 @@ func TestTrackerAgent_Integrate_Approved(t *testing.T) {
 		t.Errorf("Integrate() error = %v", err)
 	}
 
-	if output.Status != "applied" {
-		t.Errorf("Status = %q, want %q", output.Status, "applied")
+	if output.Status != DefaultInitialStatus {
+		t.Errorf("Status = %q, want %q", output.Status, DefaultInitialStatus)
 	}
 	if output.ApplicationID == "" {
 		t.Error("ApplicationID should not be empty for approved application")
@@ -555,3 +563,200 @@ func TestTrackerAgent_Integrate_AutoDeterminesJobType(t *testing.T) {
 		t.Errorf("JobType = %q, want %q", input.JobType, "fe-dev")
 	}
 }
+
+func TestAppendArchiveIndex_Concurrent(t *testing.T) {
+	processedDir := t.TempDir()
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			name := fmt.Sprintf("company%d-swe-posting.md", i)
+			if err := appendArchiveIndex(processedDir, name, nil, time.Now()); err != nil {
+				t.Errorf("appendArchiveIndex() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	index, err := LoadArchiveIndex(processedDir)
+	if err != nil {
+		t.Fatalf("LoadArchiveIndex() error = %v", err)
+	}
+	if len(index) != 20 {
+		t.Errorf("index has %d entries, want all 20", len(index))
+	}
+}
+
+func TestTrackerAgent_ArchivePosting_AppendsIndex(t *testing.T) {
+	agent := NewTrackerAgent(&AgentConfig{Type: AgentTracker}, nil, "")
+
+	tmpDir := t.TempDir()
+	processedDir := filepath.Join(tmpDir, "processed")
+	first := filepath.Join(tmpDir, "acme-swe-posting.md")
+	second := filepath.Join(tmpDir, "globex-designer-posting.md")
+	for _, path := range []string{first, second} {
+		if err := os.WriteFile(path, []byte("posting"), 0644); err != nil {
+			t.Fatalf("Failed to create test posting: %v", err)
+		}
+	}
+
+	app := &model.Application{ID: "app-123", Company: "Acme Corp", Position: "Software Engineer"}
+	if err := agent.ArchivePosting(first, processedDir, app); err != nil {
+		t.Fatalf("ArchivePosting() error = %v", err)
+	}
+	if err := agent.ArchivePosting(second, processedDir, nil); err != nil {
+		t.Fatalf("ArchivePosting() error = %v", err)
+	}
+
+	index, err := LoadArchiveIndex(processedDir)
+	if err != nil {
+		t.Fatalf("LoadArchiveIndex() error = %v", err)
+	}
+	if len(index) != 2 {
+		t.Fatalf("index has %d entries, want 2: %+v", len(index), index)
+	}
+
+	linked := index["acme-swe-posting.md"]
+	if linked.ApplicationID != "app-123" || linked.Company != "Acme Corp" || linked.Position != "Software Engineer" {
+		t.Errorf("linked entry = %+v, want app-123 Acme Corp/Software Engineer", linked)
+	}
+	if linked.ArchivedAt.IsZero() {
+		t.Error("ArchivedAt should be set")
+	}
+
+	unlinked := index["globex-designer-posting.md"]
+	if unlinked.ApplicationID != "" || unlinked.Company != "Globex" {
+		t.Errorf("unlinked entry = %+v, want filename-derived company and no application", unlinked)
+	}
+}
+
+func TestLoadArchiveIndex_Missing(t *testing.T) {
+	index, err := LoadArchiveIndex(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadArchiveIndex() error = %v", err)
+	}
+	if len(index) != 0 {
+		t.Errorf("LoadArchiveIndex() = %+v, want empty", index)
+	}
+}
+
+func TestListPostings(t *testing.T) {
+	tmpDir := t.TempDir()
+	s, err := store.New(filepath.Join(tmpDir, "data", "applications.json"))
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	app, err := s.Add(model.Application{Company: "Acme", Position: "SWE"})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	postingsDir := filepath.Join(tmpDir, "postings")
+	processedDir := filepath.Join(postingsDir, "processed")
+	if err := os.MkdirAll(postingsDir, 0755); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	for _, name := range []string{"acme-swe-posting.md", "newco-pm-posting.md"} {
+		if err := os.WriteFile(filepath.Join(postingsDir, name), []byte("posting"), 0644); err != nil {
+			t.Fatalf("Failed to write posting: %v", err)
+		}
+	}
+
+	tracker := NewTrackerAgent(&AgentConfig{Type: AgentTracker}, s, "")
+	if err := tracker.ArchivePosting(filepath.Join(postingsDir, "acme-swe-posting.md"), processedDir, &app); err != nil {
+		t.Fatalf("ArchivePosting() error = %v", err)
+	}
+
+	postings, err := ListPostings(postingsDir, s)
+	if err != nil {
+		t.Fatalf("ListPostings() error = %v", err)
+	}
+	if len(postings) != 2 {
+		t.Fatalf("ListPostings() returned %d postings, want 2: %+v", len(postings), postings)
+	}
+
+	pending, archived := postings[0], postings[1]
+	if pending.Archived || filepath.Base(pending.Path) != "newco-pm-posting.md" || pending.App != nil {
+		t.Errorf("pending = %+v, want unlinked newco-pm-posting.md", pending)
+	}
+	if !archived.Archived || filepath.Base(archived.Path) != "acme-swe-posting.md" {
+		t.Errorf("archived = %+v, want acme-swe-posting.md", archived)
+	}
+	if archived.App == nil || archived.App.ID != app.ID {
+		t.Errorf("archived posting should link to application %s, got %+v", app.ID, archived.App)
+	}
+}
+
+// trackedStatuses creates an entry through TrackerAgent.CreateApplication and
+// through a pipeline run, both configured with initialStatus, and returns the
+// status each entry got
+func trackedStatuses(t *testing.T, initialStatus string) (agentStatus, pipelineStatus string) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	s, err := store.New(filepath.Join(tmpDir, "applications.json"))
+	if err != nil {
+		t.Fatalf("store.New() error = %v", err)
+	}
+
+	tracker := NewTrackerAgent(&AgentConfig{Type: AgentTracker}, s, tmpDir)
+	tracker.InitialStatus = initialStatus
+	app, err := tracker.CreateApplication(&TrackerInput{
+		Posting: &ParsedPosting{Company: "StatusCorp", Position: "Engineer"},
+	})
+	if err != nil {
+		t.Fatalf("CreateApplication() error = %v", err)
+	}
+
+	postingPath := filepath.Join(tmpDir, "statuscorp-engineer-posting.md")
+	if err := os.WriteFile(postingPath, []byte("# Engineer\n"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	pipeline, err := NewPipeline(filepath.Join(tmpDir, ".agent", "config.json"), s)
+	if err != nil {
+		t.Fatalf("NewPipeline() error = %v", err)
+	}
+	pipeline.Config.Output.InitialStatus = initialStatus
+	if err := pipeline.Run(postingPath); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	var created model.Application
+	if err := json.Unmarshal(pipeline.State.Results[AgentTracker].Output, &created); err != nil {
+		t.Fatalf("decoding tracker output: %v", err)
+	}
+
+	return app.Status, created.Status
+}
+
+func TestInitialStatus_DefaultConsistent(t *testing.T) {
+	agentStatus, pipelineStatus := trackedStatuses(t, "")
+	if agentStatus != DefaultInitialStatus || pipelineStatus != DefaultInitialStatus {
+		t.Errorf("default status: tracker agent %q, pipeline %q, want both %q",
+			agentStatus, pipelineStatus, DefaultInitialStatus)
+	}
+}
+
+func TestInitialStatus_Configured(t *testing.T) {
+	for _, status := range []string{model.StatusSaved, model.StatusApplied} {
+		agentStatus, pipelineStatus := trackedStatuses(t, status)
+		if agentStatus != status || pipelineStatus != status {
+			t.Errorf("initial_status %q: tracker agent %q, pipeline %q", status, agentStatus, pipelineStatus)
+		}
+	}
+}
+
+func TestResolveInitialStatus_Invalid(t *testing.T) {
+	if _, err := ResolveInitialStatus(model.StatusInterview); err == nil {
+		t.Error("ResolveInitialStatus(interview) should fail")
+	}
+
+	tracker := NewTrackerAgent(&AgentConfig{Type: AgentTracker}, &store.Store{}, "")
+	tracker.InitialStatus = "offer"
+	_, err := tracker.CreateApplication(&TrackerInput{
+		Posting: &ParsedPosting{Company: "StatusCorp", Position: "Engineer"},
+	})
+	if err == nil {
+		t.Error("CreateApplication() with an invalid initial status should fail")
+	}
+}
@@ -0,0 +1,94 @@
+package fetch
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// ErrPostingClosed is returned by Fetch when a job board reports the posting
+// as closed and Force is not set
+var ErrPostingClosed = errors.New("posting is closed")
+
+// boardClosedMarkers are the banners job boards show on closed postings.
+// A match on the board's own host is treated as certain.
+var boardClosedMarkers = map[string][]string{
+	"lever.co": {
+		"this job is no longer available",
+		"the job posting you're looking for might have closed",
+	},
+	"greenhouse.io": {
+		"the job you are looking for is no longer open",
+		"this job is no longer open",
+	},
+	"linkedin.com": {
+		"no longer accepting applications",
+	},
+	"ashbyhq.com": {
+		"this job is no longer available",
+		"job not found",
+	},
+	"workday.com": {
+		"the job posting you are looking for is no longer available",
+		"this job posting is no longer available",
+	},
+}
+
+// closedPhrases suggest a closed posting on any site. They may appear in
+// unrelated copy, so a match only warns.
+var closedPhrases = []string{
+	"no longer accepting applications",
+	"this job is no longer available",
+	"this position is no longer available",
+	"this position has been filled",
+	"this role has been filled",
+	"this job has expired",
+	"this posting has expired",
+	"this job posting has expired",
+	"this job has been closed",
+	"applications are closed",
+	"applications for this position are closed",
+}
+
+// closedStatus describes whether a fetched page looks like a closed posting
+type closedStatus struct {
+	Closed bool
+	Reason string
+	// Certain is set for board-specific signals; generic phrases are only
+	// a hint
+	Certain bool
+}
+
+// detectClosed checks a fetched page for signs that the posting is closed.
+// requested is the URL asked for and final is where redirects ended up.
+func detectClosed(page string, requested, final *url.URL) closedStatus {
+	// Greenhouse redirects closed jobs back to the company board
+	// (boards.greenhouse.io/acme/jobs/123 -> boards.greenhouse.io/acme?error=true)
+	if strings.Contains(strings.ToLower(requested.Host), "greenhouse.io") &&
+		strings.Contains(requested.Path, "/jobs/") &&
+		(!strings.Contains(final.Path, "/jobs/") || final.Query().Get("error") == "true") {
+		return closedStatus{Closed: true, Reason: "Greenhouse redirected to the job board", Certain: true}
+	}
+
+	text := strings.ToLower(visibleText(page))
+	text = strings.ReplaceAll(text, "’", "'")
+
+	host := strings.ToLower(final.Host)
+	for board, markers := range boardClosedMarkers {
+		if !strings.Contains(host, board) {
+			continue
+		}
+		for _, marker := range markers {
+			if strings.Contains(text, marker) {
+				return closedStatus{Closed: true, Reason: "page says \"" + marker + "\"", Certain: true}
+			}
+		}
+	}
+
+	for _, phrase := range closedPhrases {
+		if strings.Contains(text, phrase) {
+			return closedStatus{Closed: true, Reason: "page says \"" + phrase + "\""}
+		}
+	}
+	return closedStatus{}
+}
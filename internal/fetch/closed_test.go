@@ -0,0 +1,126 @@
+package fetch
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+)
+
+const closedBannerPage = `<html><head><title>Backend Engineer</title>
+<meta property="og:site_name" content="Acme"></head>
+<body><div class="banner">No longer accepting applications</div>
+<main><h1>Backend Engineer</h1><p>Build APIs in Go.</p></main></body></html>`
+
+func TestFetcher_Fetch_DetectsClosedBanner(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, closedBannerPage)
+	}))
+	defer server.Close()
+
+	f := NewFetcher(t.TempDir())
+	result, err := f.Fetch(server.URL+"/jobs/1", "acme-backend")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if !result.Closed {
+		t.Error("Closed = false for a page with a closed banner")
+	}
+	if result.ClosedReason == "" {
+		t.Error("ClosedReason is empty")
+	}
+	// Generic phrases only warn, so the posting is still saved
+	if _, err := os.Stat(result.OutputPath); err != nil {
+		t.Errorf("posting not saved: %v", err)
+	}
+}
+
+func TestFetcher_Fetch_OpenPosting(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, redirectTargetPage)
+	}))
+	defer server.Close()
+
+	f := NewFetcher(t.TempDir())
+	result, err := f.Fetch(server.URL+"/jobs/1", "acme-backend")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if result.Closed {
+		t.Errorf("Closed = true for an open posting (%s)", result.ClosedReason)
+	}
+}
+
+func TestDetectClosed(t *testing.T) {
+	mustParse := func(raw string) *url.URL {
+		u, err := url.Parse(raw)
+		if err != nil {
+			t.Fatalf("url.Parse(%q) error = %v", raw, err)
+		}
+		return u
+	}
+
+	tests := []struct {
+		name        string
+		page        string
+		requested   string
+		final       string
+		wantClosed  bool
+		wantCertain bool
+	}{
+		{
+			name:        "lever banner",
+			page:        `<div>Sorry, this job is no longer available.</div>`,
+			requested:   "https://jobs.lever.co/acme/123",
+			final:       "https://jobs.lever.co/acme/123",
+			wantClosed:  true,
+			wantCertain: true,
+		},
+		{
+			name:        "linkedin banner",
+			page:        `<span>No longer accepting applications</span>`,
+			requested:   "https://www.linkedin.com/jobs/view/42",
+			final:       "https://www.linkedin.com/jobs/view/42",
+			wantClosed:  true,
+			wantCertain: true,
+		},
+		{
+			name:        "greenhouse redirect to board",
+			page:        `<h1>Current openings at Acme</h1>`,
+			requested:   "https://boards.greenhouse.io/acme/jobs/123",
+			final:       "https://boards.greenhouse.io/acme?error=true",
+			wantClosed:  true,
+			wantCertain: true,
+		},
+		{
+			name:       "generic phrase on other site",
+			page:       `<p>This position has been filled.</p>`,
+			requested:  "https://acme.com/careers/1",
+			final:      "https://acme.com/careers/1",
+			wantClosed: true,
+		},
+		{
+			name:      "phrase only in script is ignored",
+			page:      `<script>var msg = "this job is no longer available";</script><p>Apply now</p>`,
+			requested: "https://jobs.lever.co/acme/123",
+			final:     "https://jobs.lever.co/acme/123",
+		},
+		{
+			name:      "open posting",
+			page:      `<h1>Backend Engineer</h1><p>Apply now</p>`,
+			requested: "https://boards.greenhouse.io/acme/jobs/123",
+			final:     "https://boards.greenhouse.io/acme/jobs/123",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := detectClosed(tt.page, mustParse(tt.requested), mustParse(tt.final))
+			if got.Closed != tt.wantClosed || got.Certain != tt.wantCertain {
+				t.Errorf("detectClosed() = %+v, want closed=%v certain=%v", got, tt.wantClosed, tt.wantCertain)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package fetch
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Opening is a position listed on a company's job board
+type Opening struct {
+	Title    string `json:"title"`
+	URL      string `json:"url"`
+	Location string `json:"location,omitempty"`
+}
+
+// Public listing APIs of the company-hosted job boards, keyed by board.
+// %s is the company's board name. Variables so tests can point them at a
+// local server.
+var (
+	leverListingAPI      = "https://api.lever.co/v0/postings/%s?mode=json"
+	greenhouseListingAPI = "https://boards-api.greenhouse.io/v1/boards/%s/jobs"
+	ashbyListingAPI      = "https://api.ashbyhq.com/posting-api/job-board/%s"
+)
+
+// companyBoard identifies the job board and company behind a posting URL,
+// e.g. jobs.lever.co/acme/123 is Lever board "acme" with posting "123".
+// ok is false for hosts without a public company listing.
+func companyBoard(u *url.URL) (board, company, postingID string, ok bool) {
+	host := strings.ToLower(u.Hostname())
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(segments) == 0 || segments[0] == "" {
+		return "", "", "", false
+	}
+	company = segments[0]
+
+	switch {
+	case strings.HasSuffix(host, "lever.co"):
+		board = "lever"
+	case strings.HasSuffix(host, "greenhouse.io"):
+		board = "greenhouse"
+	case strings.HasSuffix(host, "ashbyhq.com"):
+		board = "ashby"
+	default:
+		return "", "", "", false
+	}
+	// Lever and Ashby: /{company}/{id}[/apply]; Greenhouse: /{company}/jobs/{id}
+	if len(segments) > 1 {
+		postingID = segments[1]
+		if board == "greenhouse" && postingID == "jobs" && len(segments) > 2 {
+			postingID = segments[2]
+		}
+	}
+	return board, company, postingID, true
+}
+
+// CompanyOpenings lists the other open positions on the board a posting
+// came from, excluding the posting itself. Postings on hosts without a
+// public company listing return no openings and no error.
+func (f *Fetcher) CompanyOpenings(postingURL string) ([]Opening, error) {
+	u, err := url.Parse(postingURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL: %w", err)
+	}
+	board, company, postingID, ok := companyBoard(u)
+	if !ok {
+		return nil, nil
+	}
+
+	var listing string
+	switch board {
+	case "lever":
+		listing = leverListingAPI
+	case "greenhouse":
+		listing = greenhouseListingAPI
+	case "ashby":
+		listing = ashbyListingAPI
+	}
+	apiURL, err := url.Parse(fmt.Sprintf(listing, url.PathEscape(company)))
+	if err != nil {
+		return nil, err
+	}
+
+	body, _, _, err := f.get(apiURL, u)
+	if err != nil {
+		return nil, fmt.Errorf("listing %s openings: %w", company, err)
+	}
+
+	var openings []Opening
+	switch board {
+	case "lever":
+		openings, err = parseLeverListing(body)
+	case "greenhouse":
+		openings, err = parseGreenhouseListing(body)
+	case "ashby":
+		openings, err = parseAshbyListing(body)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s listing: %w", board, err)
+	}
+
+	// Leave out the posting that was just fetched
+	var others []Opening
+	for _, opening := range openings {
+		if postingID != "" && strings.Contains(opening.URL, postingID) {
+			continue
+		}
+		others = append(others, opening)
+	}
+	return others, nil
+}
+
+// parseLeverListing reads Lever's postings API response
+func parseLeverListing(body string) ([]Opening, error) {
+	var postings []struct {
+		Text       string `json:"text"`
+		HostedURL  string `json:"hostedUrl"`
+		Categories struct {
+			Location string `json:"location"`
+		} `json:"categories"`
+	}
+	if err := json.Unmarshal([]byte(body), &postings); err != nil {
+		return nil, err
+	}
+	openings := make([]Opening, 0, len(postings))
+	for _, p := range postings {
+		openings = append(openings, Opening{Title: p.Text, URL: p.HostedURL, Location: p.Categories.Location})
+	}
+	return openings, nil
+}
+
+// parseGreenhouseListing reads Greenhouse's job board API response
+func parseGreenhouseListing(body string) ([]Opening, error) {
+	var listing struct {
+		Jobs []struct {
+			Title       string `json:"title"`
+			AbsoluteURL string `json:"absolute_url"`
+			Location    struct {
+				Name string `json:"name"`
+			} `json:"location"`
+		} `json:"jobs"`
+	}
+	if err := json.Unmarshal([]byte(body), &listing); err != nil {
+		return nil, err
+	}
+	openings := make([]Opening, 0, len(listing.Jobs))
+	for _, j := range listing.Jobs {
+		openings = append(openings, Opening{Title: j.Title, URL: j.AbsoluteURL, Location: j.Location.Name})
+	}
+	return openings, nil
+}
+
+// parseAshbyListing reads Ashby's posting API response
+func parseAshbyListing(body string) ([]Opening, error) {
+	var listing struct {
+		Jobs []struct {
+			Title    string `json:"title"`
+			JobURL   string `json:"jobUrl"`
+			Location string `json:"location"`
+		} `json:"jobs"`
+	}
+	if err := json.Unmarshal([]byte(body), &listing); err != nil {
+		return nil, err
+	}
+	openings := make([]Opening, 0, len(listing.Jobs))
+	for _, j := range listing.Jobs {
+		openings = append(openings, Opening{Title: j.Title, URL: j.JobURL, Location: j.Location})
+	}
+	return openings, nil
+}
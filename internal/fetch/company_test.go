@@ -0,0 +1,97 @@
+package fetch
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+const leverListing = `[
+  {"id": "aaa-111", "text": "Backend Engineer", "hostedUrl": "https://jobs.lever.co/acme/aaa-111", "categories": {"location": "Remote"}},
+  {"id": "bbb-222", "text": "Product Designer", "hostedUrl": "https://jobs.lever.co/acme/bbb-222", "categories": {"location": "NYC"}},
+  {"id": "ccc-333", "text": "Data Engineer", "hostedUrl": "https://jobs.lever.co/acme/ccc-333", "categories": {}}
+]`
+
+func TestFetcher_CompanyOpenings_Lever(t *testing.T) {
+	var requested string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.Path
+		fmt.Fprint(w, leverListing)
+	}))
+	defer server.Close()
+
+	original := leverListingAPI
+	leverListingAPI = server.URL + "/v0/postings/%s?mode=json"
+	defer func() { leverListingAPI = original }()
+
+	f := NewFetcher(t.TempDir())
+	openings, err := f.CompanyOpenings("https://jobs.lever.co/acme/aaa-111")
+	if err != nil {
+		t.Fatalf("CompanyOpenings() error = %v", err)
+	}
+	if requested != "/v0/postings/acme" {
+		t.Errorf("requested %q, want the acme listing", requested)
+	}
+
+	want := []Opening{
+		{Title: "Product Designer", URL: "https://jobs.lever.co/acme/bbb-222", Location: "NYC"},
+		{Title: "Data Engineer", URL: "https://jobs.lever.co/acme/ccc-333"},
+	}
+	if len(openings) != len(want) {
+		t.Fatalf("openings = %+v, want the %d siblings", openings, len(want))
+	}
+	for i := range want {
+		if openings[i] != want[i] {
+			t.Errorf("openings[%d] = %+v, want %+v", i, openings[i], want[i])
+		}
+	}
+}
+
+func TestFetcher_CompanyOpenings_GenericHost(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL)
+	}))
+	defer server.Close()
+
+	f := NewFetcher(t.TempDir())
+	openings, err := f.CompanyOpenings(server.URL + "/careers/backend-engineer")
+	if err != nil || openings != nil {
+		t.Errorf("CompanyOpenings(generic) = %+v, %v; want nothing", openings, err)
+	}
+}
+
+func TestCompanyBoard(t *testing.T) {
+	tests := []struct {
+		url                  string
+		board, company, post string
+		ok                   bool
+	}{
+		{"https://jobs.lever.co/acme/aaa-111/apply", "lever", "acme", "aaa-111", true},
+		{"https://boards.greenhouse.io/acme/jobs/4012345", "greenhouse", "acme", "4012345", true},
+		{"https://jobs.ashbyhq.com/acme/5f1c-99", "ashby", "acme", "5f1c-99", true},
+		{"https://jobs.lever.co/acme", "lever", "acme", "", true},
+		{"https://example.com/careers/123", "", "", "", false},
+		{"https://jobs.lever.co/", "", "", "", false},
+	}
+	for _, tt := range tests {
+		u, _ := url.Parse(tt.url)
+		board, company, post, ok := companyBoard(u)
+		if board != tt.board || company != tt.company || post != tt.post || ok != tt.ok {
+			t.Errorf("companyBoard(%s) = %q, %q, %q, %v; want %q, %q, %q, %v",
+				tt.url, board, company, post, ok, tt.board, tt.company, tt.post, tt.ok)
+		}
+	}
+}
+
+func TestParseGreenhouseAndAshbyListings(t *testing.T) {
+	gh, err := parseGreenhouseListing(`{"jobs": [{"title": "SRE", "absolute_url": "https://boards.greenhouse.io/acme/jobs/1", "location": {"name": "Berlin"}}]}`)
+	if err != nil || len(gh) != 1 || gh[0].Title != "SRE" || gh[0].Location != "Berlin" {
+		t.Errorf("parseGreenhouseListing() = %+v, %v", gh, err)
+	}
+	ashby, err := parseAshbyListing(`{"jobs": [{"title": "iOS Engineer", "jobUrl": "https://jobs.ashbyhq.com/acme/x", "location": "Remote"}]}`)
+	if err != nil || len(ashby) != 1 || ashby[0].URL != "https://jobs.ashbyhq.com/acme/x" {
+		t.Errorf("parseAshbyListing() = %+v, %v", ashby, err)
+	}
+}
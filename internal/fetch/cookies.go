@@ -0,0 +1,192 @@
+package fetch
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LoadCookieFile reads cookies for authenticated fetches. It accepts the
+// Netscape cookies.txt format exported by browser extensions and curl, or a
+// plain header line such as "name=value; name2=value2" (optionally prefixed
+// with "Cookie:"). Header-style cookies have no domain and are sent only to
+// the host of the URL being fetched, not to hosts it redirects to. Netscape
+// cookies follow their domain across redirects.
+func LoadCookieFile(path string) ([]*http.Cookie, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cookies, err := ParseCookies(string(data), time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return cookies, nil
+}
+
+// ParseCookies parses cookie file content, dropping Netscape entries that
+// expired before now
+func ParseCookies(content string, now time.Time) ([]*http.Cookie, error) {
+	if isNetscapeCookies(content) {
+		return parseNetscapeCookies(content, now)
+	}
+	return parseCookieHeader(content)
+}
+
+// isNetscapeCookies reports whether any entry has the 7 tab-separated
+// Netscape fields
+func isNetscapeCookies(content string) bool {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimPrefix(strings.TrimSpace(line), "#HttpOnly_")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if len(strings.Split(line, "\t")) == 7 {
+			return true
+		}
+	}
+	return false
+}
+
+// parseNetscapeCookies parses "domain flag path secure expiry name value"
+// lines. Comments are skipped, except the "#HttpOnly_" prefix which marks a
+// real cookie.
+func parseNetscapeCookies(content string, now time.Time) ([]*http.Cookie, error) {
+	var cookies []*http.Cookie
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimRight(scanner.Text(), "\r")
+		httpOnly := strings.HasPrefix(line, "#HttpOnly_")
+		line = strings.TrimPrefix(line, "#HttpOnly_")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		if len(fields) != 7 {
+			return nil, fmt.Errorf("line %d: expected 7 tab-separated fields, got %d", lineNum, len(fields))
+		}
+
+		expiry, err := strconv.ParseInt(fields[4], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid expiry %q", lineNum, fields[4])
+		}
+		// Zero expiry marks a session cookie
+		if expiry > 0 && time.Unix(expiry, 0).Before(now) {
+			continue
+		}
+
+		cookie := &http.Cookie{
+			Domain:   fields[0],
+			Path:     fields[2],
+			Secure:   strings.EqualFold(fields[3], "TRUE"),
+			Name:     fields[5],
+			Value:    fields[6],
+			HttpOnly: httpOnly,
+		}
+		if expiry > 0 {
+			cookie.Expires = time.Unix(expiry, 0)
+		}
+		cookies = append(cookies, cookie)
+	}
+	return cookies, scanner.Err()
+}
+
+// parseCookieHeader parses "name=value; name2=value2" pairs, one or more
+// lines, with an optional "Cookie:" prefix
+func parseCookieHeader(content string) ([]*http.Cookie, error) {
+	var cookies []*http.Cookie
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if len(line) > 7 && strings.EqualFold(line[:7], "cookie:") {
+			line = line[7:]
+		}
+		for _, pair := range strings.Split(line, ";") {
+			pair = strings.TrimSpace(pair)
+			if pair == "" {
+				continue
+			}
+			name, value, ok := strings.Cut(pair, "=")
+			if !ok || strings.TrimSpace(name) == "" {
+				return nil, fmt.Errorf("invalid cookie %q (expected name=value)", pair)
+			}
+			cookies = append(cookies, &http.Cookie{Name: strings.TrimSpace(name), Value: strings.TrimSpace(value)})
+		}
+	}
+	if len(cookies) == 0 {
+		return nil, fmt.Errorf("no cookies found")
+	}
+	return cookies, nil
+}
+
+// cookieMatches reports whether a loaded cookie should be sent to u, on a
+// fetch that started at origin. Cookies without a domain match only origin's
+// host.
+func cookieMatches(c *http.Cookie, u, origin *url.URL) bool {
+	if c.Secure && u.Scheme != "https" {
+		return false
+	}
+	path := u.Path
+	if path == "" {
+		path = "/"
+	}
+	if c.Path != "" && !strings.HasPrefix(path, c.Path) {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	if c.Domain == "" {
+		return host == strings.ToLower(origin.Hostname())
+	}
+
+	domain := strings.ToLower(strings.TrimPrefix(c.Domain, "."))
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+// addCookies attaches the fetcher's cookies that match the request URL, on
+// a fetch that started at origin
+func (f *Fetcher) addCookies(req *http.Request, origin *url.URL) {
+	for _, c := range f.Cookies {
+		if cookieMatches(c, req.URL, origin) {
+			req.AddCookie(&http.Cookie{Name: c.Name, Value: c.Value})
+		}
+	}
+}
+
+// maxHTTPRedirects matches http.Client's default limit on 3xx redirects
+const maxHTTPRedirects = 10
+
+// cookieClient returns a copy of f.Client that re-applies the loaded cookies
+// on each 3xx redirect of a fetch that started at origin. Left to itself the
+// client forwards the first request's Cookie header only to the same domain
+// or its subdomains, dropping cookies for an auth host elsewhere and keeping
+// domainless ones that cookieMatches would not send.
+func (f *Fetcher) cookieClient(origin *url.URL) *http.Client {
+	if len(f.Cookies) == 0 {
+		return f.Client
+	}
+	client := *f.Client
+	checkRedirect := f.Client.CheckRedirect
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if checkRedirect != nil {
+			if err := checkRedirect(req, via); err != nil {
+				return err
+			}
+		} else if len(via) >= maxHTTPRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxHTTPRedirects)
+		}
+		req.Header.Del("Cookie")
+		f.addCookies(req, origin)
+		return nil
+	}
+	return &client
+}
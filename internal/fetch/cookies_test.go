@@ -0,0 +1,229 @@
+package fetch
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const sampleCookiesTxt = "# Netscape HTTP Cookie File\n" +
+	"# This is a generated file! Do not edit.\n" +
+	"\n" +
+	".example.com\tTRUE\t/\tFALSE\t0\tsession\tabc123\n" +
+	"#HttpOnly_careers.example.com\tFALSE\t/jobs\tTRUE\t4102444800\tauth\ttok\n" +
+	".example.com\tTRUE\t/\tFALSE\t946684800\told\texpired\n"
+
+func TestParseCookies_Netscape(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cookies, err := ParseCookies(sampleCookiesTxt, now)
+	if err != nil {
+		t.Fatalf("ParseCookies() error = %v", err)
+	}
+	if len(cookies) != 2 {
+		t.Fatalf("got %d cookies, want 2 (expired entry skipped)", len(cookies))
+	}
+
+	session := cookies[0]
+	if session.Name != "session" || session.Value != "abc123" || session.Domain != ".example.com" || session.Path != "/" {
+		t.Errorf("session cookie = %+v", session)
+	}
+	if !session.Expires.IsZero() {
+		t.Errorf("session cookie Expires = %v, want zero", session.Expires)
+	}
+
+	auth := cookies[1]
+	if auth.Name != "auth" || auth.Domain != "careers.example.com" || auth.Path != "/jobs" {
+		t.Errorf("auth cookie = %+v", auth)
+	}
+	if !auth.Secure || !auth.HttpOnly {
+		t.Errorf("auth cookie Secure = %v, HttpOnly = %v, want both true", auth.Secure, auth.HttpOnly)
+	}
+}
+
+func TestParseCookies_Header(t *testing.T) {
+	cookies, err := ParseCookies("Cookie: session=abc123; csrf=x=y\n", time.Now())
+	if err != nil {
+		t.Fatalf("ParseCookies() error = %v", err)
+	}
+	if len(cookies) != 2 {
+		t.Fatalf("got %d cookies, want 2", len(cookies))
+	}
+	if cookies[0].Name != "session" || cookies[0].Value != "abc123" {
+		t.Errorf("cookies[0] = %s=%s", cookies[0].Name, cookies[0].Value)
+	}
+	// Only the first '=' separates name from value
+	if cookies[1].Name != "csrf" || cookies[1].Value != "x=y" {
+		t.Errorf("cookies[1] = %s=%s", cookies[1].Name, cookies[1].Value)
+	}
+}
+
+func TestParseCookies_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"empty", "\n# only a comment\n"},
+		{"missing equals", "session"},
+		{"bad expiry", ".example.com\tTRUE\t/\tFALSE\tsoon\tsession\tabc\n"},
+		{"short netscape line", ".example.com\tTRUE\t/\tFALSE\t0\tsession\tabc\nexample.com\tTRUE\t/\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseCookies(tt.content, time.Now()); err == nil {
+				t.Error("ParseCookies() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestCookieMatches(t *testing.T) {
+	u, _ := url.Parse("https://careers.example.com/jobs/42")
+	tests := []struct {
+		name   string
+		cookie *http.Cookie
+		want   bool
+	}{
+		{"no domain", &http.Cookie{Name: "a"}, true},
+		{"no domain, other host", &http.Cookie{Name: "a"}, false},
+		{"parent domain", &http.Cookie{Name: "a", Domain: ".example.com", Path: "/"}, true},
+		{"exact host", &http.Cookie{Name: "a", Domain: "careers.example.com", Path: "/jobs"}, true},
+		{"other domain", &http.Cookie{Name: "a", Domain: "example.org", Path: "/"}, false},
+		{"suffix without dot", &http.Cookie{Name: "a", Domain: "ample.com", Path: "/"}, false},
+		{"other path", &http.Cookie{Name: "a", Domain: "example.com", Path: "/admin"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origin := u
+			if strings.HasSuffix(tt.name, "other host") {
+				origin, _ = url.Parse("https://login.example.org/")
+			}
+			if got := cookieMatches(tt.cookie, u, origin); got != tt.want {
+				t.Errorf("cookieMatches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	plain, _ := url.Parse("http://careers.example.com/jobs/42")
+	if cookieMatches(&http.Cookie{Name: "a", Secure: true}, plain, plain) {
+		t.Error("secure cookie matched an http URL")
+	}
+}
+
+func TestFetcher_Fetch_SendsCookies(t *testing.T) {
+	var gotCookie string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotCookie = r.Header.Get("Cookie")
+		fmt.Fprint(w, redirectTargetPage)
+	}))
+	defer server.Close()
+
+	cookieFile := filepath.Join(t.TempDir(), "cookies.txt")
+	if err := os.WriteFile(cookieFile, []byte("session=abc123; csrf=xyz"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cookies, err := LoadCookieFile(cookieFile)
+	if err != nil {
+		t.Fatalf("LoadCookieFile() error = %v", err)
+	}
+
+	f := NewFetcher(t.TempDir())
+	f.Cookies = cookies
+	if _, err := f.Fetch(server.URL+"/jobs/1", "acme-backend"); err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if gotCookie != "session=abc123; csrf=xyz" {
+		t.Errorf("Cookie header = %q, want %q", gotCookie, "session=abc123; csrf=xyz")
+	}
+}
+
+func TestFetcher_Fetch_SkipsCookiesForOtherDomains(t *testing.T) {
+	var gotCookie string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotCookie = r.Header.Get("Cookie")
+		fmt.Fprint(w, redirectTargetPage)
+	}))
+	defer server.Close()
+
+	f := NewFetcher(t.TempDir())
+	f.Cookies = []*http.Cookie{{Name: "session", Value: "abc123", Domain: ".example.com", Path: "/"}}
+	if _, err := f.Fetch(server.URL+"/jobs/1", "acme-backend"); err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if gotCookie != "" {
+		t.Errorf("Cookie header = %q, want none for a different host", gotCookie)
+	}
+}
+
+func TestFetcher_Fetch_ScopesHeaderCookiesToRequestedHost(t *testing.T) {
+	var targetCookie string
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		targetCookie = r.Header.Get("Cookie")
+		fmt.Fprint(w, redirectTargetPage)
+	}))
+	defer target.Close()
+	// Same server, reached by a different host name
+	targetURL := strings.Replace(target.URL, "127.0.0.1", "localhost", 1)
+
+	var stubCookie string
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		stubCookie = r.Header.Get("Cookie")
+		fmt.Fprintf(w, `<html><head><meta http-equiv="refresh" content="0; url=%s/jobs/1"></head><body>Redirecting…</body></html>`, targetURL)
+	}))
+	defer stub.Close()
+
+	f := NewFetcher(t.TempDir())
+	f.Cookies = []*http.Cookie{{Name: "session", Value: "abc123"}}
+	result, err := f.Fetch(stub.URL+"/stub", "acme-backend")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if result.URL != targetURL+"/jobs/1" {
+		t.Fatalf("URL = %q, want the meta-refresh target on the other host", result.URL)
+	}
+	if stubCookie != "session=abc123" {
+		t.Errorf("requested host got Cookie %q, want %q", stubCookie, "session=abc123")
+	}
+	if targetCookie != "" {
+		t.Errorf("redirect target on another host got Cookie %q, want none", targetCookie)
+	}
+}
+
+func TestFetcher_Fetch_CookiesFollowHTTPRedirectToOtherHost(t *testing.T) {
+	var targetCookie string
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		targetCookie = r.Header.Get("Cookie")
+		fmt.Fprint(w, redirectTargetPage)
+	}))
+	defer target.Close()
+	// Same server, reached by a different host name, like a board
+	// redirecting to its auth host
+	targetURL := strings.Replace(target.URL, "127.0.0.1", "localhost", 1)
+
+	var boardCookie string
+	board := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		boardCookie = r.Header.Get("Cookie")
+		http.Redirect(w, r, targetURL+"/jobs/1", http.StatusFound)
+	}))
+	defer board.Close()
+
+	f := NewFetcher(t.TempDir())
+	f.Cookies = []*http.Cookie{
+		{Name: "auth", Value: "abc123", Domain: "localhost", Path: "/"},
+		{Name: "board", Value: "xyz"},
+	}
+	if _, err := f.Fetch(board.URL+"/jobs/1", "acme-backend"); err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if boardCookie != "board=xyz" {
+		t.Errorf("board got Cookie %q, want only its domainless cookie", boardCookie)
+	}
+	if targetCookie != "auth=abc123" {
+		t.Errorf("redirect target got Cookie %q, want the cookie for its domain", targetCookie)
+	}
+}
@@ -36,7 +36,7 @@ func (f *Fetcher) FetchCV(input string) (*CVResult, error) {
 	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
 	req.Header.Set("Accept", "application/json, */*")
 
-	resp, err := f.Client.Do(req)
+	resp, err := f.do(f.Client, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch CV: %w", err)
 	}
@@ -0,0 +1,36 @@
+package fetch
+
+import "strings"
+
+// JobDetails holds structured facts a posting page states outright, for
+// the posting's front matter
+type JobDetails struct {
+	Location  string
+	Remote    bool
+	SalaryMin int
+	SalaryMax int
+}
+
+// extractDetails finds the location and salary on a posting page. The job
+// board's own data is preferred; JSON-LD fills in what it lacks.
+func extractDetails(html, host string) JobDetails {
+	var details JobDetails
+	switch {
+	case strings.Contains(host, "careers.microsoft.com"):
+		if job := microsoftJob(microsoftNextData(html)); job != nil {
+			details.Location = cleanText(microsoftLocation(job))
+		}
+	case strings.Contains(host, "smartrecruiters.com"):
+		if job := smartRecruitersJob(smartRecruitersState(html)); job != nil {
+			details.Location = cleanText(smartRecruitersLocation(job))
+		}
+	}
+
+	_, _, _, location, salaryMin, salaryMax := extractJSONLD(html)
+	if details.Location == "" {
+		details.Location = location
+	}
+	details.SalaryMin, details.SalaryMax = salaryMin, salaryMax
+	details.Remote = strings.Contains(strings.ToLower(details.Location), "remote")
+	return details
+}
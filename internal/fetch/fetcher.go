@@ -3,7 +3,6 @@ package fetch
 import (
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
 	"os"
@@ -25,6 +24,14 @@ const (
 type Fetcher struct {
 	Client    *http.Client
 	OutputDir string
+	// Force saves postings even when the job board reports them closed
+	Force bool
+	// Cookies are sent with matching requests, for postings behind a login
+	Cookies []*http.Cookie
+	// MaxRetries is how many times a network error, 5xx, or 429 is retried
+	MaxRetries int
+	// RetryBackoff is the wait before the first retry, doubling after each
+	RetryBackoff time.Duration
 }
 
 // FetchResult contains the result of a fetch operation
@@ -34,6 +41,14 @@ type FetchResult struct {
 	Company     string `json:"company"`
 	Position    string `json:"position"`
 	ContentSize int    `json:"content_size"`
+	// Location, Remote, and the salary range are set when the page states them
+	Location  string `json:"location,omitempty"`
+	Remote    bool   `json:"remote,omitempty"`
+	SalaryMin int    `json:"salary_min,omitempty"`
+	SalaryMax int    `json:"salary_max,omitempty"`
+	// Closed is set when the page looks like a closed or expired posting
+	Closed       bool   `json:"closed,omitempty"`
+	ClosedReason string `json:"closed_reason,omitempty"`
 }
 
 // NewFetcher creates a new Fetcher instance
@@ -42,7 +57,9 @@ func NewFetcher(outputDir string) *Fetcher {
 		Client: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		OutputDir: outputDir,
+		OutputDir:    outputDir,
+		MaxRetries:   defaultMaxRetries,
+		RetryBackoff: defaultRetryBackoff,
 	}
 }
 
@@ -98,37 +115,35 @@ func (f *Fetcher) Fetch(rawURL string, outputName string) (*FetchResult, error)
 		return nil, fmt.Errorf("URL must be http or https")
 	}
 
-	// Fetch the page
-	req, err := http.NewRequest("GET", rawURL, nil)
+	// Fetch the page, following any HTML redirect stubs to the real posting
+	requestedURL := parsedURL
+	body, parsedURL, pdf, err := f.fetchPage(parsedURL)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
+	finalURL := parsedURL.String()
 
-	// Set a browser-like user agent to avoid being blocked
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
-	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
-
-	resp, err := f.Client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch URL: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
-	}
+	var content, company, position string
+	var details JobDetails
+	var closed closedStatus
+	if pdf {
+		// Job descriptions linked as a PDF have no markup to read company
+		// and position from; their first line is usually the title
+		content, err = extractPDFText([]byte(body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read PDF posting: %w", err)
+		}
+		position = pdfTitle(content)
+	} else {
+		closed = detectClosed(body, requestedURL, parsedURL)
+		if closed.Certain && !f.Force {
+			return nil, fmt.Errorf("%w (%s); use --force to save it anyway", ErrPostingClosed, closed.Reason)
+		}
 
-	// Read the response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		// Detect the job board and extract content
+		content, company, position, details = f.ExtractJobPosting(body, parsedURL)
 	}
 
-	htmlContent := string(body)
-
-	// Detect the job board and extract content
-	content, company, position := f.ExtractJobPosting(htmlContent, parsedURL)
-
 	// Generate output filename
 	if outputName == "" {
 		var err error
@@ -149,7 +164,7 @@ func (f *Fetcher) Fetch(rawURL string, outputName string) (*FetchResult, error)
 	}
 
 	// Add metadata header to the content
-	finalContent := f.FormatOutput(content, rawURL, company, position)
+	finalContent := f.FormatOutput(content, finalURL, company, position, details)
 
 	// Write to file
 	if err := os.WriteFile(outputPath, []byte(finalContent), 0644); err != nil {
@@ -157,35 +172,46 @@ func (f *Fetcher) Fetch(rawURL string, outputName string) (*FetchResult, error)
 	}
 
 	return &FetchResult{
-		URL:         rawURL,
-		OutputPath:  outputPath,
-		Company:     company,
-		Position:    position,
-		ContentSize: len(finalContent),
+		URL:          finalURL,
+		OutputPath:   outputPath,
+		Company:      company,
+		Position:     position,
+		ContentSize:  len(finalContent),
+		Location:     details.Location,
+		Remote:       details.Remote,
+		SalaryMin:    details.SalaryMin,
+		SalaryMax:    details.SalaryMax,
+		Closed:       closed.Closed,
+		ClosedReason: closed.Reason,
 	}, nil
 }
 
-// ExtractJobPosting extracts job posting content from HTML based on the job board
-func (f *Fetcher) ExtractJobPosting(html string, parsedURL *url.URL) (content, company, position string) {
+// ExtractJobPosting extracts job posting content from HTML based on the job
+// board, along with the location and salary when the page states them
+func (f *Fetcher) ExtractJobPosting(html string, parsedURL *url.URL) (content, company, position string, details JobDetails) {
 	host := strings.ToLower(parsedURL.Host)
+	details = extractDetails(html, host)
 
 	// Try to detect job board and use specialized extraction
 	switch {
 	case strings.Contains(host, "lever.co"):
-		return f.extractLever(html)
+		content, company, position = f.extractLever(html)
 	case strings.Contains(host, "greenhouse.io"):
-		return f.extractGreenhouse(html)
+		content, company, position = f.extractGreenhouse(html)
 	case strings.Contains(host, "workday.com"):
-		return f.extractWorkday(html)
+		content, company, position = f.extractWorkday(html)
 	case strings.Contains(host, "linkedin.com"):
-		return f.extractLinkedIn(html)
+		content, company, position = f.extractLinkedIn(html)
 	case strings.Contains(host, "ashbyhq.com"):
-		return f.extractAshby(html)
+		content, company, position = f.extractAshby(html)
 	case strings.Contains(host, "careers.microsoft.com"):
-		return f.extractMicrosoft(html)
+		content, company, position = f.extractMicrosoft(html)
+	case strings.Contains(host, "smartrecruiters.com"):
+		content, company, position = f.extractSmartRecruiters(html)
 	default:
-		return f.extractGeneric(html)
+		content, company, position = f.extractGeneric(html)
 	}
+	return content, company, position, details
 }
 
 // extractLever extracts job posting from Lever pages
@@ -292,18 +318,8 @@ func (f *Fetcher) extractMicrosoft(html string) (content, company, position stri
 	company = "Microsoft"
 
 	// Try to extract from __NEXT_DATA__ JSON
-	nextDataStart := strings.Index(html, `<script id="__NEXT_DATA__"`)
-	if nextDataStart != -1 {
-		// Find the start of the JSON content
-		jsonStart := strings.Index(html[nextDataStart:], ">")
-		if jsonStart != -1 {
-			jsonStart += nextDataStart + 1
-			jsonEnd := strings.Index(html[jsonStart:], "</script>")
-			if jsonEnd != -1 {
-				jsonData := html[jsonStart : jsonStart+jsonEnd]
-				content, position = f.parseMicrosoftNextData(jsonData)
-			}
-		}
+	if jsonData := microsoftNextData(html); jsonData != "" {
+		content, position = f.parseMicrosoftNextData(jsonData)
 	}
 
 	// Fallback to meta tags if __NEXT_DATA__ parsing failed
@@ -329,44 +345,74 @@ func (f *Fetcher) extractMicrosoft(html string) (content, company, position stri
 	return content, company, position
 }
 
+// microsoftNextData returns the contents of the page's __NEXT_DATA__
+// script, or "" if there is none
+func microsoftNextData(html string) string {
+	nextDataStart := strings.Index(html, `<script id="__NEXT_DATA__"`)
+	if nextDataStart == -1 {
+		return ""
+	}
+	// Find the start of the JSON content
+	jsonStart := strings.Index(html[nextDataStart:], ">")
+	if jsonStart == -1 {
+		return ""
+	}
+	jsonStart += nextDataStart + 1
+	jsonEnd := strings.Index(html[jsonStart:], "</script>")
+	if jsonEnd == -1 {
+		return ""
+	}
+	return html[jsonStart : jsonStart+jsonEnd]
+}
+
 // parseMicrosoftNextData parses the __NEXT_DATA__ JSON and extracts job details
 func (f *Fetcher) parseMicrosoftNextData(jsonData string) (content, position string) {
+	job := microsoftJob(jsonData)
+	if job == nil {
+		return "", ""
+	}
+	return f.extractMicrosoftJobData(job)
+}
+
+// microsoftJob finds the job object in __NEXT_DATA__ JSON, or nil if the
+// JSON isn't shaped like a Microsoft careers page
+func microsoftJob(jsonData string) map[string]interface{} {
 	var data map[string]interface{}
 	if err := json.Unmarshal([]byte(jsonData), &data); err != nil {
-		return "", ""
+		return nil
 	}
 
 	// Navigate to props.pageProps where job data typically lives
 	props, ok := data["props"].(map[string]interface{})
 	if !ok {
-		return "", ""
+		return nil
 	}
 
 	pageProps, ok := props["pageProps"].(map[string]interface{})
 	if !ok {
-		return "", ""
+		return nil
 	}
 
 	// Try to find job data - Microsoft uses various structures
 	// Common paths: pageProps.job, pageProps.jobDetail, pageProps.data
-
-	// Try pageProps.job first
-	if job, ok := pageProps["job"].(map[string]interface{}); ok {
-		return f.extractMicrosoftJobData(job)
+	for _, key := range []string{"job", "jobDetail", "data"} {
+		if job, ok := pageProps[key].(map[string]interface{}); ok {
+			return job
+		}
 	}
 
-	// Try pageProps.jobDetail
-	if jobDetail, ok := pageProps["jobDetail"].(map[string]interface{}); ok {
-		return f.extractMicrosoftJobData(jobDetail)
-	}
+	// Try pageProps directly (sometimes job data is at this level)
+	return pageProps
+}
 
-	// Try pageProps.data
-	if dataObj, ok := pageProps["data"].(map[string]interface{}); ok {
-		return f.extractMicrosoftJobData(dataObj)
+// microsoftLocation returns a job's location field
+func microsoftLocation(job map[string]interface{}) string {
+	for _, key := range []string{"location", "primaryLocation"} {
+		if loc, ok := job[key].(string); ok && loc != "" {
+			return loc
+		}
 	}
-
-	// Try pageProps directly (sometimes job data is at this level)
-	return f.extractMicrosoftJobData(pageProps)
+	return ""
 }
 
 // extractMicrosoftJobData extracts content and position from a job data object
@@ -414,9 +460,7 @@ func (f *Fetcher) extractMicrosoftJobData(job map[string]interface{}) (content,
 	}
 
 	// Try location info
-	if loc, ok := job["location"].(string); ok && loc != "" {
-		descParts = append(descParts, "\n\n**Location:** "+loc)
-	} else if loc, ok := job["primaryLocation"].(string); ok && loc != "" {
+	if loc := microsoftLocation(job); loc != "" {
 		descParts = append(descParts, "\n\n**Location:** "+loc)
 	}
 
@@ -449,6 +493,154 @@ func (f *Fetcher) validateMicrosoftExtraction(content, company, position string)
 	return content, company, position
 }
 
+// smartRecruitersStateMarker precedes the JSON job data SmartRecruiters
+// embeds in its pages
+const smartRecruitersStateMarker = "window.__OC_INITIAL_STATE__"
+
+// smartRecruitersSections are the job ad sections included in the content,
+// in page order
+var smartRecruitersSections = []string{"companyDescription", "jobDescription", "qualifications", "additionalInformation"}
+
+// extractSmartRecruiters extracts job posting from SmartRecruiters pages.
+// SmartRecruiters embeds the job as JSON in window.__OC_INITIAL_STATE__;
+// the schema.org JobPosting markup and then meta tags are fallbacks.
+func (f *Fetcher) extractSmartRecruiters(html string) (content, company, position string) {
+	if state := smartRecruitersState(html); state != "" {
+		content, company, position = f.parseSmartRecruitersState(state)
+	}
+
+	if position == "" || content == "" {
+		if ldCompany, ldPosition, ldContent, _, _, _ := extractJSONLD(html); ldPosition != "" || ldContent != "" {
+			if position == "" {
+				position = ldPosition
+			}
+			if company == "" {
+				company = ldCompany
+			}
+			if content == "" {
+				content = ldContent
+			}
+		}
+	}
+
+	if position == "" {
+		position = extractMetaContent(html, "og:title")
+		// Titles read "Job Title | SmartRecruiters"
+		if idx := strings.Index(position, " | "); idx != -1 {
+			position = position[:idx]
+		}
+	}
+	if company == "" {
+		if site := extractMetaContent(html, "og:site_name"); !strings.EqualFold(site, "SmartRecruiters") {
+			company = site
+		}
+	}
+	if content == "" {
+		content = extractMetaContent(html, "og:description")
+	}
+
+	content = cleanHTML(content)
+	company = cleanText(company)
+	position = cleanText(position)
+
+	return content, company, position
+}
+
+// parseSmartRecruitersState decodes the initial state JSON, which may be
+// followed by more script, and builds the posting from its job ad
+func (f *Fetcher) parseSmartRecruitersState(state string) (content, company, position string) {
+	job := smartRecruitersJob(state)
+	if job == nil {
+		return "", "", ""
+	}
+
+	position, _ = job["name"].(string)
+	if c, ok := job["company"].(map[string]interface{}); ok {
+		company, _ = c["name"].(string)
+	}
+
+	var parts []string
+	if location := smartRecruitersLocation(job); location != "" {
+		parts = append(parts, "**Location:** "+location)
+	}
+	if ad, ok := job["jobAd"].(map[string]interface{}); ok {
+		sections, _ := ad["sections"].(map[string]interface{})
+		for _, key := range smartRecruitersSections {
+			section, ok := sections[key].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			text, _ := section["text"].(string)
+			if strings.TrimSpace(text) == "" {
+				continue
+			}
+			if title, _ := section["title"].(string); title != "" {
+				parts = append(parts, "## "+title+"\n\n"+text)
+			} else {
+				parts = append(parts, text)
+			}
+		}
+	}
+
+	content = strings.Join(parts, "\n\n")
+	return content, company, position
+}
+
+// smartRecruitersState returns the JSON assigned to the page's state
+// variable and everything after it, or "" if there is none
+func smartRecruitersState(html string) string {
+	start := strings.Index(html, smartRecruitersStateMarker)
+	if start == -1 {
+		return ""
+	}
+	state := html[start+len(smartRecruitersStateMarker):]
+	eq := strings.Index(state, "=")
+	if eq == -1 {
+		return ""
+	}
+	return state[eq+1:]
+}
+
+// smartRecruitersJob decodes the job from the state assigned in a
+// SmartRecruiters page, or nil if it can't be decoded
+func smartRecruitersJob(state string) map[string]interface{} {
+	var data map[string]interface{}
+	if err := json.NewDecoder(strings.NewReader(state)).Decode(&data); err != nil {
+		return nil
+	}
+
+	// The job is usually under jobAd, but older pages put it at the top level
+	if nested, ok := data["jobAd"].(map[string]interface{}); ok {
+		if _, hasName := nested["name"]; hasName {
+			return nested
+		}
+	}
+	return data
+}
+
+// smartRecruitersLocation formats a job's location as "City, Region,
+// Country", noting remote roles
+func smartRecruitersLocation(job map[string]interface{}) string {
+	loc, ok := job["location"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	var parts []string
+	for _, key := range []string{"city", "region", "country"} {
+		if v, _ := loc[key].(string); v != "" {
+			parts = append(parts, v)
+		}
+	}
+	location := strings.Join(parts, ", ")
+	if remote, _ := loc["remote"].(bool); remote {
+		if location == "" {
+			return "Remote"
+		}
+		location += " (Remote)"
+	}
+	return location
+}
+
 // isNumeric checks if a string contains only digits
 func isNumeric(s string) bool {
 	s = strings.TrimSpace(s)
@@ -586,6 +778,11 @@ func extractCleanHostname(host string) string {
 
 // extractGeneric extracts job posting from any HTML page
 func (f *Fetcher) extractGeneric(html string) (content, company, position string) {
+	// Structured data is more reliable than guessing at containers
+	if company, position, content, _, _, _ := extractJSONLD(html); position != "" || content != "" {
+		return content, company, position
+	}
+
 	// Try common meta tags
 	position = extractMetaContent(html, "og:title")
 	if position == "" {
@@ -683,7 +880,7 @@ func (f *Fetcher) GenerateFilename(company, position string, parsedURL *url.URL)
 }
 
 // FormatOutput creates the final markdown output with metadata
-func (f *Fetcher) FormatOutput(content, sourceURL, company, position string) string {
+func (f *Fetcher) FormatOutput(content, sourceURL, company, position string, details JobDetails) string {
 	var sb strings.Builder
 
 	sb.WriteString("---\n")
@@ -695,6 +892,18 @@ func (f *Fetcher) FormatOutput(content, sourceURL, company, position string) str
 	if position != "" {
 		sb.WriteString(fmt.Sprintf("position: %s\n", position))
 	}
+	if details.Location != "" {
+		sb.WriteString(fmt.Sprintf("location: %s\n", details.Location))
+	}
+	if details.Remote {
+		sb.WriteString("remote: true\n")
+	}
+	if details.SalaryMin > 0 {
+		sb.WriteString(fmt.Sprintf("salary_min: %d\n", details.SalaryMin))
+	}
+	if details.SalaryMax > 0 {
+		sb.WriteString(fmt.Sprintf("salary_max: %d\n", details.SalaryMax))
+	}
 	sb.WriteString("---\n\n")
 
 	if position != "" {
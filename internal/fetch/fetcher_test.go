@@ -105,7 +105,7 @@ func TestFetcher_FormatOutput(t *testing.T) {
 	company := "Test Corp"
 	position := "Engineer"
 
-	output := f.FormatOutput(content, sourceURL, company, position)
+	output := f.FormatOutput(content, sourceURL, company, position, JobDetails{})
 
 	// Check for metadata
 	if !strings.Contains(output, "source: https://example.com/job") {
@@ -299,7 +299,7 @@ func TestFetcher_ExtractJobPosting_Generic(t *testing.T) {
 	`
 
 	parsedURL, _ := url.Parse("https://example.com/jobs/123")
-	content, company, position := f.ExtractJobPosting(html, parsedURL)
+	content, company, position, _ := f.ExtractJobPosting(html, parsedURL)
 
 	if position != "Software Engineer" {
 		t.Errorf("position = %q, want %q", position, "Software Engineer")
@@ -318,7 +318,7 @@ func TestFetcher_Fetch_WriteFile(t *testing.T) {
 
 	// We can't test actual HTTP fetching without a mock server,
 	// but we can test the file writing by using the FormatOutput directly
-	content := f.FormatOutput("Test content", "https://example.com", "Test Co", "Engineer")
+	content := f.FormatOutput("Test content", "https://example.com", "Test Co", "Engineer", JobDetails{})
 
 	outputPath := filepath.Join(tmpDir, "test-posting.md")
 	err := os.WriteFile(outputPath, []byte(content), 0644)
@@ -380,7 +380,7 @@ func TestFetcher_ExtractMicrosoft_NextData(t *testing.T) {
 	`
 
 	parsedURL, _ := url.Parse("https://careers.microsoft.com/us/en/job/123456")
-	content, company, position := f.ExtractJobPosting(html, parsedURL)
+	content, company, position, _ := f.ExtractJobPosting(html, parsedURL)
 
 	if company != "Microsoft" {
 		t.Errorf("company = %q, want %q", company, "Microsoft")
@@ -413,7 +413,7 @@ func TestFetcher_ExtractMicrosoft_FallbackToMeta(t *testing.T) {
 	`
 
 	parsedURL, _ := url.Parse("https://careers.microsoft.com/us/en/job/789")
-	content, company, position := f.ExtractJobPosting(html, parsedURL)
+	content, company, position, _ := f.ExtractJobPosting(html, parsedURL)
 
 	if company != "Microsoft" {
 		t.Errorf("company = %q, want %q", company, "Microsoft")
@@ -450,7 +450,7 @@ func TestFetcher_ExtractMicrosoft_JobDetail(t *testing.T) {
 	`
 
 	parsedURL, _ := url.Parse("https://careers.microsoft.com/job/456")
-	content, company, position := f.ExtractJobPosting(html, parsedURL)
+	content, company, position, _ := f.ExtractJobPosting(html, parsedURL)
 
 	if company != "Microsoft" {
 		t.Errorf("company = %q, want %q", company, "Microsoft")
@@ -486,7 +486,7 @@ func TestFetcher_ExtractMicrosoft_QualificationsArray(t *testing.T) {
 	`
 
 	parsedURL, _ := url.Parse("https://careers.microsoft.com/job/789")
-	content, _, _ := f.ExtractJobPosting(html, parsedURL)
+	content, _, _, _ := f.ExtractJobPosting(html, parsedURL)
 
 	if !strings.Contains(content, "Bachelor's degree") {
 		t.Errorf("content should contain qualifications, got %q", content)
@@ -611,7 +611,7 @@ func TestFetcher_ExtractMicrosoft_ApplySubdomain(t *testing.T) {
 
 	// Test apply.careers.microsoft.com subdomain
 	parsedURL, _ := url.Parse("https://apply.careers.microsoft.com/job/123")
-	content, company, position := f.ExtractJobPosting(html, parsedURL)
+	content, company, position, _ := f.ExtractJobPosting(html, parsedURL)
 
 	if company != "Microsoft" {
 		t.Errorf("company = %q, want %q", company, "Microsoft")
@@ -796,3 +796,114 @@ func TestFetcher_GenerateFilename_ImprovedFallbacks(t *testing.T) {
 		})
 	}
 }
+
+// smartRecruitersStateHTML is a SmartRecruiters job page with the job data in
+// window.__OC_INITIAL_STATE__
+const smartRecruitersStateHTML = `
+<html>
+<head>
+<meta property="og:title" content="Ignored Title | SmartRecruiters">
+<meta property="og:site_name" content="SmartRecruiters">
+</head>
+<body>
+<script>window.__OC_INITIAL_STATE__ = {"jobAd": {
+	"name": "Senior Backend Engineer",
+	"company": {"name": "Globex &amp; Co"},
+	"location": {"city": "Berlin", "country": "Germany", "remote": true},
+	"jobAd": {"sections": {
+		"companyDescription": {"title": "Company Description", "text": "<p>We build logistics software.</p>"},
+		"jobDescription": {"title": "Job Description", "text": "<ul><li>Own our order APIs</li><li>Mentor engineers</li></ul>"},
+		"qualifications": {"title": "Qualifications", "text": "<ul><li>5+ years of Go</li><li>PostgreSQL</li></ul>"},
+		"additionalInformation": {"title": "Additional Information", "text": ""}
+	}}
+}};
+window.__OTHER__ = {};</script>
+</body>
+</html>`
+
+// smartRecruitersJSONLDHTML has only the schema.org JobPosting markup
+const smartRecruitersJSONLDHTML = `
+<html>
+<head>
+<script type="application/ld+json">{"@context": "https://schema.org", "@type": "JobPosting",
+	"title": "Data Analyst",
+	"hiringOrganization": {"@type": "Organization", "name": "Initech"},
+	"description": "&lt;h2&gt;Qualifications&lt;/h2&gt;&lt;ul&gt;&lt;li&gt;SQL&lt;/li&gt;&lt;/ul&gt;"}</script>
+</head>
+<body></body>
+</html>`
+
+// smartRecruitersMetaHTML has neither, only meta tags
+const smartRecruitersMetaHTML = `
+<html>
+<head>
+<meta property="og:title" content="Product Designer | SmartRecruiters">
+<meta property="og:site_name" content="Umbrella Corp">
+<meta property="og:description" content="Design our mobile apps.">
+</head>
+<body><script>window.__OC_INITIAL_STATE__ = not json;</script></body>
+</html>`
+
+func TestFetcher_ExtractSmartRecruiters(t *testing.T) {
+	tests := []struct {
+		name         string
+		html         string
+		wantCompany  string
+		wantPosition string
+		wantContent  []string
+		skipContent  []string
+	}{
+		{
+			name:         "initial state JSON",
+			html:         smartRecruitersStateHTML,
+			wantCompany:  "Globex & Co",
+			wantPosition: "Senior Backend Engineer",
+			wantContent: []string{
+				"**Location:** Berlin, Germany (Remote)",
+				"## Job Description",
+				"- Own our order APIs",
+				"## Qualifications",
+				"- 5+ years of Go",
+			},
+			skipContent: []string{"Additional Information", "<li>", "__OTHER__"},
+		},
+		{
+			name:         "schema.org fallback",
+			html:         smartRecruitersJSONLDHTML,
+			wantCompany:  "Initech",
+			wantPosition: "Data Analyst",
+			wantContent:  []string{"## Qualifications", "- SQL"},
+		},
+		{
+			name:         "meta tag fallback",
+			html:         smartRecruitersMetaHTML,
+			wantCompany:  "Umbrella Corp",
+			wantPosition: "Product Designer",
+			wantContent:  []string{"Design our mobile apps."},
+		},
+	}
+
+	f := NewFetcher("")
+	parsedURL, _ := url.Parse("https://jobs.smartrecruiters.com/Globex/743999-senior-backend-engineer")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, company, position, _ := f.ExtractJobPosting(tt.html, parsedURL)
+			if company != tt.wantCompany {
+				t.Errorf("company = %q, want %q", company, tt.wantCompany)
+			}
+			if position != tt.wantPosition {
+				t.Errorf("position = %q, want %q", position, tt.wantPosition)
+			}
+			for _, want := range tt.wantContent {
+				if !strings.Contains(content, want) {
+					t.Errorf("content missing %q:\n%s", want, content)
+				}
+			}
+			for _, skip := range tt.skipContent {
+				if strings.Contains(content, skip) {
+					t.Errorf("content should not contain %q:\n%s", skip, content)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package fetch
+
+import (
+	"encoding/json"
+	"fmt"
+	gohtml "html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// jsonLDScriptRe matches <script type="application/ld+json"> blocks
+var jsonLDScriptRe = regexp.MustCompile(`(?is)<script[^>]*type=["']?application/ld\+json["']?[^>]*>(.*?)</script>`)
+
+// extractJSONLD extracts the first schema.org JobPosting embedded as
+// JSON-LD, which many sites publish for search engines. Everything is empty
+// when the page has no JobPosting block (or only malformed ones). The
+// salary range is only reported for yearly (or unspecified) pay, so hourly
+// rates aren't mistaken for salaries; content still lists it either way.
+func extractJSONLD(html string) (company, position, content, location string, salaryMin, salaryMax int) {
+	for _, match := range jsonLDScriptRe.FindAllStringSubmatch(html, -1) {
+		var data interface{}
+		if err := json.Unmarshal([]byte(strings.TrimSpace(match[1])), &data); err != nil {
+			continue
+		}
+		job := findJobPosting(data)
+		if job == nil {
+			continue
+		}
+
+		position = cleanText(jsonLDString(job["title"]))
+		company = cleanText(jsonLDName(job["hiringOrganization"]))
+		location = jsonLDLocation(job)
+
+		var sb strings.Builder
+		if location != "" {
+			sb.WriteString(fmt.Sprintf("Location: %s\n", location))
+		}
+		if salary := jsonLDSalary(job["baseSalary"]); salary != "" {
+			sb.WriteString(fmt.Sprintf("Salary: %s\n", salary))
+		}
+		if description := jsonLDString(job["description"]); description != "" {
+			if sb.Len() > 0 {
+				sb.WriteString("\n")
+			}
+			// Descriptions are HTML, often entity-escaped inside the JSON
+			sb.WriteString(cleanHTML(gohtml.UnescapeString(description)))
+		}
+		content = strings.TrimSpace(sb.String())
+
+		if position == "" && content == "" {
+			continue
+		}
+		salaryMin, salaryMax = jsonLDSalaryRange(job["baseSalary"])
+		return company, position, content, location, salaryMin, salaryMax
+	}
+	return "", "", "", "", 0, 0
+}
+
+// findJobPosting returns the first JobPosting object in decoded JSON-LD,
+// which may be a single object, an array, or an @graph
+func findJobPosting(data interface{}) map[string]interface{} {
+	switch v := data.(type) {
+	case []interface{}:
+		for _, item := range v {
+			if job := findJobPosting(item); job != nil {
+				return job
+			}
+		}
+	case map[string]interface{}:
+		if isJobPostingType(v["@type"]) {
+			return v
+		}
+		if graph, ok := v["@graph"]; ok {
+			return findJobPosting(graph)
+		}
+	}
+	return nil
+}
+
+// isJobPostingType reports whether an @type value (a string or a list of
+// strings) names JobPosting
+func isJobPostingType(t interface{}) bool {
+	switch v := t.(type) {
+	case string:
+		return v == "JobPosting"
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok && s == "JobPosting" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// jsonLDString returns v as a string, or "" if it isn't one
+func jsonLDString(v interface{}) string {
+	s, _ := v.(string)
+	return strings.TrimSpace(s)
+}
+
+// jsonLDName returns the name of an Organization or Country value, which
+// may also be given as a plain string
+func jsonLDName(v interface{}) string {
+	if obj, ok := v.(map[string]interface{}); ok {
+		return jsonLDString(obj["name"])
+	}
+	return jsonLDString(v)
+}
+
+// jsonLDLocation formats the posting's jobLocation addresses, noting
+// remote (telecommute) postings
+func jsonLDLocation(job map[string]interface{}) string {
+	var places []interface{}
+	switch v := job["jobLocation"].(type) {
+	case []interface{}:
+		places = v
+	case map[string]interface{}:
+		places = []interface{}{v}
+	}
+
+	var locations []string
+	for _, place := range places {
+		obj, ok := place.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		address, ok := obj["address"].(map[string]interface{})
+		if !ok {
+			if s := jsonLDString(obj["address"]); s != "" {
+				locations = append(locations, s)
+			}
+			continue
+		}
+		var parts []string
+		for _, part := range []string{
+			jsonLDString(address["addressLocality"]),
+			jsonLDString(address["addressRegion"]),
+			jsonLDName(address["addressCountry"]),
+		} {
+			if part != "" {
+				parts = append(parts, part)
+			}
+		}
+		if len(parts) > 0 {
+			locations = append(locations, strings.Join(parts, ", "))
+		}
+	}
+
+	if strings.EqualFold(jsonLDString(job["jobLocationType"]), "TELECOMMUTE") {
+		locations = append(locations, "Remote")
+	}
+	return strings.Join(locations, "; ")
+}
+
+// jsonLDSalaryValues returns the amounts and lowercased unit of a
+// baseSalary MonetaryAmount, whose value is a number or a QuantitativeValue
+// with a single value or a minValue/maxValue range
+func jsonLDSalaryValues(v interface{}) (min, max float64, unit string) {
+	salary, ok := v.(map[string]interface{})
+	if !ok {
+		return 0, 0, ""
+	}
+	switch value := salary["value"].(type) {
+	case float64:
+		min = value
+	case map[string]interface{}:
+		min, _ = value["minValue"].(float64)
+		max, _ = value["maxValue"].(float64)
+		if single, ok := value["value"].(float64); ok && min == 0 {
+			min = single
+		}
+		unit = strings.ToLower(jsonLDString(value["unitText"]))
+	}
+	return min, max, unit
+}
+
+// jsonLDSalaryRange returns a yearly baseSalary as whole amounts, with max
+// equal to min for a single value. Other units return zeros.
+func jsonLDSalaryRange(v interface{}) (min, max int) {
+	lo, hi, unit := jsonLDSalaryValues(v)
+	if unit != "" && unit != "year" {
+		return 0, 0
+	}
+	if hi == 0 {
+		hi = lo
+	}
+	return int(lo), int(hi)
+}
+
+// jsonLDSalary formats a baseSalary MonetaryAmount, e.g.
+// "USD 150,000 - 200,000 per year"
+func jsonLDSalary(v interface{}) string {
+	min, max, unit := jsonLDSalaryValues(v)
+	if min == 0 && max == 0 {
+		return ""
+	}
+	var currency string
+	if salary, ok := v.(map[string]interface{}); ok {
+		currency = jsonLDString(salary["currency"])
+	}
+
+	amount := formatAmount(min)
+	if max > 0 && max != min {
+		if min == 0 {
+			amount = formatAmount(max)
+		} else {
+			amount += " - " + formatAmount(max)
+		}
+	}
+	if currency != "" {
+		amount = currency + " " + amount
+	}
+	if unit != "" {
+		amount += " per " + unit
+	}
+	return amount
+}
+
+// formatAmount formats a whole amount with thousands separators
+func formatAmount(v float64) string {
+	digits := strconv.FormatInt(int64(v), 10)
+	var sb strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteRune(d)
+	}
+	return sb.String()
+}
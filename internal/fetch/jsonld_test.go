@@ -0,0 +1,208 @@
+package fetch
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+)
+
+const jsonLDJobPage = `<html><head>
+<meta property="og:title" content="Careers | Example">
+<script type="application/ld+json">{"@context": "https://schema.org", "@type": "Organization", "name": "Example"}</script>
+<script type="application/ld+json">
+{
+  "@context": "https://schema.org/",
+  "@type": "JobPosting",
+  "title": "Senior Backend Engineer",
+  "description": "&lt;p&gt;Build &lt;strong&gt;payments&lt;/strong&gt; APIs.&lt;/p&gt;&lt;ul&gt;&lt;li&gt;5+ years of Go&lt;/li&gt;&lt;/ul&gt;",
+  "hiringOrganization": {"@type": "Organization", "name": "Acme &amp; Co"},
+  "jobLocation": {"@type": "Place", "address": {"@type": "PostalAddress", "addressLocality": "Denver", "addressRegion": "CO", "addressCountry": {"@type": "Country", "name": "US"}}},
+  "jobLocationType": "TELECOMMUTE",
+  "baseSalary": {"@type": "MonetaryAmount", "currency": "USD", "value": {"@type": "QuantitativeValue", "minValue": 150000, "maxValue": 185000, "unitText": "YEAR"}}
+}
+</script>
+</head><body><main>Generic page body</main></body></html>`
+
+func TestExtractJSONLD_JobPosting(t *testing.T) {
+	company, position, content, location, salaryMin, salaryMax := extractJSONLD(jsonLDJobPage)
+	if position != "Senior Backend Engineer" {
+		t.Errorf("position = %q, want %q", position, "Senior Backend Engineer")
+	}
+	if company != "Acme & Co" {
+		t.Errorf("company = %q, want %q", company, "Acme & Co")
+	}
+	for _, want := range []string{
+		"Location: Denver, CO, US; Remote",
+		"Salary: USD 150,000 - 185,000 per year",
+		"Build **payments** APIs.",
+		"- 5+ years of Go",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("content missing %q:\n%s", want, content)
+		}
+	}
+	if location != "Denver, CO, US; Remote" {
+		t.Errorf("location = %q, want %q", location, "Denver, CO, US; Remote")
+	}
+	if salaryMin != 150000 || salaryMax != 185000 {
+		t.Errorf("salary = %d-%d, want 150000-185000", salaryMin, salaryMax)
+	}
+}
+
+// A posting as a large job board embeds it: an @graph alongside
+// breadcrumbs, with several locations and a single yearly salary
+const jsonLDBoardPage = `<!DOCTYPE html><html><head>
+<title>Data Engineer - Hooli - Austin, TX | Example Jobs</title>
+<meta property="og:title" content="Data Engineer - Hooli">
+<script type="application/ld+json">{"@context":"https://schema.org","@graph":[
+ {"@type":"BreadcrumbList","itemListElement":[{"@type":"ListItem","position":1,"name":"Jobs"}]},
+ {"@type":"JobPosting","title":"Data Engineer","datePosted":"2026-09-30","validThrough":"2026-11-30T00:00:00Z",
+  "employmentType":"FULL_TIME",
+  "hiringOrganization":{"@type":"Organization","name":"Hooli","sameAs":"https://hooli.example"},
+  "jobLocation":[
+   {"@type":"Place","address":{"@type":"PostalAddress","addressLocality":"Austin","addressRegion":"TX","addressCountry":"US"}},
+   {"@type":"Place","address":{"@type":"PostalAddress","addressLocality":"Seattle","addressRegion":"WA","addressCountry":"US"}}],
+  "baseSalary":{"@type":"MonetaryAmount","currency":"USD","value":{"@type":"QuantitativeValue","value":140000,"unitText":"YEAR"}},
+  "description":"<p>Own our batch and streaming pipelines.</p><ul><li>SQL</li><li>Spark</li></ul>"}
+]}</script>
+</head><body><div class="description">Sign in to see more jobs</div></body></html>`
+
+func TestExtractJSONLD_BoardPosting(t *testing.T) {
+	company, position, content, location, salaryMin, salaryMax := extractJSONLD(jsonLDBoardPage)
+	if company != "Hooli" || position != "Data Engineer" {
+		t.Errorf("extractJSONLD() = %q, %q; want Hooli, Data Engineer", company, position)
+	}
+	if location != "Austin, TX, US; Seattle, WA, US" {
+		t.Errorf("location = %q", location)
+	}
+	if salaryMin != 140000 || salaryMax != 140000 {
+		t.Errorf("salary = %d-%d, want 140000-140000", salaryMin, salaryMax)
+	}
+	if !strings.Contains(content, "Own our batch and streaming pipelines.") || !strings.Contains(content, "- Spark") {
+		t.Errorf("content missing the description:\n%s", content)
+	}
+
+	f := NewFetcher("")
+	content, _, _ = f.extractGeneric(jsonLDBoardPage)
+	if strings.Contains(content, "Sign in to see more jobs") {
+		t.Errorf("extractGeneric() used the page body over JSON-LD:\n%s", content)
+	}
+}
+
+func TestExtractJSONLD_SalaryRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		salary   string
+		min, max int
+	}{
+		{"quantitative value range", `{"@type":"MonetaryAmount","currency":"USD","value":{"@type":"QuantitativeValue","minValue":120000,"maxValue":160000,"unitText":"YEAR"}}`, 120000, 160000},
+		{"range without unit", `{"currency":"EUR","value":{"minValue":70000,"maxValue":90000}}`, 70000, 90000},
+		{"plain number", `{"currency":"GBP","value":65000}`, 65000, 65000},
+		{"hourly rate", `{"currency":"USD","value":{"minValue":45,"maxValue":60,"unitText":"HOUR"}}`, 0, 0},
+		{"missing", `null`, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := `<script type="application/ld+json">{"@type":"JobPosting","title":"Engineer","baseSalary":` + tt.salary + `}</script>`
+			_, _, _, _, salaryMin, salaryMax := extractJSONLD(page)
+			if salaryMin != tt.min || salaryMax != tt.max {
+				t.Errorf("salary = %d-%d, want %d-%d", salaryMin, salaryMax, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func TestExtractJSONLD_Graph(t *testing.T) {
+	page := `<script type="application/ld+json">{"@graph": [{"@type": "WebPage"}, {"@type": ["JobPosting"], "title": "Designer", "hiringOrganization": "Globex"}]}</script>`
+
+	company, position, _, _, _, _ := extractJSONLD(page)
+	if position != "Designer" || company != "Globex" {
+		t.Errorf("extractJSONLD() = %q, %q; want Designer, Globex", position, company)
+	}
+}
+
+func TestExtractJSONLD_MalformedFallsBack(t *testing.T) {
+	page := `<html><head>
+<meta property="og:title" content="Platform Engineer">
+<meta property="og:site_name" content="Initech">
+<script type="application/ld+json">{"@type": "JobPosting", "title": "Broken",</script>
+</head><body><div class="job-description"><p>Keep the lights on.</p></div></body></html>`
+
+	if _, position, content, _, _, _ := extractJSONLD(page); position != "" || content != "" {
+		t.Errorf("extractJSONLD() = %q, %q for malformed JSON-LD; want nothing", position, content)
+	}
+
+	f := NewFetcher("")
+	parsedURL, _ := url.Parse("https://jobs.initech.example/platform")
+	content, company, position, _ := f.ExtractJobPosting(page, parsedURL)
+	if position != "Platform Engineer" || company != "Initech" {
+		t.Errorf("ExtractJobPosting() = %q, %q; want the generic extraction", position, company)
+	}
+	if !strings.Contains(content, "Keep the lights on.") {
+		t.Errorf("content = %q, want the job-description container", content)
+	}
+}
+
+func TestFetcher_ExtractJobPosting_PrefersJSONLD(t *testing.T) {
+	f := NewFetcher("")
+	parsedURL, _ := url.Parse("https://careers.example.com/jobs/42")
+
+	content, company, position, _ := f.ExtractJobPosting(jsonLDJobPage, parsedURL)
+	if position != "Senior Backend Engineer" || company != "Acme & Co" {
+		t.Errorf("ExtractJobPosting() = %q, %q; want the JSON-LD fields", position, company)
+	}
+	if strings.Contains(content, "Generic page body") {
+		t.Errorf("content came from the generic extractor:\n%s", content)
+	}
+}
+
+func TestFetcher_Fetch_DetailsFromJSONLD(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(jsonLDJobPage))
+	}))
+	defer server.Close()
+
+	f := NewFetcher(t.TempDir())
+	result, err := f.Fetch(server.URL+"/jobs/42", "acme-backend")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if result.Location != "Denver, CO, US; Remote" || !result.Remote {
+		t.Errorf("Location, Remote = %q, %v; want the JSON-LD location, remote", result.Location, result.Remote)
+	}
+	if result.SalaryMin != 150000 || result.SalaryMax != 185000 {
+		t.Errorf("salary = %d-%d, want 150000-185000", result.SalaryMin, result.SalaryMax)
+	}
+
+	data, err := os.ReadFile(result.OutputPath)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	frontMatter, _, _ := strings.Cut(strings.TrimPrefix(string(data), "---\n"), "---\n")
+	for _, want := range []string{
+		"location: Denver, CO, US; Remote\n",
+		"remote: true\n",
+		"salary_min: 150000\n",
+		"salary_max: 185000\n",
+	} {
+		if !strings.Contains(frontMatter, want) {
+			t.Errorf("front matter missing %q:\n%s", want, frontMatter)
+		}
+	}
+}
+
+func TestFetcher_FormatOutput_OmitsUnknownDetails(t *testing.T) {
+	f := NewFetcher("")
+	output := f.FormatOutput("Body", "https://example.com/jobs/1", "Acme", "Engineer", JobDetails{Location: "Berlin, Germany"})
+	if !strings.Contains(output, "location: Berlin, Germany\n") {
+		t.Errorf("output missing location:\n%s", output)
+	}
+	for _, unwanted := range []string{"remote:", "salary_min:", "salary_max:"} {
+		if strings.Contains(output, unwanted) {
+			t.Errorf("output has %q for an unknown value:\n%s", unwanted, output)
+		}
+	}
+}
@@ -0,0 +1,281 @@
+package fetch
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/hex"
+	"errors"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// pdfStreamRe matches a PDF stream's data
+var pdfStreamRe = regexp.MustCompile(`(?s)stream\r?\n(.*?)\r?\nendstream`)
+
+// isPDF reports whether a response is a PDF, by its Content-Type or, for
+// servers that send a generic type, its %PDF magic bytes
+func isPDF(contentType, body string) bool {
+	if strings.HasPrefix(strings.ToLower(strings.TrimSpace(contentType)), "application/pdf") {
+		return true
+	}
+	return strings.HasPrefix(body, "%PDF-")
+}
+
+// extractPDFText returns the text drawn by a PDF's content streams, one line
+// per text line. It handles the common text operators in plain and
+// Flate-compressed streams; fonts with custom encodings (no ToUnicode
+// lookup) and scanned PDFs yield little or no text.
+func extractPDFText(data []byte) (string, error) {
+	if !bytes.HasPrefix(data, []byte("%PDF-")) {
+		return "", errors.New("not a PDF")
+	}
+
+	var lines []string
+	for _, m := range pdfStreamRe.FindAllSubmatch(data, -1) {
+		stream := m[1]
+		if r, err := zlib.NewReader(bytes.NewReader(stream)); err == nil {
+			if inflated, err := io.ReadAll(r); err == nil || len(inflated) > 0 {
+				stream = inflated
+			}
+		}
+		if !bytes.Contains(stream, []byte("BT")) {
+			continue
+		}
+		lines = append(lines, pdfContentText(stream)...)
+	}
+
+	text := strings.TrimSpace(strings.Join(lines, "\n"))
+	if text == "" {
+		return "", errors.New("no extractable text in PDF (it may be scanned)")
+	}
+	return text, nil
+}
+
+// pdfTitle returns the first line of a PDF's text when it's short enough
+// to be a job title, or ""
+func pdfTitle(text string) string {
+	first, _, _ := strings.Cut(text, "\n")
+	if first = strings.TrimSpace(first); len(first) > 80 {
+		return ""
+	}
+	return first
+}
+
+// pdfContentText interprets the text operators of a content stream,
+// starting a new line when the text position moves down
+func pdfContentText(stream []byte) []string {
+	var lines []string
+	var line strings.Builder
+	var operands []string // strings shown by the next operator
+	var numbers []float64 // numeric operands of the next operator
+
+	newLine := func() {
+		if s := strings.Join(strings.Fields(line.String()), " "); s != "" {
+			lines = append(lines, s)
+		}
+		line.Reset()
+	}
+
+	for _, tok := range pdfTokens(stream) {
+		switch {
+		case tok.str != nil:
+			operands = append(operands, *tok.str)
+			continue
+		case tok.gap:
+			operands = append(operands, " ")
+			continue
+		}
+		if n, err := strconv.ParseFloat(tok.word, 64); err == nil {
+			numbers = append(numbers, n)
+			continue
+		}
+
+		switch tok.word {
+		case "Tj", "TJ":
+			line.WriteString(strings.Join(operands, ""))
+		case "'", `"`:
+			newLine()
+			line.WriteString(strings.Join(operands, ""))
+		case "Td", "TD":
+			// A move along the same baseline separates words, not lines
+			if len(numbers) >= 2 && numbers[len(numbers)-1] == 0 {
+				line.WriteString(" ")
+			} else {
+				newLine()
+			}
+		case "T*", "Tm", "ET":
+			newLine()
+		}
+		operands = operands[:0]
+		numbers = numbers[:0]
+	}
+	newLine()
+	return lines
+}
+
+// pdfToken is a string operand, a word gap inside a TJ array, or any other
+// word (an operator, number, or name)
+type pdfToken struct {
+	str  *string
+	gap  bool
+	word string
+}
+
+// pdfTokens splits a content stream into tokens. Array brackets are
+// dropped, and large negative TJ adjustments become word gaps.
+func pdfTokens(stream []byte) []pdfToken {
+	var tokens []pdfToken
+	inArray := false
+	for i := 0; i < len(stream); {
+		c := stream[i]
+		switch {
+		case c == '(':
+			s, n := pdfLiteralString(stream[i:])
+			i += n
+			tokens = append(tokens, pdfToken{str: &s})
+		case c == '<' && i+1 < len(stream) && stream[i+1] != '<':
+			end := bytes.IndexByte(stream[i:], '>')
+			if end == -1 {
+				return tokens
+			}
+			s := pdfHexString(stream[i+1 : i+end])
+			i += end + 1
+			tokens = append(tokens, pdfToken{str: &s})
+		case c == '[' || c == ']':
+			inArray = c == '['
+			i++
+		case c == '%':
+			// Comment to end of line
+			for i < len(stream) && stream[i] != '\n' && stream[i] != '\r' {
+				i++
+			}
+		case isPDFSpace(c):
+			i++
+		default:
+			start := i
+			for i < len(stream) && !isPDFSpace(stream[i]) && !strings.ContainsRune("()<>[]%", rune(stream[i])) {
+				i++
+			}
+			if i == start {
+				// A stray delimiter such as ">" or "<<"
+				i++
+				continue
+			}
+			word := string(stream[start:i])
+			if inArray {
+				// Kerning: large negative adjustments are word spaces
+				if n, err := strconv.ParseFloat(word, 64); err == nil {
+					if n < -200 {
+						tokens = append(tokens, pdfToken{gap: true})
+					}
+					continue
+				}
+			}
+			tokens = append(tokens, pdfToken{word: word})
+		}
+	}
+	return tokens
+}
+
+// pdfLiteralString decodes a (parenthesised) string at the start of data,
+// returning it and the number of bytes consumed
+func pdfLiteralString(data []byte) (string, int) {
+	var sb bytes.Buffer
+	depth := 0
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch c {
+		case '(':
+			if depth > 0 {
+				sb.WriteByte(c)
+			}
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return latin1(sb.Bytes()), i + 1
+			}
+			sb.WriteByte(c)
+		case '\\':
+			if i+1 >= len(data) {
+				return latin1(sb.Bytes()), len(data)
+			}
+			i++
+			switch e := data[i]; e {
+			case 'n', 'r':
+				sb.WriteByte(' ')
+			case 't':
+				sb.WriteByte('\t')
+			case 'b', 'f':
+			case '\r', '\n':
+				// Line continuation
+			default:
+				if e >= '0' && e <= '7' {
+					j := i
+					for j < len(data) && j < i+3 && data[j] >= '0' && data[j] <= '7' {
+						j++
+					}
+					v, _ := strconv.ParseUint(string(data[i:j]), 8, 8)
+					sb.WriteByte(byte(v))
+					i = j - 1
+				} else {
+					sb.WriteByte(e)
+				}
+			}
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return latin1(sb.Bytes()), len(data)
+}
+
+// pdfHexString decodes a <hex> string. Two-byte codes with a zero high
+// byte, as written for simple CID fonts, are read as single characters.
+func pdfHexString(h []byte) string {
+	digits := strings.Join(strings.Fields(string(h)), "")
+	if len(digits)%2 == 1 {
+		digits += "0"
+	}
+	b, err := hex.DecodeString(digits)
+	if err != nil {
+		return ""
+	}
+	if len(b)%2 == 0 && len(b) > 0 {
+		wide := true
+		for i := 0; i < len(b); i += 2 {
+			if b[i] != 0 {
+				wide = false
+				break
+			}
+		}
+		if wide {
+			narrow := make([]byte, 0, len(b)/2)
+			for i := 1; i < len(b); i += 2 {
+				narrow = append(narrow, b[i])
+			}
+			b = narrow
+		}
+	}
+	return latin1(b)
+}
+
+// latin1 decodes single-byte text, which is close enough to the standard
+// PDF encodings for readable postings
+func latin1(b []byte) string {
+	runes := make([]rune, len(b))
+	for i, c := range b {
+		runes[i] = rune(c)
+	}
+	return string(runes)
+}
+
+// isPDFSpace reports whether c is PDF whitespace
+func isPDFSpace(c byte) bool {
+	switch c {
+	case ' ', '\t', '\n', '\r', '\f', 0:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package fetch
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+// postingContent draws a short job description: a title, a paragraph split
+// across two text objects, and a TJ line with kerning between words
+const postingContent = `BT
+/F1 18 Tf 72 720 Td (Staff Platform Engineer) Tj
+ET
+BT
+/F1 11 Tf 72 690 Td (Acme Robotics is hiring \(remote\).) Tj
+0 -14 Td (You will own our build and deploy tooling.) Tj
+T* [(Requirements:) -300 (Go,) -300 (Kubernetes)] TJ
+ET`
+
+// buildPDF assembles a minimal PDF whose page draws content, Flate
+// compressed as most generators write it
+func buildPDF(t *testing.T, content string) []byte {
+	t.Helper()
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	if _, err := zw.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	zw.Close()
+
+	var pdf bytes.Buffer
+	pdf.WriteString("%PDF-1.4\n")
+	pdf.WriteString("1 0 obj << /Type /Catalog /Pages 2 0 R >> endobj\n")
+	pdf.WriteString("2 0 obj << /Type /Pages /Kids [3 0 R] /Count 1 >> endobj\n")
+	pdf.WriteString("3 0 obj << /Type /Page /Parent 2 0 R /Contents 4 0 R >> endobj\n")
+	fmt.Fprintf(&pdf, "4 0 obj << /Length %d /Filter /FlateDecode >>\nstream\n", compressed.Len())
+	pdf.Write(compressed.Bytes())
+	pdf.WriteString("\nendstream\nendobj\ntrailer << /Root 1 0 R >>\n%%EOF\n")
+	return pdf.Bytes()
+}
+
+func TestExtractPDFText(t *testing.T) {
+	text, err := extractPDFText(buildPDF(t, postingContent))
+	if err != nil {
+		t.Fatalf("extractPDFText() error = %v", err)
+	}
+	want := "Staff Platform Engineer\n" +
+		"Acme Robotics is hiring (remote).\n" +
+		"You will own our build and deploy tooling.\n" +
+		"Requirements: Go, Kubernetes"
+	if text != want {
+		t.Errorf("extractPDFText() =\n%s\nwant:\n%s", text, want)
+	}
+
+	if _, err := extractPDFText(buildPDF(t, "q 100 0 0 100 0 0 cm /Im1 Do Q")); err == nil {
+		t.Error("extractPDFText() of an image-only page should return an error")
+	}
+	if _, err := extractPDFText([]byte("<html></html>")); err == nil {
+		t.Error("extractPDFText() of HTML should return an error")
+	}
+}
+
+func TestFetcher_Fetch_PDFPosting(t *testing.T) {
+	pdf := buildPDF(t, postingContent)
+	for _, contentType := range []string{"application/pdf", "application/octet-stream"} {
+		t.Run(contentType, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", contentType)
+				w.Write(pdf)
+			}))
+			defer server.Close()
+
+			f := NewFetcher(t.TempDir())
+			result, err := f.Fetch(server.URL+"/careers/staff-platform.pdf", "")
+			if err != nil {
+				t.Fatalf("Fetch() error = %v", err)
+			}
+			if result.Position != "Staff Platform Engineer" {
+				t.Errorf("Position = %q, want the PDF's first line", result.Position)
+			}
+
+			data, err := os.ReadFile(result.OutputPath)
+			if err != nil {
+				t.Fatalf("ReadFile() error = %v", err)
+			}
+			output := string(data)
+			for _, want := range []string{
+				"---\nsource: " + server.URL + "/careers/staff-platform.pdf\n",
+				"position: Staff Platform Engineer\n",
+				"## Job Description\n\n",
+				"You will own our build and deploy tooling.",
+				"Requirements: Go, Kubernetes",
+			} {
+				if !strings.Contains(output, want) {
+					t.Errorf("output missing %q:\n%s", want, output)
+				}
+			}
+		})
+	}
+}
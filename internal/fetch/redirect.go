@@ -0,0 +1,156 @@
+package fetch
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// maxHTMLRedirects bounds how many meta-refresh/JS redirect stubs Fetch will
+// follow. HTTP 3xx redirects are handled by the http.Client separately.
+const maxHTMLRedirects = 5
+
+// jsRedirectMaxText is the most visible text a page may have for a JS
+// location assignment to be treated as a redirect stub rather than an
+// incidental script on a real page
+const jsRedirectMaxText = 200
+
+var (
+	metaTagRe       = regexp.MustCompile(`(?is)<meta\s[^>]*>`)
+	metaRefreshRe   = regexp.MustCompile(`(?i)http-equiv\s*=\s*["']?refresh`)
+	metaContentRe   = regexp.MustCompile(`(?is)content\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+	refreshURLRe    = regexp.MustCompile(`(?i)^\s*\d*\s*[;,]?\s*url\s*=\s*['"]?([^'"]+)['"]?\s*$`)
+	jsLocationRe    = regexp.MustCompile(`(?i)(?:window\.|document\.|top\.|self\.)?location(?:\.href)?\s*=\s*["']([^"']+)["']`)
+	jsLocationFnRe  = regexp.MustCompile(`(?i)(?:window\.|document\.|top\.|self\.)?location\.(?:replace|assign)\(\s*["']([^"']+)["']\s*\)`)
+	scriptOrStyleRe = regexp.MustCompile(`(?is)<(script|style)[^>]*>.*?</(script|style)>`)
+)
+
+// fetchPage downloads a page, following HTML-level redirects (meta refresh
+// and obvious JavaScript redirects) up to maxHTMLRedirects. It returns the
+// final page body and URL, and whether the body is a PDF.
+func (f *Fetcher) fetchPage(pageURL *url.URL) (string, *url.URL, bool, error) {
+	visited := map[string]bool{}
+	origin := pageURL
+
+	for redirects := 0; ; redirects++ {
+		visited[pageURL.String()] = true
+
+		body, finalURL, contentType, err := f.get(pageURL, origin)
+		if err != nil {
+			return "", nil, false, err
+		}
+		// The client may have followed HTTP redirects
+		pageURL = finalURL
+		visited[pageURL.String()] = true
+
+		if isPDF(contentType, body) {
+			return body, pageURL, true, nil
+		}
+
+		target := findHTMLRedirect(body, pageURL)
+		if target == nil {
+			return body, pageURL, false, nil
+		}
+		if visited[target.String()] {
+			return "", nil, false, fmt.Errorf("redirect loop detected at %s", target)
+		}
+		if redirects >= maxHTMLRedirects {
+			return "", nil, false, fmt.Errorf("too many HTML redirects (limit %d)", maxHTMLRedirects)
+		}
+		pageURL = target
+	}
+}
+
+// get performs a single GET request with browser-like headers, returning
+// the body, final URL, and Content-Type. origin is the URL the fetch started
+// from, which scopes cookies without a domain.
+func (f *Fetcher) get(pageURL, origin *url.URL) (string, *url.URL, string, error) {
+	req, err := http.NewRequest("GET", pageURL.String(), nil)
+	if err != nil {
+		return "", nil, "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set a browser-like user agent to avoid being blocked
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,application/pdf;q=0.8,*/*;q=0.7")
+	f.addCookies(req, origin)
+
+	resp, err := f.do(f.cookieClient(origin), req)
+	if err != nil {
+		return "", nil, "", fmt.Errorf("failed to fetch URL: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", nil, "", fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", nil, "", fmt.Errorf("failed to read response: %w", err)
+	}
+
+	return string(body), resp.Request.URL, resp.Header.Get("Content-Type"), nil
+}
+
+// findHTMLRedirect returns the target of a meta-refresh tag or, on pages with
+// almost no visible text, a JavaScript location redirect. Relative targets
+// are resolved against base. Returns nil if the page is not a redirect stub.
+func findHTMLRedirect(page string, base *url.URL) *url.URL {
+	if target := findMetaRefresh(page); target != "" {
+		return resolveRedirect(base, target)
+	}
+
+	// Only trust JS redirects on stub pages; real postings often contain
+	// scripts that assign location in event handlers
+	if len(visibleText(page)) > jsRedirectMaxText {
+		return nil
+	}
+	for _, re := range []*regexp.Regexp{jsLocationFnRe, jsLocationRe} {
+		if m := re.FindStringSubmatch(page); m != nil {
+			return resolveRedirect(base, m[1])
+		}
+	}
+	return nil
+}
+
+// findMetaRefresh extracts the URL from a <meta http-equiv="refresh"> tag
+func findMetaRefresh(page string) string {
+	for _, tag := range metaTagRe.FindAllString(page, -1) {
+		if !metaRefreshRe.MatchString(tag) {
+			continue
+		}
+		m := metaContentRe.FindStringSubmatch(tag)
+		if m == nil {
+			continue
+		}
+		content := html.UnescapeString(m[1] + m[2])
+		if u := refreshURLRe.FindStringSubmatch(content); u != nil {
+			return strings.TrimSpace(u[1])
+		}
+	}
+	return ""
+}
+
+// resolveRedirect resolves target against base, accepting only http(s) URLs
+func resolveRedirect(base *url.URL, target string) *url.URL {
+	ref, err := url.Parse(strings.TrimSpace(target))
+	if err != nil {
+		return nil
+	}
+	resolved := base.ResolveReference(ref)
+	if resolved.Scheme != "http" && resolved.Scheme != "https" {
+		return nil
+	}
+	return resolved
+}
+
+// visibleText returns the page text with scripts, styles, and tags removed
+func visibleText(page string) string {
+	page = scriptOrStyleRe.ReplaceAllString(page, "")
+	return strings.TrimSpace(cleanText(page))
+}
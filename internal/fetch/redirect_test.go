@@ -0,0 +1,175 @@
+package fetch
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+)
+
+const redirectTargetPage = `<html><head>
+<title>Backend Engineer</title>
+<meta property="og:site_name" content="Acme">
+</head><body><main><h1>Backend Engineer</h1><p>Build APIs in Go.</p></main></body></html>`
+
+func TestFetcher_Fetch_FollowsMetaRefresh(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/stub", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<html><head><meta http-equiv="refresh" content="0; url=/jobs/123"></head><body>Redirecting…</body></html>`)
+	})
+	mux.HandleFunc("/jobs/123", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, redirectTargetPage)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	f := NewFetcher(t.TempDir())
+	result, err := f.Fetch(server.URL+"/stub", "acme-backend")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+
+	if result.URL != server.URL+"/jobs/123" {
+		t.Errorf("URL = %q, want final posting URL", result.URL)
+	}
+	if result.Position != "Backend Engineer" || result.Company != "Acme" {
+		t.Errorf("extracted %q at %q, want Backend Engineer at Acme", result.Position, result.Company)
+	}
+
+	data, err := os.ReadFile(result.OutputPath)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if !strings.Contains(string(data), "Build APIs in Go.") {
+		t.Errorf("output missing content from the final page:\n%s", data)
+	}
+	if !strings.Contains(string(data), "source: "+server.URL+"/jobs/123") {
+		t.Errorf("output source should be the final posting URL:\n%s", data)
+	}
+}
+
+func TestFetcher_Fetch_FollowsJSRedirect(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/stub", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<html><body><script>window.location.href = "/jobs/123";</script></body></html>`)
+	})
+	mux.HandleFunc("/jobs/123", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, redirectTargetPage)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	f := NewFetcher(t.TempDir())
+	result, err := f.Fetch(server.URL+"/stub", "acme-backend")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if result.Position != "Backend Engineer" {
+		t.Errorf("Position = %q, want Backend Engineer", result.Position)
+	}
+}
+
+func TestFetcher_Fetch_RedirectLoop(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/a", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<meta http-equiv="refresh" content="0;url=/b">`)
+	})
+	mux.HandleFunc("/b", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<meta http-equiv="refresh" content="0;url=/a">`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	f := NewFetcher(t.TempDir())
+	_, err := f.Fetch(server.URL+"/a", "loop")
+	if err == nil || !strings.Contains(err.Error(), "redirect loop") {
+		t.Errorf("Fetch() error = %v, want redirect loop error", err)
+	}
+}
+
+func TestFetcher_Fetch_RedirectLimit(t *testing.T) {
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Every hop points at a fresh URL, so only the limit stops it
+		hits++
+		fmt.Fprintf(w, `<meta http-equiv="refresh" content="0;url=/hop/%d">`, hits)
+	}))
+	defer server.Close()
+
+	f := NewFetcher(t.TempDir())
+	_, err := f.Fetch(server.URL+"/start", "chain")
+	if err == nil || !strings.Contains(err.Error(), "too many HTML redirects") {
+		t.Errorf("Fetch() error = %v, want redirect limit error", err)
+	}
+	if hits != maxHTMLRedirects+1 {
+		t.Errorf("server hit %d times, want %d", hits, maxHTMLRedirects+1)
+	}
+}
+
+func TestFindHTMLRedirect(t *testing.T) {
+	base, _ := url.Parse("https://jobs.example.com/board/stub")
+	longText := strings.Repeat("We are hiring great engineers. ", 20)
+
+	tests := []struct {
+		name string
+		page string
+		want string
+	}{
+		{
+			name: "meta refresh absolute",
+			page: `<meta http-equiv="refresh" content="0; URL=https://boards.example.com/job/1">`,
+			want: "https://boards.example.com/job/1",
+		},
+		{
+			name: "meta refresh attribute order and quotes",
+			page: `<META content='5;url=/job/2' HTTP-EQUIV='Refresh'>`,
+			want: "https://jobs.example.com/job/2",
+		},
+		{
+			name: "meta refresh relative path",
+			page: `<meta http-equiv="refresh" content="0;url=job/3">`,
+			want: "https://jobs.example.com/board/job/3",
+		},
+		{
+			name: "meta refresh without url",
+			page: `<meta http-equiv="refresh" content="30">`,
+			want: "",
+		},
+		{
+			name: "js location.replace",
+			page: `<script>window.location.replace('https://boards.example.com/job/4')</script>`,
+			want: "https://boards.example.com/job/4",
+		},
+		{
+			name: "js assignment on a real page is ignored",
+			page: `<p>` + longText + `</p><script>function go(){ location.href = "/login"; }</script>`,
+			want: "",
+		},
+		{
+			name: "non-http target ignored",
+			page: `<script>location = "javascript:void(0)"</script>`,
+			want: "",
+		},
+		{
+			name: "plain page",
+			page: `<html><body><h1>Engineer</h1></body></html>`,
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := findHTMLRedirect(tt.page, base)
+			gotStr := ""
+			if got != nil {
+				gotStr = got.String()
+			}
+			if gotStr != tt.want {
+				t.Errorf("findHTMLRedirect() = %q, want %q", gotStr, tt.want)
+			}
+		})
+	}
+}
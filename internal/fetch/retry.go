@@ -0,0 +1,39 @@
+package fetch
+
+import (
+	"net/http"
+	"time"
+)
+
+// Defaults for retrying transient failures, set by NewFetcher
+const (
+	defaultMaxRetries   = 3
+	defaultRetryBackoff = 500 * time.Millisecond
+)
+
+// do sends req with client, retrying network errors, 5xx responses, and 429 Too Many
+// Requests up to MaxRetries times. The wait doubles from RetryBackoff after
+// each attempt. Other responses, including 4xx, are returned immediately;
+// after the last retry the final response or error is returned as is.
+func (f *Fetcher) do(client *http.Client, req *http.Request) (*http.Response, error) {
+	backoff := f.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		resp, err := client.Do(req)
+		if attempt >= f.MaxRetries || !isTransient(resp, err) {
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// isTransient reports whether a request failure is worth retrying
+func isTransient(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+}
@@ -0,0 +1,78 @@
+package fetch
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFetcher_Fetch_RetriesTransientFailures(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(redirectTargetPage))
+	}))
+	defer server.Close()
+
+	f := NewFetcher(t.TempDir())
+	f.RetryBackoff = time.Millisecond
+	result, err := f.Fetch(server.URL+"/jobs/123", "acme-backend")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("requests = %d, want 3 (two 503s, then 200)", got)
+	}
+
+	data, err := os.ReadFile(result.OutputPath)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if !strings.Contains(string(data), "Build APIs in Go.") {
+		t.Errorf("saved posting missing content:\n%s", data)
+	}
+}
+
+func TestFetcher_Fetch_NotFoundFailsFast(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	f := NewFetcher(t.TempDir())
+	f.RetryBackoff = time.Hour // A retry would hang the test
+	if _, err := f.Fetch(server.URL+"/jobs/gone", "gone"); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Fatalf("Fetch() error = %v, want HTTP 404", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("requests = %d, want 1 (no retries on 404)", got)
+	}
+}
+
+func TestFetcher_Fetch_GivesUpAfterMaxRetries(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	f := NewFetcher(t.TempDir())
+	f.MaxRetries = 2
+	f.RetryBackoff = time.Millisecond
+	if _, err := f.Fetch(server.URL+"/jobs/123", "acme"); err == nil || !strings.Contains(err.Error(), "429") {
+		t.Fatalf("Fetch() error = %v, want HTTP 429", err)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("requests = %d, want 3 (first attempt plus 2 retries)", got)
+	}
+}
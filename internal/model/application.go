@@ -1,6 +1,10 @@
 package model
 
 import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -48,6 +52,38 @@ func StatusLabel(status string) string {
 	return status
 }
 
+// NormalizeStatus maps a status key or label in any case ("Interview",
+// " APPLIED ") to its canonical key. Unrecognized statuses are returned
+// trimmed but otherwise unchanged.
+func NormalizeStatus(status string) string {
+	status = strings.TrimSpace(status)
+	for _, key := range AllStatuses() {
+		if strings.EqualFold(status, key) || strings.EqualFold(status, StatusLabel(key)) {
+			return key
+		}
+	}
+	return status
+}
+
+// StatusSymbol returns a distinct symbol for a status, so it can be told
+// apart without relying on color
+func StatusSymbol(status string) string {
+	symbols := map[string]string{
+		StatusSaved:     "○",
+		StatusApplied:   "●",
+		StatusScreening: "◐",
+		StatusInterview: "◆",
+		StatusOffer:     "★",
+		StatusAccepted:  "✓",
+		StatusRejected:  "✗",
+		StatusWithdrawn: "–",
+	}
+	if symbol, ok := symbols[status]; ok {
+		return symbol
+	}
+	return "?"
+}
+
 // StatusPriority returns sort priority for a status (higher = more important)
 func StatusPriority(status string) int {
 	priorities := map[string]int{
@@ -63,6 +99,77 @@ func StatusPriority(status string) int {
 	return priorities[status]
 }
 
+// IsResponseStatus reports whether reaching status means the company
+// responded: anything past applied except a withdrawal, which is the
+// candidate's doing
+func IsResponseStatus(status string) bool {
+	switch status {
+	case StatusScreening, StatusInterview, StatusOffer, StatusAccepted, StatusRejected:
+		return true
+	}
+	return false
+}
+
+// MaxPriority is the highest application priority; 0 means unset
+const MaxPriority = 5
+
+// ClampPriority limits p to the valid range 0 to MaxPriority
+func ClampPriority(p int) int {
+	if p < 0 {
+		return 0
+	}
+	if p > MaxPriority {
+		return MaxPriority
+	}
+	return p
+}
+
+// PriorityStars renders a priority as stars (★★★), or "" when unset
+func PriorityStars(p int) string {
+	return strings.Repeat("★", ClampPriority(p))
+}
+
+// Job types, used to group applications by discipline
+const (
+	JobTypeFrontend      = "fe-dev"
+	JobTypeUXDesign      = "ux-design"
+	JobTypeProductDesign = "product-design"
+	JobTypeSWE           = "swe"
+)
+
+// InferJobType guesses the job type from a position title, defaulting to
+// general software engineering
+func InferJobType(position string) string {
+	positionLower := strings.ToLower(position)
+
+	// Check for frontend keywords
+	frontendKeywords := []string{"frontend", "front-end", "front end", "react", "vue", "angular", "ui"}
+	for _, keyword := range frontendKeywords {
+		if strings.Contains(positionLower, keyword) {
+			return JobTypeFrontend
+		}
+	}
+
+	// Check for UX/UI keywords
+	uxKeywords := []string{"ux", "ui design", "user experience", "user interface"}
+	for _, keyword := range uxKeywords {
+		if strings.Contains(positionLower, keyword) {
+			return JobTypeUXDesign
+		}
+	}
+
+	// Check for product design keywords
+	pdKeywords := []string{"product design", "product designer"}
+	for _, keyword := range pdKeywords {
+		if strings.Contains(positionLower, keyword) {
+			return JobTypeProductDesign
+		}
+	}
+
+	// Default to general SWE
+	return JobTypeSWE
+}
+
 // Interview represents a scheduled or completed interview
 type Interview struct {
 	Date     time.Time `json:"date"`
@@ -71,6 +178,43 @@ type Interview struct {
 	WithWhom string    `json:"with_whom,omitempty"`
 }
 
+// UnmarshalJSON decodes an interview, leaving Date zero when it's missing or
+// malformed (e.g. from a hand-edited file) instead of failing the whole load
+func (i *Interview) UnmarshalJSON(data []byte) error {
+	type plain Interview
+	var raw struct {
+		plain
+		Date json.RawMessage `json:"date"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*i = Interview(raw.plain)
+	if len(raw.Date) > 0 {
+		var date time.Time
+		if err := json.Unmarshal(raw.Date, &date); err == nil {
+			i.Date = date
+		}
+	}
+	return nil
+}
+
+// Contact is a person involved in an application, with when they were
+// last in touch
+type Contact struct {
+	Name          string    `json:"name"`
+	Email         string    `json:"email,omitempty"`
+	Role          string    `json:"role,omitempty"` // recruiter, hiring manager, referral, ...
+	LastContacted time.Time `json:"last_contacted"`
+	Notes         string    `json:"notes,omitempty"`
+}
+
+// StatusChange records when an application entered a status
+type StatusChange struct {
+	Status string    `json:"status"`
+	At     time.Time `json:"at"`
+}
+
 // Application represents a job application
 type Application struct {
 	ID          string    `json:"id"`
@@ -87,14 +231,55 @@ type Application struct {
 	Location  string `json:"location,omitempty"`
 	Remote    bool   `json:"remote,omitempty"`
 
+	// JobType is the discipline (fe-dev, swe, ux-design, product-design);
+	// empty for entries created before it was recorded
+	JobType string `json:"job_type,omitempty"`
+
+	// MinYearsExperience is the experience the posting asks for (0 = unknown)
+	MinYearsExperience int `json:"min_years_experience,omitempty"`
+
+	// Priority ranks how much the application matters, 1 (low) to 5 (top
+	// target); 0 means unset
+	Priority int `json:"priority,omitempty"`
+
+	// Deadline is the last day to apply, when the posting states one
+	Deadline *time.Time `json:"deadline,omitempty"`
+
 	// Contact & Interviews
 	ContactName  string      `json:"contact_name,omitempty"`
 	ContactEmail string      `json:"contact_email,omitempty"`
 	Interviews   []Interview `json:"interviews,omitempty"`
 
+	// Contacts logs everyone involved in the application. The first is the
+	// primary contact, mirrored into ContactName/ContactEmail for
+	// compatibility.
+	Contacts []Contact `json:"contacts,omitempty"`
+
 	// Documents
 	ResumeVersion string `json:"resume_version,omitempty"`
 	CoverLetter   string `json:"cover_letter,omitempty"`
+	PostingPath   string `json:"posting_path,omitempty"` // Posting file the application was generated from
+
+	// DocumentsDir is the folder the application's documents are compiled
+	// in, relative to the working directory when it's inside it
+	DocumentsDir string `json:"documents_dir,omitempty"`
+
+	// ResumeVersions are earlier compiled resumes kept when recompiling,
+	// oldest first
+	ResumeVersions []string `json:"resume_versions,omitempty"`
+
+	// SalaryEstimated marks the salary as inferred from the role and
+	// location because the posting didn't state one
+	SalaryEstimated bool `json:"salary_estimated,omitempty"`
+
+	// StatusHistory is every status the application entered, oldest
+	// first. The store appends to it on each status change.
+	StatusHistory []StatusChange `json:"status_history,omitempty"`
+
+	// FirstResponseAt is when the company first responded. The store sets
+	// it on the first status change to a response status; `ghosted respond`
+	// sets it by hand.
+	FirstResponseAt *time.Time `json:"first_response_at,omitempty"`
 
 	// Follow-up
 	NextFollowUp *time.Time `json:"next_follow_up,omitempty"`
@@ -104,21 +289,113 @@ type Application struct {
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
-// SalaryRange returns formatted salary range or empty string
+// AddContact appends a contact to the log. An application that only has
+// the legacy single contact gets it as the primary contact first.
+func (a *Application) AddContact(c Contact) {
+	if len(a.Contacts) == 0 && (a.ContactName != "" || a.ContactEmail != "") {
+		a.Contacts = append(a.Contacts, Contact{Name: a.ContactName, Email: a.ContactEmail})
+	}
+	a.Contacts = append(a.Contacts, c)
+	a.syncPrimaryContact()
+}
+
+// syncPrimaryContact copies the primary contact into the legacy fields
+func (a *Application) syncPrimaryContact() {
+	if len(a.Contacts) == 0 {
+		return
+	}
+	a.ContactName = a.Contacts[0].Name
+	a.ContactEmail = a.Contacts[0].Email
+}
+
+// ContactsByLastContacted returns the contacts most recently in touch
+// first; contacts never contacted come last, in the order they were added
+func (a *Application) ContactsByLastContacted() []Contact {
+	contacts := make([]Contact, len(a.Contacts))
+	copy(contacts, a.Contacts)
+	sort.SliceStable(contacts, func(i, j int) bool {
+		return contacts[i].LastContacted.After(contacts[j].LastContacted)
+	})
+	return contacts
+}
+
+// SalaryRange returns formatted salary range or empty string. Estimated
+// salaries are prefixed with "~".
 func (a *Application) SalaryRange() string {
 	if a.SalaryMin == 0 && a.SalaryMax == 0 {
 		return ""
 	}
+	if a.SalaryEstimated {
+		exact := *a
+		exact.SalaryEstimated = false
+		return "~" + exact.SalaryRange()
+	}
 	if a.SalaryMin > 0 && a.SalaryMax > 0 {
-		return formatSalary(a.SalaryMin) + " - " + formatSalary(a.SalaryMax)
+		return FormatSalary(a.SalaryMin) + " - " + FormatSalary(a.SalaryMax)
 	}
 	if a.SalaryMin > 0 {
-		return formatSalary(a.SalaryMin) + "+"
+		return FormatSalary(a.SalaryMin) + "+"
+	}
+	return "Up to " + FormatSalary(a.SalaryMax)
+}
+
+// DefaultGhostedDays is how long an application can go without a response
+// before it counts as ghosted, when no threshold is configured
+const DefaultGhostedDays = 21
+
+// IsGhosted reports whether the application is still waiting on a first
+// response (applied or screening, with no interviews logged) more than days
+// after it was sent
+func (a *Application) IsGhosted(now time.Time, days int) bool {
+	if a.Status != StatusApplied && a.Status != StatusScreening {
+		return false
+	}
+	if len(a.Interviews) > 0 || a.DateApplied == nil {
+		return false
+	}
+	return now.Sub(*a.DateApplied) > time.Duration(days)*24*time.Hour
+}
+
+// DefaultMaxActivePerCompany is how many active applications at one company
+// are allowed before another needs --force, when no limit is configured
+const DefaultMaxActivePerCompany = 3
+
+// IsClosed reports whether the application was rejected or withdrawn, so it
+// no longer counts towards a company's active applications
+func (a *Application) IsClosed() bool {
+	return a.Status == StatusRejected || a.Status == StatusWithdrawn
+}
+
+// BelowSalaryFloor reports whether the disclosed salary tops out under floor.
+// The maximum is compared when known, otherwise the minimum.
+func (a *Application) BelowSalaryFloor(floor int) bool {
+	if floor <= 0 {
+		return false
+	}
+	top := a.SalaryMax
+	if top == 0 {
+		top = a.SalaryMin
+	}
+	return top > 0 && top < floor
+}
+
+// SalaryWarning describes why the salary may not meet floor, or returns ""
+// when it does or no floor is set
+func (a *Application) SalaryWarning(floor int) string {
+	if floor <= 0 {
+		return ""
+	}
+	if a.SalaryMin == 0 && a.SalaryMax == 0 {
+		return "salary not disclosed"
+	}
+	if a.BelowSalaryFloor(floor) {
+		return fmt.Sprintf("salary %s is below your minimum of %s", a.SalaryRange(), FormatSalary(floor))
 	}
-	return "Up to " + formatSalary(a.SalaryMax)
+	return ""
 }
 
-func formatSalary(amount int) string {
+// FormatSalary formats an amount in thousands, e.g. $120k
+func FormatSalary(amount int) string {
 	if amount >= 1000 {
 		return "$" + formatNumber(amount/1000) + "k"
 	}
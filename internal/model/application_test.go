@@ -0,0 +1,165 @@
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestApplication_SalaryWarning(t *testing.T) {
+	tests := []struct {
+		name     string
+		min, max int
+		floor    int
+		want     string
+	}{
+		{"below floor", 80000, 100000, 120000, "salary $80k - $100k is below your minimum of $120k"},
+		{"above floor", 130000, 160000, 120000, ""},
+		{"max equals floor", 100000, 120000, 120000, ""},
+		{"range straddles floor", 100000, 140000, 120000, ""},
+		{"min only below floor", 90000, 0, 120000, "salary $90k+ is below your minimum of $120k"},
+		{"undisclosed", 0, 0, 120000, "salary not disclosed"},
+		{"no floor set", 0, 0, 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := Application{SalaryMin: tt.min, SalaryMax: tt.max}
+			if got := app.SalaryWarning(tt.floor); got != tt.want {
+				t.Errorf("SalaryWarning(%d) = %q, want %q", tt.floor, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplication_BelowSalaryFloor(t *testing.T) {
+	tests := []struct {
+		name     string
+		min, max int
+		floor    int
+		want     bool
+	}{
+		{"below", 80000, 100000, 120000, true},
+		{"above", 130000, 160000, 120000, false},
+		{"undisclosed is not below", 0, 0, 120000, false},
+		{"no floor", 80000, 100000, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := Application{SalaryMin: tt.min, SalaryMax: tt.max}
+			if got := app.BelowSalaryFloor(tt.floor); got != tt.want {
+				t.Errorf("BelowSalaryFloor(%d) = %v, want %v", tt.floor, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplication_SalaryRange_Estimated(t *testing.T) {
+	app := Application{SalaryMin: 150000, SalaryMax: 185000, SalaryEstimated: true}
+	if got, want := app.SalaryRange(), "~$150k - $185k"; got != want {
+		t.Errorf("SalaryRange() = %q, want %q", got, want)
+	}
+	if got, want := app.SalaryWarning(200000), "salary ~$150k - $185k is below your minimum of $200k"; got != want {
+		t.Errorf("SalaryWarning() = %q, want %q", got, want)
+	}
+
+	app.SalaryEstimated = false
+	if got, want := app.SalaryRange(), "$150k - $185k"; got != want {
+		t.Errorf("SalaryRange() = %q, want %q", got, want)
+	}
+}
+
+func TestNormalizeStatus(t *testing.T) {
+	tests := map[string]string{
+		"interview":   StatusInterview,
+		"Interview":   StatusInterview,
+		" APPLIED ":   StatusApplied,
+		"Withdrawn\n": StatusWithdrawn,
+		" ghosted ":   "ghosted",
+	}
+	for in, want := range tests {
+		if got := NormalizeStatus(in); got != want {
+			t.Errorf("NormalizeStatus(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestApplication_AddContact(t *testing.T) {
+	app := Application{}
+	app.AddContact(Contact{Name: "Jane Doe", Email: "jane@acme.com", Role: "recruiter"})
+	app.AddContact(Contact{Name: "Sam Lee", Role: "hiring manager"})
+
+	if len(app.Contacts) != 2 || app.Contacts[1].Name != "Sam Lee" {
+		t.Fatalf("Contacts = %+v, want Jane Doe then Sam Lee", app.Contacts)
+	}
+	if app.ContactName != "Jane Doe" || app.ContactEmail != "jane@acme.com" {
+		t.Errorf("legacy contact = %q <%q>, want the primary contact Jane Doe", app.ContactName, app.ContactEmail)
+	}
+}
+
+func TestApplication_AddContact_KeepsLegacyContactAsPrimary(t *testing.T) {
+	app := Application{ContactName: "Old Recruiter", ContactEmail: "old@acme.com"}
+	app.AddContact(Contact{Name: "Sam Lee"})
+
+	if len(app.Contacts) != 2 {
+		t.Fatalf("Contacts = %+v, want the legacy contact plus Sam Lee", app.Contacts)
+	}
+	if app.Contacts[0].Name != "Old Recruiter" || app.Contacts[0].Email != "old@acme.com" {
+		t.Errorf("primary contact = %+v, want the legacy contact", app.Contacts[0])
+	}
+	if app.ContactName != "Old Recruiter" {
+		t.Errorf("ContactName = %q, want Old Recruiter", app.ContactName)
+	}
+}
+
+func TestApplication_ContactsByLastContacted(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+	app := Application{Contacts: []Contact{
+		{Name: "a", LastContacted: day(1)},
+		{Name: "never"},
+		{Name: "c", LastContacted: day(9)},
+		{Name: "b", LastContacted: day(5)},
+	}}
+
+	var got []string
+	for _, c := range app.ContactsByLastContacted() {
+		got = append(got, c.Name)
+	}
+	if want := "c b a never"; strings.Join(got, " ") != want {
+		t.Errorf("order = %v, want %s", got, want)
+	}
+	if app.Contacts[0].Name != "a" {
+		t.Error("ContactsByLastContacted() reordered the application's contacts")
+	}
+}
+
+func TestApplication_IsGhosted(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(d int) *time.Time {
+		t := now.AddDate(0, 0, -d)
+		return &t
+	}
+	cases := []struct {
+		name string
+		app  Application
+		want bool
+	}{
+		{"applied past threshold", Application{Status: StatusApplied, DateApplied: daysAgo(30)}, true},
+		{"screening past threshold", Application{Status: StatusScreening, DateApplied: daysAgo(30)}, true},
+		{"applied within threshold", Application{Status: StatusApplied, DateApplied: daysAgo(10)}, false},
+		{"exactly at threshold", Application{Status: StatusApplied, DateApplied: daysAgo(21)}, false},
+		{"interview logged", Application{Status: StatusApplied, DateApplied: daysAgo(30), Interviews: []Interview{{Type: "phone"}}}, false},
+		{"interview status", Application{Status: StatusInterview, DateApplied: daysAgo(30)}, false},
+		{"rejected", Application{Status: StatusRejected, DateApplied: daysAgo(30)}, false},
+		{"saved", Application{Status: StatusSaved, DateApplied: daysAgo(30)}, false},
+		{"no applied date", Application{Status: StatusApplied}, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.app.IsGhosted(now, DefaultGhostedDays); got != tc.want {
+				t.Errorf("IsGhosted() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
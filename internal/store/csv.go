@@ -0,0 +1,58 @@
+package store
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+// CSVColumns are the header of ExportCSV's output
+var CSVColumns = []string{
+	"id", "company", "position", "status", "date_applied", "location",
+	"remote", "salary_min", "salary_max", "job_url", "contact_name",
+	"contact_email", "notes",
+}
+
+// ExportCSV writes apps as CSV, one row per application under CSVColumns.
+// Dates are YYYY-MM-DD and unset dates and salaries are empty.
+func ExportCSV(w io.Writer, apps []model.Application) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(CSVColumns); err != nil {
+		return err
+	}
+	for _, app := range apps {
+		dateApplied := ""
+		if app.DateApplied != nil {
+			dateApplied = app.DateApplied.Format("2006-01-02")
+		}
+		if err := cw.Write([]string{
+			app.ID,
+			app.Company,
+			app.Position,
+			app.Status,
+			dateApplied,
+			app.Location,
+			strconv.FormatBool(app.Remote),
+			csvNumber(app.SalaryMin),
+			csvNumber(app.SalaryMax),
+			app.JobURL,
+			app.ContactName,
+			app.ContactEmail,
+			app.Notes,
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvNumber formats an optional number, empty when zero
+func csvNumber(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
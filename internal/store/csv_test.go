@@ -0,0 +1,70 @@
+package store
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+func TestExportCSV(t *testing.T) {
+	applied := time.Date(2026, 3, 9, 14, 0, 0, 0, time.UTC)
+	apps := []model.Application{
+		{
+			ID: "a1", Company: "Acme, Inc.", Position: "Backend Engineer", Status: model.StatusInterview,
+			DateApplied: &applied, Location: "Portland, OR", Remote: true, SalaryMin: 150000, SalaryMax: 180000,
+			JobURL: "https://acme.example/jobs/1", ContactName: "Dana", ContactEmail: "dana@acme.example",
+			Notes: "Said \"soon\"\nFollow up Friday",
+		},
+		{ID: "b2", Company: "Globex", Position: "SRE", Status: model.StatusSaved},
+	}
+
+	var buf bytes.Buffer
+	if err := ExportCSV(&buf, apps); err != nil {
+		t.Fatalf("ExportCSV() error = %v", err)
+	}
+
+	if header, _, _ := strings.Cut(buf.String(), "\n"); header != strings.Join(CSVColumns, ",") {
+		t.Errorf("header = %q", header)
+	}
+	if !strings.Contains(buf.String(), `"Acme, Inc."`) || !strings.Contains(buf.String(), "\"Said \"\"soon\"\"\nFollow up Friday\"") {
+		t.Errorf("fields with commas, quotes, or newlines not quoted:\n%s", buf.String())
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output doesn't parse as CSV: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("got %d rows, want header and 2 applications", len(rows))
+	}
+
+	tests := []struct {
+		row    int
+		column string
+		want   string
+	}{
+		{1, "company", "Acme, Inc."},
+		{1, "date_applied", "2026-03-09"},
+		{1, "remote", "true"},
+		{1, "salary_max", "180000"},
+		{1, "notes", "Said \"soon\"\nFollow up Friday"},
+		{2, "date_applied", ""},
+		{2, "salary_min", ""},
+		{2, "remote", "false"},
+	}
+	for _, tt := range tests {
+		col := -1
+		for i, name := range CSVColumns {
+			if name == tt.column {
+				col = i
+			}
+		}
+		if got := rows[tt.row][col]; got != tt.want {
+			t.Errorf("row %d %s = %q, want %q", tt.row, tt.column, got, tt.want)
+		}
+	}
+}
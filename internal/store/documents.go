@@ -0,0 +1,57 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+// NormalizeDir cleans a documents folder path and makes it relative to the
+// working directory when it's inside it, so "dir", "dir/", "./dir", and the
+// absolute path all normalize to the same string. Paths outside the working
+// directory stay absolute.
+func NormalizeDir(dir string) string {
+	if dir == "" {
+		return ""
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return filepath.Clean(dir)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return abs
+	}
+	rel, err := filepath.Rel(cwd, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return abs
+	}
+	return rel
+}
+
+// LinkDocuments records dir, normalized, as the folder holding the
+// application's generated documents
+func (s *Store) LinkDocuments(id, dir string) error {
+	app, err := s.GetByID(id)
+	if err != nil {
+		return err
+	}
+	app.DocumentsDir = NormalizeDir(dir)
+	return s.Update(app)
+}
+
+// FindByDocumentsDir returns the application linked to dir, comparing
+// normalized paths
+func (s *Store) FindByDocumentsDir(dir string) (model.Application, bool) {
+	want := NormalizeDir(dir)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, a := range s.applications {
+		if a.DocumentsDir != "" && NormalizeDir(a.DocumentsDir) == want {
+			return a, true
+		}
+	}
+	return model.Application{}, false
+}
@@ -0,0 +1,70 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+func TestNormalizeDir(t *testing.T) {
+	cwd := t.TempDir()
+	t.Chdir(cwd)
+	outside := t.TempDir()
+
+	cases := []struct {
+		in, want string
+	}{
+		{"local/applications/swe/acme", filepath.Join("local", "applications", "swe", "acme")},
+		{"local/applications/swe/acme/", filepath.Join("local", "applications", "swe", "acme")},
+		{"./local/applications/swe/acme", filepath.Join("local", "applications", "swe", "acme")},
+		{filepath.Join(cwd, "local", "applications", "swe", "acme") + "/", filepath.Join("local", "applications", "swe", "acme")},
+		{outside + "/", outside},
+		{"", ""},
+	}
+	for _, tc := range cases {
+		if got := NormalizeDir(tc.in); got != tc.want {
+			t.Errorf("NormalizeDir(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestStore_LinkDocuments(t *testing.T) {
+	cwd := t.TempDir()
+	t.Chdir(cwd)
+	dir := filepath.Join("local", "applications", "swe", "acme-v2")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := New(filepath.Join(t.TempDir(), "applications.json"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	app, err := s.Add(model.Application{Company: "Acme", Position: "Engineer", Status: model.StatusApplied})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.LinkDocuments(app.ID, filepath.Join(cwd, dir)+"/"); err != nil {
+		t.Fatalf("LinkDocuments() error = %v", err)
+	}
+	got, _ := s.GetByID(app.ID)
+	if got.DocumentsDir != dir {
+		t.Errorf("DocumentsDir = %q, want %q", got.DocumentsDir, dir)
+	}
+
+	for _, query := range []string{dir, dir + "/", "./" + dir, filepath.Join(cwd, dir)} {
+		if found, ok := s.FindByDocumentsDir(query); !ok || found.ID != app.ID {
+			t.Errorf("FindByDocumentsDir(%q) = %v, %v; want %s", query, found.ID, ok, app.ID)
+		}
+	}
+	if _, ok := s.FindByDocumentsDir("local/applications/swe/acme"); ok {
+		t.Error("FindByDocumentsDir() matched a different folder")
+	}
+
+	if err := s.LinkDocuments("missing", dir); err != ErrNotFound {
+		t.Errorf("LinkDocuments(missing) error = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,34 @@
+package store
+
+import (
+	"sort"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+// DueFollowUps returns the applications with a follow-up on or before asOf,
+// most overdue first
+func (s *Store) DueFollowUps(asOf time.Time) []model.Application {
+	return sortByFollowUp(s.Find(func(a model.Application) bool {
+		return a.NextFollowUp != nil && !a.NextFollowUp.After(asOf)
+	}))
+}
+
+// UpcomingFollowUps returns the applications with a follow-up after asOf and
+// within the next days days, soonest first
+func (s *Store) UpcomingFollowUps(asOf time.Time, days int) []model.Application {
+	until := asOf.AddDate(0, 0, days)
+	return sortByFollowUp(s.Find(func(a model.Application) bool {
+		return a.NextFollowUp != nil && a.NextFollowUp.After(asOf) && !a.NextFollowUp.After(until)
+	}))
+}
+
+// sortByFollowUp orders apps by follow-up date, earliest first, keeping List
+// order for ties
+func sortByFollowUp(apps []model.Application) []model.Application {
+	sort.SliceStable(apps, func(i, j int) bool {
+		return apps[i].NextFollowUp.Before(*apps[j].NextFollowUp)
+	})
+	return apps
+}
@@ -0,0 +1,46 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+func TestStore_FollowUps(t *testing.T) {
+	asOf := time.Date(2026, 5, 12, 15, 0, 0, 0, time.UTC)
+	date := func(s string) *time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return &d
+	}
+	s := &Store{applications: []model.Application{
+		{ID: "next-week", NextFollowUp: date("2026-05-19")},
+		{ID: "none"},
+		{ID: "today", NextFollowUp: date("2026-05-12")},
+		{ID: "later", NextFollowUp: date("2026-06-01")},
+		{ID: "overdue", NextFollowUp: date("2026-05-02")},
+		{ID: "tomorrow", NextFollowUp: date("2026-05-13")},
+	}}
+
+	tests := []struct {
+		name string
+		got  []model.Application
+		want []string
+	}{
+		{"due", s.DueFollowUps(asOf), []string{"overdue", "today"}},
+		{"upcoming", s.UpcomingFollowUps(asOf, 7), []string{"tomorrow", "next-week"}},
+		{"upcoming in a shorter range", s.UpcomingFollowUps(asOf, 6), []string{"tomorrow"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if len(tt.got) != len(tt.want) {
+				t.Fatalf("got %d applications, want %v", len(tt.got), tt.want)
+			}
+			for i, id := range tt.want {
+				if tt.got[i].ID != id {
+					t.Errorf("position %d = %s, want %s", i, tt.got[i].ID, id)
+				}
+			}
+		})
+	}
+}
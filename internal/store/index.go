@@ -0,0 +1,29 @@
+package store
+
+// reindex rebuilds the ID index from applications. Callers must hold the
+// write lock, and call it whenever positions shift (load, delete).
+func (s *Store) reindex() {
+	s.index = make(map[string]int, len(s.applications))
+	for i, a := range s.applications {
+		s.index[a.ID] = i
+	}
+}
+
+// indexOf returns the position of the application with id, or -1. A
+// missing or stale index entry (e.g. a store built without New) falls back
+// to a linear scan, so lookups stay correct either way.
+func (s *Store) indexOf(id string) int {
+	if i, ok := s.index[id]; ok && i < len(s.applications) && s.applications[i].ID == id {
+		return i
+	}
+	if len(s.index) == len(s.applications) && s.index != nil {
+		// The index is complete, so the ID isn't present
+		return -1
+	}
+	for i, a := range s.applications {
+		if a.ID == id {
+			return i
+		}
+	}
+	return -1
+}
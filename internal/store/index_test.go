@@ -0,0 +1,148 @@
+package store
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+// assertIndexed checks every application is found at its own position and
+// that the index holds nothing else
+func assertIndexed(t *testing.T, s *Store) {
+	t.Helper()
+	if len(s.index) != len(s.applications) {
+		t.Fatalf("index has %d entries for %d applications", len(s.index), len(s.applications))
+	}
+	for i, a := range s.applications {
+		if got := s.index[a.ID]; got != i {
+			t.Errorf("index[%s] = %d, want %d", a.ID, got, i)
+		}
+	}
+}
+
+func TestStore_IndexAcrossMutations(t *testing.T) {
+	s, err := New(filepath.Join(t.TempDir(), "applications.json"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	assertIndexed(t, s)
+
+	var ids []string
+	for i := range 5 {
+		app, err := s.Add(model.Application{Company: fmt.Sprintf("Co %d", i), Position: "Engineer"})
+		if err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+		ids = append(ids, app.ID)
+	}
+	assertIndexed(t, s)
+
+	// Deleting from the middle shifts later positions
+	if err := s.Delete(ids[1]); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	assertIndexed(t, s)
+	if _, err := s.GetByID(ids[1]); err != ErrNotFound {
+		t.Errorf("GetByID(deleted) error = %v, want ErrNotFound", err)
+	}
+
+	if err := s.UpdateStatus(ids[3], model.StatusInterview); err != nil {
+		t.Fatalf("UpdateStatus() error = %v", err)
+	}
+	got, err := s.GetByID(ids[3])
+	if err != nil || got.Status != model.StatusInterview || got.Company != "Co 3" {
+		t.Errorf("GetByID() = %+v, %v; want Co 3 in interview", got, err)
+	}
+
+	added, err := s.Add(model.Application{Company: "Late", Position: "Designer"})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if err := s.Delete(ids[0]); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	assertIndexed(t, s)
+	for _, id := range []string{ids[2], ids[3], ids[4], added.ID} {
+		if a, err := s.GetByID(id); err != nil || a.ID != id {
+			t.Errorf("GetByID(%s) = %s, %v", id, a.ID, err)
+		}
+	}
+
+	// A reload from disk rebuilds the index
+	if err := s.Reload(); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	assertIndexed(t, s)
+}
+
+func TestStore_IndexAfterUndo(t *testing.T) {
+	dir := t.TempDir()
+	s, err := New(filepath.Join(dir, "applications.json"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	for _, company := range []string{"Acme", "Globex", "Initech"} {
+		if _, err := s.Add(model.Application{Company: company, Position: "Engineer"}); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+	s.SetUndoLog(NewUndoLog(filepath.Join(dir, "undo.json"), 10))
+
+	first := s.List()[0]
+	if err := s.Delete(first.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := s.Undo(); err != nil {
+		t.Fatalf("Undo() error = %v", err)
+	}
+	assertIndexed(t, s)
+	if _, err := s.GetByID(first.ID); err != nil {
+		t.Errorf("GetByID() after undoing delete error = %v", err)
+	}
+}
+
+func TestStore_LookupWithoutIndex(t *testing.T) {
+	// Stores built directly, as in tests, have no index yet
+	s := &Store{applications: []model.Application{{ID: "a"}, {ID: "b"}}}
+	if got, err := s.GetByID("b"); err != nil || got.ID != "b" {
+		t.Errorf("GetByID(b) = %q, %v", got.ID, err)
+	}
+	if _, err := s.GetByID("c"); err != ErrNotFound {
+		t.Errorf("GetByID(c) error = %v, want ErrNotFound", err)
+	}
+}
+
+// benchmarkStore returns an indexed store of n applications and their IDs
+func benchmarkStore(n int) (*Store, []string) {
+	s := &Store{}
+	ids := make([]string, n)
+	for i := range n {
+		ids[i] = fmt.Sprintf("id-%05d", i)
+		s.applications = append(s.applications, model.Application{ID: ids[i]})
+	}
+	s.reindex()
+	return s, ids
+}
+
+func BenchmarkGetByID_Indexed(b *testing.B) {
+	s, ids := benchmarkStore(10000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := s.GetByID(ids[i%len(ids)]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetByID_LinearScan(b *testing.B) {
+	s, ids := benchmarkStore(10000)
+	s.index = nil // Forces the fallback scan
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := s.GetByID(ids[i%len(ids)]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -7,8 +7,10 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/celloopa/ghosted/internal/atomicfile"
 	"github.com/celloopa/ghosted/internal/model"
 
 	"github.com/google/uuid"
@@ -20,11 +22,27 @@ var (
 
 // Store manages job applications in a JSON file
 type Store struct {
+	mu           sync.RWMutex
 	filepath     string
 	applications []model.Application
+	undo         *UndoLog
+	ops          *OpLog
+	// rules are the follow-up automations UpdateStatus applies
+	rules []Rule
+	// index maps IDs to positions in applications, for O(1) lookups
+	index map[string]int
+
+	// File state as of the last load or save, for detecting external edits
+	modTime time.Time
+	size    int64
 }
 
-// New creates a new Store with the given file path
+// SeedEnvVar, when set to a true value (1, true, yes), seeds an empty store
+// with sample applications on creation
+const SeedEnvVar = "GHOSTED_SEED"
+
+// New creates a new Store with the given file path. A missing or empty file
+// starts an empty store, unless SeedEnvVar asks for sample data.
 func New(path string) (*Store, error) {
 	s := &Store{filepath: path}
 
@@ -36,22 +54,63 @@ func New(path string) (*Store, error) {
 
 	// Load existing data or create empty file
 	if err := s.load(); err != nil {
-		if os.IsNotExist(err) {
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+		s.applications = []model.Application{}
+		s.reindex()
+		if seedRequested() {
 			s.applications = sampleData()
-			return s, s.save()
+			s.reindex()
 		}
-		return nil, err
+		return s, s.save()
 	}
 
-	// Seed with sample data if empty
-	if len(s.applications) == 0 {
+	if len(s.applications) == 0 && seedRequested() {
 		s.applications = sampleData()
+		s.reindex()
 		return s, s.save()
 	}
 
 	return s, nil
 }
 
+// seedRequested reports whether SeedEnvVar asks for sample data
+func seedRequested() bool {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(SeedEnvVar))) {
+	case "1", "true", "yes":
+		return true
+	}
+	return false
+}
+
+// LoadSampleData adds the sample applications to the store, skipping any
+// already present, and returns how many were added
+func (s *Store) LoadSampleData() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	added := 0
+	for _, app := range sampleData() {
+		if s.indexOf(app.ID) >= 0 {
+			continue
+		}
+		s.applications = append(s.applications, app)
+		added++
+	}
+	if added == 0 {
+		return 0, nil
+	}
+	s.reindex()
+	if err := s.save(); err != nil {
+		return added, err
+	}
+	for _, app := range s.applications[len(s.applications)-added:] {
+		s.recordOp(OpAdd, app)
+	}
+	return added, nil
+}
+
 // sampleData returns pre-seeded sample applications for new users
 func sampleData() []model.Application {
 	now := time.Now()
@@ -126,23 +185,141 @@ func (s *Store) load() error {
 
 	if len(data) == 0 {
 		s.applications = []model.Application{}
+		s.reindex()
+		s.recordFileState()
 		return nil
 	}
 
-	return json.Unmarshal(data, &s.applications)
+	var apps []model.Application
+	if err := json.Unmarshal(data, &apps); err != nil {
+		return err
+	}
+	for i := range apps {
+		clearZeroDates(&apps[i])
+	}
+	s.applications = apps
+	s.reindex()
+	s.recordFileState()
+	return nil
 }
 
-// save writes applications to the JSON file
+// save writes applications to the JSON file. It writes a temporary file
+// and renames it into place, so a crash mid-write never leaves a truncated
+// data file.
 func (s *Store) save() error {
 	data, err := json.MarshalIndent(s.applications, "", "  ")
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(s.filepath, data, 0644)
+	if err := atomicfile.WriteFile(s.filepath, data, 0644); err != nil {
+		return err
+	}
+	s.recordFileState()
+	return nil
+}
+
+// recordFileState remembers the data file's mtime and size
+func (s *Store) recordFileState() {
+	if info, err := os.Stat(s.filepath); err == nil {
+		s.modTime = info.ModTime()
+		s.size = info.Size()
+	}
+}
+
+// Changed reports whether the data file was modified since the store last
+// loaded or saved it, e.g. by a hand edit or another ghosted process
+func (s *Store) Changed() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	info, err := os.Stat(s.filepath)
+	if err != nil {
+		return false
+	}
+	return !info.ModTime().Equal(s.modTime) || info.Size() != s.size
+}
+
+// Reload re-reads applications from the data file, discarding the
+// in-memory copy
+func (s *Store) Reload() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.load()
+}
+
+// ReloadIfChanged reloads the data file only if it changed since the last
+// load or save, and reports whether it did
+func (s *Store) ReloadIfChanged() (bool, error) {
+	if !s.Changed() {
+		return false, nil
+	}
+	if err := s.Reload(); err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
 // Add creates a new application and returns it
 func (s *Store) Add(app model.Application) (model.Application, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	app, m := s.add(app)
+	if err := s.save(); err != nil {
+		return app, err
+	}
+	s.record(m)
+	return app, nil
+}
+
+// Update modifies an existing application. When the status changes, rules
+// set with SetRules that match the change are applied.
+func (s *Store) Update(app model.Application) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m, err := s.update(app)
+	if err != nil {
+		return err
+	}
+	if err := s.save(); err != nil {
+		return err
+	}
+	s.record(m)
+	return nil
+}
+
+// Delete removes an application by ID
+func (s *Store) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m, err := s.delete(id)
+	if err != nil {
+		return err
+	}
+	if err := s.save(); err != nil {
+		return err
+	}
+	s.record(m)
+	return nil
+}
+
+// mutation is an applied change, for the undo and operation logs once it
+// has been saved
+type mutation struct {
+	undoOp string
+	undo   model.Application // the state to restore on undo
+	op     string
+	app    model.Application // the state to log
+}
+
+// record logs a saved mutation to the undo and operation logs
+func (s *Store) record(m mutation) {
+	s.recordUndo(m.undoOp, m.undo)
+	s.recordOp(m.op, m.app)
+}
+
+// add appends a new application in memory, filling in its ID, timestamps,
+// and defaults. Callers must hold the write lock and save.
+func (s *Store) add(app model.Application) (model.Application, mutation) {
 	app.ID = uuid.New().String()
 	app.CreatedAt = time.Now()
 	app.UpdatedAt = time.Now()
@@ -154,47 +331,68 @@ func (s *Store) Add(app model.Application) (model.Application, error) {
 		now := time.Now()
 		app.DateApplied = &now
 	}
+	if len(app.StatusHistory) == 0 {
+		app.StatusHistory = []model.StatusChange{{Status: app.Status, At: app.CreatedAt}}
+	}
 
 	s.applications = append(s.applications, app)
-	return app, s.save()
+	if s.index == nil {
+		s.reindex()
+	} else {
+		s.index[app.ID] = len(s.applications) - 1
+	}
+	return app, mutation{UndoAdd, app, OpAdd, app}
 }
 
-// Update modifies an existing application
-func (s *Store) Update(app model.Application) error {
-	for i, a := range s.applications {
-		if a.ID == app.ID {
-			app.UpdatedAt = time.Now()
-			app.CreatedAt = a.CreatedAt // Preserve original creation time
-			s.applications[i] = app
-			return s.save()
+// update replaces an application in memory. Callers must hold the write
+// lock and save.
+func (s *Store) update(app model.Application) (mutation, error) {
+	i := s.indexOf(app.ID)
+	if i == -1 {
+		return mutation{}, ErrNotFound
+	}
+	a := s.applications[i]
+	app.UpdatedAt = time.Now()
+	app.CreatedAt = a.CreatedAt // Preserve original creation time
+	if app.Status != a.Status {
+		app = applyRules(s.rules, a, app, app.UpdatedAt)
+		app.StatusHistory = append(app.StatusHistory, model.StatusChange{Status: app.Status, At: app.UpdatedAt})
+		if app.FirstResponseAt == nil && model.IsResponseStatus(app.Status) {
+			at := app.UpdatedAt
+			app.FirstResponseAt = &at
 		}
 	}
-	return ErrNotFound
+	s.applications[i] = app
+	return mutation{UndoUpdate, a, OpUpdate, app}, nil
 }
 
-// Delete removes an application by ID
-func (s *Store) Delete(id string) error {
-	for i, a := range s.applications {
-		if a.ID == id {
-			s.applications = append(s.applications[:i], s.applications[i+1:]...)
-			return s.save()
-		}
+// delete removes an application in memory. Callers must hold the write
+// lock and save.
+func (s *Store) delete(id string) (mutation, error) {
+	i := s.indexOf(id)
+	if i == -1 {
+		return mutation{}, ErrNotFound
 	}
-	return ErrNotFound
+	a := s.applications[i]
+	s.applications = append(s.applications[:i], s.applications[i+1:]...)
+	s.reindex() // Later positions shifted down
+	return mutation{UndoDelete, a, OpDelete, a}, nil
 }
 
 // GetByID returns a single application by ID
 func (s *Store) GetByID(id string) (model.Application, error) {
-	for _, a := range s.applications {
-		if a.ID == id {
-			return a, nil
-		}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if i := s.indexOf(id); i != -1 {
+		return s.applications[i], nil
 	}
 	return model.Application{}, ErrNotFound
 }
 
 // List returns all applications, sorted by status priority (later stages first), then by date (newest first)
 func (s *Store) List() []model.Application {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	apps := make([]model.Application, len(s.applications))
 	copy(apps, s.applications)
 
@@ -220,8 +418,47 @@ func (s *Store) List() []model.Application {
 	return apps
 }
 
+// ByJobType groups applications by their stored job type, inferring it from
+// the position for entries that don't have one. Each group keeps List order.
+func (s *Store) ByJobType() map[string][]model.Application {
+	groups := make(map[string][]model.Application)
+	for _, a := range s.List() {
+		jobType := a.JobType
+		if jobType == "" {
+			jobType = model.InferJobType(a.Position)
+		}
+		groups[jobType] = append(groups[jobType], a)
+	}
+	return groups
+}
+
+// ByCompany returns the applications at a company, matched case-insensitively
+// and ignoring surrounding whitespace, in List order
+func (s *Store) ByCompany(company string) []model.Application {
+	company = strings.TrimSpace(company)
+	return s.Find(func(a model.Application) bool {
+		return strings.EqualFold(strings.TrimSpace(a.Company), company)
+	})
+}
+
+// ByPriority returns applications with the highest priority first. Unset
+// priorities sort last, and ties keep List order.
+func (s *Store) ByPriority() []model.Application {
+	apps := s.List()
+	sort.SliceStable(apps, func(i, j int) bool {
+		pi, pj := apps[i].Priority, apps[j].Priority
+		if pi == 0 || pj == 0 {
+			return pj == 0 && pi != 0
+		}
+		return pi > pj
+	})
+	return apps
+}
+
 // FilterByStatus returns applications with the given status
 func (s *Store) FilterByStatus(status string) []model.Application {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	var result []model.Application
 	for _, a := range s.applications {
 		if a.Status == status {
@@ -248,6 +485,8 @@ func (s *Store) FilterByStatus(status string) []model.Application {
 
 // Search returns applications matching the query in company or position
 func (s *Store) Search(query string) []model.Application {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	query = strings.ToLower(query)
 	var result []model.Application
 
@@ -281,6 +520,8 @@ func (s *Store) Search(query string) []model.Application {
 
 // CountByStatus returns a map of status to count
 func (s *Store) CountByStatus() map[string]int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	counts := make(map[string]int)
 	for _, a := range s.applications {
 		counts[a.Status]++
@@ -290,20 +531,97 @@ func (s *Store) CountByStatus() map[string]int {
 
 // Total returns the total number of applications
 func (s *Store) Total() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	return len(s.applications)
 }
 
-// UpdateStatus is a convenience method to change just the status
+// CountActive returns the number of applications still in progress
+// (not saved, accepted, rejected, or withdrawn)
+func (s *Store) CountActive() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	count := 0
+	for _, a := range s.applications {
+		switch a.Status {
+		case model.StatusSaved, model.StatusAccepted, model.StatusRejected, model.StatusWithdrawn:
+		default:
+			count++
+		}
+	}
+	return count
+}
+
+// CountThisWeek returns the number of applications sent in the 7 days up to asOf
+func (s *Store) CountThisWeek(asOf time.Time) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	weekAgo := asOf.AddDate(0, 0, -7)
+	count := 0
+	for _, a := range s.applications {
+		if a.DateApplied != nil && a.DateApplied.After(weekAgo) && !a.DateApplied.After(asOf) {
+			count++
+		}
+	}
+	return count
+}
+
+// CountInterviewing returns the number of applications in the interview stage
+func (s *Store) CountInterviewing() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	count := 0
+	for _, a := range s.applications {
+		if a.Status == model.StatusInterview {
+			count++
+		}
+	}
+	return count
+}
+
+// UpdateStatus is a convenience method to change just the status. Rules
+// set with SetRules that match the change are applied in the same update,
+// as they are by any update that changes the status.
 func (s *Store) UpdateStatus(id string, status string) error {
 	app, err := s.GetByID(id)
 	if err != nil {
 		return err
 	}
+	return s.Update(withStatus(app, status))
+}
+
+// AddInterview appends an interview to an application's interview history
+// and saves. Interviews are kept in the order they were added.
+func (s *Store) AddInterview(id string, iv model.Interview) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.indexOf(id)
+	if i == -1 {
+		return ErrNotFound
+	}
+	app := s.applications[i]
+	// Copy rather than append in place, so the undo log's previous version
+	// doesn't share the new slice
+	app.Interviews = append(append([]model.Interview(nil), app.Interviews...), iv)
+	m, err := s.update(app)
+	if err != nil {
+		return err
+	}
+	if err := s.save(); err != nil {
+		return err
+	}
+	s.record(m)
+	return nil
+}
+
+// withStatus returns app moved to status, dating it as applied when it
+// leaves saved
+func withStatus(app model.Application, status string) model.Application {
 	app.Status = status
 	// Auto-set date when transitioning to non-saved status
 	if app.DateApplied == nil && status != model.StatusSaved {
 		now := time.Now()
 		app.DateApplied = &now
 	}
-	return s.Update(app)
+	return app
 }
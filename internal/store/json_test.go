@@ -0,0 +1,456 @@
+package store
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+var testAsOf = time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+
+// daysAgo returns a pointer to a time n days before testAsOf
+func daysAgo(n int) *time.Time {
+	t := testAsOf.AddDate(0, 0, -n)
+	return &t
+}
+
+// mixedStore returns a store with one application per status plus extras
+// to exercise date boundaries
+func mixedStore() *Store {
+	return &Store{applications: []model.Application{
+		{ID: "1", Status: model.StatusSaved},
+		{ID: "2", Status: model.StatusApplied, DateApplied: daysAgo(1)},
+		{ID: "3", Status: model.StatusScreening, DateApplied: daysAgo(6)},
+		{ID: "4", Status: model.StatusInterview, DateApplied: daysAgo(7)},
+		{ID: "5", Status: model.StatusInterview, DateApplied: daysAgo(20)},
+		{ID: "6", Status: model.StatusOffer, DateApplied: daysAgo(30)},
+		{ID: "7", Status: model.StatusAccepted, DateApplied: daysAgo(40)},
+		{ID: "8", Status: model.StatusRejected, DateApplied: daysAgo(2)},
+		{ID: "9", Status: model.StatusWithdrawn, DateApplied: daysAgo(3)},
+		{ID: "10", Status: model.StatusApplied, DateApplied: daysAgo(-1)}, // future-dated
+	}}
+}
+
+func TestStore_Counters(t *testing.T) {
+	tests := []struct {
+		name  string
+		store *Store
+		count func(s *Store) int
+		want  int
+	}{
+		{"active mixed", mixedStore(), (*Store).CountActive, 6},
+		{"active empty", &Store{}, (*Store).CountActive, 0},
+		{"this week mixed", mixedStore(), func(s *Store) int { return s.CountThisWeek(testAsOf) }, 4},
+		{"this week empty", &Store{}, func(s *Store) int { return s.CountThisWeek(testAsOf) }, 0},
+		{"this week later asOf", mixedStore(), func(s *Store) int { return s.CountThisWeek(testAsOf.AddDate(0, 0, 30)) }, 0},
+		{"interviewing mixed", mixedStore(), (*Store).CountInterviewing, 2},
+		{"interviewing empty", &Store{}, (*Store).CountInterviewing, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.count(tt.store); got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStore_UpdateSetsFirstResponse(t *testing.T) {
+	s, err := New(filepath.Join(t.TempDir(), "applications.json"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	app, err := s.Add(model.Application{Company: "Acme", Position: "SWE", Status: model.StatusApplied})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Edits that don't move past applied, and withdrawing, aren't responses
+	if err := s.UpdateStatus(app.ID, model.StatusWithdrawn); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.UpdateStatus(app.ID, model.StatusApplied); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := s.GetByID(app.ID); got.FirstResponseAt != nil {
+		t.Fatalf("FirstResponseAt = %v before any response, want nil", got.FirstResponseAt)
+	}
+
+	if err := s.UpdateStatus(app.ID, model.StatusScreening); err != nil {
+		t.Fatal(err)
+	}
+	first, _ := s.GetByID(app.ID)
+	if first.FirstResponseAt == nil {
+		t.Fatal("FirstResponseAt not set on moving to screening")
+	}
+
+	// Later transitions keep the first response
+	if err := s.UpdateStatus(app.ID, model.StatusInterview); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := s.GetByID(app.ID); !got.FirstResponseAt.Equal(*first.FirstResponseAt) {
+		t.Errorf("FirstResponseAt moved from %v to %v", first.FirstResponseAt, got.FirstResponseAt)
+	}
+}
+
+func TestStore_ReloadPicksUpExternalAdd(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "applications.json")
+	s, err := New(path)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	before := s.Total()
+
+	// Another process (e.g. the CLI while the TUI is open) adds an application
+	other, err := New(path)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	added, err := other.Add(model.Application{Company: "External", Position: "Engineer"})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	// Make the change visible even on filesystems with coarse mtimes
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatalf("Chtimes() error = %v", err)
+	}
+
+	if !s.Changed() {
+		t.Fatal("Changed() = false after external edit")
+	}
+	reloaded, err := s.ReloadIfChanged()
+	if err != nil || !reloaded {
+		t.Fatalf("ReloadIfChanged() = %v, %v; want true, nil", reloaded, err)
+	}
+	if s.Total() != before+1 {
+		t.Errorf("Total() = %d, want %d", s.Total(), before+1)
+	}
+	if _, err := s.GetByID(added.ID); err != nil {
+		t.Errorf("GetByID(external) error = %v", err)
+	}
+	if s.Changed() {
+		t.Error("Changed() = true right after reload")
+	}
+}
+
+func TestStore_ReloadIfChangedSkipsUnchangedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "applications.json")
+	s, err := New(path)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	before := s.Total()
+
+	// Our own saves must not count as external changes
+	if _, err := s.Add(model.Application{Company: "Mine", Position: "Engineer"}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if s.Changed() {
+		t.Error("Changed() = true after the store's own save")
+	}
+
+	// Corrupt the file but restore its mtime and size: with no detectable
+	// change, the file must not be re-read
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat() error = %v", err)
+	}
+	if err := os.WriteFile(path, bytes.Repeat([]byte("x"), int(info.Size())), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if err := os.Chtimes(path, info.ModTime(), info.ModTime()); err != nil {
+		t.Fatalf("Chtimes() error = %v", err)
+	}
+
+	reloaded, err := s.ReloadIfChanged()
+	if err != nil || reloaded {
+		t.Fatalf("ReloadIfChanged() = %v, %v; want false, nil", reloaded, err)
+	}
+	if s.Total() != before+1 {
+		t.Errorf("Total() = %d, want %d (in-memory data kept)", s.Total(), before+1)
+	}
+}
+
+func TestStore_ReloadKeepsDataOnParseError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "applications.json")
+	s, err := New(path)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	before := s.Total()
+
+	if err := os.WriteFile(path, []byte("{not json"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if err := s.Reload(); err == nil {
+		t.Fatal("Reload() of malformed file should return error")
+	}
+	if s.Total() != before {
+		t.Errorf("Total() = %d, want %d after failed reload", s.Total(), before)
+	}
+}
+
+func TestStore_ByJobType(t *testing.T) {
+	s := &Store{applications: []model.Application{
+		{ID: "1", Position: "Frontend Developer", JobType: model.JobTypeFrontend},
+		{ID: "2", Position: "Backend Engineer", JobType: model.JobTypeSWE},
+		{ID: "3", Position: "Senior UX Designer", JobType: model.JobTypeUXDesign},
+		{ID: "4", Position: "Platform Engineer", JobType: model.JobTypeSWE},
+		// A stored type wins over the position
+		{ID: "5", Position: "Software Engineer", JobType: model.JobTypeUXDesign},
+	}}
+
+	groups := s.ByJobType()
+	want := map[string][]string{
+		model.JobTypeFrontend: {"1"},
+		model.JobTypeSWE:      {"2", "4"},
+		model.JobTypeUXDesign: {"3", "5"},
+	}
+	assertGroups(t, groups, want)
+}
+
+func TestStore_ByCompany(t *testing.T) {
+	s := &Store{applications: []model.Application{
+		{ID: "1", Company: "Acme"},
+		{ID: "2", Company: "Globex"},
+		{ID: "3", Company: " ACME "},
+		{ID: "4", Company: "Acme Labs"},
+	}}
+
+	var ids []string
+	for _, a := range s.ByCompany("acme") {
+		ids = append(ids, a.ID)
+	}
+	if len(ids) != 2 || ids[0] != "1" || ids[1] != "3" {
+		t.Errorf("ByCompany(acme) = %v, want [1 3]", ids)
+	}
+}
+
+func TestStore_ByJobType_InfersMissingType(t *testing.T) {
+	s := &Store{applications: []model.Application{
+		{ID: "1", Position: "React Engineer"},
+		{ID: "2", Position: "Senior Software Engineer"},
+		{ID: "3", Position: "Product Designer"},
+		{ID: "4", Position: "UX Researcher"},
+	}}
+
+	want := map[string][]string{
+		model.JobTypeFrontend:      {"1"},
+		model.JobTypeSWE:           {"2"},
+		model.JobTypeProductDesign: {"3"},
+		model.JobTypeUXDesign:      {"4"},
+	}
+	assertGroups(t, s.ByJobType(), want)
+}
+
+func TestStore_ByPriority(t *testing.T) {
+	s := &Store{applications: []model.Application{
+		{ID: "low", Status: model.StatusApplied, Priority: 1},
+		{ID: "unset", Status: model.StatusOffer},
+		{ID: "top", Status: model.StatusSaved, Priority: 5},
+		{ID: "mid", Status: model.StatusApplied, Priority: 3},
+	}}
+
+	assertOrder(t, s.ByPriority(), []string{"top", "mid", "low", "unset"})
+}
+
+func TestStore_ByPriority_UnsetSortsLast(t *testing.T) {
+	s := &Store{applications: []model.Application{
+		{ID: "unset-offer", Status: model.StatusOffer},
+		{ID: "set", Status: model.StatusSaved, Priority: 1},
+		{ID: "unset-saved", Status: model.StatusSaved},
+		{ID: "tie-interview", Status: model.StatusInterview, Priority: 1},
+	}}
+
+	// Ties and unset entries keep List order (status priority first)
+	assertOrder(t, s.ByPriority(), []string{"tie-interview", "set", "unset-offer", "unset-saved"})
+}
+
+// assertOrder checks application IDs in order
+func assertOrder(t *testing.T, apps []model.Application, want []string) {
+	t.Helper()
+	if len(apps) != len(want) {
+		t.Fatalf("got %d applications, want %d", len(apps), len(want))
+	}
+	for i, id := range want {
+		if apps[i].ID != id {
+			t.Errorf("position %d = %s, want %s", i, apps[i].ID, id)
+		}
+	}
+}
+
+// assertGroups checks grouped application IDs, ignoring order within a group
+func assertGroups(t *testing.T, groups map[string][]model.Application, want map[string][]string) {
+	t.Helper()
+	if len(groups) != len(want) {
+		t.Errorf("got %d groups, want %d: %v", len(groups), len(want), groups)
+	}
+	for jobType, ids := range want {
+		got := make(map[string]bool)
+		for _, a := range groups[jobType] {
+			got[a.ID] = true
+		}
+		if len(got) != len(ids) {
+			t.Errorf("%s: got %d applications, want %v", jobType, len(got), ids)
+		}
+		for _, id := range ids {
+			if !got[id] {
+				t.Errorf("%s: missing application %s", jobType, id)
+			}
+		}
+	}
+}
+
+func TestNew_StartsEmptyByDefault(t *testing.T) {
+	t.Setenv(SeedEnvVar, "")
+	path := filepath.Join(t.TempDir(), "applications.json")
+
+	s, err := New(path)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if got := s.Total(); got != 0 {
+		t.Errorf("Total() = %d, want a fresh store to be empty", got)
+	}
+	// The empty store is written, so scripts can rely on the file
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("data file not created: %v", err)
+	}
+}
+
+func TestNew_SeedsWhenRequested(t *testing.T) {
+	t.Setenv(SeedEnvVar, "1")
+
+	s, err := New(filepath.Join(t.TempDir(), "applications.json"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if got := s.Total(); got != len(sampleData()) {
+		t.Errorf("Total() = %d, want %d sample applications", got, len(sampleData()))
+	}
+}
+
+func TestNew_DoesNotSeedExistingData(t *testing.T) {
+	t.Setenv(SeedEnvVar, "true")
+	path := filepath.Join(t.TempDir(), "applications.json")
+	if err := os.WriteFile(path, []byte(`[{"id": "mine", "company": "Acme", "position": "Engineer", "status": "applied"}]`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := New(path)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if got := s.Total(); got != 1 {
+		t.Errorf("Total() = %d, want only the existing application", got)
+	}
+}
+
+func TestNew_LoadsMalformedDates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "applications.json")
+	data := `[{"id": "mine", "company": "Acme", "position": "Engineer", "status": "interview",
+		"date_applied": "0001-01-01T00:00:00Z",
+		"interviews": [{"date": "next tuesday", "type": "phone"}, {"type": "onsite"}]}]`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := New(path)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	app, err := s.GetByID("mine")
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if app.DateApplied != nil {
+		t.Errorf("DateApplied = %v, want nil for a zero date", app.DateApplied)
+	}
+	if len(app.Interviews) != 2 || app.Interviews[0].Type != "phone" || !app.Interviews[0].Date.IsZero() {
+		t.Errorf("Interviews = %+v, want both kept with zero dates", app.Interviews)
+	}
+}
+
+func TestStore_LoadSampleData(t *testing.T) {
+	t.Setenv(SeedEnvVar, "")
+	s, err := New(filepath.Join(t.TempDir(), "applications.json"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if _, err := s.Add(model.Application{Company: "Acme", Position: "Engineer"}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	added, err := s.LoadSampleData()
+	if err != nil || added != len(sampleData()) {
+		t.Fatalf("LoadSampleData() = %d, %v; want %d", added, err, len(sampleData()))
+	}
+	if _, err := s.GetByID("sample-001"); err != nil {
+		t.Errorf("GetByID(sample-001) error = %v", err)
+	}
+
+	// Loading again doesn't duplicate the samples
+	added, err = s.LoadSampleData()
+	if err != nil || added != 0 {
+		t.Errorf("second LoadSampleData() = %d, %v; want 0", added, err)
+	}
+	if got := s.Total(); got != len(sampleData())+1 {
+		t.Errorf("Total() = %d, want %d", got, len(sampleData())+1)
+	}
+}
+
+func TestStore_AddInterview(t *testing.T) {
+	dir := t.TempDir()
+	s := openStore(t, dir)
+	app, err := s.Add(model.Application{Company: "Acme", Position: "SWE"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Added out of date order; the history keeps the order they were added
+	interviews := []model.Interview{
+		{Date: time.Date(2026, 3, 12, 14, 0, 0, 0, time.UTC), Type: "technical", WithWhom: "Sam Lee"},
+		{Date: time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC), Type: "phone", Notes: "Recruiter screen"},
+	}
+	for _, iv := range interviews {
+		if err := s.AddInterview(app.ID, iv); err != nil {
+			t.Fatalf("AddInterview() error = %v", err)
+		}
+	}
+
+	for _, got := range []*Store{s, openStore(t, dir)} {
+		stored, err := got.GetByID(app.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(stored.Interviews) != 2 {
+			t.Fatalf("Interviews = %+v, want 2", stored.Interviews)
+		}
+		for i, iv := range interviews {
+			if !stored.Interviews[i].Date.Equal(iv.Date) || stored.Interviews[i].Type != iv.Type {
+				t.Errorf("interview %d = %+v, want %+v", i, stored.Interviews[i], iv)
+			}
+		}
+	}
+
+	// Undo removes only the last interview
+	if _, err := s.Undo(); err != nil {
+		t.Fatalf("Undo() error = %v", err)
+	}
+	if stored, _ := s.GetByID(app.ID); len(stored.Interviews) != 1 || stored.Interviews[0].Type != "technical" {
+		t.Errorf("after undo Interviews = %+v, want only the first", stored.Interviews)
+	}
+}
+
+func TestStore_AddInterviewUnknownID(t *testing.T) {
+	s := openStore(t, t.TempDir())
+	if err := s.AddInterview("missing", model.Interview{Type: "phone"}); err != ErrNotFound {
+		t.Errorf("AddInterview() error = %v, want ErrNotFound", err)
+	}
+}
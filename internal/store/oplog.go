@@ -0,0 +1,264 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+// Operation log ops
+const (
+	OpAdd    = "add"
+	OpUpdate = "update"
+	OpDelete = "delete"
+)
+
+// OpEntry is one mutation in the operation log
+type OpEntry struct {
+	Time time.Time `json:"time"`
+	Op   string    `json:"op"`
+	// App is the application as it is after add or update, and the
+	// removed application for delete
+	App model.Application `json:"app"`
+}
+
+// OpLog is an append-only log of store mutations, one JSON entry per line.
+// Replaying it rebuilds the data file.
+type OpLog struct {
+	path string
+}
+
+// NewOpLog creates an operation log at path
+func NewOpLog(path string) *OpLog {
+	return &OpLog{path: path}
+}
+
+// Path returns the log file's path
+func (l *OpLog) Path() string {
+	return l.path
+}
+
+// empty reports whether the log is missing or has no entries
+func (l *OpLog) empty() bool {
+	info, err := os.Stat(l.path)
+	return err != nil || info.Size() == 0
+}
+
+// Append writes entries to the end of the log
+func (l *OpLog) Append(entries ...OpEntry) error {
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(l.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	for _, e := range entries {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		w.Write(data)
+		w.WriteByte('\n')
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// ReplayResult is the state rebuilt from an operation log
+type ReplayResult struct {
+	Applications []model.Application
+	// Applied counts the operations replayed
+	Applied int
+	// Skipped lists the 1-based line numbers of malformed entries
+	Skipped []int
+	// Drift describes each application the data file has differently
+	// from the log, set when Rebuild returns ErrDrift
+	Drift []string
+}
+
+// ErrDrift is returned by Rebuild when the data file has changes the log
+// never saw, such as direct edits, which rebuilding would discard
+var ErrDrift = errors.New("the data file has changes that aren't in the operation log")
+
+// Replay rebuilds the applications from the log. Malformed lines (invalid
+// JSON, an unknown op, or no application ID) are skipped and reported.
+func (l *OpLog) Replay() (*ReplayResult, error) {
+	f, err := os.Open(l.path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return replayOps(f)
+}
+
+// replayOps applies each entry read from r in order. Adds and updates of
+// an application replace any earlier version, so replaying is forgiving
+// of logs that start after some entries were created.
+func replayOps(r io.Reader) (*ReplayResult, error) {
+	result := &ReplayResult{Applications: []model.Application{}}
+	index := make(map[string]int)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var e OpEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil || e.App.ID == "" {
+			result.Skipped = append(result.Skipped, line)
+			continue
+		}
+
+		i, exists := index[e.App.ID]
+		switch e.Op {
+		case OpAdd, OpUpdate:
+			if exists {
+				result.Applications[i] = e.App
+			} else {
+				index[e.App.ID] = len(result.Applications)
+				result.Applications = append(result.Applications, e.App)
+			}
+		case OpDelete:
+			if exists {
+				result.Applications = append(result.Applications[:i], result.Applications[i+1:]...)
+				delete(index, e.App.ID)
+				for id, j := range index {
+					if j > i {
+						index[id] = j - 1
+					}
+				}
+			}
+		default:
+			result.Skipped = append(result.Skipped, line)
+			continue
+		}
+		result.Applied++
+	}
+	return result, scanner.Err()
+}
+
+// SetOpLog enables appending Add, Update, Delete, and undo to the operation
+// log. A new log starts with an add for every existing application, so it
+// can rebuild the current data. Pass nil to disable.
+func (s *Store) SetOpLog(l *OpLog) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ops = l
+	if l == nil || !l.empty() || len(s.applications) == 0 {
+		return
+	}
+
+	now := time.Now()
+	entries := make([]OpEntry, 0, len(s.applications))
+	for _, app := range s.applications {
+		entries = append(entries, OpEntry{Time: now, Op: OpAdd, App: app})
+	}
+	_ = l.Append(entries...)
+}
+
+// recordOp appends a mutation to the operation log if one is set.
+// Best effort: a failure to log never fails the mutation itself.
+func (s *Store) recordOp(op string, app model.Application) {
+	if s.ops == nil {
+		return
+	}
+	_ = s.ops.Append(OpEntry{Time: time.Now(), Op: op, App: app})
+}
+
+// Rebuild replays the operation log and writes the result to the data file
+// at path, backing up any existing file first. It works without loading the
+// data file, so a corrupted one can be replaced. The backup's path is ""
+// when there was no data file.
+//
+// Unless force is set, a readable data file that differs from the replayed
+// log isn't overwritten: Rebuild returns ErrDrift, with the differences in
+// the result's Drift.
+func Rebuild(path string, l *OpLog, force bool) (*ReplayResult, string, error) {
+	result, err := l.Replay()
+	if err != nil {
+		return nil, "", fmt.Errorf("reading operation log: %w", err)
+	}
+	if !force {
+		if result.Drift = drift(path, result.Applications); len(result.Drift) > 0 {
+			return result, "", ErrDrift
+		}
+	}
+
+	s := &Store{filepath: path, applications: result.Applications}
+	backup := ""
+	if _, err := os.Stat(path); err == nil {
+		if backup, err = s.Backup(); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, "", err
+	}
+	if err := s.save(); err != nil {
+		return nil, backup, fmt.Errorf("writing data file: %w", err)
+	}
+	return result, backup, nil
+}
+
+// drift compares the data file at path with the replayed applications and
+// describes each one that differs, data file order first. A missing or
+// unreadable data file, the usual reason to rebuild, has no drift.
+func drift(path string, replayed []model.Application) []string {
+	data, err := os.ReadFile(path)
+	if err != nil || len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	var current []model.Application
+	if err := json.Unmarshal(data, &current); err != nil {
+		return nil
+	}
+
+	// Compare encoded, since decoded times differ in their locations
+	encode := func(a model.Application) string {
+		clearZeroDates(&a)
+		data, _ := json.Marshal(a)
+		return string(data)
+	}
+	logged := make(map[string]string, len(replayed))
+	for _, app := range replayed {
+		logged[app.ID] = encode(app)
+	}
+
+	var changes []string
+	seen := make(map[string]bool, len(current))
+	for _, app := range current {
+		seen[app.ID] = true
+		want, ok := logged[app.ID]
+		switch {
+		case !ok:
+			changes = append(changes, fmt.Sprintf("%s (%s - %s) is only in the data file", app.ID, app.Company, app.Position))
+		case want != encode(app):
+			changes = append(changes, fmt.Sprintf("%s (%s - %s) differs from the log", app.ID, app.Company, app.Position))
+		}
+	}
+	for _, app := range replayed {
+		if !seen[app.ID] {
+			changes = append(changes, fmt.Sprintf("%s (%s - %s) is only in the log", app.ID, app.Company, app.Position))
+		}
+	}
+	return changes
+}
@@ -0,0 +1,187 @@
+package store
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+func TestReplayOps(t *testing.T) {
+	log := strings.Join([]string{
+		`{"op":"add","app":{"id":"a","company":"Acme","position":"SWE","status":"applied"}}`,
+		`{"op":"add","app":{"id":"b","company":"Globex","position":"FE","status":"applied"}}`,
+		`{"op":"add","app":{"id":"c","company":"Initech","position":"UX","status":"saved"}}`,
+		`{"op":"update","app":{"id":"a","company":"Acme","position":"SWE","status":"interview"}}`,
+		`{"op":"delete","app":{"id":"b","company":"Globex","position":"FE","status":"applied"}}`,
+		`{"op":"update","app":{"id":"c","company":"Initech","position":"UX","status":"applied"}}`,
+	}, "\n")
+
+	result, err := replayOps(strings.NewReader(log))
+	if err != nil {
+		t.Fatalf("replayOps() error = %v", err)
+	}
+	if result.Applied != 6 || len(result.Skipped) != 0 {
+		t.Errorf("applied %d, skipped %v; want 6 applied, none skipped", result.Applied, result.Skipped)
+	}
+
+	got := map[string]string{}
+	for _, app := range result.Applications {
+		got[app.ID] = app.Status
+	}
+	want := map[string]string{"a": model.StatusInterview, "c": model.StatusApplied}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("replayed statuses = %v, want %v", got, want)
+	}
+}
+
+func TestReplayOps_SkipsMalformedLines(t *testing.T) {
+	log := strings.Join([]string{
+		`{"op":"add","app":{"id":"a","company":"Acme","position":"SWE"}}`,
+		`{"op":"add","app":{"id":"b","comp`,
+		`{"op":"rename","app":{"id":"a"}}`,
+		`{"op":"add","app":{"company":"No ID"}}`,
+		``,
+		`{"op":"add","app":{"id":"c","company":"Initech","position":"UX"}}`,
+	}, "\n")
+
+	result, err := replayOps(strings.NewReader(log))
+	if err != nil {
+		t.Fatalf("replayOps() error = %v", err)
+	}
+	if !reflect.DeepEqual(result.Skipped, []int{2, 3, 4}) {
+		t.Errorf("skipped lines = %v, want [2 3 4]", result.Skipped)
+	}
+	if len(result.Applications) != 2 || result.Applications[0].ID != "a" || result.Applications[1].ID != "c" {
+		t.Errorf("replayed %+v, want applications a and c", result.Applications)
+	}
+}
+
+func TestStore_OpLogRebuildsData(t *testing.T) {
+	dir := t.TempDir()
+	dataPath := filepath.Join(dir, "applications.json")
+	logPath := filepath.Join(dir, "applications.log")
+
+	s := openStore(t, dir)
+	s.SetOpLog(NewOpLog(logPath))
+
+	a, err := s.Add(model.Application{Company: "Acme", Position: "SWE"})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	b, err := s.Add(model.Application{Company: "Globex", Position: "FE"})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if err := s.UpdateStatus(a.ID, model.StatusOffer); err != nil {
+		t.Fatalf("UpdateStatus() error = %v", err)
+	}
+	if err := s.Delete(b.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	// Undoing the delete is logged too
+	if _, err := s.Undo(); err != nil {
+		t.Fatalf("Undo() error = %v", err)
+	}
+	want := s.List()
+
+	// Corrupt the data file, then rebuild it from the log
+	if err := os.WriteFile(dataPath, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	result, backup, err := Rebuild(dataPath, NewOpLog(logPath), false)
+	if err != nil {
+		t.Fatalf("Rebuild() error = %v", err)
+	}
+	if backup == "" {
+		t.Error("Rebuild() should back up the existing data file")
+	}
+	if len(result.Skipped) != 0 {
+		t.Errorf("skipped lines = %v, want none", result.Skipped)
+	}
+
+	got := openStore(t, dir).List()
+	byID := func(apps []model.Application) {
+		sort.Slice(apps, func(i, j int) bool { return apps[i].ID < apps[j].ID })
+	}
+	byID(got)
+	byID(want)
+	if len(got) != len(want) {
+		t.Fatalf("rebuilt %d applications, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].ID != want[i].ID || got[i].Status != want[i].Status || got[i].Company != want[i].Company {
+			t.Errorf("rebuilt %s %s (%s), want %s %s (%s)", got[i].ID, got[i].Company, got[i].Status, want[i].ID, want[i].Company, want[i].Status)
+		}
+	}
+}
+
+func TestStore_SetOpLogSeedsExistingApplications(t *testing.T) {
+	dir := t.TempDir()
+	s := openStore(t, dir)
+	app, err := s.Add(model.Application{Company: "Acme", Position: "SWE"})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	log := NewOpLog(filepath.Join(dir, "applications.log"))
+	s.SetOpLog(log)
+
+	result, err := log.Replay()
+	if err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+	if len(result.Applications) != 1 || result.Applications[0].ID != app.ID {
+		t.Errorf("new log should start from the existing applications, got %+v", result.Applications)
+	}
+}
+
+func TestRebuild_DetectsDrift(t *testing.T) {
+	dir := t.TempDir()
+	dataPath := filepath.Join(dir, "applications.json")
+	log := NewOpLog(filepath.Join(dir, "applications.log"))
+
+	s := openStore(t, dir)
+	s.SetOpLog(log)
+	if _, err := s.Add(model.Application{Company: "Acme", Position: "SWE"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// In sync with the log: nothing to warn about
+	if _, _, err := Rebuild(dataPath, log, false); err != nil {
+		t.Fatalf("Rebuild() of an unchanged data file error = %v", err)
+	}
+
+	// Edit the data file directly, bypassing the log
+	data, err := os.ReadFile(dataPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edited := strings.Replace(string(data), `"company": "Acme"`, `"company": "Acme Corp"`, 1)
+	if err := os.WriteFile(dataPath, []byte(edited), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, backup, err := Rebuild(dataPath, log, false)
+	if !errors.Is(err, ErrDrift) {
+		t.Fatalf("Rebuild() error = %v, want ErrDrift", err)
+	}
+	if backup != "" || len(result.Drift) != 1 || !strings.Contains(result.Drift[0], "differs from the log") {
+		t.Errorf("Rebuild() = drift %q, backup %q; want one differing application and no backup", result.Drift, backup)
+	}
+	if after, _ := os.ReadFile(dataPath); string(after) != edited {
+		t.Error("Rebuild() overwrote a data file with drift")
+	}
+
+	if _, _, err := Rebuild(dataPath, log, true); err != nil {
+		t.Fatalf("Rebuild(force) error = %v", err)
+	}
+	if got := openStore(t, dir).List(); len(got) != 1 || got[0].Company != "Acme" {
+		t.Errorf("forced rebuild = %+v, want the logged Acme", got)
+	}
+}
@@ -0,0 +1,196 @@
+package store
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+// Find returns the applications matching pred, in List order
+func (s *Store) Find(pred func(model.Application) bool) []model.Application {
+	var result []model.Application
+	for _, a := range s.List() {
+		if pred(a) {
+			result = append(result, a)
+		}
+	}
+	return result
+}
+
+// queryField describes a field usable in a query
+type queryField struct {
+	kind  string // "string", "bool", or "number"
+	value func(a model.Application) any
+}
+
+// queryFields are the fields ParseQuery understands
+var queryFields = map[string]queryField{
+	"status":   {"string", func(a model.Application) any { return a.Status }},
+	"company":  {"string", func(a model.Application) any { return a.Company }},
+	"position": {"string", func(a model.Application) any { return a.Position }},
+	"location": {"string", func(a model.Application) any { return a.Location }},
+	// type falls back to inference from the position, as in ByJobType
+	"type": {"string", func(a model.Application) any {
+		if a.JobType == "" {
+			return model.InferJobType(a.Position)
+		}
+		return a.JobType
+	}},
+	"remote": {"bool", func(a model.Application) any { return a.Remote }},
+	// salary is the top of the range, or the minimum when that's all we have
+	"salary": {"number", func(a model.Application) any {
+		if a.SalaryMax > 0 {
+			return a.SalaryMax
+		}
+		return a.SalaryMin
+	}},
+	"salary_min": {"number", func(a model.Application) any { return a.SalaryMin }},
+	"salary_max": {"number", func(a model.Application) any { return a.SalaryMax }},
+	"priority":   {"number", func(a model.Application) any { return a.Priority }},
+	"years":      {"number", func(a model.Application) any { return a.MinYearsExperience }},
+}
+
+// queryOperators split a term at the first one found; ">=" and "<=" are
+// listed before ">" and "<" so they win at the same position
+var queryOperators = []string{">=", "<=", ":", ">", "<"}
+
+// ParseQuery compiles a query like `status:interview remote:true
+// salary>150000` into a predicate. Terms are ANDed together. String fields
+// match case-insensitively with ":", numeric fields also accept >, <, >=,
+// and <=, and values containing spaces can be double-quoted
+// (company:"Acme Corp"). An empty query matches everything.
+func ParseQuery(query string) (func(model.Application) bool, error) {
+	terms, err := splitQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var preds []func(model.Application) bool
+	for _, term := range terms {
+		pred, err := parseQueryTerm(term)
+		if err != nil {
+			return nil, err
+		}
+		preds = append(preds, pred)
+	}
+
+	return func(a model.Application) bool {
+		for _, pred := range preds {
+			if !pred(a) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+// splitQuery splits a query on whitespace, keeping double-quoted values
+// together and dropping the quotes
+func splitQuery(query string) ([]string, error) {
+	var terms []string
+	var current strings.Builder
+	inQuotes := false
+	for _, r := range query {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case (r == ' ' || r == '\t') && !inQuotes:
+			if current.Len() > 0 {
+				terms = append(terms, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if inQuotes {
+		return nil, fmt.Errorf("unterminated quote in query %q", query)
+	}
+	if current.Len() > 0 {
+		terms = append(terms, current.String())
+	}
+	return terms, nil
+}
+
+// parseQueryTerm compiles a single field/operator/value term
+func parseQueryTerm(term string) (func(model.Application) bool, error) {
+	name, op, value := "", "", ""
+	best := -1
+	for _, candidate := range queryOperators {
+		if i := strings.Index(term, candidate); i > 0 && (best == -1 || i < best) {
+			best = i
+			name, op, value = term[:i], candidate, term[i+len(candidate):]
+		}
+	}
+	if best == -1 {
+		return nil, fmt.Errorf("invalid query term %q: expected field:value or field>N", term)
+	}
+	if value == "" {
+		return nil, fmt.Errorf("invalid query term %q: missing value", term)
+	}
+
+	name = strings.ToLower(name)
+	field, ok := queryFields[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown query field %q (known fields: %s)", name, knownQueryFields())
+	}
+
+	switch field.kind {
+	case "string":
+		if op != ":" {
+			return nil, fmt.Errorf("field %q only supports ':' (got %q)", name, op)
+		}
+		if name == "status" && !slices.Contains(model.AllStatuses(), strings.ToLower(value)) {
+			return nil, fmt.Errorf("unknown status %q", value)
+		}
+		return func(a model.Application) bool {
+			return strings.EqualFold(field.value(a).(string), value)
+		}, nil
+
+	case "bool":
+		if op != ":" {
+			return nil, fmt.Errorf("field %q only supports ':' (got %q)", name, op)
+		}
+		want, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("field %q expects true or false, got %q", name, value)
+		}
+		return func(a model.Application) bool {
+			return field.value(a).(bool) == want
+		}, nil
+
+	default:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("field %q expects a number, got %q", name, value)
+		}
+		return func(a model.Application) bool {
+			v := field.value(a).(int)
+			switch op {
+			case ">":
+				return v > n
+			case "<":
+				return v < n
+			case ">=":
+				return v >= n
+			case "<=":
+				return v <= n
+			}
+			return v == n
+		}, nil
+	}
+}
+
+// knownQueryFields lists the query fields, sorted, for error messages
+func knownQueryFields() string {
+	names := make([]string, 0, len(queryFields))
+	for name := range queryFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,85 @@
+package store
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+func queryDataset() *Store {
+	return &Store{applications: []model.Application{
+		{ID: "acme", Company: "Acme Corp", Position: "Backend Engineer", Status: model.StatusInterview, Remote: true, SalaryMin: 140000, SalaryMax: 180000},
+		{ID: "globex", Company: "Globex", Position: "Frontend Engineer", Status: model.StatusInterview, Remote: false, SalaryMin: 160000, SalaryMax: 200000},
+		{ID: "initech", Company: "Initech", Position: "Platform Engineer", Status: model.StatusApplied, Remote: true, SalaryMin: 170000},
+		{ID: "hooli", Company: "Hooli", Position: "Software Engineer", Status: model.StatusInterview, Remote: true, SalaryMin: 90000, SalaryMax: 120000, Priority: 4},
+		{ID: "undisclosed", Company: "Umbrella", Position: "SRE", Status: model.StatusInterview, Remote: true},
+	}}
+}
+
+func TestParseQuery_MultiTerm(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"status:interview remote:true salary>150000", []string{"acme"}},
+		{"status:INTERVIEW remote:false", []string{"globex"}},
+		{"salary>=170000", []string{"acme", "globex", "initech"}},
+		{"salary<150000 salary>0", []string{"hooli"}},
+		{`company:"acme corp"`, []string{"acme"}},
+		{"priority:4", []string{"hooli"}},
+		{"type:fe-dev", []string{"globex"}},
+		{"", []string{"acme", "globex", "hooli", "initech", "undisclosed"}},
+	}
+
+	s := queryDataset()
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			pred, err := ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("ParseQuery(%q) error = %v", tt.query, err)
+			}
+			got := s.Find(pred)
+			ids := make([]string, len(got))
+			for i, a := range got {
+				ids[i] = a.ID
+			}
+			// List order among equal statuses isn't defined, so compare as sets
+			sort.Strings(ids)
+			sort.Strings(tt.want)
+			if strings.Join(ids, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Find(%q) = %v, want %v", tt.query, ids, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseQuery_Errors(t *testing.T) {
+	tests := []struct {
+		query   string
+		wantErr string
+	}{
+		{"interview", "invalid query term"},
+		{"status:", "missing value"},
+		{":interview", "invalid query term"},
+		{"color:blue", "unknown query field"},
+		{"status:hired", "unknown status"},
+		{"company>5", "only supports ':'"},
+		{"remote:maybe", "expects true or false"},
+		{"salary>lots", "expects a number"},
+		{`company:"Acme`, "unterminated quote"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := ParseQuery(tt.query)
+			if err == nil {
+				t.Fatalf("ParseQuery(%q) error = nil, want %q", tt.query, tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseQuery(%q) error = %v, want it to mention %q", tt.query, err, tt.wantErr)
+			}
+		})
+	}
+}
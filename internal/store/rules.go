@@ -0,0 +1,143 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+// Rule is a follow-up automation: when a status change matches When, the
+// Then effects are applied to the application as part of the same update.
+//
+//	{"when": {"status": "interview"}, "then": {"set_followup": "+1d"}}
+type Rule struct {
+	When RuleCondition `json:"when"`
+	Then RuleEffects   `json:"then"`
+}
+
+// RuleCondition matches a status transition. Empty fields match anything.
+type RuleCondition struct {
+	// Status is the status the application moves to
+	Status string `json:"status,omitempty"`
+	// From is the status the application moves from
+	From string `json:"from,omitempty"`
+}
+
+// RuleEffects are the changes a matching rule makes
+type RuleEffects struct {
+	// SetFollowUp schedules the next follow-up relative to the day of the
+	// change: "+1d" is tomorrow, "+2w" two weeks out
+	SetFollowUp string `json:"set_followup,omitempty"`
+	// BumpPriority raises (or, negative, lowers) the priority, clamped to
+	// 1 through model.MaxPriority
+	BumpPriority int `json:"bump_priority,omitempty"`
+}
+
+// LoadRules reads a rules file, a JSON array of rules. A missing file has no
+// rules; a rule with an unknown status or a bad offset is an error.
+func LoadRules(path string) ([]Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read rules: %w", err)
+	}
+
+	var rules []Rule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse rules %s: %w", path, err)
+	}
+	for i := range rules {
+		if err := rules[i].normalize(); err != nil {
+			return nil, fmt.Errorf("invalid rule %d in %s: %w", i+1, path, err)
+		}
+	}
+	return rules, nil
+}
+
+// normalize canonicalizes the rule's statuses and checks its effects
+func (r *Rule) normalize() error {
+	for _, status := range []*string{&r.When.Status, &r.When.From} {
+		if *status == "" {
+			continue
+		}
+		*status = model.NormalizeStatus(*status)
+		if !slices.Contains(model.AllStatuses(), *status) {
+			return fmt.Errorf("unknown status %q", *status)
+		}
+	}
+	if r.Then.SetFollowUp != "" {
+		if _, err := parseOffset(r.Then.SetFollowUp); err != nil {
+			return err
+		}
+	}
+	if r.Then == (RuleEffects{}) {
+		return errors.New("no effects in then")
+	}
+	return nil
+}
+
+// parseOffset parses a day offset such as "+1d" or "+2w" into days
+func parseOffset(offset string) (int, error) {
+	s := strings.TrimPrefix(strings.TrimSpace(offset), "+")
+	unit := 1
+	switch {
+	case strings.HasSuffix(s, "d"):
+		s = strings.TrimSuffix(s, "d")
+	case strings.HasSuffix(s, "w"):
+		s, unit = strings.TrimSuffix(s, "w"), 7
+	default:
+		return 0, fmt.Errorf("offset %q needs a d or w unit, like +1d", offset)
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid offset %q, want a form like +1d or +2w", offset)
+	}
+	return n * unit, nil
+}
+
+// matches reports whether the rule applies to a change from one status to
+// another. Only an actual change matches.
+func (r Rule) matches(from, to string) bool {
+	if from == to {
+		return false
+	}
+	return (r.When.Status == "" || r.When.Status == to) &&
+		(r.When.From == "" || r.When.From == from)
+}
+
+// applyRules applies the effects of every rule matching the change from
+// before's status to after's, in file order, and returns the result
+func applyRules(rules []Rule, before, after model.Application, now time.Time) model.Application {
+	for _, rule := range rules {
+		if !rule.matches(before.Status, after.Status) {
+			continue
+		}
+		if rule.Then.SetFollowUp != "" {
+			// Validated on load
+			days, _ := parseOffset(rule.Then.SetFollowUp)
+			followUp := time.Date(now.Year(), now.Month(), now.Day()+days, 0, 0, 0, 0, now.Location())
+			after.NextFollowUp = &followUp
+		}
+		if rule.Then.BumpPriority != 0 {
+			after.Priority = max(model.ClampPriority(after.Priority+rule.Then.BumpPriority), 1)
+		}
+	}
+	return after
+}
+
+// SetRules sets the rules applied when an update (UpdateStatus, Update, or
+// a transaction's) changes an application's status. Pass nil to disable.
+func (s *Store) SetRules(rules []Rule) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rules = rules
+}
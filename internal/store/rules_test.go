@@ -0,0 +1,157 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+const testRules = `[
+	{"when": {"status": "Interview"}, "then": {"set_followup": "+1d"}},
+	{"when": {"status": "offer"}, "then": {"bump_priority": 2}},
+	{"when": {"status": "rejected", "from": "interview"}, "then": {"set_followup": "+2w"}}
+]`
+
+func writeRules(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "rules.json")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// rulesStore opens a store with testRules and one application in status
+func rulesStore(t *testing.T, status string, priority int) (*Store, string) {
+	t.Helper()
+	rules, err := LoadRules(writeRules(t, testRules))
+	if err != nil {
+		t.Fatalf("LoadRules() error = %v", err)
+	}
+	s := openStore(t, t.TempDir())
+	s.SetRules(rules)
+	app, err := s.Add(model.Application{Company: "Acme", Position: "SWE", Status: status, Priority: priority})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, app.ID
+}
+
+func TestUpdateStatus_RuleSetsFollowUp(t *testing.T) {
+	s, id := rulesStore(t, model.StatusScreening, 0)
+
+	if err := s.UpdateStatus(id, model.StatusInterview); err != nil {
+		t.Fatalf("UpdateStatus() error = %v", err)
+	}
+	app, _ := s.GetByID(id)
+	now := time.Now()
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+	if app.Status != model.StatusInterview || app.NextFollowUp == nil || !app.NextFollowUp.Equal(tomorrow) {
+		t.Errorf("after moving to interview: status %q, follow-up %v; want %v", app.Status, app.NextFollowUp, tomorrow)
+	}
+
+	if err := s.UpdateStatus(id, model.StatusOffer); err != nil {
+		t.Fatalf("UpdateStatus() error = %v", err)
+	}
+	if app, _ = s.GetByID(id); app.Priority != 2 {
+		t.Errorf("priority after moving to offer = %d, want 2", app.Priority)
+	}
+}
+
+func TestUpdateStatus_NonMatchingRuleLeavesApplication(t *testing.T) {
+	tests := []struct {
+		name string
+		from string
+		to   string
+	}{
+		{"no rule for the status", model.StatusSaved, model.StatusApplied},
+		{"from doesn't match", model.StatusScreening, model.StatusRejected},
+		{"status unchanged", model.StatusInterview, model.StatusInterview},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, id := rulesStore(t, tt.from, 3)
+			before, _ := s.GetByID(id)
+
+			if err := s.UpdateStatus(id, tt.to); err != nil {
+				t.Fatalf("UpdateStatus() error = %v", err)
+			}
+			app, _ := s.GetByID(id)
+			if app.NextFollowUp != nil || app.Priority != before.Priority {
+				t.Errorf("application changed by a non-matching rule: follow-up %v, priority %d", app.NextFollowUp, app.Priority)
+			}
+		})
+	}
+}
+
+func TestTx_UpdateStatusAppliesRules(t *testing.T) {
+	s, id := rulesStore(t, model.StatusApplied, 5)
+
+	err := s.Transaction(func(tx *Tx) error {
+		return tx.UpdateStatus(id, model.StatusOffer)
+	})
+	if err != nil {
+		t.Fatalf("Transaction() error = %v", err)
+	}
+	// Already at the top, the bump is clamped
+	if app, _ := s.GetByID(id); app.Priority != model.MaxPriority {
+		t.Errorf("priority = %d, want %d", app.Priority, model.MaxPriority)
+	}
+}
+
+func TestUpdate_StatusChangeAppliesRulesOnce(t *testing.T) {
+	s, id := rulesStore(t, model.StatusInterview, 1)
+
+	// A full update, as from the CLI or the TUI form, that also edits notes
+	app, _ := s.GetByID(id)
+	app.Status = model.StatusOffer
+	app.Notes = "Verbal offer"
+	if err := s.Update(app); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if app, _ = s.GetByID(id); app.Priority != 3 || app.Notes != "Verbal offer" {
+		t.Errorf("priority %d, notes %q; want the offer rule's bump to 3 and the edit kept", app.Priority, app.Notes)
+	}
+
+	// An update that leaves the status alone doesn't fire it again
+	app.Notes = "Signed"
+	if err := s.Update(app); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if app, _ = s.GetByID(id); app.Priority != 3 {
+		t.Errorf("priority = %d after an update without a status change, want 3", app.Priority)
+	}
+}
+
+func TestLoadRules(t *testing.T) {
+	rules, err := LoadRules(writeRules(t, testRules))
+	if err != nil {
+		t.Fatalf("LoadRules() error = %v", err)
+	}
+	if len(rules) != 3 || rules[0].When.Status != model.StatusInterview {
+		t.Errorf("LoadRules() = %+v, want 3 rules with normalized statuses", rules)
+	}
+
+	if rules, err := LoadRules(filepath.Join(t.TempDir(), "missing.json")); err != nil || rules != nil {
+		t.Errorf("LoadRules(missing) = %v, %v; want no rules", rules, err)
+	}
+
+	for _, tt := range []struct {
+		content string
+		wantErr string
+	}{
+		{`[{"when": {"status": "hired"}, "then": {"set_followup": "+1d"}}]`, `unknown status "hired"`},
+		{`[{"when": {"status": "offer"}, "then": {"set_followup": "+3"}}]`, "needs a d or w unit"},
+		{`[{"when": {"status": "offer"}, "then": {"set_followup": "+xd"}}]`, "invalid offset"},
+		{`[{"when": {"status": "offer"}, "then": {}}]`, "no effects"},
+		{`{"when": {}}`, "failed to parse"},
+	} {
+		if _, err := LoadRules(writeRules(t, tt.content)); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("LoadRules(%s) error = %v, want %q", tt.content, err, tt.wantErr)
+		}
+	}
+}
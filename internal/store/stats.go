@@ -0,0 +1,310 @@
+package store
+
+import (
+	"sort"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+// Stats is a snapshot of the tracker's dashboard metrics
+type Stats struct {
+	GeneratedAt  time.Time      `json:"generated_at"`
+	Total        int            `json:"total"`
+	Active       int            `json:"active"`
+	Interviewing int            `json:"interviewing"`
+	ThisWeek     int            `json:"this_week"`
+	ByStatus     map[string]int `json:"by_status"`
+	// Weekly counts applications sent per week, oldest first
+	Weekly []WeekActivity `json:"weekly"`
+
+	// ResponseRate is the percentage of sent (not saved) applications that
+	// moved beyond applied
+	ResponseRate float64 `json:"response_rate"`
+	// GhostRate is the percentage of sent applications still applied or in
+	// screening, with no interviews, StatsGhostDays after they were sent
+	GhostRate float64 `json:"ghost_rate"`
+	// AvgDaysToFirstResponse averages the days from applying to the first
+	// interview over the FirstResponses applications that have both
+	AvgDaysToFirstResponse float64 `json:"avg_days_to_first_response"`
+	FirstResponses         int     `json:"first_responses"`
+	// MedianResponseDays and AvgResponseDays measure the days from applying
+	// to FirstResponseAt, over the ResponseTimes applications with both
+	MedianResponseDays float64 `json:"median_response_days"`
+	AvgResponseDays    float64 `json:"avg_response_days"`
+	ResponseTimes      int     `json:"response_times"`
+}
+
+// StatsGhostDays is how long a sent application can go without progress
+// before it counts towards the ghost rate
+const StatsGhostDays = 30
+
+// WeekActivity is the number of applications sent in one week
+type WeekActivity struct {
+	WeekStart time.Time `json:"week_start"`
+	Applied   int       `json:"applied"`
+}
+
+// WeekStart returns midnight on the Monday starting t's week, in t's location
+func WeekStart(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7 // days since Monday
+	y, m, d := t.AddDate(0, 0, -offset).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// Stats summarizes the store as of asOf, with activity for the last weeks
+// weeks (including the current one)
+func (s *Store) Stats(asOf time.Time, weeks int) Stats {
+	stats := Stats{
+		GeneratedAt:  asOf,
+		Total:        s.Total(),
+		Active:       s.CountActive(),
+		Interviewing: s.CountInterviewing(),
+		ThisWeek:     s.CountThisWeek(asOf),
+		ByStatus:     s.CountByStatus(),
+	}
+
+	current := WeekStart(asOf)
+	index := make(map[time.Time]int, weeks)
+	for i := weeks - 1; i >= 0; i-- {
+		start := current.AddDate(0, 0, -7*i)
+		index[start] = len(stats.Weekly)
+		stats.Weekly = append(stats.Weekly, WeekActivity{WeekStart: start})
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var sent, responded, ghosted int
+	var responseDays float64
+	var responseTimes []float64
+	for _, a := range s.applications {
+		if a.Status != model.StatusSaved {
+			sent++
+			if hasResponse(a) {
+				responded++
+			}
+			if a.IsGhosted(asOf, StatsGhostDays) {
+				ghosted++
+			}
+		}
+		if days, ok := daysToFirstInterview(a); ok {
+			responseDays += days
+			stats.FirstResponses++
+		}
+		if days, ok := daysToResponse(a); ok {
+			responseTimes = append(responseTimes, days)
+		}
+
+		if a.DateApplied == nil || a.DateApplied.After(asOf) {
+			continue
+		}
+		if i, ok := index[WeekStart(a.DateApplied.In(asOf.Location()))]; ok {
+			stats.Weekly[i].Applied++
+		}
+	}
+
+	if sent > 0 {
+		stats.ResponseRate = 100 * float64(responded) / float64(sent)
+		stats.GhostRate = 100 * float64(ghosted) / float64(sent)
+	}
+	if stats.FirstResponses > 0 {
+		stats.AvgDaysToFirstResponse = responseDays / float64(stats.FirstResponses)
+	}
+	if stats.ResponseTimes = len(responseTimes); stats.ResponseTimes > 0 {
+		stats.MedianResponseDays = median(responseTimes)
+		var total float64
+		for _, days := range responseTimes {
+			total += days
+		}
+		stats.AvgResponseDays = total / float64(stats.ResponseTimes)
+	}
+	return stats
+}
+
+// daysToResponse returns the days from applying to the company's first
+// response, for applications with both dates
+func daysToResponse(a model.Application) (float64, bool) {
+	if a.DateApplied == nil || a.FirstResponseAt == nil || a.FirstResponseAt.Before(*a.DateApplied) {
+		return 0, false
+	}
+	return a.FirstResponseAt.Sub(*a.DateApplied).Hours() / 24, true
+}
+
+// median returns the middle value of a non-empty list, averaging the two
+// middle values of an even-length one. It sorts values in place.
+func median(values []float64) float64 {
+	sort.Float64s(values)
+	mid := len(values) / 2
+	if len(values)%2 == 0 {
+		return (values[mid-1] + values[mid]) / 2
+	}
+	return values[mid]
+}
+
+// hasResponse reports whether a sent application moved beyond applied. A
+// rejection counts as a response; a withdrawal only if it had progressed.
+func hasResponse(a model.Application) bool {
+	switch a.Status {
+	case model.StatusSaved, model.StatusApplied:
+		return false
+	case model.StatusWithdrawn:
+		if len(a.Interviews) > 0 || a.FirstResponseAt != nil {
+			return true
+		}
+		for _, change := range a.StatusHistory {
+			if model.IsResponseStatus(change.Status) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// daysToFirstInterview returns the days from applying to the earliest
+// interview, for applications with both dates
+func daysToFirstInterview(a model.Application) (float64, bool) {
+	if a.DateApplied == nil {
+		return 0, false
+	}
+	var first time.Time
+	for _, interview := range a.Interviews {
+		if interview.Date.IsZero() || interview.Date.Before(*a.DateApplied) {
+			continue
+		}
+		if first.IsZero() || interview.Date.Before(first) {
+			first = interview.Date
+		}
+	}
+	if first.IsZero() {
+		return 0, false
+	}
+	return first.Sub(*a.DateApplied).Hours() / 24, true
+}
+
+// OutcomeRates counts how far submitted applications got
+type OutcomeRates struct {
+	Applied     int `json:"applied"`
+	Interviewed int `json:"interviewed"`
+	Offered     int `json:"offered"`
+}
+
+// InterviewRate is the fraction of applications that reached a screen or
+// interview, or 0 with no applications
+func (r OutcomeRates) InterviewRate() float64 {
+	if r.Applied == 0 {
+		return 0
+	}
+	return float64(r.Interviewed) / float64(r.Applied)
+}
+
+// OfferRate is the fraction of applications that reached an offer
+func (r OutcomeRates) OfferRate() float64 {
+	if r.Applied == 0 {
+		return 0
+	}
+	return float64(r.Offered) / float64(r.Applied)
+}
+
+// OutcomeRates counts outcomes for the submitted applications match accepts
+// (all of them when match is nil). Saved applications aren't submitted.
+// Rejected or withdrawn applications with interviews logged still count as
+// interviewed.
+func (s *Store) OutcomeRates(match func(model.Application) bool) OutcomeRates {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var rates OutcomeRates
+	for _, a := range s.applications {
+		if a.Status == model.StatusSaved || (match != nil && !match(a)) {
+			continue
+		}
+		rates.Applied++
+		switch {
+		case a.Status == model.StatusOffer || a.Status == model.StatusAccepted:
+			rates.Offered++
+			rates.Interviewed++
+		case a.Status == model.StatusScreening || a.Status == model.StatusInterview || len(a.Interviews) > 0:
+			rates.Interviewed++
+		}
+	}
+	return rates
+}
+
+// StageDuration is how long an application spent in one status
+type StageDuration struct {
+	Status   string        `json:"status"`
+	Duration time.Duration `json:"duration"`
+	// Current marks the status the application is still in, timed up to now
+	Current bool `json:"current,omitempty"`
+}
+
+// StageDurations returns the time the application spent in each status it
+// entered, in order. Applications created before status history was
+// recorded only have the stages since.
+func (s *Store) StageDurations(id string) ([]StageDuration, error) {
+	app, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return stageDurations(app.StatusHistory, time.Now()), nil
+}
+
+// stageDurations times each status in history until the next change; the
+// last one is current and timed until asOf
+func stageDurations(history []model.StatusChange, asOf time.Time) []StageDuration {
+	stages := make([]StageDuration, 0, len(history))
+	for i, change := range history {
+		stage := StageDuration{Status: change.Status}
+		if i+1 < len(history) {
+			stage.Duration = history[i+1].At.Sub(change.At)
+		} else {
+			stage.Duration = asOf.Sub(change.At)
+			stage.Current = true
+		}
+		stages = append(stages, stage)
+	}
+	return stages
+}
+
+// StageAverage is the mean time applications spent in a status before
+// moving on
+type StageAverage struct {
+	Status  string        `json:"status"`
+	Average time.Duration `json:"average"`
+	// Count is how many times an application left the status
+	Count int `json:"count"`
+}
+
+// AverageStageDurations averages time-in-stage across all applications, in
+// pipeline order. Only finished stages count, so an application still
+// sitting in a status doesn't drag the average down.
+func (s *Store) AverageStageDurations() []StageAverage {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	totals := make(map[string]time.Duration)
+	counts := make(map[string]int)
+	for _, a := range s.applications {
+		for _, stage := range stageDurations(a.StatusHistory, time.Time{}) {
+			if stage.Current {
+				continue
+			}
+			totals[stage.Status] += stage.Duration
+			counts[stage.Status]++
+		}
+	}
+
+	var averages []StageAverage
+	for _, status := range model.AllStatuses() {
+		if counts[status] == 0 {
+			continue
+		}
+		averages = append(averages, StageAverage{
+			Status:  status,
+			Average: totals[status] / time.Duration(counts[status]),
+			Count:   counts[status],
+		})
+	}
+	return averages
+}
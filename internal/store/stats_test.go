@@ -0,0 +1,279 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+func TestWeekStart(t *testing.T) {
+	monday := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+	for _, day := range []time.Time{
+		monday,
+		time.Date(2026, 3, 11, 15, 30, 0, 0, time.UTC),
+		time.Date(2026, 3, 15, 23, 59, 0, 0, time.UTC), // Sunday
+	} {
+		if got := WeekStart(day); !got.Equal(monday) {
+			t.Errorf("WeekStart(%s) = %s, want %s", day.Format(time.RFC3339), got, monday)
+		}
+	}
+}
+
+func TestStore_Stats(t *testing.T) {
+	asOf := time.Date(2026, 3, 11, 12, 0, 0, 0, time.UTC) // Wednesday
+	day := func(d int) *time.Time {
+		t := time.Date(2026, 3, d, 10, 0, 0, 0, time.UTC)
+		return &t
+	}
+	s := &Store{applications: []model.Application{
+		{ID: "1", Status: model.StatusApplied, DateApplied: day(10)},
+		{ID: "2", Status: model.StatusInterview, DateApplied: day(9)},
+		{ID: "3", Status: model.StatusRejected, DateApplied: day(3)},
+		{ID: "4", Status: model.StatusSaved},
+		{ID: "5", Status: model.StatusApplied, DateApplied: day(20)}, // after asOf
+	}}
+
+	stats := s.Stats(asOf, 3)
+
+	if stats.Total != 5 || stats.Active != 3 || stats.Interviewing != 1 || stats.ByStatus[model.StatusApplied] != 2 {
+		t.Errorf("Stats() = %+v, want total 5, active 3, interviewing 1, 2 applied", stats)
+	}
+	want := []int{0, 1, 2} // weeks of Feb 23, Mar 2, Mar 9
+	if len(stats.Weekly) != len(want) {
+		t.Fatalf("Weekly = %+v, want %d weeks", stats.Weekly, len(want))
+	}
+	for i, n := range want {
+		if stats.Weekly[i].Applied != n {
+			t.Errorf("Weekly[%d] (%s) = %d, want %d", i, stats.Weekly[i].WeekStart.Format("Jan 02"), stats.Weekly[i].Applied, n)
+		}
+	}
+	if !stats.Weekly[2].WeekStart.Equal(WeekStart(asOf)) {
+		t.Errorf("last week starts %s, want the current week", stats.Weekly[2].WeekStart)
+	}
+}
+
+func TestStore_StatsRates(t *testing.T) {
+	asOf := time.Date(2026, 4, 30, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(d int) *time.Time {
+		t := asOf.AddDate(0, 0, -d)
+		return &t
+	}
+	interviewAt := func(d int) []model.Interview {
+		return []model.Interview{{Type: "phone", Date: asOf.AddDate(0, 0, -d)}}
+	}
+
+	tests := []struct {
+		name         string
+		apps         []model.Application
+		response     float64
+		ghost        float64
+		avgDays      float64
+		firstReplies int
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "saved only",
+			apps: []model.Application{{ID: "1", Status: model.StatusSaved}},
+		},
+		{
+			name: "mixed",
+			apps: []model.Application{
+				{ID: "1", Status: model.StatusApplied, DateApplied: daysAgo(45)},   // ghosted
+				{ID: "2", Status: model.StatusScreening, DateApplied: daysAgo(40)}, // ghosted, responded
+				{ID: "3", Status: model.StatusApplied, DateApplied: daysAgo(5)},
+				{ID: "4", Status: model.StatusInterview, DateApplied: daysAgo(30), Interviews: interviewAt(20)},
+				{ID: "5", Status: model.StatusRejected, DateApplied: daysAgo(60), Interviews: interviewAt(40)},
+				{ID: "6", Status: model.StatusSaved},
+			},
+			response:     60,
+			ghost:        40,
+			avgDays:      15,
+			firstReplies: 2,
+		},
+		{
+			name: "withdrawal counts only after progress",
+			apps: []model.Application{
+				{ID: "1", Status: model.StatusWithdrawn, DateApplied: daysAgo(10)},
+				{ID: "2", Status: model.StatusWithdrawn, DateApplied: daysAgo(10), StatusHistory: []model.StatusChange{
+					{Status: model.StatusApplied, At: *daysAgo(10)},
+					{Status: model.StatusScreening, At: *daysAgo(5)},
+				}},
+			},
+			response: 50,
+		},
+		{
+			name: "undated interviews are ignored",
+			apps: []model.Application{
+				{ID: "1", Status: model.StatusInterview, DateApplied: daysAgo(10), Interviews: []model.Interview{{Type: "phone"}}},
+			},
+			response: 100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Store{applications: tt.apps}
+			stats := s.Stats(asOf, 1)
+			if stats.ResponseRate != tt.response || stats.GhostRate != tt.ghost {
+				t.Errorf("rates = %.1f%% response, %.1f%% ghost, want %.1f%%, %.1f%%",
+					stats.ResponseRate, stats.GhostRate, tt.response, tt.ghost)
+			}
+			if stats.AvgDaysToFirstResponse != tt.avgDays || stats.FirstResponses != tt.firstReplies {
+				t.Errorf("first response = %.1f days over %d, want %.1f over %d",
+					stats.AvgDaysToFirstResponse, stats.FirstResponses, tt.avgDays, tt.firstReplies)
+			}
+		})
+	}
+}
+
+func TestStore_StatsResponseTimes(t *testing.T) {
+	applied := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	respondedAfter := func(days int) model.Application {
+		at := applied.AddDate(0, 0, days)
+		return model.Application{Status: model.StatusScreening, DateApplied: &applied, FirstResponseAt: &at}
+	}
+	early := applied.AddDate(0, 0, -1)
+
+	tests := []struct {
+		name   string
+		apps   []model.Application
+		median float64
+		avg    float64
+		count  int
+	}{
+		{"none logged", []model.Application{{Status: model.StatusApplied, DateApplied: &applied}}, 0, 0, 0},
+		{"odd count", []model.Application{respondedAfter(2), respondedAfter(10), respondedAfter(3)}, 3, 5, 3},
+		{"even count", []model.Application{respondedAfter(2), respondedAfter(4), respondedAfter(6), respondedAfter(20)}, 5, 8, 4},
+		{"response before applying is ignored", []model.Application{
+			respondedAfter(4),
+			{Status: model.StatusScreening, DateApplied: &applied, FirstResponseAt: &early},
+		}, 4, 4, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Store{applications: tt.apps}
+			stats := s.Stats(applied.AddDate(0, 1, 0), 1)
+			if stats.MedianResponseDays != tt.median || stats.AvgResponseDays != tt.avg || stats.ResponseTimes != tt.count {
+				t.Errorf("response times = median %.1f, avg %.1f over %d, want %.1f, %.1f over %d",
+					stats.MedianResponseDays, stats.AvgResponseDays, stats.ResponseTimes, tt.median, tt.avg, tt.count)
+			}
+		})
+	}
+}
+
+func TestStore_OutcomeRates(t *testing.T) {
+	s := &Store{applications: []model.Application{
+		{ID: "1", JobType: model.JobTypeSWE, Status: model.StatusApplied},
+		{ID: "2", JobType: model.JobTypeSWE, Status: model.StatusScreening},
+		{ID: "3", JobType: model.JobTypeSWE, Status: model.StatusAccepted},
+		{ID: "4", JobType: model.JobTypeSWE, Status: model.StatusRejected, Interviews: []model.Interview{{Type: "phone"}}},
+		{ID: "5", JobType: model.JobTypeSWE, Status: model.StatusSaved},
+		{ID: "6", JobType: model.JobTypeUXDesign, Status: model.StatusOffer},
+	}}
+
+	swe := s.OutcomeRates(func(a model.Application) bool { return a.JobType == model.JobTypeSWE })
+	if swe != (OutcomeRates{Applied: 4, Interviewed: 3, Offered: 1}) {
+		t.Errorf("OutcomeRates(swe) = %+v, want 4 applied, 3 interviewed, 1 offered", swe)
+	}
+	if got := swe.InterviewRate(); got != 0.75 {
+		t.Errorf("InterviewRate() = %v, want 0.75", got)
+	}
+
+	if all := s.OutcomeRates(nil); all.Applied != 5 || all.Offered != 2 {
+		t.Errorf("OutcomeRates(nil) = %+v, want 5 applied, 2 offered", all)
+	}
+	if got := (OutcomeRates{}).OfferRate(); got != 0 {
+		t.Errorf("OfferRate() with no applications = %v, want 0", got)
+	}
+}
+
+func TestStageDurations(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 9, 0, 0, 0, time.UTC) }
+	history := []model.StatusChange{
+		{Status: model.StatusApplied, At: day(1)},
+		{Status: model.StatusScreening, At: day(4)},
+		{Status: model.StatusInterview, At: day(9)},
+		{Status: model.StatusOffer, At: day(16)},
+	}
+
+	got := stageDurations(history, day(20))
+	want := []StageDuration{
+		{Status: model.StatusApplied, Duration: 3 * 24 * time.Hour},
+		{Status: model.StatusScreening, Duration: 5 * 24 * time.Hour},
+		{Status: model.StatusInterview, Duration: 7 * 24 * time.Hour},
+		{Status: model.StatusOffer, Duration: 4 * 24 * time.Hour, Current: true},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("stageDurations() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("stage %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestStore_StageDurationsRecordsStatusChanges(t *testing.T) {
+	s := openStore(t, t.TempDir())
+	app, err := s.Add(model.Application{Company: "Acme", Position: "SWE", Status: model.StatusApplied})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if err := s.UpdateStatus(app.ID, model.StatusScreening); err != nil {
+		t.Fatalf("UpdateStatus() error = %v", err)
+	}
+	// Updates that keep the status don't add a stage
+	if err := s.UpdateStatus(app.ID, model.StatusScreening); err != nil {
+		t.Fatalf("UpdateStatus() error = %v", err)
+	}
+
+	stages, err := s.StageDurations(app.ID)
+	if err != nil {
+		t.Fatalf("StageDurations() error = %v", err)
+	}
+	if len(stages) != 2 || stages[0].Status != model.StatusApplied || stages[1].Status != model.StatusScreening || !stages[1].Current {
+		t.Errorf("StageDurations() = %+v, want applied then current screening", stages)
+	}
+	if _, err := s.StageDurations("missing"); err != ErrNotFound {
+		t.Errorf("StageDurations(missing) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestStore_AverageStageDurations(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 9, 0, 0, 0, time.UTC) }
+	s := &Store{applications: []model.Application{
+		{ID: "1", Status: model.StatusInterview, StatusHistory: []model.StatusChange{
+			{Status: model.StatusApplied, At: day(1)},
+			{Status: model.StatusScreening, At: day(3)},
+			{Status: model.StatusInterview, At: day(7)},
+		}},
+		{ID: "2", Status: model.StatusRejected, StatusHistory: []model.StatusChange{
+			{Status: model.StatusApplied, At: day(2)},
+			{Status: model.StatusScreening, At: day(8)},
+			{Status: model.StatusRejected, At: day(14)},
+		}},
+		// Still in screening: its open stage isn't averaged
+		{ID: "3", Status: model.StatusScreening, StatusHistory: []model.StatusChange{
+			{Status: model.StatusApplied, At: day(1)},
+			{Status: model.StatusScreening, At: day(5)},
+		}},
+		{ID: "4", Status: model.StatusApplied},
+	}}
+
+	got := s.AverageStageDurations()
+	want := []StageAverage{
+		{Status: model.StatusApplied, Average: 4 * 24 * time.Hour, Count: 3},
+		{Status: model.StatusScreening, Average: 5 * 24 * time.Hour, Count: 2},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("AverageStageDurations() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("average %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package store
+
+import (
+	"slices"
+
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+// Tx groups mutations made inside Store.Transaction. They apply to the
+// store's applications immediately, so later calls see earlier ones, but
+// nothing is saved or logged until the transaction commits.
+type Tx struct {
+	s         *Store
+	mutations []mutation
+}
+
+// Transaction runs fn with the store locked and saves its mutations with a
+// single write when it returns nil. If fn returns an error, or the save
+// fails, the mutations are rolled back and the data file is left as it
+// was. Each mutation is still logged separately for undo.
+//
+// fn must use tx rather than the store's own methods, which would deadlock.
+func (s *Store) Transaction(fn func(tx *Tx) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Mutations replace elements rather than changing them in place, so a
+	// shallow copy is enough to roll back
+	snapshot := slices.Clone(s.applications)
+	rollback := func() {
+		s.applications = snapshot
+		s.reindex()
+	}
+
+	tx := &Tx{s: s}
+	if err := fn(tx); err != nil {
+		rollback()
+		return err
+	}
+	if len(tx.mutations) == 0 {
+		return nil
+	}
+	if err := s.save(); err != nil {
+		rollback()
+		return err
+	}
+	for _, m := range tx.mutations {
+		s.record(m)
+	}
+	return nil
+}
+
+// Add creates a new application and returns it, as Store.Add
+func (tx *Tx) Add(app model.Application) model.Application {
+	app, m := tx.s.add(app)
+	tx.mutations = append(tx.mutations, m)
+	return app
+}
+
+// Update modifies an existing application, as Store.Update
+func (tx *Tx) Update(app model.Application) error {
+	m, err := tx.s.update(app)
+	if err != nil {
+		return err
+	}
+	tx.mutations = append(tx.mutations, m)
+	return nil
+}
+
+// Delete removes an application by ID, as Store.Delete
+func (tx *Tx) Delete(id string) error {
+	m, err := tx.s.delete(id)
+	if err != nil {
+		return err
+	}
+	tx.mutations = append(tx.mutations, m)
+	return nil
+}
+
+// UpdateStatus changes an application's status, as Store.UpdateStatus
+func (tx *Tx) UpdateStatus(id, status string) error {
+	app, err := tx.GetByID(id)
+	if err != nil {
+		return err
+	}
+	return tx.Update(withStatus(app, status))
+}
+
+// GetByID returns a single application by ID, including changes made
+// earlier in the transaction
+func (tx *Tx) GetByID(id string) (model.Application, error) {
+	if i := tx.s.indexOf(id); i != -1 {
+		return tx.s.applications[i], nil
+	}
+	return model.Application{}, ErrNotFound
+}
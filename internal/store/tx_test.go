@@ -0,0 +1,113 @@
+package store
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+func TestStore_TransactionSavesOnce(t *testing.T) {
+	dir := t.TempDir()
+	s := openStore(t, dir)
+	path := filepath.Join(dir, "applications.json")
+	before, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = s.Transaction(func(tx *Tx) error {
+		for _, company := range []string{"Acme", "Globex", "Initech"} {
+			tx.Add(model.Application{Company: company, Position: "SWE"})
+
+			// Nothing reaches the data file until the transaction commits
+			if during, _ := os.ReadFile(path); string(during) != string(before) {
+				t.Errorf("data file written mid-transaction after adding %s", company)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Transaction() error = %v", err)
+	}
+
+	if s.Total() != 3 {
+		t.Errorf("Total() = %d, want 3", s.Total())
+	}
+	if reopened := openStore(t, dir); reopened.Total() != 3 {
+		t.Errorf("reopened store has %d applications, want 3 saved", reopened.Total())
+	}
+
+	// Each mutation is still undoable on its own
+	if entries, _ := s.undo.Entries(); len(entries) != 3 {
+		t.Errorf("undo log has %d entries, want 3", len(entries))
+	}
+}
+
+func TestStore_TransactionRollsBackOnError(t *testing.T) {
+	dir := t.TempDir()
+	s := openStore(t, dir)
+	kept, err := s.Add(model.Application{Company: "Acme", Position: "SWE", Status: model.StatusApplied})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "applications.json")
+	before, _ := os.ReadFile(path)
+
+	errStop := errors.New("stop")
+	err = s.Transaction(func(tx *Tx) error {
+		tx.Add(model.Application{Company: "Globex", Position: "SWE"})
+		if err := tx.UpdateStatus(kept.ID, model.StatusInterview); err != nil {
+			return err
+		}
+		if app, _ := tx.GetByID(kept.ID); app.Status != model.StatusInterview {
+			t.Errorf("GetByID() inside transaction = %s, want the pending interview status", app.Status)
+		}
+		if err := tx.Delete(kept.ID); err != nil {
+			return err
+		}
+		return errStop
+	})
+	if !errors.Is(err, errStop) {
+		t.Fatalf("Transaction() error = %v, want %v", err, errStop)
+	}
+
+	if s.Total() != 1 {
+		t.Errorf("Total() = %d, want 1 after rollback", s.Total())
+	}
+	got, err := s.GetByID(kept.ID)
+	if err != nil || got.Status != model.StatusApplied {
+		t.Errorf("GetByID() = %+v, %v, want the original applied application", got, err)
+	}
+	if after, _ := os.ReadFile(path); string(after) != string(before) {
+		t.Error("data file changed by a failed transaction")
+	}
+	if entries, _ := s.undo.Entries(); len(entries) != 1 {
+		t.Errorf("undo log has %d entries, want only the add before the transaction", len(entries))
+	}
+}
+
+func TestStore_SaveFollowsSymlink(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "real.json")
+	link := filepath.Join(dir, "applications.json")
+	if err := os.WriteFile(target, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(target, link); err != nil {
+		t.Skipf("symlinks unsupported: %v", err)
+	}
+
+	s := openStore(t, dir)
+	if _, err := s.Add(model.Application{Company: "Acme", Position: "SWE"}); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Lstat(link); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("data file symlink replaced by save")
+	}
+	if data, _ := os.ReadFile(target); len(data) == 0 {
+		t.Error("save didn't write through the symlink")
+	}
+}
@@ -0,0 +1,215 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/atomicfile"
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+// DefaultUndoDepth is how many mutations the undo log keeps by default
+const DefaultUndoDepth = 50
+
+// Undo operations, named after the mutation being reversed
+const (
+	UndoAdd    = "add"
+	UndoUpdate = "update"
+	UndoDelete = "delete"
+)
+
+var ErrNothingToUndo = errors.New("nothing to undo")
+
+// UndoEntry records a mutation and the state needed to reverse it
+type UndoEntry struct {
+	Time time.Time `json:"time"`
+	Op   string    `json:"op"`
+	// App is the created application for add, the previous version for
+	// update, and the removed application for delete
+	App model.Application `json:"app"`
+}
+
+// UndoLog is a bounded stack of undo entries persisted as JSON lines, so
+// undo works across process restarts
+type UndoLog struct {
+	path  string
+	depth int
+}
+
+// NewUndoLog creates an undo log at path keeping at most depth entries
+func NewUndoLog(path string, depth int) *UndoLog {
+	if depth <= 0 {
+		depth = DefaultUndoDepth
+	}
+	return &UndoLog{path: path, depth: depth}
+}
+
+// Entries returns the logged entries, oldest first. A missing log is empty;
+// malformed lines are skipped.
+func (l *UndoLog) Entries() ([]UndoEntry, error) {
+	f, err := os.Open(l.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []UndoEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var e UndoEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries, scanner.Err()
+}
+
+// Push adds an entry, evicting the oldest entries beyond the depth cap
+func (l *UndoLog) Push(e UndoEntry) error {
+	entries, err := l.Entries()
+	if err != nil {
+		return err
+	}
+	entries = append(entries, e)
+	if len(entries) > l.depth {
+		entries = entries[len(entries)-l.depth:]
+	}
+	return l.write(entries)
+}
+
+// Peek returns the most recent entry without removing it
+func (l *UndoLog) Peek() (*UndoEntry, error) {
+	entries, err := l.Entries()
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, ErrNothingToUndo
+	}
+	return &entries[len(entries)-1], nil
+}
+
+// Pop removes and returns the most recent entry
+func (l *UndoLog) Pop() (*UndoEntry, error) {
+	entries, err := l.Entries()
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, ErrNothingToUndo
+	}
+	last := entries[len(entries)-1]
+	return &last, l.write(entries[:len(entries)-1])
+}
+
+// write replaces the log with entries. The log is written to a temporary
+// file and renamed into place, so a failed write keeps the previous history.
+func (l *UndoLog) write(entries []UndoEntry) error {
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	for _, e := range entries {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	return atomicfile.WriteFile(l.path, buf.Bytes(), 0644)
+}
+
+// SetUndoLog enables recording of Add, Update, and Delete in the undo log.
+// Pass nil to disable.
+func (s *Store) SetUndoLog(l *UndoLog) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.undo = l
+}
+
+// recordUndo logs a mutation if an undo log is set.
+// Best effort: a failure to log never fails the mutation itself.
+func (s *Store) recordUndo(op string, app model.Application) {
+	if s.undo == nil {
+		return
+	}
+	_ = s.undo.Push(UndoEntry{Time: time.Now(), Op: op, App: app})
+}
+
+// Undo reverses the most recent logged mutation and returns it.
+// The reversal itself is not logged.
+func (s *Store) Undo() (*UndoEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.undo == nil {
+		return nil, ErrNothingToUndo
+	}
+
+	entry, err := s.undo.Peek()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.reverse(entry); err != nil {
+		return nil, fmt.Errorf("cannot undo %s of %s - %s: %w", entry.Op, entry.App.Company, entry.App.Position, err)
+	}
+
+	if _, err := s.undo.Pop(); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// reverse applies the inverse of an undo entry and saves
+func (s *Store) reverse(entry *UndoEntry) error {
+	idx := s.indexOf(entry.App.ID)
+
+	switch entry.Op {
+	case UndoAdd:
+		if idx == -1 {
+			return ErrNotFound
+		}
+		s.applications = append(s.applications[:idx], s.applications[idx+1:]...)
+	case UndoUpdate:
+		if idx == -1 {
+			return ErrNotFound
+		}
+		s.applications[idx] = entry.App
+	case UndoDelete:
+		if idx != -1 {
+			return fmt.Errorf("application %s already exists", entry.App.ID)
+		}
+		s.applications = append(s.applications, entry.App)
+	default:
+		return fmt.Errorf("unknown operation %q", entry.Op)
+	}
+	s.reindex()
+
+	if err := s.save(); err != nil {
+		return err
+	}
+	s.recordOp(inverseOps[entry.Op], entry.App)
+	return nil
+}
+
+// inverseOps maps an undone mutation to the operation log entry for its
+// reversal
+var inverseOps = map[string]string{
+	UndoAdd:    OpDelete,
+	UndoUpdate: OpUpdate,
+	UndoDelete: OpAdd,
+}
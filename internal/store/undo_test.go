@@ -0,0 +1,174 @@
+package store
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+// openStore opens the store and undo log in dir, as a fresh process would
+func openStore(t *testing.T, dir string) *Store {
+	t.Helper()
+	s, err := New(filepath.Join(dir, "applications.json"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	s.SetUndoLog(NewUndoLog(filepath.Join(dir, "undo.log"), DefaultUndoDepth))
+	return s
+}
+
+func TestStore_UndoAcrossRestart(t *testing.T) {
+	dir := t.TempDir()
+
+	s := openStore(t, dir)
+	initial := s.Total()
+
+	kept, err := s.Add(model.Application{Company: "Acme", Position: "SWE", Status: model.StatusApplied})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if err := s.UpdateStatus(kept.ID, model.StatusInterview); err != nil {
+		t.Fatalf("UpdateStatus() error = %v", err)
+	}
+	if err := s.Delete(kept.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	// Simulate a restart: reload both the data file and the undo log
+	s = openStore(t, dir)
+
+	// Undo delete: the application comes back with its last status
+	entry, err := s.Undo()
+	if err != nil {
+		t.Fatalf("Undo() delete error = %v", err)
+	}
+	if entry.Op != UndoDelete {
+		t.Errorf("first undo op = %q, want %q", entry.Op, UndoDelete)
+	}
+	app, err := s.GetByID(kept.ID)
+	if err != nil {
+		t.Fatalf("GetByID() after undoing delete error = %v", err)
+	}
+	if app.Status != model.StatusInterview {
+		t.Errorf("restored status = %q, want %q", app.Status, model.StatusInterview)
+	}
+
+	// Undo update: the previous status is restored
+	if _, err := s.Undo(); err != nil {
+		t.Fatalf("Undo() update error = %v", err)
+	}
+	app, _ = s.GetByID(kept.ID)
+	if app.Status != model.StatusApplied {
+		t.Errorf("status after undoing update = %q, want %q", app.Status, model.StatusApplied)
+	}
+
+	// Undo add: the application is removed again
+	if _, err := s.Undo(); err != nil {
+		t.Fatalf("Undo() add error = %v", err)
+	}
+	if _, err := s.GetByID(kept.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetByID() after undoing add error = %v, want ErrNotFound", err)
+	}
+	if s.Total() != initial {
+		t.Errorf("Total() = %d, want %d", s.Total(), initial)
+	}
+
+	// Undo itself is not logged, so the stack is now empty
+	if _, err := s.Undo(); !errors.Is(err, ErrNothingToUndo) {
+		t.Errorf("Undo() on empty log error = %v, want ErrNothingToUndo", err)
+	}
+
+	// The reversals were persisted
+	s = openStore(t, dir)
+	if s.Total() != initial {
+		t.Errorf("Total() after reload = %d, want %d", s.Total(), initial)
+	}
+}
+
+func TestUndoLog_DepthCapEvictsOldest(t *testing.T) {
+	log := NewUndoLog(filepath.Join(t.TempDir(), "undo.log"), 3)
+
+	for _, company := range []string{"A", "B", "C", "D", "E"} {
+		if err := log.Push(UndoEntry{Op: UndoAdd, App: model.Application{ID: company, Company: company}}); err != nil {
+			t.Fatalf("Push() error = %v", err)
+		}
+	}
+
+	entries, err := log.Entries()
+	if err != nil {
+		t.Fatalf("Entries() error = %v", err)
+	}
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.App.Company)
+	}
+	want := []string{"C", "D", "E"}
+	if len(got) != len(want) {
+		t.Fatalf("Entries() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Entries()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	last, err := log.Pop()
+	if err != nil || last.App.Company != "E" {
+		t.Errorf("Pop() = %+v, %v; want E", last, err)
+	}
+}
+
+func TestUndoLog_WriteLeavesOnlyLog(t *testing.T) {
+	dir := t.TempDir()
+	log := NewUndoLog(filepath.Join(dir, "undo.log"), 3)
+
+	for _, company := range []string{"A", "B"} {
+		if err := log.Push(UndoEntry{Op: UndoAdd, App: model.Application{ID: company, Company: company}}); err != nil {
+			t.Fatalf("Push() error = %v", err)
+		}
+	}
+	if _, err := log.Pop(); err != nil {
+		t.Fatalf("Pop() error = %v", err)
+	}
+
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("dir has %d entries, want only undo.log", len(entries))
+	}
+	entries, err := log.Entries()
+	if err != nil || len(entries) != 1 || entries[0].App.Company != "A" {
+		t.Errorf("Entries() = %+v, %v; want [A]", entries, err)
+	}
+}
+
+func TestStore_UndoWithoutLog(t *testing.T) {
+	s := &Store{}
+	if _, err := s.Undo(); !errors.Is(err, ErrNothingToUndo) {
+		t.Errorf("Undo() without log error = %v, want ErrNothingToUndo", err)
+	}
+}
+
+func TestStore_UndoConflictKeepsEntry(t *testing.T) {
+	dir := t.TempDir()
+	s := openStore(t, dir)
+
+	app, err := s.Add(model.Application{Company: "Acme", Position: "SWE"})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	// Remove it without logging so undoing the add has nothing to remove
+	s.SetUndoLog(nil)
+	if err := s.Delete(app.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	s.SetUndoLog(NewUndoLog(filepath.Join(dir, "undo.log"), DefaultUndoDepth))
+
+	if _, err := s.Undo(); err == nil {
+		t.Fatal("Undo() should fail when the added application is gone")
+	}
+	if entries, _ := s.undo.Entries(); len(entries) != 1 {
+		t.Errorf("failed undo should leave the entry on the stack, got %d entries", len(entries))
+	}
+}
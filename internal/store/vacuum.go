@@ -0,0 +1,134 @@
+package store
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// backupLayout timestamps data file backups
+const backupLayout = "2006-01-02-150405"
+
+// Backup copies the data file next to itself as
+// {name}.backup.{timestamp}.json and returns the copy's path
+func (s *Store) Backup() (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data, err := os.ReadFile(s.filepath)
+	if err != nil {
+		return "", fmt.Errorf("reading data file: %w", err)
+	}
+	ext := filepath.Ext(s.filepath)
+	path := fmt.Sprintf("%s.backup.%s%s", strings.TrimSuffix(s.filepath, ext), time.Now().Format(backupLayout), ext)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("writing backup: %w", err)
+	}
+	return path, nil
+}
+
+// Vacuum normalizes every application and rewrites the data file: string
+// fields are trimmed, statuses use their canonical keys, interviews are
+// sorted by date, missing IDs are generated, and unset dates stored as
+// zero times are cleared. It returns how many applications changed.
+// Vacuuming isn't recorded for undo; take a Backup first.
+func (s *Store) Vacuum() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var changed int
+	var ops []OpEntry
+	for i := range s.applications {
+		before := cloneApplication(s.applications[i])
+		normalizeApplication(&s.applications[i])
+		after := s.applications[i]
+		if reflect.DeepEqual(before, after) {
+			continue
+		}
+		changed++
+		switch {
+		case before.ID == after.ID:
+			ops = append(ops, OpEntry{Op: OpUpdate, App: after})
+		case before.ID == "":
+			// Never in the log, which skips entries without an ID
+			ops = append(ops, OpEntry{Op: OpAdd, App: after})
+		default:
+			// A new or trimmed ID is a different application to the log
+			ops = append(ops,
+				OpEntry{Op: OpDelete, App: before},
+				OpEntry{Op: OpAdd, App: after})
+		}
+	}
+	s.reindex()
+	if err := s.save(); err != nil {
+		return changed, err
+	}
+	for _, e := range ops {
+		s.recordOp(e.Op, e.App)
+	}
+	return changed, nil
+}
+
+// cloneApplication copies an application deeply enough that normalizing
+// the original leaves the copy untouched
+func cloneApplication(a model.Application) model.Application {
+	a.Interviews = append([]model.Interview(nil), a.Interviews...)
+	a.ResumeVersions = append([]string(nil), a.ResumeVersions...)
+	return a
+}
+
+// normalizeApplication cleans up one application in place
+func normalizeApplication(a *model.Application) {
+	if strings.TrimSpace(a.ID) == "" {
+		a.ID = uuid.New().String()
+	}
+	for _, field := range []*string{
+		&a.ID, &a.Company, &a.Position, &a.Notes, &a.JobURL, &a.Location,
+		&a.JobType, &a.ContactName, &a.ContactEmail, &a.ResumeVersion,
+		&a.CoverLetter, &a.PostingPath, &a.DocumentsDir,
+	} {
+		*field = strings.TrimSpace(*field)
+	}
+	a.Status = model.NormalizeStatus(a.Status)
+
+	for i := range a.ResumeVersions {
+		a.ResumeVersions[i] = strings.TrimSpace(a.ResumeVersions[i])
+	}
+	for i := range a.Interviews {
+		interview := &a.Interviews[i]
+		interview.Type = strings.TrimSpace(interview.Type)
+		interview.Notes = strings.TrimSpace(interview.Notes)
+		interview.WithWhom = strings.TrimSpace(interview.WithWhom)
+	}
+	sort.SliceStable(a.Interviews, func(i, j int) bool {
+		return a.Interviews[i].Date.Before(a.Interviews[j].Date)
+	})
+
+	clearZeroDates(a)
+
+	// Optional slices left empty by hand edits are dropped, like absent ones
+	if len(a.Interviews) == 0 {
+		a.Interviews = nil
+	}
+	if len(a.ResumeVersions) == 0 {
+		a.ResumeVersions = nil
+	}
+}
+
+// clearZeroDates treats optional dates set to the zero time (e.g.
+// "0001-01-01T00:00:00Z" in a hand-edited file) as unset
+func clearZeroDates(a *model.Application) {
+	for _, date := range []**time.Time{&a.DateApplied, &a.Deadline, &a.NextFollowUp, &a.FirstResponseAt} {
+		if *date != nil && (*date).IsZero() {
+			*date = nil
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+func TestStore_Vacuum(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "applications.json")
+	early := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	late := time.Date(2026, 3, 9, 10, 0, 0, 0, time.UTC)
+	var zero time.Time
+	s := &Store{filepath: path, applications: []model.Application{
+		{
+			ID: "messy", Company: "  Acme ", Position: "Engineer\n", Status: "Interview",
+			Notes: "  call back  ", NextFollowUp: &zero,
+			Interviews: []model.Interview{
+				{Date: late, Type: "onsite "},
+				{Date: early, Type: " phone"},
+			},
+		},
+		{ID: "", Company: "Globex", Position: "Designer", Status: " APPLIED "},
+		{ID: "clean", Company: "Initech", Position: "PM", Status: model.StatusSaved},
+	}}
+	s.reindex()
+
+	changed, err := s.Vacuum()
+	if err != nil {
+		t.Fatalf("Vacuum() error = %v", err)
+	}
+	if changed != 2 {
+		t.Errorf("Vacuum() changed = %d, want 2", changed)
+	}
+
+	messy, err := s.GetByID("messy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if messy.Company != "Acme" || messy.Position != "Engineer" || messy.Notes != "call back" {
+		t.Errorf("fields not trimmed: %q, %q, %q", messy.Company, messy.Position, messy.Notes)
+	}
+	if messy.Status != model.StatusInterview {
+		t.Errorf("Status = %q, want %q from the label", messy.Status, model.StatusInterview)
+	}
+	if !messy.Interviews[0].Date.Equal(early) || messy.Interviews[0].Type != "phone" {
+		t.Errorf("Interviews = %+v, want sorted by date and trimmed", messy.Interviews)
+	}
+	if messy.NextFollowUp != nil {
+		t.Errorf("NextFollowUp = %v, want a zero date cleared", messy.NextFollowUp)
+	}
+
+	globex := s.applications[1]
+	if globex.ID == "" || globex.Status != model.StatusApplied {
+		t.Errorf("Globex = ID %q, status %q; want a generated ID and %q", globex.ID, globex.Status, model.StatusApplied)
+	}
+	if _, err := s.GetByID(globex.ID); err != nil {
+		t.Errorf("GetByID(generated ID) error = %v, want the index rebuilt", err)
+	}
+
+	// The cleaned data is what's on disk
+	reloaded, err := New(path)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if app, _ := reloaded.GetByID("messy"); app.Company != "Acme" {
+		t.Errorf("reloaded Company = %q, want Acme", app.Company)
+	}
+}
+
+func TestStore_Backup(t *testing.T) {
+	dir := t.TempDir()
+	s, err := New(filepath.Join(dir, "applications.json"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if _, err := s.Add(model.Application{Company: "Acme", Position: "Engineer"}); err != nil {
+		t.Fatal(err)
+	}
+
+	backup, err := s.Backup()
+	if err != nil {
+		t.Fatalf("Backup() error = %v", err)
+	}
+	if matched, _ := filepath.Match(filepath.Join(dir, "applications.backup.*.json"), backup); !matched {
+		t.Errorf("Backup() = %q, want applications.backup.<timestamp>.json next to the data file", backup)
+	}
+	original, _ := os.ReadFile(filepath.Join(dir, "applications.json"))
+	copied, _ := os.ReadFile(backup)
+	if string(original) != string(copied) {
+		t.Error("backup differs from the data file")
+	}
+}
+
+func TestStore_VacuumLogsRegeneratedIDs(t *testing.T) {
+	dir := t.TempDir()
+	s := &Store{filepath: filepath.Join(dir, "applications.json"), applications: []model.Application{
+		{ID: " padded ", Company: "Acme", Position: "Engineer"},
+		{ID: "", Company: "Globex", Position: "Designer"},
+		{ID: "clean", Company: "Initech ", Position: "PM"},
+	}}
+	s.reindex()
+	log := NewOpLog(filepath.Join(dir, "applications.log"))
+	s.SetOpLog(log)
+
+	if _, err := s.Vacuum(); err != nil {
+		t.Fatalf("Vacuum() error = %v", err)
+	}
+
+	result, err := log.Replay()
+	if err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+	want := make(map[string]bool)
+	for _, app := range s.List() {
+		want[app.ID] = true
+	}
+	got := make(map[string]bool)
+	for _, app := range result.Applications {
+		got[app.ID] = true
+	}
+	if len(result.Applications) != len(want) || len(got) != len(want) {
+		t.Fatalf("replayed %d applications (%v), want the %d vacuumed ones %v", len(result.Applications), got, len(want), want)
+	}
+	for id := range want {
+		if !got[id] {
+			t.Errorf("replayed log is missing %q", id)
+		}
+	}
+}
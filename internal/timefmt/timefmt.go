@@ -0,0 +1,59 @@
+// Package timefmt formats times for display in the TUI and CLI
+package timefmt
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	day   = 24 * time.Hour
+	week  = 7 * day
+	month = 30 * day
+	year  = 365 * day
+)
+
+// HumanizeTime describes t relative to now, e.g. "just now", "5 minutes ago",
+// "yesterday", "2 weeks ago", or "in 3 days" for future times
+func HumanizeTime(t time.Time, now time.Time) string {
+	d := now.Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+
+	if d < time.Minute {
+		return "just now"
+	}
+	if d >= day && d < 2*day {
+		if future {
+			return "tomorrow"
+		}
+		return "yesterday"
+	}
+
+	var n int
+	var unit string
+	switch {
+	case d < time.Hour:
+		n, unit = int(d/time.Minute), "minute"
+	case d < day:
+		n, unit = int(d/time.Hour), "hour"
+	case d < week:
+		n, unit = int(d/day), "day"
+	case d < month:
+		n, unit = int(d/week), "week"
+	case d < year:
+		n, unit = int(d/month), "month"
+	default:
+		n, unit = int(d/year), "year"
+	}
+	if n != 1 {
+		unit += "s"
+	}
+
+	if future {
+		return fmt.Sprintf("in %d %s", n, unit)
+	}
+	return fmt.Sprintf("%d %s ago", n, unit)
+}
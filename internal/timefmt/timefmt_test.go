@@ -0,0 +1,41 @@
+package timefmt
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHumanizeTime(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want string
+	}{
+		{"same instant", now, "just now"},
+		{"seconds ago", now.Add(-30 * time.Second), "just now"},
+		{"seconds ahead", now.Add(30 * time.Second), "just now"},
+		{"one minute", now.Add(-time.Minute), "1 minute ago"},
+		{"minutes", now.Add(-5 * time.Minute), "5 minutes ago"},
+		{"hours", now.Add(-3 * time.Hour), "3 hours ago"},
+		{"yesterday", now.Add(-30 * time.Hour), "yesterday"},
+		{"days", now.AddDate(0, 0, -4), "4 days ago"},
+		{"one week", now.AddDate(0, 0, -7), "1 week ago"},
+		{"weeks", now.AddDate(0, 0, -15), "2 weeks ago"},
+		{"months", now.AddDate(0, 0, -65), "2 months ago"},
+		{"years", now.AddDate(-2, 0, 0), "2 years ago"},
+		{"tomorrow", now.Add(25 * time.Hour), "tomorrow"},
+		{"future days", now.AddDate(0, 0, 3), "in 3 days"},
+		{"future hours", now.Add(2 * time.Hour), "in 2 hours"},
+		{"future weeks", now.AddDate(0, 0, 21), "in 3 weeks"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HumanizeTime(tt.t, now); got != tt.want {
+				t.Errorf("HumanizeTime() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -11,6 +12,7 @@ import (
 	"github.com/celloopa/ghosted/internal/store"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -26,11 +28,26 @@ const (
 	ViewFilter
 	ViewConfirmDelete
 	ViewFetch
+	ViewPalette
+	ViewQuickAdd
 )
 
 // splashDoneMsg signals the splash screen is done
 type splashDoneMsg struct{}
 
+// storePollMsg triggers a check of the data file for external edits
+type storePollMsg struct{}
+
+// storePollInterval is how often the data file is checked for external edits
+const storePollInterval = 2 * time.Second
+
+// pollStore schedules the next external-edit check
+func pollStore() tea.Cmd {
+	return tea.Tick(storePollInterval, func(time.Time) tea.Msg {
+		return storePollMsg{}
+	})
+}
+
 // App is the main Bubble Tea model
 type App struct {
 	store        *store.Store
@@ -48,14 +65,16 @@ type App struct {
 	detailView DetailView
 	formView   FormView
 	fetchView  FetchView
+	palette    PaletteView
+	quickAdd   QuickAddView
 
 	// Filter state
 	filterOptions  []string
 	filterCursor   int
 	selectedFilter string
 
-	// Confirm delete
-	deleteTarget *model.Application
+	// Confirm delete; several when deleting a multi-selection
+	deleteTargets []model.Application
 }
 
 // New creates a new App
@@ -64,9 +83,12 @@ func New(s *store.Store) App {
 
 	apps := s.List()
 	listView := NewListView(apps, keys)
+	listView.SetSummary(storeSummary(s, time.Now()))
 	detailView := NewDetailView(nil, keys)
 	formView := NewFormView(keys)
 	fetchView := NewFetchView(keys)
+	palette := NewPaletteView(keys)
+	quickAdd := NewQuickAddView(keys)
 
 	return App{
 		store:      s,
@@ -76,6 +98,8 @@ func New(s *store.Store) App {
 		detailView: detailView,
 		formView:   formView,
 		fetchView:  fetchView,
+		palette:    palette,
+		quickAdd:   quickAdd,
 		filterOptions: append([]string{"All"}, func() []string {
 			statuses := model.AllStatuses()
 			labels := make([]string, len(statuses))
@@ -87,16 +111,34 @@ func New(s *store.Store) App {
 	}
 }
 
+// SetSalaryFloor dims list entries paying below floor
+func (a *App) SetSalaryFloor(floor int) {
+	a.listView.SetSalaryFloor(floor)
+}
+
 // Init initializes the app
 func (a App) Init() tea.Cmd {
-	// Start splash screen timer
-	return tea.Tick(1500*time.Millisecond, func(t time.Time) tea.Msg {
-		return splashDoneMsg{}
-	})
+	// Start splash screen timer and watch for external edits
+	return tea.Batch(
+		tea.Tick(1500*time.Millisecond, func(t time.Time) tea.Msg {
+			return splashDoneMsg{}
+		}),
+		pollStore(),
+	)
 }
 
-// Update handles messages
+// Update handles messages and then refreshes the list's metrics line, so
+// View only renders state and the counts follow every store change.
 func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	m, cmd := a.update(msg)
+	if next, ok := m.(App); ok {
+		next.listView.SetSummary(storeSummary(next.store, time.Now()))
+		return next, cmd
+	}
+	return m, cmd
+}
+
+func (a App) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		a.width = msg.Width
@@ -113,6 +155,7 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.detailView.SetSize(a.contentWidth, msg.Height)
 		a.formView.SetSize(a.contentWidth, msg.Height)
 		a.fetchView.SetSize(a.contentWidth, msg.Height)
+		a.palette.SetSize(a.contentWidth)
 		return a, nil
 
 	case splashDoneMsg:
@@ -123,6 +166,22 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.fetchView.HandleFetchComplete(msg)
 		return a, nil
 
+	case storePollMsg:
+		// Pick up edits made outside the TUI (hand edits, CLI, AI agents)
+		changed, err := a.store.ReloadIfChanged()
+		if err != nil {
+			a.statusMsg = fmt.Sprintf("Could not reload data file: %v", err)
+		} else if changed {
+			a.refreshList()
+			if app := a.detailView.application; app != nil {
+				if updated, err := a.store.GetByID(app.ID); err == nil {
+					a.detailView.SetApplication(&updated)
+				}
+			}
+			a.statusMsg = "Reloaded external changes"
+		}
+		return a, pollStore()
+
 	case tea.KeyMsg:
 		// Skip splash on any key press
 		if a.viewState == ViewSplash {
@@ -152,11 +211,42 @@ func (a App) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return a.handleDeleteConfirmKey(msg)
 	case ViewFetch:
 		return a.handleFetchKey(msg)
+	case ViewPalette:
+		return a.handlePaletteKey(msg)
+	case ViewQuickAdd:
+		return a.handleQuickAddKey(msg)
 	}
 
 	return a, nil
 }
 
+// openPalette shows the command palette with the actions of the current view
+func (a App) openPalette(actions []PaletteAction) (tea.Model, tea.Cmd) {
+	a.palette.Open(actions)
+	a.prevState = a.viewState
+	a.viewState = ViewPalette
+	return a, textinput.Blink
+}
+
+// handlePaletteKey runs the selected palette action through the handler of
+// the view the palette was opened from
+func (a App) handlePaletteKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	_, action := a.palette.HandleKey(msg)
+	switch action {
+	case "":
+		return a, nil
+	case "cancel":
+		a.viewState = a.prevState
+		return a, nil
+	}
+
+	a.viewState = a.prevState
+	if a.viewState == ViewDetail {
+		return a.runDetailAction(action)
+	}
+	return a.runListAction(action)
+}
+
 func (a App) handleListKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Handle search input first
 	if a.listView.IsSearchMode() {
@@ -187,52 +277,116 @@ func (a App) handleListKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return a, nil
 	}
 
+	if key.Matches(msg, a.keys.Palette) {
+		return a.openPalette(listPaletteActions(a.keys))
+	}
+
 	handled, action := a.listView.HandleKey(msg)
 	if handled {
-		switch action {
-		case "quit":
-			return a, tea.Quit
-		case "add":
-			a.formView.Reset()
+		return a.runListAction(action)
+	}
+
+	return a, nil
+}
+
+// runListAction performs a list view action, from a keybinding or the palette
+func (a App) runListAction(action string) (tea.Model, tea.Cmd) {
+	switch action {
+	case "quit":
+		return a, tea.Quit
+	case "add":
+		a.formView.Reset()
+		a.prevState = a.viewState
+		a.viewState = ViewForm
+	case "quick-add":
+		a.quickAdd.Reset()
+		a.viewState = ViewQuickAdd
+		return a, textinput.Blink
+	case "edit":
+		if app := a.listView.SelectedApplication(); app != nil {
+			a.formView.SetApplication(app)
 			a.prevState = a.viewState
 			a.viewState = ViewForm
-		case "edit":
-			if app := a.listView.SelectedApplication(); app != nil {
-				a.formView.SetApplication(app)
-				a.prevState = a.viewState
-				a.viewState = ViewForm
+		}
+	case "delete":
+		if targets := a.listView.TargetApplications(); len(targets) > 0 {
+			a.deleteTargets = targets
+			a.viewState = ViewConfirmDelete
+		}
+	case "view":
+		if app := a.listView.SelectedApplication(); app != nil {
+			a.detailView.SetApplication(app)
+			a.prevState = a.viewState
+			a.viewState = ViewDetail
+		}
+	case "filter":
+		a.filterCursor = 0
+		a.viewState = ViewFilter
+	case "search":
+		// Search was just performed, refresh handled above
+	case "search-start":
+		a.listView.StartSearch()
+	case "clear":
+		a.listView.SetApplications(a.store.List())
+		a.listView.SetFilterStatus("")
+	case "fetch":
+		a.fetchView.Reset()
+		a.prevState = a.viewState
+		a.viewState = ViewFetch
+	case "status-next", "status-prev":
+		step := 1
+		if action == "status-prev" {
+			step = -1
+		}
+		if targets := a.listView.TargetApplications(); len(targets) == 1 {
+			id := targets[0].ID
+			status := cycleStatus(targets[0].Status, step)
+			if err := a.store.UpdateStatus(id, status); err != nil {
+				a.statusMsg = fmt.Sprintf("Could not change status: %v", err)
+			} else {
+				a.refreshList()
+				// Keep the cursor on the same application after re-sorting
+				a.listView.SelectApplication(id)
+				a.statusMsg = fmt.Sprintf("Changed status to %s", model.StatusLabel(status))
 			}
-		case "delete":
-			if app := a.listView.SelectedApplication(); app != nil {
-				a.deleteTarget = app
-				a.viewState = ViewConfirmDelete
+		} else if len(targets) > 1 {
+			updated, err := a.setStatuses(targets, func(app model.Application) string {
+				return cycleStatus(app.Status, step)
+			})
+			if err != nil {
+				a.statusMsg = fmt.Sprintf("Could not change status: %v", err)
+				break
 			}
-		case "view":
-			if app := a.listView.SelectedApplication(); app != nil {
-				a.detailView.SetApplication(app)
-				a.prevState = a.viewState
-				a.viewState = ViewDetail
+			a.refreshList()
+			a.statusMsg = fmt.Sprintf("Changed status of %d applications", updated)
+		}
+	case "priority-next":
+		if app := a.listView.SelectedApplication(); app != nil {
+			priority, err := a.cyclePriority(app.ID)
+			if err != nil {
+				a.statusMsg = fmt.Sprintf("Could not change priority: %v", err)
+				break
 			}
-		case "filter":
-			a.filterCursor = 0
-			a.viewState = ViewFilter
-		case "search":
-			// Search was just performed, refresh handled above
-		case "clear":
-			a.listView.SetApplications(a.store.List())
-			a.listView.SetFilterStatus("")
-		case "fetch":
-			a.fetchView.Reset()
-			a.prevState = a.viewState
-			a.viewState = ViewFetch
-		default:
-			if strings.HasPrefix(action, "status:") {
-				status := strings.TrimPrefix(action, "status:")
-				if app := a.listView.SelectedApplication(); app != nil {
-					if err := a.store.UpdateStatus(app.ID, status); err == nil {
-						a.refreshList()
-						a.statusMsg = fmt.Sprintf("Changed status to %s", model.StatusLabel(status))
-					}
+			a.refreshList()
+			a.listView.SelectApplication(app.ID)
+			if priority == 0 {
+				a.statusMsg = "Cleared priority"
+			} else {
+				a.statusMsg = fmt.Sprintf("Priority %s", model.PriorityStars(priority))
+			}
+		}
+	default:
+		if strings.HasPrefix(action, "status:") {
+			status := strings.TrimPrefix(action, "status:")
+			targets := a.listView.TargetApplications()
+			updated, err := a.setStatuses(targets, func(model.Application) string { return status })
+			if err != nil {
+				a.statusMsg = fmt.Sprintf("Could not change status: %v", err)
+			} else if updated > 0 {
+				a.refreshList()
+				a.statusMsg = fmt.Sprintf("Changed status to %s", model.StatusLabel(status))
+				if len(targets) > 1 {
+					a.statusMsg = fmt.Sprintf("Changed %d applications to %s", updated, model.StatusLabel(status))
 				}
 			}
 		}
@@ -241,34 +395,112 @@ func (a App) handleListKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return a, nil
 }
 
+// setStatuses moves each target to the status next picks for it, saving
+// them together, and returns how many changed. Applications deleted since
+// they were listed are skipped; any other failure changes none and is
+// returned.
+func (a App) setStatuses(targets []model.Application, next func(model.Application) string) (int, error) {
+	updated := 0
+	err := a.store.Transaction(func(tx *store.Tx) error {
+		for _, app := range targets {
+			err := tx.UpdateStatus(app.ID, next(app))
+			if errors.Is(err, store.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			updated++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return updated, nil
+}
+
+// cyclePriority advances the stored application's priority, wrapping from
+// the maximum back to none, and returns the new priority. Only the priority
+// changes, so edits picked up since the list was loaded are kept.
+func (a App) cyclePriority(id string) (int, error) {
+	var priority int
+	err := a.store.Transaction(func(tx *store.Tx) error {
+		app, err := tx.GetByID(id)
+		if err != nil {
+			return err
+		}
+		app.Priority = (app.Priority + 1) % (model.MaxPriority + 1)
+		priority = app.Priority
+		return tx.Update(app)
+	})
+	return priority, err
+}
+
+// deleteApplications deletes targets with a single save and returns how
+// many were deleted. Applications already deleted are skipped; any other
+// failure deletes none and is returned.
+func (a App) deleteApplications(targets []model.Application) (int, error) {
+	deleted := 0
+	err := a.store.Transaction(func(tx *store.Tx) error {
+		for _, app := range targets {
+			err := tx.Delete(app.ID)
+			if errors.Is(err, store.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			deleted++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return deleted, nil
+}
+
 func (a App) handleDetailKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key.Matches(msg, a.keys.Palette) {
+		return a.openPalette(detailPaletteActions(a.keys))
+	}
+
 	handled, action := a.detailView.HandleKey(msg)
 	if handled {
-		switch action {
-		case "quit":
-			return a, tea.Quit
-		case "back":
-			a.viewState = ViewList
-		case "edit":
-			if a.detailView.application != nil {
-				a.formView.SetApplication(a.detailView.application)
-				a.prevState = a.viewState
-				a.viewState = ViewForm
-			}
-		case "delete":
+		return a.runDetailAction(action)
+	}
+	return a, nil
+}
+
+// runDetailAction performs a detail view action, from a keybinding or the palette
+func (a App) runDetailAction(action string) (tea.Model, tea.Cmd) {
+	switch action {
+	case "quit":
+		return a, tea.Quit
+	case "back":
+		a.viewState = ViewList
+	case "edit":
+		if a.detailView.application != nil {
+			a.formView.SetApplication(a.detailView.application)
+			a.prevState = a.viewState
+			a.viewState = ViewForm
+		}
+	case "delete":
+		if a.detailView.application != nil {
+			a.deleteTargets = []model.Application{*a.detailView.application}
+			a.viewState = ViewConfirmDelete
+		}
+	default:
+		if strings.HasPrefix(action, "status:") {
+			status := strings.TrimPrefix(action, "status:")
 			if a.detailView.application != nil {
-				a.deleteTarget = a.detailView.application
-				a.viewState = ViewConfirmDelete
-			}
-		default:
-			if strings.HasPrefix(action, "status:") {
-				status := strings.TrimPrefix(action, "status:")
-				if a.detailView.application != nil {
-					if err := a.store.UpdateStatus(a.detailView.application.ID, status); err == nil {
-						app, _ := a.store.GetByID(a.detailView.application.ID)
-						a.detailView.SetApplication(&app)
-						a.statusMsg = fmt.Sprintf("Changed status to %s", model.StatusLabel(status))
-					}
+				if err := a.store.UpdateStatus(a.detailView.application.ID, status); err == nil {
+					app, _ := a.store.GetByID(a.detailView.application.ID)
+					a.detailView.SetApplication(&app)
+					a.statusMsg = fmt.Sprintf("Changed status to %s", model.StatusLabel(status))
+				} else {
+					a.statusMsg = fmt.Sprintf("Could not change status: %v", err)
 				}
 			}
 		}
@@ -320,6 +552,36 @@ func (a App) handleFormKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return a, nil
 }
 
+// handleQuickAddKey saves the "Company @ Position" line as a saved
+// application and returns to the list, or shows why it can't
+func (a App) handleQuickAddKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	handled, action := a.quickAdd.HandleKey(msg)
+	if handled {
+		switch action {
+		case "cancel":
+			a.viewState = ViewList
+		case "submit":
+			if app, ok := a.quickAdd.Application(); ok {
+				added, err := a.store.Add(app)
+				if err != nil {
+					a.err = err
+					return a, nil
+				}
+				a.refreshList()
+				a.listView.SelectApplication(added.ID)
+				a.statusMsg = fmt.Sprintf("Saved %s @ %s", added.Company, added.Position)
+				a.viewState = ViewList
+			}
+		}
+		return a, nil
+	}
+
+	input := a.quickAdd.Input()
+	newInput, cmd := input.Update(msg)
+	a.quickAdd.UpdateInput(newInput)
+	return a, cmd
+}
+
 func (a App) handleFilterKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch {
 	case key.Matches(msg, a.keys.Cancel):
@@ -353,16 +615,23 @@ func (a App) handleFilterKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 func (a App) handleDeleteConfirmKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "y", "Y":
-		if a.deleteTarget != nil {
-			if err := a.store.Delete(a.deleteTarget.ID); err == nil {
-				a.statusMsg = fmt.Sprintf("Deleted %s @ %s", a.deleteTarget.Position, a.deleteTarget.Company)
-				a.refreshList()
-			}
+		deleted, err := a.deleteApplications(a.deleteTargets)
+		switch {
+		case err != nil:
+			a.statusMsg = fmt.Sprintf("Could not delete: %v", err)
+		case len(a.deleteTargets) == 1 && deleted == 1:
+			a.statusMsg = fmt.Sprintf("Deleted %s @ %s", a.deleteTargets[0].Position, a.deleteTargets[0].Company)
+		case deleted > 0:
+			a.statusMsg = fmt.Sprintf("Deleted %d applications", deleted)
 		}
-		a.deleteTarget = nil
+		if deleted > 0 {
+			a.listView.ClearSelection()
+			a.refreshList()
+		}
+		a.deleteTargets = nil
 		a.viewState = ViewList
 	case "n", "N", "esc":
-		a.deleteTarget = nil
+		a.deleteTargets = nil
 		if a.prevState == ViewDetail {
 			a.viewState = ViewDetail
 		} else {
@@ -404,6 +673,12 @@ func (a App) handleFetchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return a, cmd
 }
 
+// storeSummary returns the dashboard metrics line shown above the list
+func storeSummary(s *store.Store, now time.Time) string {
+	return fmt.Sprintf("%d active · %d this week · %d interviewing",
+		s.CountActive(), s.CountThisWeek(now), s.CountInterviewing())
+}
+
 func (a *App) refreshList() {
 	if filter := a.listView.FilterStatus(); filter != "" {
 		a.listView.SetApplications(a.store.FilterByStatus(filter))
@@ -498,6 +773,10 @@ func (a App) View() string {
 		b.WriteString(a.renderDeleteConfirm())
 	case ViewFetch:
 		b.WriteString(a.fetchView.View())
+	case ViewPalette:
+		b.WriteString(a.palette.View())
+	case ViewQuickAdd:
+		b.WriteString(a.quickAdd.View())
 	}
 
 	// Status message
@@ -558,12 +837,22 @@ func (a App) renderDeleteConfirm() string {
 	b.WriteString(TitleStyle.Render("Confirm Delete"))
 	b.WriteString("\n\n")
 
-	if a.deleteTarget != nil {
+	if len(a.deleteTargets) == 1 {
 		b.WriteString(fmt.Sprintf("Are you sure you want to delete:\n\n"))
-		b.WriteString(HighlightStyle.Render(a.deleteTarget.Position))
+		b.WriteString(HighlightStyle.Render(a.deleteTargets[0].Position))
 		b.WriteString(" @ ")
-		b.WriteString(HighlightStyle.Render(a.deleteTarget.Company))
+		b.WriteString(HighlightStyle.Render(a.deleteTargets[0].Company))
 		b.WriteString("\n\n")
+	} else if len(a.deleteTargets) > 1 {
+		b.WriteString(fmt.Sprintf("Are you sure you want to delete these %d applications:\n\n", len(a.deleteTargets)))
+		for _, app := range a.deleteTargets {
+			b.WriteString("  ")
+			b.WriteString(HighlightStyle.Render(app.Position))
+			b.WriteString(" @ ")
+			b.WriteString(HighlightStyle.Render(app.Company))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
 	}
 
 	b.WriteString(fmt.Sprintf("%s %s  %s %s",
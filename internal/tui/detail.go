@@ -3,8 +3,10 @@ package tui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/celloopa/ghosted/internal/model"
+	"github.com/celloopa/ghosted/internal/timefmt"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
@@ -98,14 +100,14 @@ func (d *DetailView) View() string {
 
 	// Status badge
 	statusStyle := StatusBadgeStyle(app.Status)
-	b.WriteString(statusStyle.Render(model.StatusLabel(app.Status)))
+	b.WriteString(statusStyle.Render(StatusText(app.Status)))
 	b.WriteString("\n\n")
 
 	// Basic Info Section
 	b.WriteString(SectionStyle.Render("Basic Information"))
 	b.WriteString("\n")
 	if app.DateApplied != nil {
-		b.WriteString(d.renderField("Applied", app.DateApplied.Format("January 2, 2006")))
+		b.WriteString(d.renderField("Applied", datedWithRelative(*app.DateApplied, "January 2, 2006")))
 	} else {
 		b.WriteString(d.renderField("Applied", "Not sent"))
 	}
@@ -118,6 +120,12 @@ func (d *DetailView) View() string {
 	} else if app.Remote {
 		b.WriteString(d.renderField("Location", "Remote"))
 	}
+	if app.Priority > 0 {
+		b.WriteString(d.renderField("Priority", model.PriorityStars(app.Priority)))
+	}
+	if app.Deadline != nil {
+		b.WriteString(d.renderField("Deadline", datedWithRelative(*app.Deadline, "January 2, 2006")))
+	}
 	if salary := app.SalaryRange(); salary != "" {
 		b.WriteString(d.renderField("Salary", salary))
 	}
@@ -126,7 +134,28 @@ func (d *DetailView) View() string {
 	}
 
 	// Contact Info
-	if app.ContactName != "" || app.ContactEmail != "" {
+	if len(app.Contacts) > 0 {
+		b.WriteString("\n")
+		b.WriteString(SectionStyle.Render("Contacts"))
+		b.WriteString("\n")
+		for _, c := range app.ContactsByLastContacted() {
+			line := c.Name
+			if c.Role != "" {
+				line += " (" + c.Role + ")"
+			}
+			if c.Email != "" {
+				line += " <" + c.Email + ">"
+			}
+			b.WriteString(ValueStyle.Render(line))
+			if !c.LastContacted.IsZero() {
+				b.WriteString(" " + SubtleStyle.Render(c.LastContacted.Format("Jan 2, 2006")))
+			}
+			b.WriteString("\n")
+			if c.Notes != "" {
+				b.WriteString(fmt.Sprintf("   Notes: %s\n", c.Notes))
+			}
+		}
+	} else if app.ContactName != "" || app.ContactEmail != "" {
 		b.WriteString("\n")
 		b.WriteString(SectionStyle.Render("Contact"))
 		b.WriteString("\n")
@@ -158,7 +187,10 @@ func (d *DetailView) View() string {
 		b.WriteString("\n")
 		for i, interview := range app.Interviews {
 			prefix := fmt.Sprintf("%d. ", i+1)
-			date := interview.Date.Format("Jan 2, 2006 3:04 PM")
+			date := "Date unknown"
+			if !interview.Date.IsZero() {
+				date = interview.Date.Format("Jan 2, 2006 3:04 PM")
+			}
 			interviewType := interview.Type
 			if interviewType == "" {
 				interviewType = "Interview"
@@ -182,7 +214,7 @@ func (d *DetailView) View() string {
 		b.WriteString("\n")
 		b.WriteString(SectionStyle.Render("Follow-up"))
 		b.WriteString("\n")
-		b.WriteString(d.renderField("Next", app.NextFollowUp.Format("January 2, 2006")))
+		b.WriteString(d.renderField("Next", datedWithRelative(*app.NextFollowUp, "January 2, 2006")))
 	}
 
 	// Notes
@@ -234,3 +266,9 @@ func (d *DetailView) renderHelp() string {
 		HelpDescStyle.Render("back"),
 	)
 }
+
+// datedWithRelative formats t with layout followed by the relative time,
+// e.g. "March 3, 2026 (12 days ago)"
+func datedWithRelative(t time.Time, layout string) string {
+	return fmt.Sprintf("%s (%s)", t.Format(layout), timefmt.HumanizeTime(t, time.Now()))
+}
@@ -314,25 +314,30 @@ func (f *FormView) Validate() bool {
 	return true
 }
 
-// GetApplication returns the application from form data
+// GetApplication returns the application from form data. When editing, it
+// starts from the application being edited, so fields the form doesn't
+// show (status history, priority, contacts, ...) are kept.
 func (f *FormView) GetApplication() model.Application {
 	statuses := model.AllStatuses()
 
-	app := model.Application{
-		Company:       strings.TrimSpace(f.inputs[FieldCompany].Value()),
-		Position:      strings.TrimSpace(f.inputs[FieldPosition].Value()),
-		Status:        statuses[f.statusIndex],
-		Location:      strings.TrimSpace(f.inputs[FieldLocation].Value()),
-		Remote:        f.remoteToggle,
-		JobURL:        strings.TrimSpace(f.inputs[FieldJobURL].Value()),
-		ContactName:   strings.TrimSpace(f.inputs[FieldContactName].Value()),
-		ContactEmail:  strings.TrimSpace(f.inputs[FieldContactEmail].Value()),
-		ResumeVersion: strings.TrimSpace(f.inputs[FieldResumeVersion].Value()),
-		CoverLetter:   strings.TrimSpace(f.inputs[FieldCoverLetter].Value()),
-		Notes:         strings.TrimSpace(f.inputs[FieldNotes].Value()),
+	var app model.Application
+	if f.isEdit && f.application != nil {
+		app = *f.application
 	}
+	app.Company = strings.TrimSpace(f.inputs[FieldCompany].Value())
+	app.Position = strings.TrimSpace(f.inputs[FieldPosition].Value())
+	app.Status = statuses[f.statusIndex]
+	app.Location = strings.TrimSpace(f.inputs[FieldLocation].Value())
+	app.Remote = f.remoteToggle
+	app.JobURL = strings.TrimSpace(f.inputs[FieldJobURL].Value())
+	app.ContactName = strings.TrimSpace(f.inputs[FieldContactName].Value())
+	app.ContactEmail = strings.TrimSpace(f.inputs[FieldContactEmail].Value())
+	app.ResumeVersion = strings.TrimSpace(f.inputs[FieldResumeVersion].Value())
+	app.CoverLetter = strings.TrimSpace(f.inputs[FieldCoverLetter].Value())
+	app.Notes = strings.TrimSpace(f.inputs[FieldNotes].Value())
 
 	// Parse date
+	app.DateApplied = nil
 	dateStr := strings.TrimSpace(f.inputs[FieldDateApplied].Value())
 	if dateStr != "" {
 		if t, err := time.Parse("2006-01-02", dateStr); err == nil {
@@ -347,6 +352,7 @@ func (f *FormView) GetApplication() model.Application {
 	}
 
 	// Parse salary
+	app.SalaryMin, app.SalaryMax = 0, 0
 	if s := strings.TrimSpace(f.inputs[FieldSalaryMin].Value()); s != "" {
 		if v, err := strconv.Atoi(s); err == nil {
 			app.SalaryMin = v
@@ -358,12 +364,10 @@ func (f *FormView) GetApplication() model.Application {
 		}
 	}
 
-	// If editing, preserve ID and interviews
+	// An estimate stays an estimate until the salary is edited
 	if f.isEdit && f.application != nil {
-		app.ID = f.application.ID
-		app.Interviews = f.application.Interviews
-		app.NextFollowUp = f.application.NextFollowUp
-		app.CreatedAt = f.application.CreatedAt
+		app.SalaryEstimated = f.application.SalaryEstimated &&
+			app.SalaryMin == f.application.SalaryMin && app.SalaryMax == f.application.SalaryMax
 	}
 
 	return app
@@ -434,7 +438,7 @@ func (f *FormView) renderStatusField() string {
 	}
 
 	statusStyle := StatusBadgeStyle(currentStatus)
-	statusText := statusStyle.Render(model.StatusLabel(currentStatus))
+	statusText := statusStyle.Render(StatusText(currentStatus))
 
 	arrows := ""
 	if focused {
@@ -0,0 +1,73 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+func TestFormView_SetApplicationWithoutDates(t *testing.T) {
+	// A hand-edited or imported entry may have no applied date and
+	// interviews whose dates failed to parse
+	app := &model.Application{
+		ID:         "abc123",
+		Company:    "Acme",
+		Position:   "Engineer",
+		Status:     model.StatusInterview,
+		Interviews: []model.Interview{{Type: "phone"}},
+	}
+
+	form := NewFormView(DefaultKeyMap())
+	form.SetSize(80, 40)
+	form.SetApplication(app)
+	if got := form.inputs[FieldDateApplied].Value(); got != "" {
+		t.Errorf("date applied input = %q, want empty", got)
+	}
+	_ = form.View()
+
+	detail := NewDetailView(app, DefaultKeyMap())
+	detail.SetSize(80, 40)
+	if view := detail.View(); !strings.Contains(view, "Date unknown") {
+		t.Errorf("detail view should mark the interview date unknown:\n%s", view)
+	}
+}
+
+func TestFormView_GetApplicationKeepsUneditedFields(t *testing.T) {
+	applied := time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local)
+	deadline := time.Date(2026, 4, 1, 0, 0, 0, 0, time.Local)
+	app := &model.Application{
+		ID:                 "abc123",
+		Company:            "Acme",
+		Position:           "Engineer",
+		Status:             model.StatusApplied,
+		DateApplied:        &applied,
+		JobType:            "swe",
+		MinYearsExperience: 3,
+		Priority:           4,
+		Deadline:           &deadline,
+		Contacts:           []model.Contact{{Name: "Dana", Role: "recruiter"}},
+		PostingPath:        "local/postings/acme.md",
+		StatusHistory:      []model.StatusChange{{Status: model.StatusApplied, At: applied}},
+	}
+
+	form := NewFormView(DefaultKeyMap())
+	form.SetApplication(app)
+	form.inputs[FieldNotes].SetValue("Referred by Dana")
+
+	got := form.GetApplication()
+	if got.Notes != "Referred by Dana" {
+		t.Errorf("Notes = %q, want the edited value", got.Notes)
+	}
+	if got.ID != app.ID || got.Priority != 4 || got.JobType != "swe" || got.MinYearsExperience != 3 ||
+		got.PostingPath != app.PostingPath || got.Deadline == nil || !got.Deadline.Equal(deadline) {
+		t.Errorf("GetApplication() dropped unedited fields: %+v", got)
+	}
+	if len(got.Contacts) != 1 || got.Contacts[0].Name != "Dana" {
+		t.Errorf("Contacts = %+v, want the original contact", got.Contacts)
+	}
+	if len(got.StatusHistory) != 1 || got.StatusHistory[0].Status != model.StatusApplied {
+		t.Errorf("StatusHistory = %+v, want the original history", got.StatusHistory)
+	}
+}
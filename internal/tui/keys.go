@@ -15,11 +15,13 @@ type KeyMap struct {
 	Bottom key.Binding
 
 	// Actions
-	Add    key.Binding
-	Edit   key.Binding
-	Delete key.Binding
-	Enter  key.Binding
-	Back   key.Binding
+	Add      key.Binding
+	QuickAdd key.Binding
+	Edit     key.Binding
+	Delete   key.Binding
+	Enter    key.Binding
+	Back     key.Binding
+	Select   key.Binding
 
 	// Status shortcuts
 	Status1 key.Binding
@@ -31,6 +33,13 @@ type KeyMap struct {
 	Status7 key.Binding
 	Status8 key.Binding
 
+	// Status cycling
+	StatusNext key.Binding
+	StatusPrev key.Binding
+
+	// Priority cycling
+	Priority key.Binding
+
 	// Search and filter
 	Search key.Binding
 	Filter key.Binding
@@ -41,9 +50,10 @@ type KeyMap struct {
 	CopyContext key.Binding
 
 	// General
-	Help key.Binding
-	Quit key.Binding
-	Tab  key.Binding
+	Palette key.Binding
+	Help    key.Binding
+	Quit    key.Binding
+	Tab     key.Binding
 
 	// Form specific
 	Submit key.Binding
@@ -84,6 +94,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("a"),
 			key.WithHelp("a", "add"),
 		),
+		QuickAdd: key.NewBinding(
+			key.WithKeys("A"),
+			key.WithHelp("A", "quick add"),
+		),
 		Edit: key.NewBinding(
 			key.WithKeys("e"),
 			key.WithHelp("e", "edit"),
@@ -100,6 +114,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("esc", "backspace"),
 			key.WithHelp("esc", "back"),
 		),
+		Select: key.NewBinding(
+			key.WithKeys(" "),
+			key.WithHelp("space", "select"),
+		),
 
 		// Status shortcuts (1-8 for quick status change)
 		Status1: key.NewBinding(
@@ -135,6 +153,22 @@ func DefaultKeyMap() KeyMap {
 			key.WithHelp("8", "withdrawn"),
 		),
 
+		// Status cycling (wraps around at either end)
+		StatusNext: key.NewBinding(
+			key.WithKeys("]"),
+			key.WithHelp("]", "next status"),
+		),
+		StatusPrev: key.NewBinding(
+			key.WithKeys("["),
+			key.WithHelp("[", "prev status"),
+		),
+
+		// Priority cycling (1-5 stars, then back to unset)
+		Priority: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "priority"),
+		),
+
 		// Search and filter
 		Search: key.NewBinding(
 			key.WithKeys("/"),
@@ -160,6 +194,10 @@ func DefaultKeyMap() KeyMap {
 		),
 
 		// General
+		Palette: key.NewBinding(
+			key.WithKeys(":", "ctrl+k"),
+			key.WithHelp(":", "commands"),
+		),
 		Help: key.NewBinding(
 			key.WithKeys("?"),
 			key.WithHelp("?", "help"),
@@ -194,8 +232,8 @@ func (k KeyMap) ShortHelp() []key.Binding {
 func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.Top, k.Bottom},
-		{k.Add, k.Edit, k.Delete, k.Enter},
+		{k.Add, k.QuickAdd, k.Edit, k.Delete, k.Enter, k.Priority, k.Select},
 		{k.Search, k.Filter, k.Clear, k.Fetch},
-		{k.Help, k.Quit},
+		{k.Palette, k.Help, k.Quit},
 	}
 }
@@ -26,8 +26,17 @@ type ListView struct {
 	searchQuery  string
 	filterStatus string
 
+	// Summary line shown under the title (e.g. "3 active · 1 this week")
+	summary string
+
+	// Rows paying below this salary are dimmed; zero disables
+	salaryFloor int
+
 	// Help
 	showHelp bool
+
+	// selected holds the IDs marked for bulk actions
+	selected map[string]bool
 }
 
 // NewListView creates a new list view
@@ -52,6 +61,16 @@ func (l *ListView) SetApplications(apps []model.Application) {
 	}
 }
 
+// SetSummary sets the metrics line shown under the title
+func (l *ListView) SetSummary(summary string) {
+	l.summary = summary
+}
+
+// SetSalaryFloor sets the minimum acceptable salary used to dim rows
+func (l *ListView) SetSalaryFloor(floor int) {
+	l.salaryFloor = floor
+}
+
 // SetSize sets the view dimensions
 func (l *ListView) SetSize(width, height int) {
 	l.width = width
@@ -66,6 +85,67 @@ func (l *ListView) SelectedApplication() *model.Application {
 	return &l.applications[l.cursor]
 }
 
+// SelectApplication moves the cursor to the application with the given ID,
+// leaving it unchanged if the application is not in the list
+func (l *ListView) SelectApplication(id string) {
+	for i, app := range l.applications {
+		if app.ID == id {
+			l.cursor = i
+			return
+		}
+	}
+}
+
+// ToggleSelection marks or unmarks the application under the cursor for
+// bulk actions
+func (l *ListView) ToggleSelection() {
+	app := l.SelectedApplication()
+	if app == nil {
+		return
+	}
+	if l.selected == nil {
+		l.selected = make(map[string]bool)
+	}
+	if l.selected[app.ID] {
+		delete(l.selected, app.ID)
+	} else {
+		l.selected[app.ID] = true
+	}
+}
+
+// ClearSelection unmarks every application
+func (l *ListView) ClearSelection() {
+	l.selected = nil
+}
+
+// IsSelected reports whether an application is marked for bulk actions
+func (l *ListView) IsSelected(id string) bool {
+	return l.selected[id]
+}
+
+// SelectionCount returns how many applications are marked
+func (l *ListView) SelectionCount() int {
+	return len(l.selected)
+}
+
+// TargetApplications returns the applications an action applies to: the
+// marked ones still in the list, in list order, or else the one under the
+// cursor
+func (l *ListView) TargetApplications() []model.Application {
+	var targets []model.Application
+	for _, app := range l.applications {
+		if l.selected[app.ID] {
+			targets = append(targets, app)
+		}
+	}
+	if len(targets) == 0 {
+		if app := l.SelectedApplication(); app != nil {
+			targets = append(targets, *app)
+		}
+	}
+	return targets
+}
+
 // HandleKey processes a key press and returns true if handled
 func (l *ListView) HandleKey(msg tea.KeyMsg) (handled bool, action string) {
 	// If in search mode, handle search input
@@ -99,8 +179,7 @@ func (l *ListView) HandleKey(msg tea.KeyMsg) (handled bool, action string) {
 		l.cursor = max(0, len(l.applications)-1)
 		return true, ""
 	case key.Matches(msg, l.keys.Search):
-		l.searchMode = true
-		l.searchInput.Focus()
+		l.StartSearch()
 		return true, ""
 	case key.Matches(msg, l.keys.Clear):
 		l.searchQuery = ""
@@ -109,14 +188,23 @@ func (l *ListView) HandleKey(msg tea.KeyMsg) (handled bool, action string) {
 	case key.Matches(msg, l.keys.Help):
 		l.showHelp = !l.showHelp
 		return true, ""
+	case key.Matches(msg, l.keys.Select):
+		l.ToggleSelection()
+		return true, ""
 	case key.Matches(msg, l.keys.Back):
 		if l.showHelp {
 			l.showHelp = false
 			return true, ""
 		}
+		if len(l.selected) > 0 {
+			l.ClearSelection()
+			return true, ""
+		}
 		return false, ""
 	case key.Matches(msg, l.keys.Add):
 		return true, "add"
+	case key.Matches(msg, l.keys.QuickAdd):
+		return true, "quick-add"
 	case key.Matches(msg, l.keys.Edit):
 		return true, "edit"
 	case key.Matches(msg, l.keys.Delete):
@@ -143,6 +231,12 @@ func (l *ListView) HandleKey(msg tea.KeyMsg) (handled bool, action string) {
 		return true, "status:rejected"
 	case key.Matches(msg, l.keys.Status8):
 		return true, "status:withdrawn"
+	case key.Matches(msg, l.keys.StatusNext):
+		return true, "status-next"
+	case key.Matches(msg, l.keys.StatusPrev):
+		return true, "status-prev"
+	case key.Matches(msg, l.keys.Priority):
+		return true, "priority-next"
 	case key.Matches(msg, l.keys.Quit):
 		return true, "quit"
 	}
@@ -150,6 +244,12 @@ func (l *ListView) HandleKey(msg tea.KeyMsg) (handled bool, action string) {
 	return false, ""
 }
 
+// StartSearch switches the list into search input mode
+func (l *ListView) StartSearch() {
+	l.searchMode = true
+	l.searchInput.Focus()
+}
+
 // UpdateSearchInput updates the search input
 func (l *ListView) UpdateSearchInput(msg textinput.Model) {
 	l.searchInput = msg
@@ -214,6 +314,10 @@ func (l *ListView) View() string {
    ╚═════╝ ╚═╝  ╚═╝ ╚═════╝ ╚══════╝   ╚═╝   ╚══════╝╚═════╝ `
 	b.WriteString(SubtleStyle.Render(ghost))
 	b.WriteString("\n\n")
+	if l.summary != "" {
+		b.WriteString(SubtleStyle.Render(l.summary))
+		b.WriteString("\n\n")
+	}
 
 	// Search input if in search mode
 	if l.searchMode {
@@ -250,6 +354,9 @@ func (l *ListView) View() string {
 
 		// Calculate visible rows
 		listHeight := l.height - 12 // Account for header, footer, spacing, etc.
+		if l.summary != "" {
+			listHeight -= 2
+		}
 		if listHeight < 3 {
 			listHeight = 3
 		}
@@ -281,6 +388,12 @@ func (l *ListView) View() string {
 
 	// Short help bar at the bottom
 	b.WriteString("\n\n")
+	if n := len(l.selected); n > 0 {
+		b.WriteString(HighlightStyle.Render(fmt.Sprintf("%d selected", n)))
+		b.WriteString(" ")
+		b.WriteString(SubtleStyle.Render("(space to toggle, esc to clear)"))
+		b.WriteString("\n")
+	}
 	b.WriteString(l.renderShortHelp())
 
 	content := b.String()
@@ -315,11 +428,12 @@ func (l *ListView) renderHeader() string {
 	status := truncate("STATUS", statusW)
 	date := truncate("DATE SENT", dateW)
 
-	header := fmt.Sprintf("%-*s %-*s %-*s %-*s",
+	header := fmt.Sprintf("%-*s %-*s %-*s %-*s %s",
 		companyW, company,
 		positionW, position,
 		statusW+10, status, // Match row padding for ANSI codes
 		dateW, date,
+		"PRIORITY",
 	)
 
 	return HeaderStyle.Render(header)
@@ -343,20 +457,30 @@ func (l *ListView) renderRow(index int, selected bool) string {
 
 	// Status with color
 	statusStyle := StatusBadgeStyle(app.Status)
-	statusText := statusStyle.Render(status)
+	statusText := statusStyle.Render(withStatusSymbol(app.Status, status))
 
-	row := fmt.Sprintf("%-*s %-*s %s %-*s",
+	row := fmt.Sprintf("%-*s %-*s %s %-*s %s",
 		companyW, company,
 		positionW, position,
 		padRight(statusText, statusW+10), // Extra padding for ANSI codes
 		dateW, date,
+		model.PriorityStars(app.Priority),
 	)
 
+	// Marked rows get a checkmark after the cursor column
+	mark := "  "
+	if l.selected[app.ID] {
+		mark = "✓ "
+	}
+
 	if selected {
 		// Highlight the entire row
-		return SelectedRowStyle.Render("> " + row)
+		return SelectedRowStyle.Render(">" + mark + row)
 	}
-	return NormalRowStyle.Render("  " + row)
+	if app.BelowSalaryFloor(l.salaryFloor) {
+		return DimmedRowStyle.Render(" " + mark + row)
+	}
+	return NormalRowStyle.Render(" " + mark + row)
 }
 
 func (l *ListView) renderShortHelp() string {
@@ -414,6 +538,13 @@ func (l *ListView) renderHelpDialog() string {
 		HelpKeyStyle.Render("8") + " " + HelpDescStyle.Render("withdrawn"),
 	}
 	b.WriteString(strings.Join(statusKeys2, "  "))
+	b.WriteString("\n  ")
+	statusKeys3 := []string{
+		HelpKeyStyle.Render("]") + " " + HelpDescStyle.Render("next status"),
+		HelpKeyStyle.Render("[") + " " + HelpDescStyle.Render("prev status"),
+		HelpKeyStyle.Render("p") + " " + HelpDescStyle.Render("cycle priority"),
+	}
+	b.WriteString(strings.Join(statusKeys3, "  "))
 	b.WriteString("\n")
 
 	// CLI commands section
@@ -442,6 +573,26 @@ func (l *ListView) renderHelpDialog() string {
 }
 
 // Helper functions
+
+// cycleStatus returns the status step positions away from current in
+// model.AllStatuses() order, wrapping around at either end.
+// Unknown statuses start from the beginning of the pipeline.
+func cycleStatus(current string, step int) string {
+	statuses := model.AllStatuses()
+	idx := -1
+	for i, s := range statuses {
+		if s == current {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		return statuses[0]
+	}
+	n := len(statuses)
+	return statuses[((idx+step)%n+n)%n]
+}
+
 func truncate(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
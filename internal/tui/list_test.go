@@ -0,0 +1,429 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/celloopa/ghosted/internal/model"
+	"github.com/celloopa/ghosted/internal/store"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func runeKey(r rune) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+}
+
+func TestCycleStatus(t *testing.T) {
+	tests := []struct {
+		current string
+		step    int
+		want    string
+	}{
+		{model.StatusSaved, 1, model.StatusApplied},
+		{model.StatusApplied, 1, model.StatusScreening},
+		{model.StatusWithdrawn, 1, model.StatusSaved}, // wraps forward
+		{model.StatusApplied, -1, model.StatusSaved},
+		{model.StatusSaved, -1, model.StatusWithdrawn}, // wraps backward
+		{"unknown", 1, model.StatusSaved},
+	}
+
+	for _, tt := range tests {
+		if got := cycleStatus(tt.current, tt.step); got != tt.want {
+			t.Errorf("cycleStatus(%q, %d) = %q, want %q", tt.current, tt.step, got, tt.want)
+		}
+	}
+}
+
+func TestListView_HandleKey_StatusCycle(t *testing.T) {
+	l := NewListView(nil, DefaultKeyMap())
+
+	if handled, action := l.HandleKey(runeKey(']')); !handled || action != "status-next" {
+		t.Errorf("HandleKey(]) = %v, %q; want true, %q", handled, action, "status-next")
+	}
+	if handled, action := l.HandleKey(runeKey('[')); !handled || action != "status-prev" {
+		t.Errorf("HandleKey([) = %v, %q; want true, %q", handled, action, "status-prev")
+	}
+}
+
+func TestApp_HandleListKey_CyclesSelectedStatus(t *testing.T) {
+	s, err := store.New(filepath.Join(t.TempDir(), "applications.json"))
+	if err != nil {
+		t.Fatalf("store.New() error = %v", err)
+	}
+	for _, app := range s.List() {
+		if err := s.Delete(app.ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+	}
+	created, err := s.Add(model.Application{Company: "Acme", Position: "Engineer", Status: model.StatusOffer})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if _, err := s.Add(model.Application{Company: "Other", Position: "Designer", Status: model.StatusInterview}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	app := New(s)
+	app.viewState = ViewList
+
+	statusOf := func() string {
+		got, err := s.GetByID(created.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		return got.Status
+	}
+
+	// Offer sorts first, so it is selected; advance through the pipeline
+	for _, want := range []string{model.StatusAccepted, model.StatusRejected, model.StatusWithdrawn, model.StatusSaved} {
+		m, _ := app.handleListKey(runeKey(']'))
+		app = m.(App)
+		if got := statusOf(); got != want {
+			t.Fatalf("after ] status = %q, want %q", got, want)
+		}
+		if sel := app.listView.SelectedApplication(); sel == nil || sel.ID != created.ID {
+			t.Fatalf("cursor should stay on the cycled application, got %+v", sel)
+		}
+	}
+
+	// And back again, wrapping from saved to withdrawn
+	m, _ := app.handleListKey(runeKey('['))
+	app = m.(App)
+	if got := statusOf(); got != model.StatusWithdrawn {
+		t.Errorf("after [ status = %q, want %q", got, model.StatusWithdrawn)
+	}
+	if app.statusMsg != "Changed status to Withdrawn" {
+		t.Errorf("statusMsg = %q, want feedback for the new status", app.statusMsg)
+	}
+}
+
+func TestApp_UpdateRefreshesSummary(t *testing.T) {
+	s, err := store.New(filepath.Join(t.TempDir(), "applications.json"))
+	if err != nil {
+		t.Fatalf("store.New() error = %v", err)
+	}
+	for _, app := range s.List() {
+		if err := s.Delete(app.ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+	}
+	if _, err := s.Add(model.Application{Company: "Acme", Position: "Engineer", Status: model.StatusInterview}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	app := New(s)
+	app.viewState = ViewList
+	if !strings.HasSuffix(app.listView.summary, "1 interviewing") {
+		t.Fatalf("summary = %q, want it set when the app is created", app.listView.summary)
+	}
+
+	// Interview advances to offer, which no longer counts as interviewing
+	m, _ := app.Update(runeKey(']'))
+	app = m.(App)
+	if !strings.HasSuffix(app.listView.summary, "0 interviewing") {
+		t.Errorf("summary = %q, want it refreshed after the status change", app.listView.summary)
+	}
+}
+
+func TestApp_HandleListKey_CycleReportsSaveFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "applications.json")
+	s, err := store.New(path)
+	if err != nil {
+		t.Fatalf("store.New() error = %v", err)
+	}
+	created, err := s.Add(model.Application{Company: "Acme", Position: "Engineer", Status: model.StatusApplied})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	app := New(s)
+	app.viewState = ViewList
+	app.listView.SelectApplication(created.ID)
+
+	// A directory where the data file was makes the save fail
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(path, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	m, _ := app.handleListKey(runeKey(']'))
+	app = m.(App)
+	if !strings.HasPrefix(app.statusMsg, "Could not change status:") {
+		t.Errorf("statusMsg = %q, want the save error", app.statusMsg)
+	}
+}
+
+func TestListView_ToggleSelection(t *testing.T) {
+	apps := []model.Application{{ID: "a", Company: "Acme"}, {ID: "b", Company: "Globex"}, {ID: "c", Company: "Initech"}}
+	l := NewListView(apps, DefaultKeyMap())
+	space := tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+
+	// With nothing marked, actions target the cursor row
+	if got := l.TargetApplications(); len(got) != 1 || got[0].ID != "a" {
+		t.Fatalf("TargetApplications() = %+v, want the cursor row", got)
+	}
+
+	if handled, _ := l.HandleKey(space); !handled {
+		t.Fatal("HandleKey(space) not handled")
+	}
+	l.MoveDown()
+	l.MoveDown()
+	l.HandleKey(space)
+	if !l.IsSelected("a") || l.IsSelected("b") || !l.IsSelected("c") || l.SelectionCount() != 2 {
+		t.Fatalf("selection = %v, want a and c", l.selected)
+	}
+	if row := l.renderRow(0, false); !strings.Contains(row, "✓") {
+		t.Errorf("renderRow() = %q, want a checkmark on a marked row", row)
+	}
+
+	// Toggling again unmarks
+	l.HandleKey(space)
+	if l.IsSelected("c") {
+		t.Error("second space should unmark c")
+	}
+
+	// Esc clears the selection before doing anything else
+	if handled, _ := l.HandleKey(tea.KeyMsg{Type: tea.KeyEsc}); !handled || l.SelectionCount() != 0 {
+		t.Errorf("esc: handled = %v, selection = %v; want the selection cleared", handled, l.selected)
+	}
+}
+
+func TestApp_BulkStatusUpdatesSelection(t *testing.T) {
+	s, err := store.New(filepath.Join(t.TempDir(), "applications.json"))
+	if err != nil {
+		t.Fatalf("store.New() error = %v", err)
+	}
+	var ids []string
+	for _, company := range []string{"Acme", "Globex", "Initech"} {
+		created, err := s.Add(model.Application{Company: company, Position: "Engineer", Status: model.StatusApplied})
+		if err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+		ids = append(ids, created.ID)
+	}
+
+	app := New(s)
+	app.viewState = ViewList
+	app.listView.SelectApplication(ids[0])
+	app.listView.ToggleSelection()
+	app.listView.SelectApplication(ids[2])
+	app.listView.ToggleSelection()
+
+	m, _ := app.handleListKey(runeKey('7'))
+	app = m.(App)
+
+	want := map[string]string{ids[0]: model.StatusRejected, ids[1]: model.StatusApplied, ids[2]: model.StatusRejected}
+	for id, status := range want {
+		got, err := s.GetByID(id)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.Status != status {
+			t.Errorf("%s status = %q, want %q", got.Company, got.Status, status)
+		}
+	}
+	if app.statusMsg != "Changed 2 applications to Rejected" {
+		t.Errorf("statusMsg = %q, want bulk feedback", app.statusMsg)
+	}
+}
+
+func TestApp_BulkStatusReportsSaveFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "applications.json")
+	s, err := store.New(path)
+	if err != nil {
+		t.Fatalf("store.New() error = %v", err)
+	}
+	var ids []string
+	for _, company := range []string{"Acme", "Globex"} {
+		created, err := s.Add(model.Application{Company: company, Position: "Engineer", Status: model.StatusApplied})
+		if err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+		ids = append(ids, created.ID)
+	}
+
+	app := New(s)
+	app.viewState = ViewList
+	for _, id := range ids {
+		app.listView.SelectApplication(id)
+		app.listView.ToggleSelection()
+	}
+
+	// A directory where the data file was makes the save fail
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(path, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	m, _ := app.handleListKey(runeKey('7'))
+	app = m.(App)
+	if !strings.HasPrefix(app.statusMsg, "Could not change status:") {
+		t.Errorf("statusMsg = %q, want the save error", app.statusMsg)
+	}
+	for _, id := range ids {
+		if got, _ := s.GetByID(id); got.Status != model.StatusApplied {
+			t.Errorf("%s status = %q, want it unchanged after the failed save", got.Company, got.Status)
+		}
+	}
+}
+
+func TestApp_BulkDeleteAsksOnce(t *testing.T) {
+	s, err := store.New(filepath.Join(t.TempDir(), "applications.json"))
+	if err != nil {
+		t.Fatalf("store.New() error = %v", err)
+	}
+	var ids []string
+	for _, company := range []string{"Acme", "Globex", "Initech"} {
+		created, err := s.Add(model.Application{Company: company, Position: "Engineer"})
+		if err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+		ids = append(ids, created.ID)
+	}
+
+	app := New(s)
+	app.viewState = ViewList
+	for _, id := range ids[:2] {
+		app.listView.SelectApplication(id)
+		app.listView.ToggleSelection()
+	}
+
+	m, _ := app.handleListKey(runeKey('d'))
+	app = m.(App)
+	if app.viewState != ViewConfirmDelete || len(app.deleteTargets) != 2 {
+		t.Fatalf("viewState = %v, targets = %d; want confirmation for 2", app.viewState, len(app.deleteTargets))
+	}
+
+	m, _ = app.handleDeleteConfirmKey(runeKey('y'))
+	app = m.(App)
+	if s.Total() != 1 {
+		t.Errorf("Total() = %d, want 1 left", s.Total())
+	}
+	if _, err := s.GetByID(ids[2]); err != nil {
+		t.Error("unselected application was deleted")
+	}
+	if app.listView.SelectionCount() != 0 {
+		t.Error("selection should be cleared after deleting")
+	}
+}
+
+func TestApp_BulkDeleteReportsSaveFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "applications.json")
+	s, err := store.New(path)
+	if err != nil {
+		t.Fatalf("store.New() error = %v", err)
+	}
+	var ids []string
+	for _, company := range []string{"Acme", "Globex"} {
+		created, err := s.Add(model.Application{Company: company, Position: "Engineer"})
+		if err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+		ids = append(ids, created.ID)
+	}
+
+	app := New(s)
+	app.viewState = ViewList
+	for _, id := range ids {
+		app.listView.SelectApplication(id)
+		app.listView.ToggleSelection()
+	}
+	m, _ := app.handleListKey(runeKey('d'))
+	app = m.(App)
+
+	// A directory where the data file was makes the save fail
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(path, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	m, _ = app.handleDeleteConfirmKey(runeKey('y'))
+	app = m.(App)
+	if !strings.HasPrefix(app.statusMsg, "Could not delete:") {
+		t.Errorf("statusMsg = %q, want the save error", app.statusMsg)
+	}
+	for _, id := range ids {
+		if _, err := s.GetByID(id); err != nil {
+			t.Errorf("%s was deleted despite the failed save", id)
+		}
+	}
+}
+
+func TestApp_DetailStatusReportsSaveFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "applications.json")
+	s, err := store.New(path)
+	if err != nil {
+		t.Fatalf("store.New() error = %v", err)
+	}
+	created, err := s.Add(model.Application{Company: "Acme", Position: "Engineer", Status: model.StatusApplied})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	app := New(s)
+	app.viewState = ViewDetail
+	app.detailView.SetApplication(&created)
+
+	// A directory where the data file was makes the save fail
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(path, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	m, _ := app.runDetailAction("status:" + model.StatusInterview)
+	app = m.(App)
+	if !strings.HasPrefix(app.statusMsg, "Could not change status:") {
+		t.Errorf("statusMsg = %q, want the save error", app.statusMsg)
+	}
+}
+
+func TestApp_PriorityNextKeepsStoredChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "applications.json")
+	s, err := store.New(path)
+	if err != nil {
+		t.Fatalf("store.New() error = %v", err)
+	}
+	created, err := s.Add(model.Application{Company: "Acme", Position: "Engineer", Priority: 1})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	app := New(s)
+	app.viewState = ViewList
+	app.listView.SelectApplication(created.ID)
+
+	// Changed since the list was loaded, e.g. by the CLI
+	changed := created
+	changed.Notes = "Referred by Sam"
+	if err := s.Update(changed); err != nil {
+		t.Fatal(err)
+	}
+
+	m, _ := app.runListAction("priority-next")
+	app = m.(App)
+	got, _ := s.GetByID(created.ID)
+	if got.Priority != 2 || got.Notes != "Referred by Sam" {
+		t.Errorf("priority %d, notes %q; want 2 with the newer notes kept", got.Priority, got.Notes)
+	}
+
+	// A directory where the data file was makes the save fail
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(path, 0755); err != nil {
+		t.Fatal(err)
+	}
+	m, _ = app.runListAction("priority-next")
+	app = m.(App)
+	if !strings.HasPrefix(app.statusMsg, "Could not change priority:") {
+		t.Errorf("statusMsg = %q, want the save error", app.statusMsg)
+	}
+}
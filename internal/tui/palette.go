@@ -0,0 +1,210 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/celloopa/ghosted/internal/model"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// PaletteAction is an action listed in the command palette. Action is the
+// same string the view's key handler returns for the keybinding.
+type PaletteAction struct {
+	Action string
+	Label  string
+	Key    string
+}
+
+// PaletteView is an overlay listing the current view's actions with a
+// fuzzy filter, so they can be found without knowing the keybindings
+type PaletteView struct {
+	keys     KeyMap
+	input    textinput.Model
+	actions  []PaletteAction
+	filtered []PaletteAction
+	cursor   int
+	width    int
+}
+
+// NewPaletteView creates a new command palette
+func NewPaletteView(keys KeyMap) PaletteView {
+	input := textinput.New()
+	input.Placeholder = "Type to filter actions"
+	input.CharLimit = 50
+	input.Width = 40
+
+	return PaletteView{
+		keys:  keys,
+		input: input,
+	}
+}
+
+// Open resets the palette to list actions
+func (p *PaletteView) Open(actions []PaletteAction) {
+	p.actions = actions
+	p.input.SetValue("")
+	p.input.Focus()
+	p.Filter("")
+}
+
+// SetSize sets the view width
+func (p *PaletteView) SetSize(width int) {
+	p.width = width
+	p.input.Width = min(40, max(10, width-10))
+}
+
+// Filter narrows the list to actions whose label or key fuzzily matches
+// query, keeping their original order
+func (p *PaletteView) Filter(query string) []PaletteAction {
+	p.filtered = p.filtered[:0]
+	for _, action := range p.actions {
+		if fuzzyMatch(query, action.Label) || fuzzyMatch(query, action.Key) {
+			p.filtered = append(p.filtered, action)
+		}
+	}
+	p.cursor = 0
+	return p.filtered
+}
+
+// fuzzyMatch reports whether the characters of query appear in s in order,
+// ignoring case and spaces
+func fuzzyMatch(query, s string) bool {
+	s = strings.ToLower(s)
+	for _, r := range strings.ToLower(query) {
+		if r == ' ' {
+			continue
+		}
+		i := strings.IndexRune(s, r)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(string(r)):]
+	}
+	return true
+}
+
+// HandleKey handles navigation, selection, and typing. Selecting an action
+// returns its action string; closing the palette returns "cancel".
+func (p *PaletteView) HandleKey(msg tea.KeyMsg) (handled bool, action string) {
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		p.input.Blur()
+		return true, "cancel"
+	case "enter":
+		if len(p.filtered) == 0 {
+			return true, ""
+		}
+		p.input.Blur()
+		return true, p.filtered[p.cursor].Action
+	case "up", "ctrl+p":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+		return true, ""
+	case "down", "ctrl+n":
+		if p.cursor < len(p.filtered)-1 {
+			p.cursor++
+		}
+		return true, ""
+	}
+
+	before := p.input.Value()
+	p.input, _ = p.input.Update(msg)
+	if p.input.Value() != before {
+		p.Filter(p.input.Value())
+	}
+	return true, ""
+}
+
+// View renders the palette
+func (p *PaletteView) View() string {
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render("Commands"))
+	b.WriteString("\n\n")
+	b.WriteString(p.input.View())
+	b.WriteString("\n\n")
+
+	if len(p.filtered) == 0 {
+		b.WriteString(SubtleStyle.Render("  No matching actions"))
+		b.WriteString("\n")
+	}
+	for i, action := range p.filtered {
+		cursor := "  "
+		style := NormalRowStyle
+		if i == p.cursor {
+			cursor = "> "
+			style = SelectedRowStyle
+		}
+		b.WriteString(style.Render(fmt.Sprintf("%s%-24s", cursor, action.Label)))
+		b.WriteString(" ")
+		b.WriteString(HelpKeyStyle.Render(action.Key))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("%s %s  %s %s  %s %s",
+		HelpKeyStyle.Render("up/down"),
+		HelpDescStyle.Render("move"),
+		HelpKeyStyle.Render("enter"),
+		HelpDescStyle.Render("run"),
+		HelpKeyStyle.Render("esc"),
+		HelpDescStyle.Render("cancel"),
+	))
+
+	return b.String()
+}
+
+// paletteAction builds a palette entry from a keybinding's help text
+func paletteAction(action, label string, binding key.Binding) PaletteAction {
+	return PaletteAction{Action: action, Label: label, Key: binding.Help().Key}
+}
+
+// statusPaletteActions lists the status shortcuts, shared by the list and
+// detail views
+func statusPaletteActions(k KeyMap) []PaletteAction {
+	bindings := []key.Binding{k.Status1, k.Status2, k.Status3, k.Status4, k.Status5, k.Status6, k.Status7, k.Status8}
+	var actions []PaletteAction
+	for i, status := range model.AllStatuses() {
+		if i >= len(bindings) {
+			break
+		}
+		actions = append(actions, paletteAction("status:"+status, "Set status: "+model.StatusLabel(status), bindings[i]))
+	}
+	return actions
+}
+
+// listPaletteActions lists the actions available from the application list
+func listPaletteActions(k KeyMap) []PaletteAction {
+	actions := []PaletteAction{
+		paletteAction("view", "View application", k.Enter),
+		paletteAction("add", "Add application", k.Add),
+		paletteAction("quick-add", "Quick add (Company @ Position)", k.QuickAdd),
+		paletteAction("edit", "Edit application", k.Edit),
+		paletteAction("delete", "Delete application", k.Delete),
+		paletteAction("search-start", "Search", k.Search),
+		paletteAction("filter", "Filter by status", k.Filter),
+		paletteAction("clear", "Clear filter", k.Clear),
+		paletteAction("fetch", "Fetch posting or CV", k.Fetch),
+		paletteAction("status-next", "Next status", k.StatusNext),
+		paletteAction("status-prev", "Previous status", k.StatusPrev),
+		paletteAction("priority-next", "Cycle priority", k.Priority),
+	}
+	actions = append(actions, statusPaletteActions(k)...)
+	return append(actions, paletteAction("quit", "Quit", k.Quit))
+}
+
+// detailPaletteActions lists the actions available from the detail view
+func detailPaletteActions(k KeyMap) []PaletteAction {
+	actions := []PaletteAction{
+		paletteAction("edit", "Edit application", k.Edit),
+		paletteAction("delete", "Delete application", k.Delete),
+		paletteAction("back", "Back to list", k.Back),
+	}
+	actions = append(actions, statusPaletteActions(k)...)
+	return append(actions, paletteAction("quit", "Quit", k.Quit))
+}
@@ -0,0 +1,105 @@
+package tui
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/celloopa/ghosted/internal/store"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// typeQuery sends each character of q to the palette
+func typeQuery(p *PaletteView, q string) {
+	for _, r := range q {
+		p.HandleKey(runeKey(r))
+	}
+}
+
+func TestPaletteView_TypingNarrowsActions(t *testing.T) {
+	keys := DefaultKeyMap()
+	p := NewPaletteView(keys)
+	p.Open(listPaletteActions(keys))
+	all := len(p.filtered)
+	if all != len(listPaletteActions(keys)) {
+		t.Fatalf("empty query lists %d actions, want all %d", all, len(listPaletteActions(keys)))
+	}
+
+	typeQuery(&p, "st")
+	narrowed := len(p.filtered)
+	if narrowed == 0 || narrowed >= all {
+		t.Fatalf("query %q lists %d of %d actions, want fewer but some", "st", narrowed, all)
+	}
+
+	typeQuery(&p, "at int")
+	if len(p.filtered) != 1 || p.filtered[0].Action != "status:interview" {
+		t.Errorf("query %q = %+v, want only the interview status action", "stat int", p.filtered)
+	}
+
+	typeQuery(&p, "zz")
+	if len(p.filtered) != 0 {
+		t.Errorf("query with no match lists %+v", p.filtered)
+	}
+}
+
+func TestPaletteView_SelectReturnsAction(t *testing.T) {
+	keys := DefaultKeyMap()
+	p := NewPaletteView(keys)
+	p.Open(listPaletteActions(keys))
+
+	typeQuery(&p, "fetch")
+	if handled, action := p.HandleKey(tea.KeyMsg{Type: tea.KeyEnter}); !handled || action != "fetch" {
+		t.Errorf("enter = %v, %q; want true, %q", handled, action, "fetch")
+	}
+
+	// Moving the cursor selects a later match
+	p.Open(listPaletteActions(keys))
+	typeQuery(&p, "status")
+	p.HandleKey(tea.KeyMsg{Type: tea.KeyDown})
+	if _, action := p.HandleKey(tea.KeyMsg{Type: tea.KeyEnter}); action != p.filtered[1].Action {
+		t.Errorf("enter after down = %q, want %q", action, p.filtered[1].Action)
+	}
+
+	if _, action := p.HandleKey(tea.KeyMsg{Type: tea.KeyEsc}); action != "cancel" {
+		t.Errorf("esc = %q, want cancel", action)
+	}
+}
+
+func TestPaletteView_MatchesKey(t *testing.T) {
+	keys := DefaultKeyMap()
+	p := NewPaletteView(keys)
+	p.Open(detailPaletteActions(keys))
+
+	got := p.Filter("e")
+	found := false
+	for _, action := range got {
+		if action.Action == "edit" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Filter(%q) = %+v, want the edit action matched by its key", "e", got)
+	}
+}
+
+func TestApp_PaletteDispatchesToView(t *testing.T) {
+	s, err := store.New(filepath.Join(t.TempDir(), "applications.json"))
+	if err != nil {
+		t.Fatalf("store.New() error = %v", err)
+	}
+	app := New(s)
+	app.viewState = ViewList
+
+	m, _ := app.handleListKey(runeKey(':'))
+	app = m.(App)
+	if app.viewState != ViewPalette {
+		t.Fatalf("viewState = %v, want the palette", app.viewState)
+	}
+
+	typeQuery(&app.palette, "add app")
+	m, _ = app.handlePaletteKey(tea.KeyMsg{Type: tea.KeyEnter})
+	app = m.(App)
+	if app.viewState != ViewForm || app.prevState != ViewList {
+		t.Errorf("after selecting add: viewState = %v, prevState = %v; want the form over the list", app.viewState, app.prevState)
+	}
+}
@@ -0,0 +1,120 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/celloopa/ghosted/internal/model"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// QuickAddView captures an application from a single "Company @ Position"
+// line, for saving jobs to triage later
+type QuickAddView struct {
+	keys  KeyMap
+	input textinput.Model
+	err   error
+}
+
+// NewQuickAddView creates a new quick-add view
+func NewQuickAddView(keys KeyMap) QuickAddView {
+	input := textinput.New()
+	input.Placeholder = "Company @ Position"
+	input.CharLimit = 200
+	input.Width = 50
+	input.Focus()
+
+	return QuickAddView{
+		keys:  keys,
+		input: input,
+	}
+}
+
+// Reset clears the input and any validation error
+func (v *QuickAddView) Reset() {
+	v.input.SetValue("")
+	v.input.Focus()
+	v.err = nil
+}
+
+// Input returns the text input model
+func (v *QuickAddView) Input() textinput.Model {
+	return v.input
+}
+
+// UpdateInput updates the text input model
+func (v *QuickAddView) UpdateInput(input textinput.Model) {
+	v.input = input
+}
+
+// HandleKey processes key events
+func (v *QuickAddView) HandleKey(msg tea.KeyMsg) (handled bool, action string) {
+	switch {
+	case key.Matches(msg, v.keys.Cancel):
+		return true, "cancel"
+	case key.Matches(msg, v.keys.Enter):
+		return true, "submit"
+	}
+	return false, ""
+}
+
+// Application parses the input into a saved application, recording a
+// validation error to show when it's incomplete
+func (v *QuickAddView) Application() (model.Application, bool) {
+	company, position, err := parseQuickAdd(v.input.Value())
+	if err != nil {
+		v.err = err
+		return model.Application{}, false
+	}
+	v.err = nil
+	return model.Application{Company: company, Position: position, Status: model.StatusSaved}, true
+}
+
+// parseQuickAdd splits "Company @ Position" on the first @, requiring both
+// parts
+func parseQuickAdd(line string) (company, position string, err error) {
+	company, position, found := strings.Cut(line, "@")
+	if !found {
+		return "", "", errors.New("use the form Company @ Position")
+	}
+	company = strings.TrimSpace(company)
+	position = strings.TrimSpace(position)
+	switch {
+	case company == "":
+		return "", "", errors.New("company is required before the @")
+	case position == "":
+		return "", "", errors.New("position is required after the @")
+	}
+	return company, position, nil
+}
+
+// View renders the quick-add view
+func (v QuickAddView) View() string {
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render("Quick Add"))
+	b.WriteString("\n\n")
+	b.WriteString(SubtleStyle.Render("Saved with status saved; edit it later for the details"))
+	b.WriteString("\n\n")
+	b.WriteString(v.input.View())
+	b.WriteString("\n")
+
+	if v.err != nil {
+		b.WriteString(ErrorStyle.Render(v.err.Error()))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("%s %s  %s %s",
+		HelpKeyStyle.Render("enter"),
+		HelpDescStyle.Render("save"),
+		HelpKeyStyle.Render("esc"),
+		HelpDescStyle.Render("cancel"),
+	))
+
+	return b.String()
+}
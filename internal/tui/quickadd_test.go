@@ -0,0 +1,45 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+func TestParseQuickAdd(t *testing.T) {
+	company, position, err := parseQuickAdd("  Acme @ Engineer ")
+	if err != nil {
+		t.Fatalf("parseQuickAdd() error = %v", err)
+	}
+	if company != "Acme" || position != "Engineer" {
+		t.Errorf("parseQuickAdd() = %q, %q; want Acme, Engineer", company, position)
+	}
+
+	// Only the first @ separates, so positions may contain one
+	if _, position, _ := parseQuickAdd("Acme @ Engineer @ Platform"); position != "Engineer @ Platform" {
+		t.Errorf("position = %q, want %q", position, "Engineer @ Platform")
+	}
+
+	for _, line := range []string{"Acme Engineer", "", " @ Engineer", "Acme @  "} {
+		if _, _, err := parseQuickAdd(line); err == nil {
+			t.Errorf("parseQuickAdd(%q) should return a validation error", line)
+		}
+	}
+}
+
+func TestQuickAddView_ApplicationIsSaved(t *testing.T) {
+	v := NewQuickAddView(DefaultKeyMap())
+	v.input.SetValue("Acme")
+	if _, ok := v.Application(); ok || v.err == nil {
+		t.Fatal("Application() without @ should fail with an error to show")
+	}
+
+	v.input.SetValue("Acme @ Engineer")
+	app, ok := v.Application()
+	if !ok || v.err != nil {
+		t.Fatalf("Application() failed: %v", v.err)
+	}
+	if app.Company != "Acme" || app.Position != "Engineer" || app.Status != model.StatusSaved {
+		t.Errorf("Application() = %+v, want a saved Acme Engineer", app)
+	}
+}
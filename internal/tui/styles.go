@@ -4,6 +4,7 @@ import (
 	"github.com/celloopa/ghosted/internal/model"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
 // Layout constants
@@ -87,6 +88,11 @@ var (
 	NormalRowStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#CCCCCC"))
 
+	// Dimmed row style for applications paying below the salary floor
+	DimmedRowStyle = lipgloss.NewStyle().
+			Foreground(colorMuted).
+			Faint(true)
+
 	// Header style for tables
 	HeaderStyle = lipgloss.NewStyle().
 			Bold(true).
@@ -96,6 +102,34 @@ var (
 			BorderForeground(colorSecondary)
 )
 
+// accessible prefixes status badges with a symbol so status is not
+// conveyed by color alone
+var accessible bool
+
+// SetAccessible toggles symbol prefixes on status badges
+func SetAccessible(on bool) {
+	accessible = on
+}
+
+// DisableColor turns off all color output and enables accessible badges
+func DisableColor() {
+	lipgloss.SetColorProfile(termenv.Ascii)
+	SetAccessible(true)
+}
+
+// StatusText returns the badge text for a status
+func StatusText(status string) string {
+	return withStatusSymbol(status, model.StatusLabel(status))
+}
+
+// withStatusSymbol prefixes label with the status symbol in accessible mode
+func withStatusSymbol(status, label string) string {
+	if !accessible {
+		return label
+	}
+	return model.StatusSymbol(status) + " " + label
+}
+
 // Status badge style
 func StatusBadgeStyle(status string) lipgloss.Style {
 	color := GetStatusColor(status)
@@ -0,0 +1,54 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+func TestStatusText_AccessibleMode(t *testing.T) {
+	SetAccessible(true)
+	defer SetAccessible(false)
+
+	want := map[string]string{
+		model.StatusSaved:     "○ Saved",
+		model.StatusApplied:   "● Applied",
+		model.StatusScreening: "◐ Screening",
+		model.StatusInterview: "◆ Interview",
+		model.StatusOffer:     "★ Offer",
+		model.StatusAccepted:  "✓ Accepted",
+		model.StatusRejected:  "✗ Rejected",
+		model.StatusWithdrawn: "– Withdrawn",
+	}
+
+	seen := make(map[string]string)
+	for _, status := range model.AllStatuses() {
+		got := StatusText(status)
+		if got != want[status] {
+			t.Errorf("StatusText(%q) = %q, want %q", status, got, want[status])
+		}
+		symbol := strings.SplitN(got, " ", 2)[0]
+		if other, dup := seen[symbol]; dup {
+			t.Errorf("symbol %q shared by %q and %q", symbol, other, status)
+		}
+		seen[symbol] = status
+	}
+}
+
+func TestStatusText_DefaultMode(t *testing.T) {
+	SetAccessible(false)
+	if got := StatusText(model.StatusInterview); got != "Interview" {
+		t.Errorf("StatusText() = %q, want plain label", got)
+	}
+}
+
+func TestListView_RendersStatusSymbols(t *testing.T) {
+	SetAccessible(true)
+	defer SetAccessible(false)
+
+	l := NewListView([]model.Application{{ID: "1", Company: "Acme", Position: "SWE", Status: model.StatusRejected}}, DefaultKeyMap())
+	if row := l.renderRow(0, false); !strings.Contains(row, "✗ Rejected") {
+		t.Errorf("renderRow() = %q, want rejected symbol", row)
+	}
+}
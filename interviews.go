@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/model"
+	"github.com/celloopa/ghosted/internal/store"
+)
+
+const interviewUsage = "Usage: ghosted interview add <id> --json '{\"date\":\"2026-05-12 14:00\",\"type\":\"technical\",\"with_whom\":\"...\",\"notes\":\"...\"}'"
+
+// cmdInterview records interviews on an application
+func cmdInterview(s *store.Store, args []string) {
+	if len(args) < 4 || args[0] != "add" || args[2] != "--json" {
+		fmt.Fprintln(os.Stderr, interviewUsage)
+		os.Exit(1)
+	}
+
+	iv, err := parseInterviewJSON(args[3])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	app := findAppByID(s, args[1])
+	if app == nil {
+		fmt.Fprintf(os.Stderr, "Error: application not found: %s\n", args[1])
+		os.Exit(1)
+	}
+	if err := s.AddInterview(app.ID, iv); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Added %s interview on %s to %s @ %s\n", interviewType(iv), iv.Date.Format("2006-01-02"), app.Position, app.Company)
+}
+
+// interviewDateLayouts are the accepted forms of an interview's date, tried
+// in order; the ones without a zone are local time
+var interviewDateLayouts = []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02 15:04", "2006-01-02"}
+
+// parseInterviewJSON decodes an interview from the interview add JSON. The
+// date is required and may be RFC 3339 or a local date with optional time.
+func parseInterviewJSON(data string) (model.Interview, error) {
+	var raw struct {
+		Date     string `json:"date"`
+		Type     string `json:"type"`
+		WithWhom string `json:"with_whom"`
+		Notes    string `json:"notes"`
+	}
+	if err := json.Unmarshal([]byte(data), &raw); err != nil {
+		return model.Interview{}, fmt.Errorf("parsing JSON: %w", err)
+	}
+	if strings.TrimSpace(raw.Date) == "" {
+		return model.Interview{}, errors.New("date is required")
+	}
+
+	iv := model.Interview{
+		Type:     strings.TrimSpace(raw.Type),
+		WithWhom: strings.TrimSpace(raw.WithWhom),
+		Notes:    strings.TrimSpace(raw.Notes),
+	}
+	for _, layout := range interviewDateLayouts {
+		if date, err := time.ParseInLocation(layout, strings.TrimSpace(raw.Date), time.Local); err == nil {
+			iv.Date = date
+			return iv, nil
+		}
+	}
+	return model.Interview{}, fmt.Errorf("date %q should look like 2026-05-12 or 2026-05-12 14:00", raw.Date)
+}
+
+// interviewType is an interview's type, or "interview" when unset
+func interviewType(iv model.Interview) string {
+	if iv.Type == "" {
+		return "interview"
+	}
+	return iv.Type
+}
+
+// printInterviews lists an application's interviews for `get`: date, type,
+// and who it was with, then any notes. Nothing is printed without any.
+func printInterviews(w io.Writer, app model.Application) {
+	if len(app.Interviews) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "Interviews:")
+	for _, iv := range app.Interviews {
+		date := "date unknown"
+		if !iv.Date.IsZero() {
+			date = iv.Date.Format("2006-01-02 15:04")
+		}
+		line := fmt.Sprintf("  %s  %s", date, interviewType(iv))
+		if iv.WithWhom != "" {
+			line += " with " + iv.WithWhom
+		}
+		fmt.Fprintln(w, line)
+		if iv.Notes != "" {
+			fmt.Fprintf(w, "    %s\n", iv.Notes)
+		}
+	}
+}
+
+// dedupeInterviews removes interviews that share a calendar day and type,
+// keeping the entry with the most notes. Order of first appearance is kept.
+// It is for applications assembled outside the tracker, such as imported
+// ones, where the same interview can be recorded more than once.
+func dedupeInterviews(interviews []model.Interview) []model.Interview {
+	if len(interviews) < 2 {
+		return interviews
+	}
+
+	type key struct {
+		day string
+		typ string
+	}
+
+	result := make([]model.Interview, 0, len(interviews))
+	index := make(map[key]int)
+	for _, iv := range interviews {
+		k := key{day: iv.Date.Format("2006-01-02"), typ: iv.Type}
+		if i, ok := index[k]; ok {
+			if len(iv.Notes) > len(result[i].Notes) {
+				result[i] = iv
+			}
+			continue
+		}
+		index[k] = len(result)
+		result = append(result, iv)
+	}
+	return result
+}
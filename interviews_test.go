@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+func TestDedupeInterviews_CollapsesSameDayAndType(t *testing.T) {
+	morning := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	afternoon := time.Date(2026, 3, 10, 15, 30, 0, 0, time.UTC)
+
+	interviews := []model.Interview{
+		{Date: morning, Type: "phone", Notes: "Recruiter"},
+		{Date: afternoon, Type: "phone", Notes: "Recruiter screen, asked about salary expectations"},
+	}
+
+	got := dedupeInterviews(interviews)
+	if len(got) != 1 {
+		t.Fatalf("dedupeInterviews() returned %d interviews, want 1", len(got))
+	}
+	if got[0].Notes != interviews[1].Notes {
+		t.Errorf("kept notes %q, want the more detailed %q", got[0].Notes, interviews[1].Notes)
+	}
+}
+
+func TestDedupeInterviews_KeepsFirstWhenNotesEqual(t *testing.T) {
+	day := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	interviews := []model.Interview{
+		{Date: day, Type: "video", WithWhom: "First"},
+		{Date: day, Type: "video", WithWhom: "Second"},
+	}
+
+	got := dedupeInterviews(interviews)
+	if len(got) != 1 || got[0].WithWhom != "First" {
+		t.Errorf("dedupeInterviews() = %+v, want only the first entry", got)
+	}
+}
+
+func TestDedupeInterviews_PreservesDistinct(t *testing.T) {
+	day1 := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	day2 := time.Date(2026, 3, 11, 9, 0, 0, 0, time.UTC)
+
+	interviews := []model.Interview{
+		{Date: day1, Type: "phone"},
+		{Date: day1, Type: "technical"}, // same day, different type
+		{Date: day2, Type: "phone"},     // same type, different day
+	}
+
+	got := dedupeInterviews(interviews)
+	if len(got) != 3 {
+		t.Fatalf("dedupeInterviews() returned %d interviews, want 3", len(got))
+	}
+	for i := range interviews {
+		if got[i].Date != interviews[i].Date || got[i].Type != interviews[i].Type {
+			t.Errorf("got[%d] = %+v, want %+v (order preserved)", i, got[i], interviews[i])
+		}
+	}
+}
+
+func TestDedupeInterviews_Empty(t *testing.T) {
+	if got := dedupeInterviews(nil); len(got) != 0 {
+		t.Errorf("dedupeInterviews(nil) = %v, want empty", got)
+	}
+}
+
+func TestParseInterviewJSON(t *testing.T) {
+	iv, err := parseInterviewJSON(`{"date": "2026-03-10 14:00", "type": "technical", "with_whom": "Sam Lee", "notes": " System design "}`)
+	if err != nil {
+		t.Fatalf("parseInterviewJSON() error = %v", err)
+	}
+	want := model.Interview{Date: time.Date(2026, 3, 10, 14, 0, 0, 0, time.Local), Type: "technical", WithWhom: "Sam Lee", Notes: "System design"}
+	if !iv.Date.Equal(want.Date) || iv.Type != want.Type || iv.WithWhom != want.WithWhom || iv.Notes != want.Notes {
+		t.Errorf("parseInterviewJSON() = %+v, want %+v", iv, want)
+	}
+
+	for _, tt := range []struct {
+		data    string
+		wantErr string
+	}{
+		{`{"type": "phone"}`, "date is required"},
+		{`{"date": "next tuesday"}`, "should look like"},
+		{`{"date": `, "parsing JSON"},
+	} {
+		if _, err := parseInterviewJSON(tt.data); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("parseInterviewJSON(%s) error = %v, want %q", tt.data, err, tt.wantErr)
+		}
+	}
+}
+
+func TestPrintInterviews(t *testing.T) {
+	app := model.Application{Interviews: []model.Interview{
+		{Date: time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC), Type: "phone", Notes: "Recruiter screen"},
+		{Date: time.Date(2026, 3, 12, 14, 0, 0, 0, time.UTC), Type: "technical", WithWhom: "Sam Lee"},
+		{},
+	}}
+	var out bytes.Buffer
+	printInterviews(&out, app)
+	want := "Interviews:\n" +
+		"  2026-03-10 09:00  phone\n" +
+		"    Recruiter screen\n" +
+		"  2026-03-12 14:00  technical with Sam Lee\n" +
+		"  date unknown  interview\n"
+	if out.String() != want {
+		t.Errorf("printInterviews() =\n%s\nwant\n%s", out.String(), want)
+	}
+
+	out.Reset()
+	printInterviews(&out, model.Application{})
+	if out.Len() != 0 {
+		t.Errorf("printInterviews() without interviews = %q, want nothing", out.String())
+	}
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/celloopa/ghosted/internal/agent"
+)
+
+// warnLanguage notes when a posting isn't in English, since the generated
+// documents should then be written in (or translated to) its language
+func warnLanguage(code string) {
+	if code == "" || code == agent.LanguageEnglish {
+		return
+	}
+	name := agent.LanguageName(code)
+	fmt.Fprintf(os.Stderr, "\nNote: this posting appears to be in %s; generated documents should be written in %s (or translated)\n", name, name)
+}
+
+// warnPostingLanguage detects the language of a saved posting file and
+// warns when it isn't English. Unreadable files are skipped silently.
+func warnPostingLanguage(path string) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	warnLanguage(agent.DetectLanguage(string(content)))
+}
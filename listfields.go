@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+// applicationFields returns the JSON field names of an application, in
+// declaration order
+func applicationFields() []string {
+	t := reflect.TypeOf(model.Application{})
+	fields := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
+
+// parseJSONFields splits a comma-separated --json-fields value, rejecting
+// names that aren't application fields
+func parseJSONFields(value string) ([]string, error) {
+	known := applicationFields()
+	var fields []string
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		valid := false
+		for _, k := range known {
+			if name == k {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("unknown field %q (valid fields: %s)", name, strings.Join(known, ", "))
+		}
+		fields = append(fields, name)
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no fields given (valid fields: %s)", strings.Join(known, ", "))
+	}
+	return fields, nil
+}
+
+// projectApplications renders apps as an indented JSON array of objects
+// holding only fields, in the order given. Unset fields are null.
+func projectApplications(apps []model.Application, fields []string) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, app := range apps {
+		data, err := json.Marshal(app)
+		if err != nil {
+			return nil, err
+		}
+		var all map[string]json.RawMessage
+		if err := json.Unmarshal(data, &all); err != nil {
+			return nil, err
+		}
+
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('{')
+		for j, field := range fields {
+			if j > 0 {
+				buf.WriteByte(',')
+			}
+			key, _ := json.Marshal(field)
+			buf.Write(key)
+			buf.WriteByte(':')
+			if value, ok := all[field]; ok {
+				buf.Write(value)
+			} else {
+				buf.WriteString("null")
+			}
+		}
+		buf.WriteByte('}')
+	}
+	buf.WriteByte(']')
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, buf.Bytes(), "", "  "); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+func TestProjectApplications(t *testing.T) {
+	apps := []model.Application{
+		{ID: "1", Company: "Acme", Position: "Engineer", Status: model.StatusApplied, SalaryMin: 120000, Notes: "Referred"},
+		{ID: "2", Company: "Globex", Position: "Designer", Status: model.StatusSaved},
+	}
+
+	fields, err := parseJSONFields("company, position,salary_min")
+	if err != nil {
+		t.Fatalf("parseJSONFields() error = %v", err)
+	}
+	output, err := projectApplications(apps, fields)
+	if err != nil {
+		t.Fatalf("projectApplications() error = %v", err)
+	}
+
+	var got []map[string]any
+	if err := json.Unmarshal(output, &got); err != nil {
+		t.Fatalf("output is not a JSON array: %v\n%s", err, output)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d objects, want 2", len(got))
+	}
+	for i, obj := range got {
+		if len(obj) != 3 {
+			t.Errorf("object %d has fields %v, want only company, position, salary_min", i, obj)
+		}
+	}
+	if got[0]["company"] != "Acme" || got[0]["salary_min"] != float64(120000) {
+		t.Errorf("first object = %v", got[0])
+	}
+	// Unset fields are null rather than missing
+	if v, ok := got[1]["salary_min"]; !ok || v != nil {
+		t.Errorf("salary_min = %v, %v; want null", v, ok)
+	}
+	// Fields come out in the requested order
+	if !strings.HasPrefix(strings.TrimSpace(string(output)), "[\n  {\n    \"company\"") {
+		t.Errorf("fields not in requested order:\n%s", output)
+	}
+}
+
+func TestParseJSONFields_UnknownField(t *testing.T) {
+	_, err := parseJSONFields("company,salary")
+	if err == nil {
+		t.Fatal("parseJSONFields() should reject an unknown field")
+	}
+	for _, want := range []string{`"salary"`, "salary_min", "date_applied", "status_history"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q should mention %s", err, want)
+		}
+	}
+}
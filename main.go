@@ -9,6 +9,9 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -16,12 +19,26 @@ import (
 	"github.com/celloopa/ghosted/internal/fetch"
 	"github.com/celloopa/ghosted/internal/model"
 	"github.com/celloopa/ghosted/internal/store"
+	"github.com/celloopa/ghosted/internal/timefmt"
 	"github.com/celloopa/ghosted/internal/tui"
+	"github.com/charmbracelet/x/term"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 func main() {
+	// Rebuilding replaces the data file, so it runs before loading it
+	if len(os.Args) > 1 && os.Args[1] == "rebuild" {
+		cmdRebuild(os.Args[2:])
+		return
+	}
+
+	// Printing the version doesn't need the data file
+	if len(os.Args) > 1 && (os.Args[1] == "version" || os.Args[1] == "--version") {
+		cmdVersion(os.Args[2:])
+		return
+	}
+
 	// Determine data file location
 	dataPath := getDataPath()
 
@@ -32,12 +49,26 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Record mutations so they can be reversed with `ghosted undo`
+	s.SetUndoLog(store.NewUndoLog(getUndoPath(), store.DefaultUndoDepth))
+	// Keep an append-only log of mutations for `ghosted rebuild`
+	s.SetOpLog(store.NewOpLog(getOpLogPath()))
+	// Apply follow-up automations on status changes
+	if rules, err := store.LoadRules(defaultRulesPath); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring rules: %v\n", err)
+	} else {
+		s.SetRules(rules)
+	}
+
 	// If no args or just the binary name, run TUI
 	if len(os.Args) < 2 {
-		runTUI(s)
+		runTUI(s, nil)
 		return
 	}
 
+	// Record the invocation (best effort)
+	recordHistory(os.Args[1:])
+
 	// Handle subcommands
 	switch os.Args[1] {
 	case "add":
@@ -48,30 +79,91 @@ func main() {
 		cmdGet(s, os.Args[2:])
 	case "update":
 		cmdUpdate(s, os.Args[2:])
+	case "compare":
+		cmdCompare(s, os.Args[2:])
 	case "delete":
 		cmdDelete(s, os.Args[2:])
+	case "priority":
+		cmdPriority(s, os.Args[2:])
+	case "contact":
+		cmdContact(s, os.Args[2:])
+	case "interview":
+		cmdInterview(s, os.Args[2:])
+	case "note":
+		cmdNote(s, os.Args[2:])
+	case "deadlines":
+		cmdDeadlines(s, os.Args[2:])
+	case "respond":
+		cmdRespond(s, os.Args[2:])
+	case "followups":
+		cmdFollowUps(s, os.Args[2:])
+	case "whereis":
+		cmdWhereis(s, os.Args[2:])
+	case "stats":
+		cmdStats(s, os.Args[2:])
+	case "versions":
+		cmdVersions(s, os.Args[2:])
+	case "undo":
+		cmdUndo(s, os.Args[2:])
+	case "vacuum":
+		cmdVacuum(s, os.Args[2:])
+	case "demo":
+		cmdDemo(s, os.Args[2:])
+	case "init":
+		cmdInit(os.Args[2:])
 	case "fetch":
 		cmdFetch(os.Args[2:])
 	case "context":
 		cmdContext(s)
 	case "apply":
 		cmdApply(s, os.Args[2:])
+	case "pipeline":
+		cmdPipeline(os.Args[2:])
+	case "import":
+		cmdImport(s, os.Args[2:])
+	case "export":
+		cmdExport(s, os.Args[2:])
+	case "postings":
+		cmdPostings(s, os.Args[2:])
+	case "parse-check":
+		cmdParseCheck(os.Args[2:])
+	case "gaps":
+		cmdGaps(os.Args[2:])
+	case "predict":
+		cmdPredict(s, os.Args[2:])
 	case "compile":
 		cmdCompile(s, os.Args[2:])
 	case "upgrade":
 		cmdUpgrade()
 	case "cv":
 		cmdCV(os.Args[2:])
+	case "history":
+		cmdHistory(os.Args[2:])
 	case "help", "--help", "-h":
 		printHelp()
 	default:
-		// Unknown command, run TUI
-		runTUI(s)
+		// Unknown command or display flags, run TUI
+		runTUI(s, os.Args[1:])
 	}
 }
 
-func runTUI(s *store.Store) {
+// runTUI starts the interactive UI. --accessible adds symbols to status
+// badges; --no-color (or the NO_COLOR environment variable) also disables color.
+func runTUI(s *store.Store, args []string) {
+	if os.Getenv("NO_COLOR") != "" {
+		tui.DisableColor()
+	}
+	for _, arg := range args {
+		switch arg {
+		case "--accessible":
+			tui.SetAccessible(true)
+		case "--no-color":
+			tui.DisableColor()
+		}
+	}
+
 	app := tui.New(s)
+	app.SetSalaryFloor(loadSalaryFloor())
 	p := tea.NewProgram(app, tea.WithAltScreen())
 
 	if _, err := p.Run(); err != nil {
@@ -90,30 +182,92 @@ func printHelp() {
 
 Usage:
   ghosted              Launch interactive TUI
+  ghosted --accessible Launch the TUI with status symbols (○ ● ◐ ◆ ★ ✓ ✗ –)
+  ghosted --no-color   Launch the TUI without color (implies --accessible)
   ghosted <command>    Run a command
 
 Commands:
-  add --json '<json>'   Add a new application from JSON
+  init [--force]        Create the local/ workspace, starter config.json, and cv.json template
+  add --json '<json>' [--force]  Add a new application from JSON (--force past the per-company limit)
   list [--json]         List all applications (--json for JSON output)
+  list --format table   List applications in a bordered table
+  list --json-fields a,b  JSON with only the given fields (e.g. company,position,status)
+  list --max-years N    Hide roles asking for more than N years of experience
+  list --by-type        Group applications by job type (fe-dev, swe, ux-design, ...)
+  list --by-priority    List top-priority applications first (unset last)
+  list --query '<q>'    Filter with field:value and salary>N terms (see README)
+  list --stale [--days N]  Applications with no response after N days (default 21 or ghosted_after_days)
+  list --count [--group-by status]  Print how many applications match the other list filters
   get <id> [--json]     Get application by ID
   update <id> --json '<json>'  Update application fields
   delete <id>           Delete an application
+  compare <id> <id>     Compare two applications side by side, including salary overlap
+  priority <id> <0-5>   Set an application's priority (5 = top target, 0 clears)
+  note <id> <text>      Append a note; @shortcodes expand from local/note-templates.json
+  contact add <id> --name N [--email E] [--role R] [--notes T] [--date D]  Log a contact
+  contact list <id>     List an application's contacts, most recently contacted first
+  interview add <id> --json '{...}'  Record an interview (date, type, with_whom, notes); get lists them
+  respond <id> [--date YYYY-MM-DD]  Record when a company first responded (default: today)
+  deadlines [--all]     List upcoming application deadlines, soonest first
+  followups [--upcoming]  List follow-ups that are due, most overdue first (--upcoming: next 7 days)
+  stats [--weeks N] [--json]  Show totals, status counts, response/ghost rates, and applications sent per week
+  stats --export <dir> [--markdown]  Write a timestamped stats report (JSON, optionally markdown)
+  stats --stages        Average days applications spend in each status before moving on
+  whereis <id> [--resume|--cover|--folder|--posting|...]  Print paths to an application's files
+  versions <id>         List earlier resume versions kept by compile
+  undo [--list]         Undo the last add/update/delete (repeatable), or list the undo stack
+  vacuum                Back up the data file, then trim fields, fix statuses, and sort interviews
+  rebuild [--force]     Back up the data file, then recreate it by replaying applications.log
+  demo                  Load sample applications to explore the TUI
   fetch <url|domain>    Fetch job posting or CV (auto-detected)
+  fetch <url> --force   Save a posting even if the job board reports it closed
+  fetch <url> --cookies <file>  Send cookies (cookies.txt or "name=value; ...") for postings behind a login
+  fetch <url> --follow-company  Also list the company's other openings (Lever, Greenhouse, Ashby)
   apply <posting> [flags]      Run full pipeline on a job posting
+  apply <posting> --resume     Continue a failed run from the step it failed at
+  apply --dir <folder> [flags] Run the pipeline on every posting in a folder
+  apply --since-cv-change      Regenerate documents of open applications generated from an older CV
+  apply --prune-state [--older-than DAYS]  Remove failed run states older than DAYS (default 7)
+  pipeline list         List failed pipeline runs and the step each failed at
+  postings [dir]        List pending and archived postings with linked applications
+  postings dedup [dir] [--remove]  Report postings fetched more than once; --remove keeps only the newest
+  parse-check [dir] [-v]       Report how many postings parse locally vs. need the AI parser
+  gaps <posting> [--cv path]   Show which posting requirements your CV meets and misses
+  predict <posting> [--cv path]  Estimate interview likelihood from CV fit and past outcomes (heuristic)
   compile <id|dir>      Compile .typ files to PDF and link to tracker
+  compile <dir> --link <id>  Compile a folder and link it to an application
+  export --pdf-bundle <out.zip>  Zip every application's compiled PDFs
+  export --format csv [--output file.csv]  Export every application as a spreadsheet-friendly CSV
+  import <file.csv|file.json> [--skip-duplicates]  Add applications from a CSV export or JSON array
+  export --format notion [--status-map file.json] [--output file.csv]  Export a CSV for a Notion database
+  export --format html [--output report.html]  Export a shareable HTML report with stats and a sortable table
   context               Show context for AI agents (postings, CV, applications)
   cv fetch <website>    Fetch CV from website (downloads https://<website>/cv.json)
+  history [N] [--clear] Show the last N commands run (default 20) or clear the log
   upgrade               Update ghosted to the latest version
+  version [--short]     Print the version, git commit, and build date
   help                  Show this help
 
 Environment:
   GHOSTED_DATA         Path to data file (default: ~/.local/share/ghosted/applications.json)
+  ANTHROPIC_API_KEY    Generate and review apply's documents with the Anthropic API (drafts from the CV without it)
+  OPENAI_API_KEY       API key when the document config sets "provider": "openai"
+  GHOSTED_MIN_SALARY   Minimum acceptable salary; add and apply warn below it
+  NO_COLOR             Disable color in the TUI (same as --no-color)
 
 Examples:
   ghosted add --json '{"company":"Acme Corp","position":"Software Engineer"}'
   ghosted list --json
+  ghosted list --json-fields company,position,status --query 'status:interview'
+  ghosted list --format table
   ghosted update abc123 --json '{"status":"interview"}'
+  ghosted priority abc123 5
+  ghosted list --by-priority
+  ghosted list --query 'status:interview remote:true salary>150000'
+  ghosted whereis abc123                               # Folder, documents, and posting
+  open "$(ghosted whereis abc123 --resume)"            # Just the resume PDF path
   ghosted delete abc123
+  ghosted undo                                         # Restore the deleted application
   ghosted fetch https://jobs.lever.co/company/job-id   # Fetch job posting
   ghosted fetch cello.design                           # Fetch CV from domain/cv.json
   ghosted fetch https://example.com/cv.json            # Fetch CV from explicit URL
@@ -121,13 +275,42 @@ Examples:
   ghosted apply local/postings/acme-swe.md
   ghosted apply --dry-run local/postings/test.md
   ghosted apply --auto-approve local/postings/acme-swe.md
+  ghosted apply --tone casual local/postings/startup-swe.md
+  ghosted apply --parse-only local/postings/acme-swe.md
+  ghosted apply --emit-prompts local/postings/acme-swe.md
+  ghosted apply --json-output local/postings/acme-swe.md
+  pbpaste | ghosted apply -                      # Posting from stdin, saved to local/postings/
+  ghosted apply --dir local/postings --skip-existing
+  ghosted apply --dir local/postings --concurrency 4
+  ghosted apply --since-cv-change                # After editing local/cv.json
+  ghosted pipeline list                          # Failed runs; re-run with apply <posting>
+  ghosted apply --prune-state --older-than 14
   ghosted compile abc123                         # Compile by application ID
   ghosted compile local/applications/swe/acme/   # Compile by directory
+  ghosted export --pdf-bundle applications.zip
   ghosted cv fetch cello.design
 
 Apply Command Flags:
-  --dry-run       Generate documents without adding to tracker
+  --dry-run       Write (and compile) documents, but add no tracker entry
   --auto-approve  Skip review confirmation step
+  --auto-revise N Regenerate with reviewer feedback up to N times on rejection
+  --tone <tone>   Cover letter tone: formal, casual, or enthusiastic
+  --cover-style S Cover letter length: brief (2 short paragraphs), standard, or detailed (4)
+  --format F      Document format: typst (default) or markdown (compiled with pandoc)
+  --reviewer-cv P Have the reviewer verify the documents against this CV instead of local/cv.json
+  --dir <folder>  Run the pipeline on every posting in a folder
+  --skip-existing Skip postings that already have a tracker entry
+  --concurrency N Run up to N postings at once with --dir or --since-cv-change (default 1)
+  --since-cv-change  Regenerate documents (tracker entries unchanged) of open applications whose documents predate local/cv.json
+  --parse-only    Print the parsed posting as JSON without generating anything
+  --emit-prompts  Print each agent's system and user prompt without running them
+  --json-output   Print a JSON summary of the run instead of status text
+  --keep-failed-state=false  Remove a failed run's state instead of keeping it for pipeline list
+  --resume        Continue the posting's failed run from the step it failed at
+  --attach-posting  Include the raw posting text, not just the parsed data, in the resume and cover letter prompts
+  --explain       Print the reviewer's score breakdown by criterion
+  --preview-cover Print the generated cover letter as plain text, without compiling it
+  --force         Apply even with max_active_per_company (default 3) active applications at the company
 
 ─────────────────────────────────────────────────────────────────────────────────
 AI AGENT WORKFLOW
@@ -173,9 +356,10 @@ Prompt Templates: internal/agent/prompts/
 // cmdAdd adds a new application from JSON input
 func cmdAdd(s *store.Store, args []string) {
 	if len(args) < 2 || args[0] != "--json" {
-		fmt.Fprintln(os.Stderr, "Usage: ghosted add --json '<json>'")
+		fmt.Fprintln(os.Stderr, "Usage: ghosted add --json '<json>' [--force]")
 		os.Exit(1)
 	}
+	force := slices.Contains(args[2:], "--force")
 
 	jsonData := args[1]
 	var app model.Application
@@ -199,12 +383,17 @@ func cmdAdd(s *store.Store, args []string) {
 		fmt.Fprintln(os.Stderr, "Error: position is required")
 		os.Exit(1)
 	}
+	if err := checkCompanyCadence(s, app.Company, loadCompanyLimit(), force); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
 
 	created, err := s.Add(app)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error adding application: %v\n", err)
 		os.Exit(1)
 	}
+	warnSalary(created, loadSalaryFloor())
 
 	// Output the created application as JSON
 	output, _ := json.MarshalIndent(created, "", "  ")
@@ -215,19 +404,163 @@ func cmdAdd(s *store.Store, args []string) {
 func cmdList(s *store.Store, args []string) {
 	apps := s.List()
 
-	// Check for --json flag
-	jsonOutput := false
-	for _, arg := range args {
-		if arg == "--json" {
-			jsonOutput = true
-			break
+	// Parse flags
+	format := "text"
+	maxYears := -1
+	byType := false
+	stale := false
+	staleDays := 0
+	count := false
+	groupBy := ""
+	var jsonFields []string
+	var query func(model.Application) bool
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--json":
+			format = "json"
+		case "--json-fields":
+			if i+1 < len(args) {
+				fields, err := parseJSONFields(args[i+1])
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error: --json-fields: %v\n", err)
+					os.Exit(1)
+				}
+				jsonFields = fields
+				i++
+			}
+		case "--stale":
+			stale = true
+		case "--count":
+			count = true
+		case "--group-by":
+			if i+1 < len(args) {
+				groupBy = args[i+1]
+				i++
+			}
+		case "--days":
+			if i+1 < len(args) {
+				n, err := strconv.Atoi(args[i+1])
+				if err != nil || n < 1 {
+					fmt.Fprintf(os.Stderr, "Error: --days expects a positive number, got %q\n", args[i+1])
+					os.Exit(1)
+				}
+				staleDays = n
+				i++
+			}
+		case "--by-type":
+			byType = true
+		case "--by-priority":
+			apps = s.ByPriority()
+		case "--format":
+			if i+1 < len(args) {
+				format = args[i+1]
+				i++
+			}
+		case "--query":
+			if i+1 < len(args) {
+				pred, err := store.ParseQuery(args[i+1])
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error: invalid --query: %v\n", err)
+					os.Exit(1)
+				}
+				query = pred
+				i++
+			}
+		case "--max-years":
+			if i+1 < len(args) {
+				n, err := strconv.Atoi(args[i+1])
+				if err != nil || n < 0 {
+					fmt.Fprintf(os.Stderr, "Error: --max-years expects a non-negative number, got %q\n", args[i+1])
+					os.Exit(1)
+				}
+				maxYears = n
+				i++
+			}
 		}
 	}
 
-	if jsonOutput {
+	if staleDays > 0 && !stale {
+		fmt.Fprintln(os.Stderr, "Error: --days only applies with --stale")
+		os.Exit(1)
+	}
+	if stale {
+		if staleDays == 0 {
+			staleDays = loadGhostedDays()
+		}
+		query = andPredicates(query, stalePredicate(time.Now(), staleDays))
+	}
+	if groupBy != "" && !count {
+		fmt.Fprintln(os.Stderr, "Error: --group-by only applies with --count")
+		os.Exit(1)
+	}
+	if groupBy != "" && groupBy != "status" {
+		fmt.Fprintf(os.Stderr, "Error: unknown --group-by %q (expected status)\n", groupBy)
+		os.Exit(1)
+	}
+	if jsonFields != nil {
+		if count || byType || (format != "text" && format != "json") {
+			fmt.Fprintln(os.Stderr, "Error: --json-fields prints JSON and can't be combined with --count, --by-type, or --format")
+			os.Exit(1)
+		}
+		format = "json"
+	}
+
+	if maxYears >= 0 {
+		apps = filterMaxYears(apps, maxYears)
+	}
+	if query != nil {
+		apps = filterQuery(apps, query)
+	}
+
+	if count {
+		if groupBy == "status" {
+			printStatusCounts(os.Stdout, apps)
+		} else {
+			printCount(os.Stdout, apps)
+		}
+		return
+	}
+
+	if byType {
+		listByType(s, format, maxYears, query)
+		return
+	}
+
+	switch format {
+	case "json":
+		if jsonFields != nil {
+			output, err := projectApplications(apps, jsonFields)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Println(string(output))
+			return
+		}
 		output, _ := json.MarshalIndent(apps, "", "  ")
 		fmt.Println(string(output))
-	} else {
+	case "table":
+		if len(apps) == 0 {
+			fmt.Println("No applications found.")
+			return
+		}
+		headers := []string{"ID", "Company", "Position", "Status", "Applied"}
+		rows := make([][]string, 0, len(apps))
+		for _, app := range apps {
+			date := "—"
+			if app.DateApplied != nil {
+				date = app.DateApplied.Format("2006-01-02")
+			}
+			rows = append(rows, []string{
+				shortID(app.ID),
+				app.Company,
+				app.Position,
+				model.StatusLabel(app.Status),
+				date,
+			})
+		}
+		fmt.Print(renderBoxTable(headers, rows, terminalWidth()))
+	case "text":
 		// Simple text output
 		if len(apps) == 0 {
 			fmt.Println("No applications found.")
@@ -238,17 +571,97 @@ func cmdList(s *store.Store, args []string) {
 			if app.DateApplied != nil {
 				date = app.DateApplied.Format("2006-01-02")
 			}
-			fmt.Printf("[%s] %s @ %s - %s (%s)\n",
-				app.ID[:8],
+			stars := ""
+			if app.Priority > 0 {
+				stars = " " + model.PriorityStars(app.Priority)
+			}
+			fmt.Printf("[%s] %s @ %s - %s (%s)%s\n",
+				shortID(app.ID),
 				app.Position,
 				app.Company,
 				model.StatusLabel(app.Status),
 				date,
+				stars,
 			)
 		}
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown format: %s (expected text, table, or json)\n", format)
+		os.Exit(1)
 	}
 }
 
+// listByType prints applications grouped by job type, in text or JSON.
+// query, if set, filters the applications like --query.
+func listByType(s *store.Store, format string, maxYears int, query func(model.Application) bool) {
+	groups := s.ByJobType()
+	if maxYears >= 0 || query != nil {
+		for jobType, apps := range groups {
+			if maxYears >= 0 {
+				apps = filterMaxYears(apps, maxYears)
+			}
+			if query != nil {
+				apps = filterQuery(apps, query)
+			}
+			if len(apps) > 0 {
+				groups[jobType] = apps
+			} else {
+				delete(groups, jobType)
+			}
+		}
+	}
+
+	switch format {
+	case "json":
+		output, _ := json.MarshalIndent(groups, "", "  ")
+		fmt.Println(string(output))
+	case "text":
+		if len(groups) == 0 {
+			fmt.Println("No applications found.")
+			return
+		}
+		types := make([]string, 0, len(groups))
+		for jobType := range groups {
+			types = append(types, jobType)
+		}
+		sort.Strings(types)
+		for i, jobType := range types {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Printf("%s (%d)\n", jobType, len(groups[jobType]))
+			for _, app := range groups[jobType] {
+				fmt.Printf("  [%s] %s @ %s - %s\n", shortID(app.ID), app.Position, app.Company, model.StatusLabel(app.Status))
+			}
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "--by-type supports text or json output, not %s\n", format)
+		os.Exit(1)
+	}
+}
+
+// filterMaxYears drops applications asking for more than maxYears of
+// experience. Applications with no stated requirement are kept.
+func filterMaxYears(apps []model.Application, maxYears int) []model.Application {
+	kept := make([]model.Application, 0, len(apps))
+	for _, app := range apps {
+		if app.MinYearsExperience <= maxYears {
+			kept = append(kept, app)
+		}
+	}
+	return kept
+}
+
+// filterQuery keeps the applications matching a parsed --query
+func filterQuery(apps []model.Application, query func(model.Application) bool) []model.Application {
+	kept := make([]model.Application, 0, len(apps))
+	for _, app := range apps {
+		if query(app) {
+			kept = append(kept, app)
+		}
+	}
+	return kept
+}
+
 // cmdGet gets a single application by ID
 func cmdGet(s *store.Store, args []string) {
 	if len(args) < 1 {
@@ -293,7 +706,7 @@ func cmdGet(s *store.Store, args []string) {
 		fmt.Printf("Position: %s\n", app.Position)
 		fmt.Printf("Status:   %s\n", model.StatusLabel(app.Status))
 		if app.DateApplied != nil {
-			fmt.Printf("Applied:  %s\n", app.DateApplied.Format("2006-01-02"))
+			fmt.Printf("Applied:  %s (%s)\n", app.DateApplied.Format("2006-01-02"), timefmt.HumanizeTime(*app.DateApplied, time.Now()))
 		} else {
 			fmt.Printf("Applied:  Not sent\n")
 		}
@@ -306,12 +719,22 @@ func cmdGet(s *store.Store, args []string) {
 		if app.SalaryRange() != "" {
 			fmt.Printf("Salary:   %s\n", app.SalaryRange())
 		}
+		if app.MinYearsExperience > 0 {
+			fmt.Printf("Experience: %d+ years\n", app.MinYearsExperience)
+		}
+		if app.Priority > 0 {
+			fmt.Printf("Priority: %d %s\n", app.Priority, model.PriorityStars(app.Priority))
+		}
+		if app.Deadline != nil {
+			fmt.Printf("Deadline: %s (%s)\n", app.Deadline.Format("2006-01-02"), deadlineLabel(*app.Deadline, time.Now()))
+		}
 		if app.JobURL != "" {
 			fmt.Printf("URL:      %s\n", app.JobURL)
 		}
 		if app.Notes != "" {
 			fmt.Printf("Notes:    %s\n", app.Notes)
 		}
+		printInterviews(os.Stdout, app)
 	}
 }
 
@@ -380,9 +803,11 @@ func cmdUpdate(s *store.Store, args []string) {
 	}
 	if v, ok := updates["salary_min"].(float64); ok {
 		app.SalaryMin = int(v)
+		app.SalaryEstimated = false
 	}
 	if v, ok := updates["salary_max"].(float64); ok {
 		app.SalaryMax = int(v)
+		app.SalaryEstimated = false
 	}
 	if v, ok := updates["contact_name"].(string); ok {
 		app.ContactName = v
@@ -396,6 +821,19 @@ func cmdUpdate(s *store.Store, args []string) {
 	if v, ok := updates["cover_letter"].(string); ok {
 		app.CoverLetter = v
 	}
+	if v, ok := updates["job_type"].(string); ok {
+		app.JobType = v
+	}
+	if v, ok := updates["priority"].(float64); ok {
+		app.Priority = model.ClampPriority(int(v))
+	}
+	if v, ok := updates["deadline"].(string); ok {
+		if v == "" {
+			app.Deadline = nil
+		} else if t, err := time.Parse("2006-01-02", v); err == nil {
+			app.Deadline = &t
+		}
+	}
 	if v, ok := updates["date_applied"].(string); ok {
 		if t, err := time.Parse("2006-01-02", v); err == nil {
 			parsedDate := t
@@ -407,6 +845,10 @@ func cmdUpdate(s *store.Store, args []string) {
 		fmt.Fprintf(os.Stderr, "Error updating application: %v\n", err)
 		os.Exit(1)
 	}
+	// Reload to show what rules changed along with the status
+	if updated, err := s.GetByID(app.ID); err == nil {
+		app = updated
+	}
 
 	// Output the updated application
 	output, _ := json.MarshalIndent(app, "", "  ")
@@ -456,7 +898,7 @@ func cmdDelete(s *store.Store, args []string) {
 // - Any URL with a path → Job posting fetch to local/postings/
 func cmdFetch(args []string) {
 	if len(args) < 1 {
-		fmt.Fprintln(os.Stderr, "Usage: ghosted fetch <url|domain> [--output name]")
+		fmt.Fprintln(os.Stderr, "Usage: ghosted fetch <url|domain> [--output name] [--force] [--cookies file] [--follow-company]")
 		fmt.Fprintln(os.Stderr, "")
 		fmt.Fprintln(os.Stderr, "Examples:")
 		fmt.Fprintln(os.Stderr, "  ghosted fetch https://jobs.lever.co/company/123  # Job posting")
@@ -467,10 +909,22 @@ func cmdFetch(args []string) {
 
 	var inputArg string
 	var outputName string
+	var cookiesPath string
+	force := false
+	followCompany := false
 
 	// Parse arguments
 	for i := 0; i < len(args); i++ {
-		if args[i] == "--output" || args[i] == "-o" {
+		if args[i] == "--force" {
+			force = true
+		} else if args[i] == "--follow-company" {
+			followCompany = true
+		} else if args[i] == "--cookies" {
+			if i+1 < len(args) {
+				cookiesPath = args[i+1]
+				i++
+			}
+		} else if args[i] == "--output" || args[i] == "-o" {
 			if i+1 < len(args) {
 				outputName = args[i+1]
 				i++
@@ -482,7 +936,7 @@ func cmdFetch(args []string) {
 
 	if inputArg == "" {
 		fmt.Fprintln(os.Stderr, "Error: URL or domain is required")
-		fmt.Fprintln(os.Stderr, "Usage: ghosted fetch <url|domain> [--output name]")
+		fmt.Fprintln(os.Stderr, "Usage: ghosted fetch <url|domain> [--output name] [--force] [--cookies file] [--follow-company]")
 		os.Exit(1)
 	}
 
@@ -493,7 +947,7 @@ func cmdFetch(args []string) {
 	case fetch.FetchTypeCV:
 		fetchCV(inputArg)
 	case fetch.FetchTypeJobPosting:
-		fetchJobPosting(inputArg, outputName)
+		fetchJobPosting(inputArg, outputName, force, cookiesPath, followCompany)
 	}
 }
 
@@ -519,8 +973,12 @@ func fetchCV(input string) {
 	fmt.Printf("Size:     %d bytes\n", result.Size)
 }
 
-// fetchJobPosting fetches a job posting from a URL and saves it to local/postings/
-func fetchJobPosting(urlArg string, outputName string) {
+// fetchJobPosting fetches a job posting from a URL and saves it to local/postings/.
+// Postings the job board reports as closed are only saved with force.
+// cookiesPath, if set, points at a cookie file for postings behind a login.
+// With followCompany, the company's other openings on the same board are
+// listed afterwards.
+func fetchJobPosting(urlArg string, outputName string, force bool, cookiesPath string, followCompany bool) {
 	// Ensure URL has a scheme
 	if !fetch.IsURL(urlArg) {
 		urlArg = "https://" + urlArg
@@ -531,6 +989,15 @@ func fetchJobPosting(urlArg string, outputName string) {
 
 	// Create fetcher and fetch
 	f := fetch.NewFetcher(outputDir)
+	f.Force = force
+	if cookiesPath != "" {
+		cookies, err := fetch.LoadCookieFile(cookiesPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading cookies: %v\n", err)
+			os.Exit(1)
+		}
+		f.Cookies = cookies
+	}
 
 	fmt.Printf("Fetching job posting: %s\n", urlArg)
 
@@ -551,10 +1018,62 @@ func fetchJobPosting(urlArg string, outputName string) {
 	if result.Position != "" {
 		fmt.Printf("Position: %s\n", result.Position)
 	}
+	if result.Location != "" {
+		fmt.Printf("Location: %s\n", result.Location)
+	}
+	if result.SalaryMin > 0 || result.SalaryMax > 0 {
+		salary := model.Application{SalaryMin: result.SalaryMin, SalaryMax: result.SalaryMax}
+		fmt.Printf("Salary:   %s\n", salary.SalaryRange())
+	}
 	fmt.Printf("Size:     %d bytes\n", result.ContentSize)
+	if result.Closed {
+		fmt.Fprintf(os.Stderr, "\nWarning: this posting looks closed or expired (%s)\n", result.ClosedReason)
+	}
+	warnPostingLanguage(result.OutputPath)
 	fmt.Println("\nNext step: ghosted apply", result.OutputPath)
+
+	if followCompany {
+		printCompanyOpenings(f, result)
+	}
+}
+
+// printCompanyOpenings lists the other openings on the posting's job board.
+// Failures only warn: the posting itself was already saved.
+func printCompanyOpenings(f *fetch.Fetcher, result *fetch.FetchResult) {
+	openings, err := f.CompanyOpenings(result.URL)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\nWarning: could not list other openings: %v\n", err)
+		return
+	}
+
+	company := result.Company
+	if company == "" {
+		company = "this company"
+	}
+	if openings == nil {
+		fmt.Println("\n--follow-company: listing other openings is supported for Lever, Greenhouse, and Ashby boards")
+		return
+	}
+	if len(openings) == 0 {
+		fmt.Printf("\nNo other open positions at %s\n", company)
+		return
+	}
+
+	fmt.Printf("\nOther open positions at %s (%d):\n", company, len(openings))
+	for _, opening := range openings {
+		title := opening.Title
+		if opening.Location != "" {
+			title += " (" + opening.Location + ")"
+		}
+		fmt.Printf("  • %s\n    %s\n", title, opening.URL)
+	}
+	fmt.Println("\nFetch one with: ghosted fetch <url>")
 }
 
+// defaultRulesPath holds the status-change rules, such as setting a
+// follow-up on moving to interview
+var defaultRulesPath = filepath.Join("local", "rules.json")
+
 func getDataPath() string {
 	// Check for environment variable override
 	if path := os.Getenv("GHOSTED_DATA"); path != "" {
@@ -600,7 +1119,7 @@ func cmdContext(s *store.Store) {
 	if entries, err := os.ReadDir(postingsDir); err == nil {
 		count := 0
 		for _, entry := range entries {
-			if !entry.IsDir() && (filepath.Ext(entry.Name()) == ".md" || filepath.Ext(entry.Name()) == ".txt") {
+			if ext := filepath.Ext(entry.Name()); !entry.IsDir() && (ext == ".md" || ext == ".txt" || ext == ".docx") {
 				count++
 				fmt.Printf("  • %s/%s\n", postingsDir, entry.Name())
 			}
@@ -740,97 +1259,24 @@ WORKFLOW: parse → resume → cover → compile → review → iterate → trac
 ═══════════════════════════════════════════════════════════════════════════════`)
 }
 
-// cmdApply runs the full pipeline on a job posting
-func cmdApply(s *store.Store, args []string) {
-	if len(args) < 1 {
-		fmt.Fprintln(os.Stderr, "Usage: ghosted apply <posting-file> [--dry-run] [--auto-approve]")
-		os.Exit(1)
-	}
-
-	// Parse arguments
-	var postingPath string
-	dryRun := false
-	autoApprove := false
-
-	for _, arg := range args {
-		switch arg {
-		case "--dry-run":
-			dryRun = true
-		case "--auto-approve":
-			autoApprove = true
+// cmdCompile compiles .typ files to PDF and links them to the tracker
+func cmdCompile(s *store.Store, args []string) {
+	var target, linkID string
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--link" && i+1 < len(args):
+			linkID = args[i+1]
+			i++
+		case !isFlag(args[i]) && target == "":
+			target = args[i]
 		default:
-			if postingPath == "" && !isFlag(arg) {
-				postingPath = arg
-			}
+			compileUsage()
 		}
 	}
-
-	if postingPath == "" {
-		fmt.Fprintln(os.Stderr, "Error: posting file is required")
-		fmt.Fprintln(os.Stderr, "Usage: ghosted apply <posting-file> [--dry-run] [--auto-approve]")
-		os.Exit(1)
-	}
-
-	// Check if file exists
-	if _, err := os.Stat(postingPath); os.IsNotExist(err) {
-		fmt.Fprintf(os.Stderr, "Error: file not found: %s\n", postingPath)
-		os.Exit(1)
-	}
-
-	// Create pipeline config path
-	configPath := filepath.Join("local", "document-generation", ".agent", "config.json")
-
-	// For dry run, don't pass the store (prevents tracker entry)
-	var pipelineStore *store.Store
-	if !dryRun {
-		pipelineStore = s
-	}
-
-	// Create pipeline
-	pipeline, err := agent.NewPipeline(configPath, pipelineStore)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error creating pipeline: %v\n", err)
-		os.Exit(1)
-	}
-
-	fmt.Printf("Running pipeline on: %s\n", postingPath)
-	if dryRun {
-		fmt.Println("Mode: dry-run (no tracker entry will be created)")
-	}
-	if autoApprove {
-		fmt.Println("Mode: auto-approve (skipping review confirmation)")
-	}
-	fmt.Println()
-
-	// Run pipeline
-	if err := pipeline.Run(postingPath); err != nil {
-		fmt.Fprintf(os.Stderr, "\nPipeline failed: %v\n", err)
-		fmt.Println("\n" + pipeline.GetStatus())
-		os.Exit(1)
-	}
-
-	// Output status
-	fmt.Println("\n" + pipeline.GetStatus())
-
-	if dryRun {
-		fmt.Println("\nDry run complete. No application was added to tracker.")
-	} else {
-		fmt.Println("\nApplication added to tracker. Run 'ghosted list' to view.")
-	}
-}
-
-// cmdCompile compiles .typ files to PDF and links them to the tracker
-func cmdCompile(s *store.Store, args []string) {
-	if len(args) < 1 {
-		fmt.Fprintln(os.Stderr, "Usage: ghosted compile <id|dir>")
-		fmt.Fprintln(os.Stderr, "")
-		fmt.Fprintln(os.Stderr, "Examples:")
-		fmt.Fprintln(os.Stderr, "  ghosted compile abc123")
-		fmt.Fprintln(os.Stderr, "  ghosted compile local/applications/swe/acme/")
-		os.Exit(1)
+	if target == "" {
+		compileUsage()
 	}
 
-	target := args[0]
 	var appDir string
 	var app *model.Application
 
@@ -838,9 +1284,24 @@ func cmdCompile(s *store.Store, args []string) {
 	if info, err := os.Stat(target); err == nil && info.IsDir() {
 		// Target is a directory
 		appDir = target
-		// Try to find matching application by company/position from folder name
-		app = findAppByFolder(s, target)
+		if linkID != "" {
+			app = findAppByID(s, linkID)
+			if app == nil {
+				fmt.Fprintf(os.Stderr, "Error: application not found: %s\n", linkID)
+				os.Exit(1)
+			}
+		} else {
+			// Linked folder, then company/position from the folder name
+			app = findAppForDir(s, target)
+		}
+		if app == nil && term.IsTerminal(os.Stdin.Fd()) {
+			app = promptLink(os.Stdin, os.Stdout, target, linkCandidates(s, target))
+		}
 	} else {
+		if linkID != "" {
+			fmt.Fprintln(os.Stderr, "Error: --link needs a directory to link")
+			os.Exit(1)
+		}
 		// Target is an application ID - find the app and its folder
 		app = findAppByID(s, target)
 		if app == nil {
@@ -862,25 +1323,44 @@ func cmdCompile(s *store.Store, args []string) {
 		os.Exit(1)
 	}
 
-	// Find .typ files
-	resumeTyp := filepath.Join(appDir, "resume.typ")
-	coverTyp := filepath.Join(appDir, "cover-letter.typ")
+	// Find the document sources: Typst, or markdown from apply --format markdown
+	resumeSrc := documentSource(appDir, "resume")
+	coverSrc := documentSource(appDir, "cover-letter")
 
 	var resumePDF, coverPDF string
-	compiled := false
 
-	// Check for typst
-	if _, err := exec.LookPath("typst"); err != nil {
-		fmt.Fprintln(os.Stderr, "Error: typst is not installed or not in PATH")
-		fmt.Fprintln(os.Stderr, "Install from: https://github.com/typst/typst")
+	output := loadOutputConfig()
+	if !output.GeneratePDF {
+		fmt.Fprintf(os.Stderr, "Error: PDF generation is off (output.generate_pdf in %s); the source files are the final documents\n", pipelineConfigPath)
 		os.Exit(1)
 	}
 
+	if resumeSrc == "" && coverSrc == "" {
+		fmt.Fprintln(os.Stderr, "No .typ or .md documents found in", appDir)
+		fmt.Fprintln(os.Stderr, "Expected: resume.typ and/or cover-letter.typ (or resume.md and/or cover-letter.md)")
+		os.Exit(1)
+	}
+
+	// Check for the compilers the documents need
+	for _, src := range []string{resumeSrc, coverSrc} {
+		if src != "" {
+			requireCompiler(src)
+		}
+	}
+
 	// Compile resume if exists
-	if _, err := os.Stat(resumeTyp); err == nil {
+	if resumeSrc != "" {
 		resumePDF = filepath.Join(appDir, "resume.pdf")
-		fmt.Printf("Compiling %s...\n", resumeTyp)
-		cmd := exec.Command("typst", "compile", resumeTyp, resumePDF)
+		archived, err := versionResume(app, appDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if archived != "" {
+			fmt.Printf("Kept previous resume as %s\n", archived)
+		}
+		fmt.Printf("Compiling %s...\n", resumeSrc)
+		cmd := compileCommand(resumeSrc, resumePDF)
 		cmd.Stdout = os.Stdout
 		cmd.Stderr = os.Stderr
 		if err := cmd.Run(); err != nil {
@@ -888,14 +1368,15 @@ func cmdCompile(s *store.Store, args []string) {
 			os.Exit(1)
 		}
 		fmt.Printf("  → %s\n", resumePDF)
-		compiled = true
+		warnPageCount("Resume", resumePDF, maxResumePages)
+		removeTypst(resumeSrc, output)
 	}
 
 	// Compile cover letter if exists
-	if _, err := os.Stat(coverTyp); err == nil {
+	if coverSrc != "" {
 		coverPDF = filepath.Join(appDir, "cover-letter.pdf")
-		fmt.Printf("Compiling %s...\n", coverTyp)
-		cmd := exec.Command("typst", "compile", coverTyp, coverPDF)
+		fmt.Printf("Compiling %s...\n", coverSrc)
+		cmd := compileCommand(coverSrc, coverPDF)
 		cmd.Stdout = os.Stdout
 		cmd.Stderr = os.Stderr
 		if err := cmd.Run(); err != nil {
@@ -903,18 +1384,18 @@ func cmdCompile(s *store.Store, args []string) {
 			os.Exit(1)
 		}
 		fmt.Printf("  → %s\n", coverPDF)
-		compiled = true
-	}
-
-	if !compiled {
-		fmt.Fprintln(os.Stderr, "No .typ files found in", appDir)
-		fmt.Fprintln(os.Stderr, "Expected: resume.typ and/or cover-letter.typ")
-		os.Exit(1)
+		warnPageCount("Cover letter", coverPDF, maxCoverLetterPages)
+		removeTypst(coverSrc, output)
 	}
 
 	// Update tracker if we have an application
 	if app != nil {
 		updated := false
+		linked := false
+		if dir := store.NormalizeDir(appDir); app.DocumentsDir != dir {
+			app.DocumentsDir = dir
+			updated, linked = true, true
+		}
 		if resumePDF != "" {
 			app.ResumeVersion = resumePDF
 			updated = true
@@ -929,8 +1410,14 @@ func cmdCompile(s *store.Store, args []string) {
 				fmt.Fprintf(os.Stderr, "Warning: failed to update tracker: %v\n", err)
 			} else {
 				fmt.Println("\nTracker updated:")
+				if linked {
+					fmt.Printf("  documents_dir: %s\n", app.DocumentsDir)
+				}
 				if resumePDF != "" {
 					fmt.Printf("  resume_version: %s\n", resumePDF)
+					if n := len(app.ResumeVersions); n > 0 {
+						fmt.Printf("  resume_versions: %d earlier (see 'ghosted versions %s')\n", n, shortID(app.ID))
+					}
 				}
 				if coverPDF != "" {
 					fmt.Printf("  cover_letter: %s\n", coverPDF)
@@ -939,7 +1426,7 @@ func cmdCompile(s *store.Store, args []string) {
 		}
 	} else {
 		fmt.Println("\nNote: No matching application found in tracker.")
-		fmt.Println("Documents compiled but not linked. Use 'ghosted update' to link manually.")
+		fmt.Println("Documents compiled but not linked. Link them with 'ghosted compile <dir> --link <id>'.")
 	}
 
 	// Open folder (macOS/Linux)
@@ -947,6 +1434,80 @@ func cmdCompile(s *store.Store, args []string) {
 	openFolder(appDir)
 }
 
+// compileUsage prints compile's usage and exits
+func compileUsage() {
+	fmt.Fprintln(os.Stderr, "Usage: ghosted compile <id|dir> [--link <id>]")
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintln(os.Stderr, "Examples:")
+	fmt.Fprintln(os.Stderr, "  ghosted compile abc123")
+	fmt.Fprintln(os.Stderr, "  ghosted compile local/applications/swe/acme/")
+	fmt.Fprintln(os.Stderr, "  ghosted compile local/applications/swe/acme-v2/ --link abc123")
+	os.Exit(1)
+}
+
+// documentSource returns the source of a document in dir: name.typ, else
+// name.md, else ""
+func documentSource(dir, name string) string {
+	for _, ext := range []string{".typ", ".md"} {
+		path := filepath.Join(dir, name+ext)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// requireCompiler exits if the tool that compiles src isn't installed:
+// pandoc for markdown, typst otherwise
+func requireCompiler(src string) {
+	if agent.IsMarkdownDocument(src) {
+		if !agent.IsPandocAvailable() {
+			fmt.Fprintln(os.Stderr, "Error: pandoc is not installed or not in PATH (needed for markdown documents)")
+			fmt.Fprintln(os.Stderr, "Install from: https://pandoc.org/installing.html")
+			os.Exit(1)
+		}
+		return
+	}
+	if _, err := exec.LookPath("typst"); err != nil {
+		fmt.Fprintln(os.Stderr, "Error: typst is not installed or not in PATH")
+		fmt.Fprintln(os.Stderr, "Install from: https://github.com/typst/typst")
+		os.Exit(1)
+	}
+}
+
+// compileCommand builds the command compiling src to pdf: pandoc for
+// markdown, typst otherwise
+func compileCommand(src, pdf string) *exec.Cmd {
+	if agent.IsMarkdownDocument(src) {
+		return exec.Command("pandoc", src, "-o", pdf)
+	}
+	return exec.Command("typst", "compile", src, pdf)
+}
+
+// loadOutputConfig reads the pipeline's output settings, falling back to
+// the defaults when there's no usable config
+func loadOutputConfig() agent.OutputConfig {
+	config, err := agent.LoadConfig(pipelineConfigPath)
+	if err != nil {
+		return agent.DefaultConfig().Output
+	}
+	return config.Output
+}
+
+// removeTypst deletes a compiled .typ (or .md) source unless
+// output.keep_typst is set.
+// Failing to remove it only warns: the PDF was already written.
+func removeTypst(path string, output agent.OutputConfig) {
+	if output.KeepTypst {
+		return
+	}
+	if err := os.Remove(path); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not remove %s: %v\n", path, err)
+		return
+	}
+	fmt.Printf("  Removed %s (output.keep_typst is off)\n", path)
+}
+
 // findAppByID finds an application by partial ID
 func findAppByID(s *store.Store, id string) *model.Application {
 	apps := s.List()
@@ -984,13 +1545,21 @@ func findAppByFolder(s *store.Store, folderPath string) *model.Application {
 
 // findAppFolder finds the application folder for an app
 func findAppFolder(app *model.Application) string {
+	// A linked folder wins over name matching
+	if app.DocumentsDir != "" {
+		if info, err := os.Stat(app.DocumentsDir); err == nil && info.IsDir() {
+			return app.DocumentsDir
+		}
+	}
 	// Look in local/applications for a matching folder
-	baseDir := "local/applications"
-	jobTypes := []string{"swe", "fe-dev", "ux-design", "product-design"}
+	return findAppFolderIn("local/applications", app)
+}
 
-	company := sanitizeFilename(app.Company)
-	position := sanitizeFilename(app.Position)
-	folderName := company + "-" + position
+// findAppFolderIn looks for an app's folder under baseDir, with or without
+// a job-type subfolder
+func findAppFolderIn(baseDir string, app *model.Application) string {
+	jobTypes := []string{"swe", "fe-dev", "ux-design", "product-design"}
+	folderName := appBaseName(app)
 
 	for _, jobType := range jobTypes {
 		path := filepath.Join(baseDir, jobType, folderName)
@@ -1008,6 +1577,12 @@ func findAppFolder(app *model.Application) string {
 	return ""
 }
 
+// appBaseName returns the "{company}-{position}" name used for an app's
+// folder and exported documents
+func appBaseName(app *model.Application) string {
+	return sanitizeFilename(app.Company) + "-" + sanitizeFilename(app.Position)
+}
+
 // sanitizeFilename creates a safe filename from a string
 func sanitizeFilename(s string) string {
 	s = strings.ToLower(s)
@@ -1052,7 +1627,8 @@ func isFlag(arg string) bool {
 
 // cmdUpgrade updates ghosted to the latest version
 func cmdUpgrade() {
-	fmt.Println("Upgrading ghosted to latest version...")
+	before := currentBuild().Version
+	fmt.Printf("Upgrading ghosted from %s to the latest version...\n", before)
 
 	// Check if go is available
 	if _, err := exec.LookPath("go"); err != nil {
@@ -1084,6 +1660,11 @@ func cmdUpgrade() {
 		binPath += ".exe"
 	}
 	fmt.Printf("Installed to: %s\n", binPath)
+
+	// Ask the new binary for its version
+	if out, err := exec.Command(binPath, "version", "--short").Output(); err == nil {
+		fmt.Printf("Version: %s -> %s\n", before, strings.TrimSpace(string(out)))
+	}
 }
 
 // cmdCV handles the cv subcommands
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/model"
+	"github.com/celloopa/ghosted/internal/store"
+)
+
+func TestCmdUpdate_StatusChangeAppliesRules(t *testing.T) {
+	dir := t.TempDir()
+	rulesPath := filepath.Join(dir, "rules.json")
+	rules := `[{"when": {"status": "interview"}, "then": {"set_followup": "+3d"}}]`
+	if err := os.WriteFile(rulesPath, []byte(rules), 0644); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := store.LoadRules(rulesPath)
+	if err != nil {
+		t.Fatalf("LoadRules() error = %v", err)
+	}
+
+	s, err := store.New(filepath.Join(dir, "applications.json"))
+	if err != nil {
+		t.Fatalf("store.New() error = %v", err)
+	}
+	s.SetRules(loaded)
+	app, err := s.Add(model.Application{Company: "Acme", Position: "Engineer", Status: model.StatusApplied})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	cmdUpdate(s, []string{shortID(app.ID), "--json", `{"status": "interview"}`})
+
+	got, _ := s.GetByID(app.ID)
+	now := time.Now()
+	want := time.Date(now.Year(), now.Month(), now.Day()+3, 0, 0, 0, 0, now.Location())
+	if got.Status != model.StatusInterview || got.NextFollowUp == nil || !got.NextFollowUp.Equal(want) {
+		t.Errorf("status %q, follow-up %v; want interview with the rule's follow-up %v", got.Status, got.NextFollowUp, want)
+	}
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/celloopa/ghosted/internal/model"
+	"github.com/celloopa/ghosted/internal/store"
+)
+
+const noteUsage = "Usage: ghosted note <id> <text | @shortcode [args]>..."
+
+// noteTemplatesPath maps note shortcodes to their text, e.g.
+// {"referral": "Referred by {{args}}"}
+var noteTemplatesPath = filepath.Join("local", "note-templates.json")
+
+// noteArgsPlaceholder is replaced with the words following a shortcode
+const noteArgsPlaceholder = "{{args}}"
+
+// cmdNote appends a note to an application, expanding @shortcodes from
+// local/note-templates.json
+func cmdNote(s *store.Store, args []string) {
+	if len(args) < 2 {
+		fmt.Fprintln(os.Stderr, noteUsage)
+		fmt.Fprintln(os.Stderr, "")
+		fmt.Fprintln(os.Stderr, "Examples:")
+		fmt.Fprintln(os.Stderr, "  ghosted note abc123 Spoke with the hiring manager")
+		fmt.Fprintln(os.Stderr, "  ghosted note abc123 @referral Jane Doe")
+		os.Exit(1)
+	}
+
+	templates, err := loadNoteTemplates(noteTemplatesPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	text, unknown := expandNote(strings.Join(args[1:], " "), templates)
+	for _, code := range unknown {
+		fmt.Fprintf(os.Stderr, "Warning: no note template for @%s in %s, leaving it as written\n", code, noteTemplatesPath)
+	}
+
+	app, err := appendNote(s, args[0], text)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Added note to %s @ %s: %s\n", app.Position, app.Company, text)
+}
+
+// loadNoteTemplates reads the shortcode templates. A missing file means no
+// templates.
+func loadNoteTemplates(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading note templates: %w", err)
+	}
+
+	var templates map[string]string
+	if err := json.Unmarshal(data, &templates); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return templates, nil
+}
+
+// expandNote replaces each @shortcode word in text with its template. A
+// template containing {{args}} takes the words after the shortcode, up to
+// the next shortcode, as its arguments. Unknown shortcodes are left as
+// written and returned.
+func expandNote(text string, templates map[string]string) (string, []string) {
+	words := strings.Fields(text)
+	var out, unknown []string
+
+	for i := 0; i < len(words); i++ {
+		code, ok := noteShortcode(words[i])
+		if !ok {
+			out = append(out, words[i])
+			continue
+		}
+		template, found := templates[code]
+		if !found {
+			unknown = append(unknown, code)
+			out = append(out, words[i])
+			continue
+		}
+		if !strings.Contains(template, noteArgsPlaceholder) {
+			out = append(out, template)
+			continue
+		}
+
+		var args []string
+		for i+1 < len(words) {
+			if _, next := noteShortcode(words[i+1]); next {
+				break
+			}
+			i++
+			args = append(args, words[i])
+		}
+		out = append(out, strings.ReplaceAll(template, noteArgsPlaceholder, strings.Join(args, " ")))
+	}
+
+	return strings.Join(out, " "), unknown
+}
+
+// noteShortcode returns the name of a "@name" word
+func noteShortcode(word string) (string, bool) {
+	if len(word) < 2 || word[0] != '@' {
+		return "", false
+	}
+	return word[1:], true
+}
+
+// appendNote adds text on a new line after the application's existing notes
+func appendNote(s *store.Store, id, text string) (model.Application, error) {
+	app := findAppByID(s, id)
+	if app == nil {
+		return model.Application{}, fmt.Errorf("application not found: %s", id)
+	}
+	if app.Notes == "" {
+		app.Notes = text
+	} else {
+		app.Notes = strings.TrimRight(app.Notes, "\n") + "\n" + text
+	}
+	if err := s.Update(*app); err != nil {
+		return model.Application{}, fmt.Errorf("updating application: %w", err)
+	}
+	return *app, nil
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/celloopa/ghosted/internal/model"
+	"github.com/celloopa/ghosted/internal/store"
+)
+
+var testNoteTemplates = map[string]string{
+	"referral": "Referred by {{args}}",
+	"easy":     "Applied via LinkedIn Easy Apply",
+}
+
+func TestExpandNote(t *testing.T) {
+	tests := []struct {
+		text        string
+		want        string
+		wantUnknown []string
+	}{
+		{"@easy", "Applied via LinkedIn Easy Apply", nil},
+		{"@referral Jane Doe", "Referred by Jane Doe", nil},
+		{"@referral Jane Doe @easy", "Referred by Jane Doe Applied via LinkedIn Easy Apply", nil},
+		{"@easy after the phone screen", "Applied via LinkedIn Easy Apply after the phone screen", nil},
+		{"@referral", "Referred by ", nil},
+		{"Emailed bob@acme.com directly", "Emailed bob@acme.com directly", nil},
+		{"@recruiter reached out", "@recruiter reached out", []string{"recruiter"}},
+	}
+	for _, tt := range tests {
+		got, unknown := expandNote(tt.text, testNoteTemplates)
+		if got != tt.want {
+			t.Errorf("expandNote(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+		if !reflect.DeepEqual(unknown, tt.wantUnknown) {
+			t.Errorf("expandNote(%q) unknown = %v, want %v", tt.text, unknown, tt.wantUnknown)
+		}
+	}
+}
+
+func TestLoadNoteTemplates(t *testing.T) {
+	dir := t.TempDir()
+
+	templates, err := loadNoteTemplates(filepath.Join(dir, "missing.json"))
+	if err != nil || len(templates) != 0 {
+		t.Errorf("loadNoteTemplates(missing) = %v, %v; want none", templates, err)
+	}
+
+	path := filepath.Join(dir, "note-templates.json")
+	if err := os.WriteFile(path, []byte(`{"referral": "Referred by {{args}}"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	templates, err = loadNoteTemplates(path)
+	if err != nil || templates["referral"] != "Referred by {{args}}" {
+		t.Errorf("loadNoteTemplates() = %v, %v", templates, err)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"referral": `), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadNoteTemplates(path); err == nil {
+		t.Error("loadNoteTemplates() with invalid JSON should error")
+	}
+}
+
+func TestAppendNote(t *testing.T) {
+	s, err := store.New(filepath.Join(t.TempDir(), "applications.json"))
+	if err != nil {
+		t.Fatalf("store.New() error = %v", err)
+	}
+	app, err := s.Add(model.Application{Company: "Acme", Position: "Engineer", Notes: "Found on HN"})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	if _, err := appendNote(s, shortID(app.ID), "Referred by Jane Doe"); err != nil {
+		t.Fatalf("appendNote() error = %v", err)
+	}
+	stored, _ := s.GetByID(app.ID)
+	if want := "Found on HN\nReferred by Jane Doe"; stored.Notes != want {
+		t.Errorf("Notes = %q, want %q", stored.Notes, want)
+	}
+
+	if _, err := appendNote(s, "nope", "x"); err == nil {
+		t.Error("appendNote() for an unknown ID should error")
+	}
+}
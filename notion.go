@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+// notionDateLayout is the date format Notion's CSV import recognizes
+const notionDateLayout = "2006-01-02"
+
+// notionColumns are the CSV header for a Notion database import. Name is
+// the title property; Status imports as a select.
+var notionColumns = []string{
+	"Name", "Company", "Position", "Status", "Date Applied", "Job Type",
+	"Location", "Remote", "Salary Min", "Salary Max", "Priority",
+	"Deadline", "Next Follow-up", "Contact", "Contact Email", "Job URL", "Notes",
+}
+
+// loadStatusMap reads a JSON object mapping ghosted statuses to Notion
+// status names, e.g. {"applied": "Submitted"}. Unknown statuses are an
+// error so a typo doesn't silently go unmapped.
+func loadStatusMap(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading status map: %w", err)
+	}
+	var statusMap map[string]string
+	if err := json.Unmarshal(data, &statusMap); err != nil {
+		return nil, fmt.Errorf("parsing status map %s: %w", path, err)
+	}
+	for status := range statusMap {
+		if !slices.Contains(model.AllStatuses(), status) {
+			return nil, fmt.Errorf("status map %s: unknown status %q", path, status)
+		}
+	}
+	return statusMap, nil
+}
+
+// notionStatus names a status for Notion: the mapped name if there is one,
+// otherwise its label ("Applied", "Interview", ...)
+func notionStatus(status string, statusMap map[string]string) string {
+	if name, ok := statusMap[status]; ok {
+		return name
+	}
+	return model.StatusLabel(status)
+}
+
+// notionDate formats an optional date, empty when unset
+func notionDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(notionDateLayout)
+}
+
+// notionNumber formats an optional number, empty when zero
+func notionNumber(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
+// writeNotionCSV writes apps as CSV ready to import into a Notion database
+func writeNotionCSV(w io.Writer, apps []model.Application, statusMap map[string]string) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(notionColumns); err != nil {
+		return err
+	}
+	for _, app := range apps {
+		remote := "No"
+		if app.Remote {
+			remote = "Yes"
+		}
+		if err := cw.Write([]string{
+			app.Position + " @ " + app.Company,
+			app.Company,
+			app.Position,
+			notionStatus(app.Status, statusMap),
+			notionDate(app.DateApplied),
+			appJobType(app),
+			app.Location,
+			remote,
+			notionNumber(app.SalaryMin),
+			notionNumber(app.SalaryMax),
+			notionNumber(app.Priority),
+			notionDate(app.Deadline),
+			notionDate(app.NextFollowUp),
+			app.ContactName,
+			app.ContactEmail,
+			app.JobURL,
+			app.Notes,
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
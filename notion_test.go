@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+func notionTestApps() []model.Application {
+	applied := time.Date(2026, 3, 9, 15, 4, 0, 0, time.UTC)
+	return []model.Application{
+		{ID: "1", Company: "Acme", Position: "Frontend Engineer", Status: model.StatusInterview,
+			DateApplied: &applied, Remote: true, SalaryMin: 140000, SalaryMax: 180000, Notes: "Line one\nline, two"},
+		{ID: "2", Company: "Globex", Position: "Designer", Status: model.StatusSaved},
+	}
+}
+
+func readNotionCSV(t *testing.T, apps []model.Application, statusMap map[string]string) [][]string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := writeNotionCSV(&buf, apps, statusMap); err != nil {
+		t.Fatalf("writeNotionCSV() error = %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("reading CSV back: %v", err)
+	}
+	return records
+}
+
+func TestWriteNotionCSV(t *testing.T) {
+	records := readNotionCSV(t, notionTestApps(), nil)
+
+	want := []string{
+		"Name", "Company", "Position", "Status", "Date Applied", "Job Type",
+		"Location", "Remote", "Salary Min", "Salary Max", "Priority",
+		"Deadline", "Next Follow-up", "Contact", "Contact Email", "Job URL", "Notes",
+	}
+	if !slices.Equal(records[0], want) {
+		t.Fatalf("header = %v, want %v", records[0], want)
+	}
+	if len(records) != 3 {
+		t.Fatalf("got %d rows, want header + 2", len(records))
+	}
+
+	col := func(row []string, name string) string { return row[slices.Index(want, name)] }
+	acme, globex := records[1], records[2]
+	checks := []struct{ got, want string }{
+		{col(acme, "Name"), "Frontend Engineer @ Acme"},
+		{col(acme, "Status"), "Interview"},
+		{col(acme, "Date Applied"), "2026-03-09"},
+		{col(acme, "Remote"), "Yes"},
+		{col(acme, "Salary Min"), "140000"},
+		{col(acme, "Job Type"), model.JobTypeFrontend},
+		{col(acme, "Notes"), "Line one\nline, two"},
+		{col(globex, "Status"), "Saved"},
+		{col(globex, "Remote"), "No"},
+		{col(globex, "Date Applied"), ""},
+		{col(globex, "Salary Min"), ""},
+	}
+	for _, c := range checks {
+		if c.got != c.want {
+			t.Errorf("got %q, want %q", c.got, c.want)
+		}
+	}
+}
+
+func TestWriteNotionCSV_StatusMap(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "statuses.json")
+	writeTestFile(t, path, `{"interview": "Interviewing", "saved": "Wishlist"}`)
+
+	statusMap, err := loadStatusMap(path)
+	if err != nil {
+		t.Fatalf("loadStatusMap() error = %v", err)
+	}
+	records := readNotionCSV(t, notionTestApps(), statusMap)
+
+	status := slices.Index(records[0], "Status")
+	if got := records[1][status]; got != "Interviewing" {
+		t.Errorf("Acme status = %q, want Interviewing", got)
+	}
+	if got := records[2][status]; got != "Wishlist" {
+		t.Errorf("Globex status = %q, want Wishlist", got)
+	}
+}
+
+func TestLoadStatusMap_UnknownStatus(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "statuses.json")
+	writeTestFile(t, path, `{"interviewing": "Interviewing"}`)
+
+	_, err := loadStatusMap(path)
+	if err == nil || !strings.Contains(err.Error(), `"interviewing"`) {
+		t.Errorf("loadStatusMap() error = %v, want unknown status error", err)
+	}
+}
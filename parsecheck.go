@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/celloopa/ghosted/internal/agent"
+)
+
+// cmdParseCheck reports how well local parsing handles the postings in a
+// directory, to show where the AI parser is still needed
+func cmdParseCheck(args []string) {
+	dir := "local/postings"
+	verbose := false
+
+	for _, arg := range args {
+		switch {
+		case arg == "--verbose" || arg == "-v":
+			verbose = true
+		case !isFlag(arg):
+			dir = arg
+		default:
+			fmt.Fprintln(os.Stderr, "Usage: ghosted parse-check [dir] [--verbose]")
+			os.Exit(1)
+		}
+	}
+
+	report, err := agent.CheckPostingsQuality(dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(report.Postings) == 0 {
+		fmt.Printf("No postings found in %s\n", dir)
+		return
+	}
+
+	for _, q := range report.Postings {
+		if !verbose && q.Usable() {
+			continue
+		}
+		fmt.Printf("%-9s %s\n", parseCheckLabel(q), filepath.Base(q.Path))
+		if q.Err != nil {
+			fmt.Printf("          %v\n", q.Err)
+		} else if missing := parseCheckMissing(q); len(missing) > 0 {
+			fmt.Printf("          missing: %s\n", strings.Join(missing, ", "))
+		}
+	}
+	if !verbose && report.Usable < len(report.Postings) {
+		fmt.Println()
+	}
+
+	fmt.Printf("Parse check: %d postings, %d usable, %d fallback, %d failed\n",
+		len(report.Postings), report.Usable, report.Fallback, report.Failed)
+}
+
+// parseCheckLabel returns the short result label for a posting
+func parseCheckLabel(q agent.PostingQuality) string {
+	switch {
+	case q.Err != nil:
+		return "failed"
+	case q.Usable():
+		return "usable"
+	default:
+		return "fallback"
+	}
+}
+
+// parseCheckMissing lists the fields local parsing could not read from content
+func parseCheckMissing(q agent.PostingQuality) []string {
+	var missing []string
+	if !q.CompanyFromContent {
+		missing = append(missing, "company (guessed from filename)")
+	}
+	if !q.PositionFromContent {
+		missing = append(missing, "position (guessed from filename)")
+	}
+	if q.Requirements == 0 {
+		missing = append(missing, "requirements")
+	}
+	return missing
+}
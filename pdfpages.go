@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+
+	"github.com/celloopa/ghosted/internal/agent"
+)
+
+// Page limits past which a compiled document is flagged
+const (
+	maxResumePages      = 2
+	maxCoverLetterPages = 1
+)
+
+var (
+	// pdfPagesDict matches a page tree node dictionary, which has no nested
+	// dictionaries
+	pdfPagesDict = regexp.MustCompile(`<<[^<>]*/Type\s*/Pages\b[^<>]*>>`)
+	pdfCount     = regexp.MustCompile(`/Count\s+(\d+)`)
+	// pdfPageObject matches a leaf page, for files without a readable count
+	pdfPageObject = regexp.MustCompile(`/Type\s*/Page\b`)
+	pdfStream     = regexp.MustCompile(`(?s)stream\r?\n(.*?)\r?\nendstream`)
+)
+
+// pdfPageCount returns the number of pages in a PDF. It reads the page tree
+// root's /Count, looking inside compressed object streams too, and falls
+// back to counting page objects. It doesn't resolve indirect references, so
+// unusual files may fail to count.
+func pdfPageCount(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	if !bytes.HasPrefix(data, []byte("%PDF-")) {
+		return 0, fmt.Errorf("%s is not a PDF", path)
+	}
+
+	// Page trees written by PDF 1.5+ tools may sit in compressed object
+	// streams; inflate every stream that decompresses and search it too
+	content := data
+	for _, m := range pdfStream.FindAllSubmatch(data, -1) {
+		r, err := zlib.NewReader(bytes.NewReader(m[1]))
+		if err != nil {
+			continue
+		}
+		inflated, err := io.ReadAll(r)
+		if err != nil && len(inflated) == 0 {
+			continue
+		}
+		content = append(content, inflated...)
+	}
+
+	// The root of the page tree has the largest count
+	pages := 0
+	for _, dict := range pdfPagesDict.FindAll(content, -1) {
+		if m := pdfCount.FindSubmatch(dict); m != nil {
+			if n, err := strconv.Atoi(string(m[1])); err == nil && n > pages {
+				pages = n
+			}
+		}
+	}
+	if pages == 0 {
+		pages = len(pdfPageObject.FindAll(content, -1))
+	}
+	if pages == 0 {
+		return 0, fmt.Errorf("no pages found in %s", path)
+	}
+	return pages, nil
+}
+
+// pageCountWarning describes a document longer than limit pages, or returns
+// "" when it fits
+func pageCountWarning(kind string, pages, limit int) string {
+	if pages <= limit {
+		return ""
+	}
+	return fmt.Sprintf("%s is %d pages (recommended: at most %d)", kind, pages, limit)
+}
+
+// warnPageCount prints a warning when a compiled PDF runs past limit pages.
+// PDFs that can't be counted are skipped quietly.
+func warnPageCount(kind, path string, limit int) {
+	pages, err := pdfPageCount(path)
+	if err != nil {
+		return
+	}
+	if msg := pageCountWarning(kind, pages, limit); msg != "" {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", msg)
+	}
+}
+
+// warnDocumentPages checks the page counts of a run's compiled PDFs
+func warnDocumentPages(docs *agent.GeneratedDocuments) {
+	if docs.ResumePDF != "" {
+		warnPageCount("Resume", docs.ResumePDF, maxResumePages)
+	}
+	if docs.CoverLetterPDF != "" {
+		warnPageCount("Cover letter", docs.CoverLetterPDF, maxCoverLetterPages)
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+func TestPDFPageCount(t *testing.T) {
+	pages, err := pdfPageCount(filepath.Join("testdata", "three-pages.pdf"))
+	if err != nil {
+		t.Fatalf("pdfPageCount() error = %v", err)
+	}
+	if pages != 3 {
+		t.Errorf("pdfPageCount() = %d, want 3", pages)
+	}
+}
+
+func TestPDFPageCount_ObjectStream(t *testing.T) {
+	// Page tree inside a compressed object stream, as PDF 1.5+ writers emit
+	var objects bytes.Buffer
+	zw := zlib.NewWriter(&objects)
+	fmt.Fprint(zw, "<< /Type /Pages /Kids [3 0 R 4 0 R] /Count 2 >> << /Type /Page /Parent 2 0 R >> << /Type /Page /Parent 2 0 R >>")
+	zw.Close()
+
+	path := filepath.Join(t.TempDir(), "compressed.pdf")
+	writeTestFile(t, path, "%PDF-1.7\n1 0 obj\n<< /Type /ObjStm /Filter /FlateDecode >>\nstream\n"+objects.String()+"\nendstream\nendobj\n%%EOF\n")
+
+	pages, err := pdfPageCount(path)
+	if err != nil {
+		t.Fatalf("pdfPageCount() error = %v", err)
+	}
+	if pages != 2 {
+		t.Errorf("pdfPageCount() = %d, want 2", pages)
+	}
+}
+
+func TestPDFPageCount_NotPDF(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "resume.pdf")
+	writeTestFile(t, path, "not a pdf")
+	if _, err := pdfPageCount(path); err == nil {
+		t.Error("pdfPageCount() on a non-PDF should error")
+	}
+}
+
+func TestPageCountWarning(t *testing.T) {
+	pages, err := pdfPageCount(filepath.Join("testdata", "three-pages.pdf"))
+	if err != nil {
+		t.Fatalf("pdfPageCount() error = %v", err)
+	}
+	if msg := pageCountWarning("Resume", pages, maxResumePages); msg != "Resume is 3 pages (recommended: at most 2)" {
+		t.Errorf("resume warning = %q", msg)
+	}
+	if msg := pageCountWarning("Cover letter", 1, maxCoverLetterPages); msg != "" {
+		t.Errorf("one-page cover letter warned: %q", msg)
+	}
+	if msg := pageCountWarning("Resume", 2, maxResumePages); msg != "" {
+		t.Errorf("two-page resume warned: %q", msg)
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/agent"
+	"github.com/celloopa/ghosted/internal/timefmt"
+)
+
+const pipelineUsage = "Usage: ghosted pipeline list"
+
+// defaultPruneAge is how old a failed run's state must be for
+// apply --prune-state to remove it
+const defaultPruneAge = 7 * 24 * time.Hour
+
+// pipelineStateDir holds the pipeline's run states and kept failed states
+func pipelineStateDir() string {
+	return filepath.Dir(pipelineConfigPath)
+}
+
+// cmdPipeline inspects pipeline runs
+func cmdPipeline(args []string) {
+	if len(args) != 1 || args[0] != "list" {
+		fmt.Fprintln(os.Stderr, pipelineUsage)
+		os.Exit(1)
+	}
+
+	states, err := agent.ListResumableStates(pipelineStateDir())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	printResumableStates(os.Stdout, states, time.Now())
+}
+
+// printResumableStates lists failed runs with their posting and failed step
+func printResumableStates(w io.Writer, states []agent.ResumableState, now time.Time) {
+	if len(states) == 0 {
+		fmt.Fprintln(w, "No failed pipeline runs kept.")
+		return
+	}
+
+	fmt.Fprintf(w, "%d failed run(s):\n", len(states))
+	for _, state := range states {
+		fmt.Fprintf(w, "\n  %s\n", state.PostingPath)
+		fmt.Fprintf(w, "    Failed at: %s (%s)\n", state.FailedStep, timefmt.HumanizeTime(state.ModTime, now))
+		if state.Error != "" {
+			fmt.Fprintf(w, "    Error:     %s\n", state.Error)
+		}
+		fmt.Fprintf(w, "    State:     %s\n", state.Path)
+	}
+	fmt.Fprintln(w, "\nResume with 'ghosted apply <posting> --resume', re-run with 'ghosted apply <posting>', or clear old runs with 'ghosted apply --prune-state'.")
+}
+
+// pruneStates removes failed run states older than olderThan and reports
+// what was removed
+func pruneStates(w io.Writer, olderThan time.Duration) error {
+	pruned, err := agent.PruneFailedStates(pipelineStateDir(), olderThan, time.Now())
+	if err != nil {
+		return err
+	}
+	if len(pruned) == 0 {
+		fmt.Fprintf(w, "No failed pipeline states older than %d day(s).\n", int(olderThan.Hours()/24))
+		return nil
+	}
+	for _, state := range pruned {
+		fmt.Fprintf(w, "Removed state for %s (failed at %s)\n", state.PostingPath, state.FailedStep)
+	}
+	return nil
+}
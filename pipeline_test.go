@@ -0,0 +1,36 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/agent"
+)
+
+func TestPrintResumableStates(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+	states := []agent.ResumableState{{
+		Path:        "local/document-generation/.agent/failed/acme-swe.json",
+		PostingPath: "local/postings/acme-swe.md",
+		FailedStep:  agent.AgentResume,
+		Error:       "template not found",
+		ModTime:     now.Add(-2 * time.Hour),
+	}}
+
+	var buf bytes.Buffer
+	printResumableStates(&buf, states, now)
+	out := buf.String()
+	for _, want := range []string{"1 failed run(s)", "local/postings/acme-swe.md", "Failed at: resume", "template not found", "failed/acme-swe.json"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	printResumableStates(&buf, nil, now)
+	if !strings.Contains(buf.String(), "No failed pipeline runs") {
+		t.Errorf("empty output = %q", buf.String())
+	}
+}
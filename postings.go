@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/celloopa/ghosted/internal/agent"
+	"github.com/celloopa/ghosted/internal/store"
+)
+
+const postingsDedupUsage = "Usage: ghosted postings dedup [dir] [--dry-run|--remove]"
+
+// cmdPostings lists pending and archived postings with their linked applications
+func cmdPostings(s *store.Store, args []string) {
+	if len(args) > 0 && args[0] == "dedup" {
+		cmdPostingsDedup(args[1:])
+		return
+	}
+
+	dir := "local/postings"
+	if len(args) > 0 && !isFlag(args[0]) {
+		dir = args[0]
+	}
+
+	postings, err := agent.ListPostings(dir, s)
+	if err != nil {
+		if os.IsNotExist(err) {
+			fmt.Fprintf(os.Stderr, "Error: postings directory not found: %s\n", dir)
+		} else {
+			fmt.Fprintf(os.Stderr, "Error listing postings: %v\n", err)
+		}
+		os.Exit(1)
+	}
+
+	var pending, archived []agent.PostingInfo
+	for _, p := range postings {
+		if p.Archived {
+			archived = append(archived, p)
+		} else {
+			pending = append(pending, p)
+		}
+	}
+
+	fmt.Printf("Pending (%d):\n", len(pending))
+	if len(pending) == 0 {
+		fmt.Println("  (none)")
+	}
+	for _, p := range pending {
+		fmt.Printf("  • %s%s\n", filepath.Base(p.Path), postingLink(p))
+	}
+
+	fmt.Printf("\nArchived (%d):\n", len(archived))
+	if len(archived) == 0 {
+		fmt.Println("  (none)")
+	}
+	for _, p := range archived {
+		line := filepath.Base(p.Path)
+		if !p.Entry.ArchivedAt.IsZero() {
+			line += fmt.Sprintf(" (archived %s)", p.Entry.ArchivedAt.Local().Format("2006-01-02"))
+		}
+		fmt.Printf("  • %s%s\n", line, postingLink(p))
+	}
+}
+
+// postingLink describes the application a posting is linked to, if any
+func postingLink(p agent.PostingInfo) string {
+	switch {
+	case p.App != nil:
+		return fmt.Sprintf(" → %s %s - %s", shortID(p.App.ID), p.App.Company, p.App.Position)
+	case p.Entry.ApplicationID != "":
+		return fmt.Sprintf(" → %s (application deleted)", shortID(p.Entry.ApplicationID))
+	default:
+		return ""
+	}
+}
+
+// cmdPostingsDedup reports pending postings that are the same job fetched
+// more than once, and with --remove deletes all but the newest of each.
+// Reporting only (--dry-run) is the default.
+func cmdPostingsDedup(args []string) {
+	dir := "local/postings"
+	remove := false
+	dirSet := false
+	for _, arg := range args {
+		switch {
+		case arg == "--dry-run":
+			remove = false
+		case arg == "--remove":
+			remove = true
+		case !dirSet && !isFlag(arg):
+			dir, dirSet = arg, true
+		default:
+			fmt.Fprintln(os.Stderr, postingsDedupUsage)
+			os.Exit(1)
+		}
+	}
+
+	groups, err := agent.FindDuplicatePostings(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			fmt.Fprintf(os.Stderr, "Error: postings directory not found: %s\n", dir)
+		} else {
+			fmt.Fprintf(os.Stderr, "Error checking postings: %v\n", err)
+		}
+		os.Exit(1)
+	}
+
+	if len(groups) == 0 {
+		fmt.Printf("No duplicate postings in %s\n", dir)
+		return
+	}
+
+	count := 0
+	for _, group := range groups {
+		fmt.Printf("Keep %s (matched by %s)\n", filepath.Base(group.Keep), group.Reason)
+		for _, path := range group.Duplicates {
+			fmt.Printf("  duplicate: %s\n", filepath.Base(path))
+			count++
+		}
+	}
+
+	if !remove {
+		fmt.Printf("\n%d duplicate(s) found. Run with --remove to delete them, keeping the newest of each.\n", count)
+		return
+	}
+	removed, err := agent.RemoveDuplicatePostings(groups)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: removed %d duplicate(s), then: %v\n", len(removed), err)
+		os.Exit(1)
+	}
+	fmt.Printf("\nRemoved %d duplicate(s)\n", len(removed))
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/agent"
+	"github.com/celloopa/ghosted/internal/model"
+	"github.com/celloopa/ghosted/internal/store"
+)
+
+const predictUsage = "Usage: ghosted predict <posting-file> [--cv path]"
+
+// minPredictHistory is how many past applications a group needs before its
+// rates are used; smaller groups fall back to a broader one
+const minPredictHistory = 5
+
+// Likelihood tuning. Historical interview rates are smoothed toward
+// baselineInterviewRate as if baselineWeight extra applications had been
+// sent, so a thin history doesn't swing the estimate to 0 or 100.
+const (
+	baselineInterviewRate = 0.2
+	baselineWeight        = 5
+	coverageWeight        = 0.7 // share of the profile score from keyword coverage; the rest is seniority fit
+)
+
+// Prediction is a heuristic estimate of how likely a posting is to lead to
+// an interview
+type Prediction struct {
+	Likelihood   int     // 0-100
+	Coverage     float64 // keyword coverage percent
+	Band         string
+	CVYears      int
+	Required     int
+	SeniorityFit float64
+	JobType      string
+	Rates        store.OutcomeRates
+	Basis        string // which past applications the rates come from
+}
+
+// cmdPredict estimates the chance of an interview for a posting from CV
+// coverage, seniority fit, and the outcomes of similar past applications
+func cmdPredict(s *store.Store, args []string) {
+	var postingPath string
+	cvPath := defaultCVPath
+
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--cv" && i+1 < len(args):
+			cvPath = args[i+1]
+			i++
+		case !isFlag(args[i]) && postingPath == "":
+			postingPath = args[i]
+		default:
+			fmt.Fprintln(os.Stderr, predictUsage)
+			os.Exit(1)
+		}
+	}
+	if postingPath == "" {
+		fmt.Fprintln(os.Stderr, predictUsage)
+		os.Exit(1)
+	}
+
+	cv, err := readCV(cvPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	pipeline, err := agent.NewPipeline(pipelineConfigPath, nil)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	posting, err := pipeline.Parse(postingPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	bands := historicalBands(s, pipeline, cv)
+	prediction := predict(s, cv, posting, bands, time.Now())
+	printPrediction(os.Stdout, posting, prediction)
+}
+
+// readCV loads a JSON Resume CV
+func readCV(path string) (*agent.CVData, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading CV: %w", err)
+	}
+	var cv agent.CVData
+	if err := json.Unmarshal(data, &cv); err != nil {
+		return nil, fmt.Errorf("parsing CV %s: %w", path, err)
+	}
+	return &cv, nil
+}
+
+// coverageBand groups a keyword coverage percent so past applications with
+// similar coverage can be compared
+func coverageBand(coverage float64) string {
+	switch {
+	case coverage >= 70:
+		return "high"
+	case coverage >= 40:
+		return "medium"
+	default:
+		return "low"
+	}
+}
+
+// historicalBands returns the coverage band of each past application whose
+// posting file is still on disk, keyed by ID. Applications without a
+// readable posting are left out.
+func historicalBands(s *store.Store, pipeline *agent.Pipeline, cv *agent.CVData) map[string]string {
+	bands := make(map[string]string)
+	for _, app := range s.List() {
+		if app.Status == model.StatusSaved || app.PostingPath == "" || !fileExists(app.PostingPath) {
+			continue
+		}
+		posting, err := pipeline.Parse(app.PostingPath)
+		if err != nil {
+			continue
+		}
+		bands[app.ID] = coverageBand(agent.AnalyzeGaps(cv, posting).MatchPercent)
+	}
+	return bands
+}
+
+// appJobType is the application's recorded job type, inferred from the
+// position for entries created before it was recorded
+func appJobType(app model.Application) string {
+	if app.JobType != "" {
+		return app.JobType
+	}
+	return model.InferJobType(app.Position)
+}
+
+// predict combines the posting's coverage and seniority fit with the rates
+// of the most specific group of past applications that has enough history:
+// the same job type and coverage band, then the same job type, then all
+func predict(s *store.Store, cv *agent.CVData, posting *agent.ParsedPosting, bands map[string]string, now time.Time) Prediction {
+	p := Prediction{
+		Coverage: agent.AnalyzeGaps(cv, posting).MatchPercent,
+		CVYears:  cv.YearsExperience(now),
+		Required: posting.MinYearsExperience,
+		JobType:  model.InferJobType(posting.Position),
+	}
+	p.Band = coverageBand(p.Coverage)
+	p.SeniorityFit = agent.SeniorityFit(p.CVYears, p.Required)
+
+	groups := []struct {
+		basis string
+		match func(model.Application) bool
+	}{
+		{fmt.Sprintf("%s applications in the %s coverage band", p.JobType, p.Band), func(a model.Application) bool {
+			return appJobType(a) == p.JobType && bands[a.ID] == p.Band
+		}},
+		{fmt.Sprintf("%s applications", p.JobType), func(a model.Application) bool {
+			return appJobType(a) == p.JobType
+		}},
+		{"applications", nil},
+	}
+	for _, group := range groups {
+		p.Rates, p.Basis = s.OutcomeRates(group.match), group.basis
+		if p.Rates.Applied >= minPredictHistory {
+			break
+		}
+	}
+
+	p.Likelihood = predictLikelihood(p.Coverage, p.SeniorityFit, p.Rates)
+	return p
+}
+
+// predictLikelihood scales the smoothed historical interview rate by how
+// well the profile matches: a perfect match raises it by half, no match
+// halves it. The result is clamped to 0-100.
+func predictLikelihood(coverage, seniorityFit float64, rates store.OutcomeRates) int {
+	coverage = math.Max(0, math.Min(100, coverage))
+	seniorityFit = math.Max(0, math.Min(1, seniorityFit))
+	profile := coverageWeight*coverage/100 + (1-coverageWeight)*seniorityFit
+
+	rate := (float64(rates.Interviewed) + baselineInterviewRate*baselineWeight) / float64(rates.Applied+baselineWeight)
+	likelihood := int(math.Round(100 * rate * (0.5 + profile)))
+	return max(0, min(100, likelihood))
+}
+
+// printPrediction writes the estimate and the factors behind it
+func printPrediction(w io.Writer, posting *agent.ParsedPosting, p Prediction) {
+	fmt.Fprintf(w, "%s @ %s\n", posting.Position, posting.Company)
+	fmt.Fprintf(w, "Estimated interview likelihood: %d%% (heuristic)\n\n", p.Likelihood)
+
+	fmt.Fprintf(w, "  Keyword coverage: %.1f%% (%s band)\n", p.Coverage, p.Band)
+	if p.Required > 0 {
+		fmt.Fprintf(w, "  Seniority fit:    %d of %d+ years (%.0f%%)\n", p.CVYears, p.Required, p.SeniorityFit*100)
+	} else {
+		fmt.Fprintf(w, "  Seniority fit:    no experience requirement found\n")
+	}
+	if p.Rates.Applied == 0 {
+		fmt.Fprintf(w, "  History:          no past applications, using a %.0f%% baseline\n", baselineInterviewRate*100)
+	} else {
+		fmt.Fprintf(w, "  History:          %d of %d %s reached an interview (%.0f%%), %d an offer (%.0f%%)\n",
+			p.Rates.Interviewed, p.Rates.Applied, p.Basis, p.Rates.InterviewRate()*100, p.Rates.Offered, p.Rates.OfferRate()*100)
+	}
+
+	fmt.Fprintln(w, "\nThis is a rough heuristic from keyword matching and your own history, not a forecast.")
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/agent"
+	"github.com/celloopa/ghosted/internal/model"
+	"github.com/celloopa/ghosted/internal/store"
+)
+
+func TestPredictLikelihood_Bounded(t *testing.T) {
+	cases := []struct {
+		name     string
+		coverage float64
+		fit      float64
+		rates    store.OutcomeRates
+	}{
+		{"no history, no match", 0, 0, store.OutcomeRates{}},
+		{"perfect history, perfect match", 100, 1, store.OutcomeRates{Applied: 50, Interviewed: 50, Offered: 20}},
+		{"out of range inputs", 250, 3, store.OutcomeRates{Applied: 10, Interviewed: 10}},
+		{"negative inputs", -40, -1, store.OutcomeRates{Applied: 10}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := predictLikelihood(tc.coverage, tc.fit, tc.rates)
+			if got < 0 || got > 100 {
+				t.Errorf("predictLikelihood() = %d, want 0-100", got)
+			}
+		})
+	}
+}
+
+func TestPredictLikelihood_CombinesCoverageAndHistory(t *testing.T) {
+	good := store.OutcomeRates{Applied: 20, Interviewed: 10}
+	poor := store.OutcomeRates{Applied: 20, Interviewed: 1}
+
+	if high, low := predictLikelihood(90, 1, good), predictLikelihood(20, 1, good); high <= low {
+		t.Errorf("higher coverage gave %d, lower gave %d; want higher coverage to score higher", high, low)
+	}
+	if high, low := predictLikelihood(80, 1, good), predictLikelihood(80, 1, poor); high <= low {
+		t.Errorf("better history gave %d, worse gave %d; want better history to score higher", high, low)
+	}
+	if fit, underqualified := predictLikelihood(80, 1, good), predictLikelihood(80, 0.4, good); fit <= underqualified {
+		t.Errorf("full seniority fit gave %d, partial gave %d; want full fit to score higher", fit, underqualified)
+	}
+
+	// No history falls back to the baseline rate rather than zero
+	if got := predictLikelihood(100, 1, store.OutcomeRates{}); got != 30 {
+		t.Errorf("predictLikelihood() with no history = %d, want 30 (20%% baseline x 1.5)", got)
+	}
+}
+
+func TestCoverageBand(t *testing.T) {
+	for coverage, want := range map[float64]string{0: "low", 39.9: "low", 40: "medium", 69.9: "medium", 70: "high", 100: "high"} {
+		if got := coverageBand(coverage); got != want {
+			t.Errorf("coverageBand(%v) = %q, want %q", coverage, got, want)
+		}
+	}
+}
+
+func TestPredict_FallsBackToBroaderHistory(t *testing.T) {
+	s, err := store.New(filepath.Join(t.TempDir(), "applications.json"))
+	if err != nil {
+		t.Fatalf("store.New() error = %v", err)
+	}
+	add := func(position, status string) string {
+		app, err := s.Add(model.Application{Company: "Acme", Position: position, Status: status})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return app.ID
+	}
+
+	// Five frontend applications, two in the high coverage band
+	bands := map[string]string{}
+	bands[add("Frontend Engineer", model.StatusInterview)] = "high"
+	bands[add("Frontend Engineer", model.StatusOffer)] = "high"
+	add("Frontend Engineer", model.StatusRejected)
+	add("React Developer", model.StatusRejected)
+	add("UI Engineer", model.StatusApplied)
+	add("Backend Engineer", model.StatusRejected)
+	add("Frontend Engineer", model.StatusSaved)
+
+	cv := &agent.CVData{
+		Skills: []agent.CVSkill{{Name: "Frontend", Keywords: []string{"React", "TypeScript"}}},
+		Work:   []agent.CVWork{{StartDate: "2020-01"}},
+	}
+	posting := &agent.ParsedPosting{Position: "Frontend Engineer", TechStack: []string{"React", "TypeScript"}, MinYearsExperience: 3}
+
+	p := predict(s, cv, posting, bands, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	if p.Band != "high" || p.JobType != model.JobTypeFrontend {
+		t.Fatalf("prediction = %+v, want high band, %s", p, model.JobTypeFrontend)
+	}
+	// The high band only has two applications, so the job type's rates are used
+	if p.Rates != (store.OutcomeRates{Applied: 5, Interviewed: 2, Offered: 1}) {
+		t.Errorf("Rates = %+v, want the five frontend applications", p.Rates)
+	}
+	if p.CVYears != 6 || p.SeniorityFit != 1 {
+		t.Errorf("CVYears = %d, SeniorityFit = %v, want 6 and 1", p.CVYears, p.SeniorityFit)
+	}
+	if p.Likelihood < 0 || p.Likelihood > 100 {
+		t.Errorf("Likelihood = %d, want 0-100", p.Likelihood)
+	}
+}
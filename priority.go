@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/celloopa/ghosted/internal/model"
+	"github.com/celloopa/ghosted/internal/store"
+)
+
+// cmdPriority sets an application's priority (1-5, 0 clears it)
+func cmdPriority(s *store.Store, args []string) {
+	if len(args) != 2 {
+		fmt.Fprintln(os.Stderr, "Usage: ghosted priority <id> <0-5>")
+		os.Exit(1)
+	}
+
+	priority, err := strconv.Atoi(args[1])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: priority must be a number from 0 to %d, got %q\n", model.MaxPriority, args[1])
+		os.Exit(1)
+	}
+	if clamped := model.ClampPriority(priority); clamped != priority {
+		fmt.Fprintf(os.Stderr, "Warning: priority %d is out of range, using %d\n", priority, clamped)
+	}
+
+	app, err := setPriority(s, args[0], priority)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if app.Priority == 0 {
+		fmt.Printf("Cleared priority for %s @ %s\n", app.Position, app.Company)
+		return
+	}
+	fmt.Printf("Set priority %d %s for %s @ %s\n", app.Priority, model.PriorityStars(app.Priority), app.Position, app.Company)
+}
+
+// setPriority stores priority on the application matching id (or an ID
+// prefix), clamped to the valid range
+func setPriority(s *store.Store, id string, priority int) (model.Application, error) {
+	app := findAppByID(s, id)
+	if app == nil {
+		return model.Application{}, fmt.Errorf("application not found: %s", id)
+	}
+	app.Priority = model.ClampPriority(priority)
+	if err := s.Update(*app); err != nil {
+		return model.Application{}, fmt.Errorf("updating application: %w", err)
+	}
+	return *app, nil
+}
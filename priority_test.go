@@ -0,0 +1,53 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/celloopa/ghosted/internal/model"
+	"github.com/celloopa/ghosted/internal/store"
+)
+
+func TestSetPriority_ClampsOutOfRange(t *testing.T) {
+	s, err := store.New(filepath.Join(t.TempDir(), "applications.json"))
+	if err != nil {
+		t.Fatalf("store.New() error = %v", err)
+	}
+	app, err := s.Add(model.Application{Company: "Acme", Position: "Software Engineer", Status: model.StatusSaved})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	tests := []struct {
+		priority int
+		want     int
+	}{
+		{3, 3},
+		{9, 5},
+		{-2, 0},
+		{0, 0},
+	}
+	for _, tt := range tests {
+		got, err := setPriority(s, shortID(app.ID), tt.priority)
+		if err != nil {
+			t.Fatalf("setPriority(%d) error = %v", tt.priority, err)
+		}
+		if got.Priority != tt.want {
+			t.Errorf("setPriority(%d) = %d, want %d", tt.priority, got.Priority, tt.want)
+		}
+		stored, _ := s.GetByID(app.ID)
+		if stored.Priority != tt.want {
+			t.Errorf("stored priority after setPriority(%d) = %d, want %d", tt.priority, stored.Priority, tt.want)
+		}
+	}
+}
+
+func TestSetPriority_UnknownID(t *testing.T) {
+	s, err := store.New(filepath.Join(t.TempDir(), "applications.json"))
+	if err != nil {
+		t.Fatalf("store.New() error = %v", err)
+	}
+	if _, err := setPriority(s, "does-not-exist", 3); err == nil {
+		t.Error("setPriority() error = nil for an unknown ID")
+	}
+}
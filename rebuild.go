@@ -0,0 +1,57 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/celloopa/ghosted/internal/store"
+)
+
+// getOpLogPath returns the operation log, stored next to the data file with
+// a .log extension (applications.log)
+func getOpLogPath() string {
+	dataPath := getDataPath()
+	return strings.TrimSuffix(dataPath, filepath.Ext(dataPath)) + ".log"
+}
+
+// cmdRebuild replaces the data file with the state replayed from the
+// operation log. It runs before the store is loaded, so it works when the
+// data file is corrupted. A data file with changes the log never saw is
+// left alone unless --force is given.
+func cmdRebuild(args []string) {
+	force := len(args) == 1 && args[0] == "--force"
+	if len(args) > 0 && !force {
+		fmt.Fprintln(os.Stderr, "Usage: ghosted rebuild [--force]")
+		os.Exit(1)
+	}
+
+	log := store.NewOpLog(getOpLogPath())
+	if !fileExists(log.Path()) {
+		fmt.Fprintf(os.Stderr, "Error: no operation log at %s\n", log.Path())
+		os.Exit(1)
+	}
+
+	result, backup, err := store.Rebuild(getDataPath(), log, force)
+	if errors.Is(err, store.ErrDrift) {
+		fmt.Fprintf(os.Stderr, "Error: %s has changes that aren't in %s, so rebuilding would discard them:\n", getDataPath(), log.Path())
+		for _, change := range result.Drift {
+			fmt.Fprintf(os.Stderr, "  %s\n", change)
+		}
+		fmt.Fprintln(os.Stderr, "Run 'ghosted rebuild --force' to rebuild anyway (the data file is backed up first).")
+		os.Exit(1)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if backup != "" {
+		fmt.Printf("Backup created: %s\n", backup)
+	}
+	for _, line := range result.Skipped {
+		fmt.Fprintf(os.Stderr, "Warning: skipped malformed line %d of %s\n", line, log.Path())
+	}
+	fmt.Printf("Rebuilt %d application(s) from %d operation(s)\n", len(result.Applications), result.Applied)
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/model"
+	"github.com/celloopa/ghosted/internal/store"
+)
+
+const respondUsage = "Usage: ghosted respond <id> [--date YYYY-MM-DD]"
+
+// cmdRespond records when a company first responded to an application,
+// without changing its status
+func cmdRespond(s *store.Store, args []string) {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, respondUsage)
+		os.Exit(1)
+	}
+
+	at := time.Now()
+	for i := 1; i < len(args); i++ {
+		if args[i] != "--date" || i+1 >= len(args) {
+			fmt.Fprintln(os.Stderr, respondUsage)
+			os.Exit(1)
+		}
+		t, err := time.ParseInLocation("2006-01-02", args[i+1], time.Local)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --date expects YYYY-MM-DD, got %q\n", args[i+1])
+			os.Exit(1)
+		}
+		at = t
+		i++
+	}
+
+	app, err := setFirstResponse(s, args[0], at)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Recorded a response from %s (%s) on %s", app.Company, app.Position, at.Format("2006-01-02"))
+	if app.DateApplied != nil && !at.Before(*app.DateApplied) {
+		fmt.Printf(", %d day(s) after applying", int(at.Sub(*app.DateApplied).Hours()/24))
+	}
+	fmt.Println()
+}
+
+// setFirstResponse records at as the first response to the application
+// matching id (or an ID prefix), replacing any earlier record
+func setFirstResponse(s *store.Store, id string, at time.Time) (model.Application, error) {
+	app := findAppByID(s, id)
+	if app == nil {
+		return model.Application{}, fmt.Errorf("application not found: %s", id)
+	}
+	app.FirstResponseAt = &at
+	if err := s.Update(*app); err != nil {
+		return model.Application{}, fmt.Errorf("updating application: %w", err)
+	}
+	return *app, nil
+}
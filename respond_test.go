@@ -0,0 +1,38 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/model"
+	"github.com/celloopa/ghosted/internal/store"
+)
+
+func TestSetFirstResponse(t *testing.T) {
+	s, err := store.New(filepath.Join(t.TempDir(), "applications.json"))
+	if err != nil {
+		t.Fatalf("store.New() error = %v", err)
+	}
+	app, err := s.Add(model.Application{Company: "Acme", Position: "Software Engineer", Status: model.StatusApplied})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	at := time.Date(2026, 4, 2, 0, 0, 0, 0, time.UTC)
+	got, err := setFirstResponse(s, shortID(app.ID), at)
+	if err != nil {
+		t.Fatalf("setFirstResponse() error = %v", err)
+	}
+	if got.FirstResponseAt == nil || !got.FirstResponseAt.Equal(at) {
+		t.Errorf("FirstResponseAt = %v, want %v", got.FirstResponseAt, at)
+	}
+	stored, _ := s.GetByID(app.ID)
+	if stored.FirstResponseAt == nil || !stored.FirstResponseAt.Equal(at) || stored.Status != model.StatusApplied {
+		t.Errorf("stored = %v (%s), want the response recorded and status unchanged", stored.FirstResponseAt, stored.Status)
+	}
+
+	if _, err := setFirstResponse(s, "nope", at); err == nil {
+		t.Error("setFirstResponse() with an unknown ID succeeded")
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/celloopa/ghosted/internal/agent"
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+// minAcceptableSalary returns the salary floor: GHOSTED_MIN_SALARY if set,
+// otherwise the pipeline config's min_acceptable_salary. Zero means no floor.
+// config may be nil.
+func minAcceptableSalary(config *agent.PipelineConfig) int {
+	if v := os.Getenv("GHOSTED_MIN_SALARY"); v != "" {
+		floor, err := strconv.Atoi(v)
+		if err == nil && floor >= 0 {
+			return floor
+		}
+		fmt.Fprintf(os.Stderr, "Warning: ignoring invalid GHOSTED_MIN_SALARY %q\n", v)
+	}
+	if config != nil {
+		return config.MinAcceptableSalary
+	}
+	return 0
+}
+
+// loadSalaryFloor reads the salary floor without requiring a pipeline config
+func loadSalaryFloor() int {
+	config, _ := agent.LoadConfig(pipelineConfigPath)
+	return minAcceptableSalary(config)
+}
+
+// warnSalary prints a warning when the application's salary is below the
+// floor or undisclosed
+func warnSalary(app model.Application, floor int) {
+	if msg := app.SalaryWarning(floor); msg != "" {
+		fmt.Fprintf(os.Stderr, "Warning: %s - %s: %s\n", app.Company, app.Position, msg)
+	}
+}
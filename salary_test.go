@@ -0,0 +1,32 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/celloopa/ghosted/internal/agent"
+)
+
+func TestMinAcceptableSalary(t *testing.T) {
+	config := &agent.PipelineConfig{MinAcceptableSalary: 120000}
+
+	tests := []struct {
+		name   string
+		env    string
+		config *agent.PipelineConfig
+		want   int
+	}{
+		{"config only", "", config, 120000},
+		{"env overrides config", "150000", config, 150000},
+		{"invalid env falls back to config", "lots", config, 120000},
+		{"no config", "", nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GHOSTED_MIN_SALARY", tt.env)
+			if got := minAcceptableSalary(tt.config); got != tt.want {
+				t.Errorf("minAcceptableSalary() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
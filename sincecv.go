@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/celloopa/ghosted/internal/agent"
+	"github.com/celloopa/ghosted/internal/model"
+	"github.com/celloopa/ghosted/internal/store"
+
+	"github.com/charmbracelet/x/term"
+)
+
+// staleApplication is an application whose documents were generated from
+// an older version of the CV
+type staleApplication struct {
+	App model.Application
+	// Posting is the posting file to regenerate from, or "" if none is left
+	Posting string
+}
+
+// applicationsPredatingCV returns the open applications (not accepted,
+// rejected, or withdrawn) with a generated document in their folder under
+// baseDir that predates the CV at cvPath. Documents without a metadata
+// header can't be dated and are left alone.
+func applicationsPredatingCV(apps []model.Application, baseDir, cvPath string) ([]staleApplication, error) {
+	info, err := os.Stat(cvPath)
+	if err != nil {
+		return nil, err
+	}
+	cvHash, err := agent.HashCV(cvPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var stale []staleApplication
+	for _, app := range apps {
+		if app.IsClosed() || app.Status == model.StatusAccepted {
+			continue
+		}
+
+		var folder, posting string
+		outdated := false
+		for _, loc := range appLocations(&app, baseDir) {
+			switch loc.Key {
+			case "folder":
+				folder = loc.Path
+			case "resume-typ", "cover-typ":
+				if !loc.Exists {
+					continue
+				}
+				meta, err := agent.ReadDocumentMetadata(loc.Path)
+				if err == nil && meta != nil && meta.PredatesCV(cvHash, info.ModTime()) {
+					outdated = true
+				}
+			case "posting":
+				if loc.Exists {
+					posting = loc.Path
+				}
+			}
+		}
+		if !outdated {
+			continue
+		}
+
+		// Fall back to the copy the tracker keeps with the documents
+		if copied := filepath.Join(folder, "posting.md"); posting == "" && fileExists(copied) {
+			posting = copied
+		}
+		stale = append(stale, staleApplication{App: app, Posting: posting})
+	}
+	return stale, nil
+}
+
+// regenerateSinceCVChange reruns document generation for applications whose
+// documents predate the current CV. The tracker entries are left as they
+// are; only the documents are rewritten.
+func regenerateSinceCVChange(s *store.Store, opts applyOptions, concurrency int) {
+	cvPath := draftCVPath()
+	if cvPath == "" {
+		fmt.Fprintf(os.Stderr, "Error: CV not found: %s\n", defaultCVPath)
+		os.Exit(1)
+	}
+
+	stale, err := applicationsPredatingCV(s.List(), "local/applications", cvPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if len(stale) == 0 {
+		fmt.Println("All documents are up to date with the CV.")
+		return
+	}
+
+	var postings []string
+	for _, sa := range stale {
+		if sa.Posting == "" {
+			fmt.Printf("Skipping [%s] %s @ %s (no posting file to regenerate from)\n",
+				shortID(sa.App.ID), sa.App.Position, sa.App.Company)
+			continue
+		}
+		postings = append(postings, sa.Posting)
+	}
+	if len(postings) == 0 {
+		return
+	}
+
+	fmt.Printf("Regenerating documents for %d application(s) generated from an older CV\n", len(postings))
+
+	// Without the store the tracker step is skipped, so no duplicate
+	// entries are created
+	opts.dryRun = true
+	progress := newBatchProgress(os.Stdout, len(postings), term.IsTerminal(os.Stdout.Fd()))
+	failed := runBatch(postings, concurrency, progress, func(postingPath string) (string, error) {
+		return applyQuiet(s, postingPath, opts, progress)
+	})
+	progress.summary()
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+// writeGeneratedResume writes a resume.typ with a metadata header into the
+// application's folder under baseDir
+func writeGeneratedResume(t *testing.T, baseDir string, app model.Application, generated, cvHash string) {
+	t.Helper()
+	folder := filepath.Join(baseDir, "swe", appBaseName(&app))
+	if err := os.MkdirAll(folder, 0755); err != nil {
+		t.Fatal(err)
+	}
+	header := "// Generated by ghosted on " + generated + " for " + app.Company + " - " + app.Position + "\n"
+	if cvHash != "" {
+		header += "// cv: " + cvHash + "\n"
+	}
+	if err := os.WriteFile(filepath.Join(folder, "resume.typ"), []byte(header+"= Resume\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(folder, "posting.md"), []byte("# "+app.Position+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestApplicationsPredatingCV(t *testing.T) {
+	dir := t.TempDir()
+	baseDir := filepath.Join(dir, "applications")
+	cvPath := filepath.Join(dir, "cv.json")
+	if err := os.WriteFile(cvPath, []byte(`{"basics":{"name":"Test"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// The CV was last edited on March 10
+	modified := time.Date(2026, 3, 10, 15, 0, 0, 0, time.Local)
+	if err := os.Chtimes(cvPath, modified, modified); err != nil {
+		t.Fatal(err)
+	}
+
+	before := model.Application{ID: "before", Company: "Acme", Position: "Engineer", Status: model.StatusApplied}
+	after := model.Application{ID: "after", Company: "Globex", Position: "Engineer", Status: model.StatusInterview}
+	closed := model.Application{ID: "closed", Company: "Initech", Position: "Engineer", Status: model.StatusRejected}
+	unheadered := model.Application{ID: "unheadered", Company: "Hooli", Position: "Engineer", Status: model.StatusApplied}
+	oldHash := model.Application{ID: "old-hash", Company: "Umbrella", Position: "Engineer", Status: model.StatusSaved}
+
+	writeGeneratedResume(t, baseDir, before, "2026-03-01", "")
+	writeGeneratedResume(t, baseDir, after, "2026-03-12", "")
+	writeGeneratedResume(t, baseDir, closed, "2026-03-01", "")
+	// Generated after the edit, but from a different CV version
+	writeGeneratedResume(t, baseDir, oldHash, "2026-03-20", "0123456789ab")
+
+	folder := filepath.Join(baseDir, "swe", appBaseName(&unheadered))
+	if err := os.MkdirAll(folder, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(folder, "resume.typ"), []byte("= Resume\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	stale, err := applicationsPredatingCV([]model.Application{before, after, closed, unheadered, oldHash}, baseDir, cvPath)
+	if err != nil {
+		t.Fatalf("applicationsPredatingCV() error = %v", err)
+	}
+
+	var ids []string
+	for _, sa := range stale {
+		ids = append(ids, sa.App.ID)
+		if filepath.Base(sa.Posting) != "posting.md" {
+			t.Errorf("%s: Posting = %q, want the copy in its folder", sa.App.ID, sa.Posting)
+		}
+	}
+	sort.Strings(ids)
+	if len(ids) != 2 || ids[0] != "before" || ids[1] != "old-hash" {
+		t.Errorf("stale applications = %v, want [before old-hash]", ids)
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"time"
+
+	"github.com/celloopa/ghosted/internal/agent"
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+// loadGhostedDays reads the ghosted threshold from the pipeline config,
+// falling back to model.DefaultGhostedDays
+func loadGhostedDays() int {
+	config, _ := agent.LoadConfig(pipelineConfigPath)
+	if config != nil && config.GhostedAfterDays > 0 {
+		return config.GhostedAfterDays
+	}
+	return model.DefaultGhostedDays
+}
+
+// stalePredicate matches applications ghosted for more than days as of now
+func stalePredicate(now time.Time, days int) func(model.Application) bool {
+	return func(app model.Application) bool {
+		return app.IsGhosted(now, days)
+	}
+}
+
+// andPredicates matches applications matching both a and b; either may be
+// nil to match everything
+func andPredicates(a, b func(model.Application) bool) func(model.Application) bool {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	return func(app model.Application) bool {
+		return a(app) && b(app)
+	}
+}
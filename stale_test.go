@@ -0,0 +1,46 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+func TestStalePredicate(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	applied := func(days int) *time.Time {
+		t := now.AddDate(0, 0, -days)
+		return &t
+	}
+	apps := []model.Application{
+		{Company: "Old", Status: model.StatusApplied, DateApplied: applied(20)},
+		{Company: "Fresh", Status: model.StatusApplied, DateApplied: applied(5)},
+		{Company: "Interviewed", Status: model.StatusApplied, DateApplied: applied(20), Interviews: []model.Interview{{Type: "phone"}}},
+		{Company: "Closed", Status: model.StatusRejected, DateApplied: applied(20)},
+		{Company: "Remote Old", Status: model.StatusScreening, DateApplied: applied(20), Remote: true},
+	}
+
+	names := func(apps []model.Application) []string {
+		var out []string
+		for _, a := range apps {
+			out = append(out, a.Company)
+		}
+		return out
+	}
+
+	got := names(filterQuery(apps, stalePredicate(now, 14)))
+	if len(got) != 2 || got[0] != "Old" || got[1] != "Remote Old" {
+		t.Errorf("stale after 14 days = %v, want [Old Remote Old]", got)
+	}
+	if got := filterQuery(apps, stalePredicate(now, 30)); len(got) != 0 {
+		t.Errorf("stale after 30 days = %v, want none", names(got))
+	}
+
+	// Composes with --query
+	remote := func(a model.Application) bool { return a.Remote }
+	got = names(filterQuery(apps, andPredicates(remote, stalePredicate(now, 14))))
+	if len(got) != 1 || got[0] != "Remote Old" {
+		t.Errorf("remote and stale = %v, want [Remote Old]", got)
+	}
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/model"
+	"github.com/celloopa/ghosted/internal/store"
+)
+
+const statsUsage = "Usage: ghosted stats [--weeks N] [--json | --export <dir> [--markdown]] | --stages"
+
+// defaultStatsWeeks is how many weeks of activity stats covers by default
+const defaultStatsWeeks = 8
+
+// statsReportLayout timestamps exported report filenames
+const statsReportLayout = "2006-01-02-150405"
+
+// maxStatsBar is the width of the longest bar in the weekly chart
+const maxStatsBar = 30
+
+// cmdStats prints tracker metrics and weekly activity, or with --export
+// writes them to a timestamped report so repeated runs build a history
+func cmdStats(s *store.Store, args []string) {
+	weeks := defaultStatsWeeks
+	exportDir := ""
+	markdown := false
+	stages := false
+	jsonOutput := false
+
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--weeks" && i+1 < len(args):
+			n, err := strconv.Atoi(args[i+1])
+			if err != nil || n < 1 {
+				fmt.Fprintf(os.Stderr, "Error: --weeks expects a positive number, got %q\n", args[i+1])
+				os.Exit(1)
+			}
+			weeks = n
+			i++
+		case args[i] == "--export" && i+1 < len(args):
+			exportDir = args[i+1]
+			i++
+		case args[i] == "--markdown":
+			markdown = true
+		case args[i] == "--stages":
+			stages = true
+		case args[i] == "--json":
+			jsonOutput = true
+		default:
+			fmt.Fprintln(os.Stderr, statsUsage)
+			os.Exit(1)
+		}
+	}
+	if markdown && exportDir == "" {
+		fmt.Fprintln(os.Stderr, "Error: --markdown requires --export")
+		os.Exit(1)
+	}
+
+	if jsonOutput && exportDir != "" {
+		fmt.Fprintln(os.Stderr, "Error: --json prints to stdout; --export already writes JSON")
+		os.Exit(1)
+	}
+
+	if stages {
+		if exportDir != "" || jsonOutput {
+			fmt.Fprintln(os.Stderr, "Error: --stages can't be combined with --export or --json")
+			os.Exit(1)
+		}
+		printStageAverages(os.Stdout, s.AverageStageDurations())
+		return
+	}
+
+	stats := s.Stats(time.Now(), weeks)
+
+	if jsonOutput {
+		data, err := json.MarshalIndent(stats, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(data))
+		return
+	}
+
+	if exportDir == "" {
+		printStats(os.Stdout, stats)
+		return
+	}
+
+	written, err := writeStatsReport(exportDir, stats, markdown)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	for _, path := range written {
+		fmt.Printf("Wrote %s\n", path)
+	}
+}
+
+// printStats writes the metrics followed by the weekly chart
+func printStats(w io.Writer, stats store.Stats) {
+	fmt.Fprintf(w, "Total: %d  Active: %d  Interviewing: %d  This week: %d\n",
+		stats.Total, stats.Active, stats.Interviewing, stats.ThisWeek)
+	fmt.Fprintf(w, "Response rate: %.1f%%  Ghost rate: %.1f%% (no response after %d days)\n",
+		stats.ResponseRate, stats.GhostRate, store.StatsGhostDays)
+	fmt.Fprintf(w, "Avg days to first interview: %s\n", firstResponseSummary(stats))
+	fmt.Fprintf(w, "Response time: %s\n", responseTimeSummary(stats))
+
+	fmt.Fprintln(w, "\nBy status:")
+	for _, status := range sortedStatuses(stats.ByStatus) {
+		fmt.Fprintf(w, "  %-10s %d\n", status, stats.ByStatus[status])
+	}
+
+	fmt.Fprintln(w, "\nApplied per week:")
+	fmt.Fprint(w, weeklyChart(stats.Weekly))
+}
+
+// responseTimeSummary describes how long companies take to respond, or
+// notes that no responses are logged yet
+func responseTimeSummary(stats store.Stats) string {
+	if stats.ResponseTimes == 0 {
+		return "— (no responses logged)"
+	}
+	return fmt.Sprintf("median %.1f days, average %.1f (%d response(s))", stats.MedianResponseDays, stats.AvgResponseDays, stats.ResponseTimes)
+}
+
+// firstResponseSummary describes the average time to a first interview,
+// or notes that no application has one yet
+func firstResponseSummary(stats store.Stats) string {
+	if stats.FirstResponses == 0 {
+		return "— (no interviews logged)"
+	}
+	return fmt.Sprintf("%.1f (%d application(s) with interviews)", stats.AvgDaysToFirstResponse, stats.FirstResponses)
+}
+
+// printStageAverages writes the average time applications spent in each
+// status before moving on
+func printStageAverages(w io.Writer, averages []store.StageAverage) {
+	if len(averages) == 0 {
+		fmt.Fprintln(w, "No status changes recorded yet.")
+		return
+	}
+	fmt.Fprintln(w, "Average time in stage:")
+	for _, avg := range averages {
+		fmt.Fprintf(w, "  %-10s avg %.1f days  (%d application(s))\n", avg.Status, avg.Average.Hours()/24, avg.Count)
+	}
+}
+
+// sortedStatuses returns the statuses present in counts, in pipeline order
+func sortedStatuses(counts map[string]int) []string {
+	var statuses []string
+	for _, status := range model.AllStatuses() {
+		if _, ok := counts[status]; ok {
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses
+}
+
+// weeklyChart renders weekly activity as a text bar chart, one line per week
+func weeklyChart(weekly []store.WeekActivity) string {
+	peak := 0
+	for _, week := range weekly {
+		peak = max(peak, week.Applied)
+	}
+
+	var sb strings.Builder
+	for _, week := range weekly {
+		bar := 0
+		if peak > 0 {
+			bar = (week.Applied*maxStatsBar + peak - 1) / peak
+		}
+		fmt.Fprintf(&sb, "  %s  %-*s %d\n", week.WeekStart.Format("Jan 02"), maxStatsBar, strings.Repeat("█", bar), week.Applied)
+	}
+	return sb.String()
+}
+
+// writeStatsReport writes stats as stats-<timestamp>.json in dir, plus a
+// markdown version if requested, and returns the paths written
+func writeStatsReport(dir string, stats store.Stats, markdown bool) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("creating %s: %w", dir, err)
+	}
+
+	base := filepath.Join(dir, "stats-"+stats.GeneratedAt.Format(statsReportLayout))
+	data, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(base+".json", data, 0644); err != nil {
+		return nil, fmt.Errorf("writing report: %w", err)
+	}
+	written := []string{base + ".json"}
+
+	if markdown {
+		if err := os.WriteFile(base+".md", []byte(statsMarkdown(stats)), 0644); err != nil {
+			return written, fmt.Errorf("writing markdown report: %w", err)
+		}
+		written = append(written, base+".md")
+	}
+	return written, nil
+}
+
+// statsMarkdown renders stats as a human-readable markdown report
+func statsMarkdown(stats store.Stats) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Application Stats: %s\n\n", stats.GeneratedAt.Format("2006-01-02"))
+	fmt.Fprintf(&sb, "- **Total:** %d\n- **Active:** %d\n- **Interviewing:** %d\n- **Applied this week:** %d\n\n",
+		stats.Total, stats.Active, stats.Interviewing, stats.ThisWeek)
+	fmt.Fprintf(&sb, "- **Response rate:** %.1f%%\n- **Ghost rate:** %.1f%% (no response after %d days)\n- **Avg days to first interview:** %s\n- **Response time:** %s\n\n",
+		stats.ResponseRate, stats.GhostRate, store.StatsGhostDays, firstResponseSummary(stats), responseTimeSummary(stats))
+
+	sb.WriteString("## By Status\n\n| Status | Count |\n|--------|-------|\n")
+	for _, status := range sortedStatuses(stats.ByStatus) {
+		fmt.Fprintf(&sb, "| %s | %d |\n", status, stats.ByStatus[status])
+	}
+
+	sb.WriteString("\n## Applied Per Week\n\n```\n")
+	sb.WriteString(weeklyChart(stats.Weekly))
+	sb.WriteString("```\n")
+	return sb.String()
+}
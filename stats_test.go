@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/store"
+)
+
+func TestWriteStatsReport(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "snapshots")
+	s, err := store.New(filepath.Join(t.TempDir(), "applications.json"))
+	if err != nil {
+		t.Fatalf("store.New() error = %v", err)
+	}
+	stats := s.Stats(time.Date(2026, 3, 11, 9, 5, 7, 0, time.UTC), 4)
+
+	written, err := writeStatsReport(dir, stats, true)
+	if err != nil {
+		t.Fatalf("writeStatsReport() error = %v", err)
+	}
+	if len(written) != 2 {
+		t.Fatalf("written = %v, want JSON and markdown", written)
+	}
+	if got, want := filepath.Base(written[0]), "stats-2026-03-11-090507.json"; got != want {
+		t.Errorf("report name = %q, want %q", got, want)
+	}
+	if !regexp.MustCompile(`^stats-\d{4}-\d{2}-\d{2}-\d{6}\.md$`).MatchString(filepath.Base(written[1])) {
+		t.Errorf("markdown name = %q, want stats-<timestamp>.md", filepath.Base(written[1]))
+	}
+
+	data, err := os.ReadFile(written[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("report is not JSON: %v", err)
+	}
+	for _, key := range []string{"generated_at", "total", "active", "interviewing", "this_week", "by_status", "weekly"} {
+		if _, ok := fields[key]; !ok {
+			t.Errorf("report missing %q", key)
+		}
+	}
+	var decoded store.Stats
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Total != stats.Total || len(decoded.Weekly) != 4 {
+		t.Errorf("decoded report = %+v, want total %d and 4 weeks", decoded, stats.Total)
+	}
+
+	md, err := os.ReadFile(written[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(md), "## Applied Per Week") || !strings.Contains(string(md), "Mar 09") {
+		t.Errorf("markdown missing the weekly chart:\n%s", md)
+	}
+}
+
+func TestWeeklyChart_ScalesToPeak(t *testing.T) {
+	monday := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	chart := weeklyChart([]store.WeekActivity{
+		{WeekStart: monday, Applied: 0},
+		{WeekStart: monday.AddDate(0, 0, 7), Applied: 2},
+		{WeekStart: monday.AddDate(0, 0, 14), Applied: 4},
+	})
+
+	lines := strings.Split(strings.TrimRight(chart, "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("chart has %d lines, want 3:\n%s", len(lines), chart)
+	}
+	for i, bars := range []int{0, maxStatsBar / 2, maxStatsBar} {
+		if got := strings.Count(lines[i], "█"); got != bars {
+			t.Errorf("line %d has %d bar cells, want %d: %q", i, got, bars, lines[i])
+		}
+	}
+}
+
+func TestPrintStageAverages(t *testing.T) {
+	var out strings.Builder
+	printStageAverages(&out, []store.StageAverage{
+		{Status: "screening", Average: 5 * 24 * time.Hour, Count: 3},
+	})
+	if !strings.Contains(out.String(), "screening  avg 5.0 days  (3 application(s))") {
+		t.Errorf("unexpected output:\n%s", out.String())
+	}
+
+	out.Reset()
+	printStageAverages(&out, nil)
+	if !strings.Contains(out.String(), "No status changes") {
+		t.Errorf("empty averages should say so, got %q", out.String())
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// stdinPosting is the posting argument that reads the posting from stdin
+const stdinPosting = "-"
+
+// stdinPostingsDir is where postings read from stdin are saved
+var stdinPostingsDir = filepath.Join("local", "postings")
+
+// maxStdinSlugLength caps the part of a stdin posting's filename taken from
+// its title
+const maxStdinSlugLength = 50
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// savePostingFromStdin writes posting text read from r to a new file in dir
+// and returns its path. The name comes from the posting's first line plus a
+// timestamp, e.g. "senior-backend-engineer-20260301-094500.md".
+func savePostingFromStdin(r io.Reader, dir string, now time.Time) (string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("reading stdin: %w", err)
+	}
+	content := strings.TrimSpace(string(data))
+	if content == "" {
+		return "", fmt.Errorf("no posting text on stdin")
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("creating %s: %w", dir, err)
+	}
+	path := filepath.Join(dir, stdinPostingName(content, now))
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return "", fmt.Errorf("saving posting: %w", err)
+	}
+	if _, err := f.WriteString(content + "\n"); err != nil {
+		f.Close()
+		return "", fmt.Errorf("saving posting: %w", err)
+	}
+	return path, f.Close()
+}
+
+// stdinPostingName derives a posting filename from the first non-empty line
+// of content, falling back to "stdin" when it has nothing usable
+func stdinPostingName(content string, now time.Time) string {
+	slug := ""
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(strings.TrimLeft(scanner.Text(), "# "))
+		if line == "" {
+			continue
+		}
+		slug = strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(line), "-"), "-")
+		break
+	}
+	if len(slug) > maxStdinSlugLength {
+		slug = strings.TrimRight(slug[:maxStdinSlugLength], "-")
+	}
+	if slug == "" {
+		slug = "stdin"
+	}
+	return fmt.Sprintf("%s-%s.md", slug, now.Format("20060102-150405"))
+}
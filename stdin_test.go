@@ -0,0 +1,73 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/celloopa/ghosted/internal/agent"
+	"github.com/celloopa/ghosted/internal/store"
+)
+
+func TestStdinPostingName(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 45, 0, 0, time.UTC)
+	cases := []struct {
+		content string
+		want    string
+	}{
+		{"# Senior Backend Engineer\n\nCompany: Acme", "senior-backend-engineer-20260301-094500.md"},
+		{"\n\n  Staff Engineer (Platform) @ Zephyr!\nmore", "staff-engineer-platform-zephyr-20260301-094500.md"},
+		{"###\n!!!", "stdin-20260301-094500.md"},
+		{strings.Repeat("very long title ", 10), "very-long-title-very-long-title-very-long-title-ve-20260301-094500.md"},
+	}
+	for _, tc := range cases {
+		if got := stdinPostingName(tc.content, now); got != tc.want {
+			t.Errorf("stdinPostingName(%q) = %q, want %q", tc.content, got, tc.want)
+		}
+	}
+}
+
+func TestSavePostingFromStdin_RunsPipeline(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	stdin := strings.NewReader("# Software Engineer\n\nCompany: Acme\n\n## Requirements\n\n- Go\n")
+	path, err := savePostingFromStdin(stdin, stdinPostingsDir, time.Date(2026, 3, 1, 9, 45, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("savePostingFromStdin() error = %v", err)
+	}
+	if want := filepath.Join("local", "postings", "software-engineer-20260301-094500.md"); path != want {
+		t.Errorf("path = %q, want %q", path, want)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil || !strings.Contains(string(content), "Company: Acme") {
+		t.Fatalf("saved posting = %q, %v", content, err)
+	}
+
+	s, err := store.New(filepath.Join(dir, "applications.json"))
+	if err != nil {
+		t.Fatalf("store.New() error = %v", err)
+	}
+	t.Setenv(agent.AnthropicAPIKeyEnv, "") // draft locally, never call the API
+	t.Setenv(agent.OpenAIAPIKeyEnv, "")
+	if err := applyPosting(s, path, applyOptions{}); err != nil {
+		t.Fatalf("applyPosting() error = %v", err)
+	}
+	archived := filepath.Join(stdinPostingsDir, "processed", filepath.Base(path))
+	apps := s.List()
+	if len(apps) != 1 || apps[0].Company != "Acme" || apps[0].PostingPath != archived {
+		t.Errorf("tracked %+v, want one Acme application from %s", apps, archived)
+	}
+}
+
+func TestSavePostingFromStdin_Empty(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := savePostingFromStdin(strings.NewReader(" \n\t\n"), dir, time.Now()); err == nil {
+		t.Error("savePostingFromStdin() with empty input should error")
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("empty stdin wrote %d files", len(entries))
+	}
+}
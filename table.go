@@ -0,0 +1,118 @@
+package main
+
+import (
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/mattn/go-runewidth"
+)
+
+// minColumnWidth is the narrowest a column is shrunk to when fitting a table
+const minColumnWidth = 3
+
+// renderBoxTable renders rows in a bordered box-drawing table.
+// Column widths follow the widest cell (by display width, so wide CJK
+// characters count as two cells). If maxWidth > 0 and the table would be
+// wider, the widest columns are shrunk and their cells truncated with "…".
+func renderBoxTable(headers []string, rows [][]string, maxWidth int) string {
+	cols := len(headers)
+	for _, row := range rows {
+		if len(row) > cols {
+			cols = len(row)
+		}
+	}
+	if cols == 0 {
+		return ""
+	}
+
+	// Natural width of each column
+	widths := make([]int, cols)
+	measure := func(cells []string) {
+		for i, cell := range cells {
+			if w := runewidth.StringWidth(cell); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+	measure(headers)
+	for _, row := range rows {
+		measure(row)
+	}
+
+	// Shrink the widest column one cell at a time until the table fits.
+	// Each column costs its width plus "│ " and " ", and the row ends with "│".
+	if maxWidth > 0 {
+		for tableWidth(widths) > maxWidth {
+			widest := 0
+			for i, w := range widths {
+				if w > widths[widest] {
+					widest = i
+				}
+			}
+			if widths[widest] <= minColumnWidth {
+				break
+			}
+			widths[widest]--
+		}
+	}
+
+	var sb strings.Builder
+	writeBorder := func(left, mid, right string) {
+		sb.WriteString(left)
+		for i, w := range widths {
+			if i > 0 {
+				sb.WriteString(mid)
+			}
+			sb.WriteString(strings.Repeat("─", w+2))
+		}
+		sb.WriteString(right)
+		sb.WriteString("\n")
+	}
+	writeRow := func(cells []string) {
+		sb.WriteString("│")
+		for i, w := range widths {
+			cell := ""
+			if i < len(cells) {
+				cell = cells[i]
+			}
+			cell = runewidth.Truncate(cell, w, "…")
+			sb.WriteString(" ")
+			sb.WriteString(runewidth.FillRight(cell, w))
+			sb.WriteString(" │")
+		}
+		sb.WriteString("\n")
+	}
+
+	writeBorder("┌", "┬", "┐")
+	writeRow(headers)
+	writeBorder("├", "┼", "┤")
+	for _, row := range rows {
+		writeRow(row)
+	}
+	writeBorder("└", "┴", "┘")
+
+	return sb.String()
+}
+
+// tableWidth returns the rendered display width of a box table row
+func tableWidth(widths []int) int {
+	total := 1
+	for _, w := range widths {
+		total += w + 3
+	}
+	return total
+}
+
+// terminalWidth returns the width of stdout, or 0 when it is not a terminal
+func terminalWidth() int {
+	fd := os.Stdout.Fd()
+	if !term.IsTerminal(fd) {
+		return 0
+	}
+	width, _, err := term.GetSize(fd)
+	if err != nil {
+		return 0
+	}
+	return width
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mattn/go-runewidth"
+)
+
+func TestRenderBoxTable_Borders(t *testing.T) {
+	out := renderBoxTable([]string{"A", "B"}, [][]string{{"1", "2"}}, 0)
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+
+	want := []string{
+		"┌───┬───┐",
+		"│ A │ B │",
+		"├───┼───┤",
+		"│ 1 │ 2 │",
+		"└───┴───┘",
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("renderBoxTable() produced %d lines, want %d:\n%s", len(lines), len(want), out)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d = %q, want %q", i, lines[i], want[i])
+		}
+	}
+}
+
+func TestRenderBoxTable_Alignment(t *testing.T) {
+	headers := []string{"Company", "Status"}
+	rows := [][]string{
+		{"Acme", "Applied"},
+		{"A Much Longer Company Name", "Offer"},
+		{"株式会社テスト", "Interview"},
+	}
+
+	out := renderBoxTable(headers, rows, 0)
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+
+	// Every line must have the same display width
+	width := runewidth.StringWidth(lines[0])
+	for i, line := range lines {
+		if got := runewidth.StringWidth(line); got != width {
+			t.Errorf("line %d width = %d, want %d: %q", i, got, width, line)
+		}
+	}
+
+	// Column separators must line up across rows
+	sepCol := -1
+	for i, line := range lines {
+		if i == 0 || i == 2 || i == len(lines)-1 {
+			continue // border lines use ┬/┼/┴
+		}
+		inner := strings.TrimPrefix(line, "│")
+		col := runewidth.StringWidth(inner[:strings.Index(inner, "│")])
+		if sepCol == -1 {
+			sepCol = col
+		} else if col != sepCol {
+			t.Errorf("line %d separator at column %d, want %d: %q", i, col, sepCol, line)
+		}
+	}
+
+	if !strings.Contains(out, "│ 株式会社テスト             │") {
+		t.Errorf("CJK cell not padded to column width:\n%s", out)
+	}
+}
+
+func TestRenderBoxTable_TruncatesToMaxWidth(t *testing.T) {
+	headers := []string{"ID", "Position"}
+	rows := [][]string{
+		{"abc", "Senior Staff Principal Software Engineer"},
+		{"def", "ソフトウェアエンジニア（バックエンド）"},
+	}
+
+	out := renderBoxTable(headers, rows, 30)
+	for i, line := range strings.Split(strings.TrimSuffix(out, "\n"), "\n") {
+		if w := runewidth.StringWidth(line); w > 30 {
+			t.Errorf("line %d width = %d, exceeds max 30: %q", i, w, line)
+		}
+	}
+	if !strings.Contains(out, "…") {
+		t.Errorf("expected truncated cells to end with an ellipsis:\n%s", out)
+	}
+	if !strings.Contains(out, "│ abc │") {
+		t.Errorf("narrow column should not be shrunk:\n%s", out)
+	}
+}
+
+func TestRenderBoxTable_ShortRows(t *testing.T) {
+	out := renderBoxTable([]string{"A", "B", "C"}, [][]string{{"1"}}, 0)
+	if !strings.Contains(out, "│ 1 │   │   │") {
+		t.Errorf("missing cells should render blank:\n%s", out)
+	}
+}
+
+func TestRenderBoxTable_Empty(t *testing.T) {
+	if out := renderBoxTable(nil, nil, 80); out != "" {
+		t.Errorf("renderBoxTable() with no columns = %q, want empty", out)
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/celloopa/ghosted/internal/model"
+	"github.com/celloopa/ghosted/internal/store"
+)
+
+// getUndoPath returns the undo log, stored next to the data file
+func getUndoPath() string {
+	return filepath.Join(filepath.Dir(getDataPath()), "undo.log")
+}
+
+// cmdUndo reverses the most recent change, or lists the undo stack with --list
+func cmdUndo(s *store.Store, args []string) {
+	for _, arg := range args {
+		switch arg {
+		case "--list":
+			listUndo(store.NewUndoLog(getUndoPath(), store.DefaultUndoDepth))
+			return
+		default:
+			fmt.Fprintln(os.Stderr, "Usage: ghosted undo [--list]")
+			os.Exit(1)
+		}
+	}
+
+	entry, err := s.Undo()
+	if err != nil {
+		if errors.Is(err, store.ErrNothingToUndo) {
+			fmt.Println("Nothing to undo.")
+			return
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Undid %s\n", describeUndo(*entry))
+}
+
+// listUndo prints the undo stack, most recent first
+func listUndo(log *store.UndoLog) {
+	entries, err := log.Entries()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading undo log: %v\n", err)
+		os.Exit(1)
+	}
+	if len(entries) == 0 {
+		fmt.Println("Nothing to undo.")
+		return
+	}
+
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		fmt.Printf("%2d. %s  %s\n", len(entries)-i, e.Time.Local().Format("2006-01-02 15:04:05"), describeUndo(e))
+	}
+}
+
+// describeUndo summarizes the change an undo entry reverses
+func describeUndo(e store.UndoEntry) string {
+	target := fmt.Sprintf("%s - %s (%s)", e.App.Company, e.App.Position, shortID(e.App.ID))
+	switch e.Op {
+	case store.UndoAdd:
+		return "add of " + target
+	case store.UndoUpdate:
+		return fmt.Sprintf("update of %s (restores status %s)", target, model.StatusLabel(e.App.Status))
+	case store.UndoDelete:
+		return "delete of " + target
+	default:
+		return e.Op + " of " + target
+	}
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/celloopa/ghosted/internal/store"
+)
+
+// cmdVacuum backs up the data file, then normalizes and rewrites it
+func cmdVacuum(s *store.Store, args []string) {
+	if len(args) > 0 {
+		fmt.Fprintln(os.Stderr, "Usage: ghosted vacuum")
+		os.Exit(1)
+	}
+
+	backup, err := s.Backup()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Backup created: %s\n", backup)
+
+	changed, err := s.Vacuum()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Normalized %d of %d application(s)\n", changed, s.Total())
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime/debug"
+)
+
+// Build metadata, injected at build time (see the Makefile):
+//
+//	go build -ldflags "-X main.Version=v1.4.0 -X main.Commit=abc1234 -X main.BuildDate=2026-01-02T15:04:05Z"
+var (
+	Version   string
+	Commit    string
+	BuildDate string
+)
+
+// buildInfo is the version, commit, and build date of the running binary
+type buildInfo struct {
+	Version   string
+	Commit    string
+	BuildDate string
+}
+
+// currentBuild returns the injected build metadata. Anything not injected
+// comes from the module version and VCS stamp Go records (set for
+// `go install ...@latest`), and otherwise defaults to "dev"/"unknown".
+func currentBuild() buildInfo {
+	info := buildInfo{Version: Version, Commit: Commit, BuildDate: BuildDate}
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		if info.Version == "" && bi.Main.Version != "(devel)" {
+			info.Version = bi.Main.Version
+		}
+		for _, setting := range bi.Settings {
+			switch {
+			case setting.Key == "vcs.revision" && info.Commit == "":
+				info.Commit = setting.Value
+			case setting.Key == "vcs.time" && info.BuildDate == "":
+				info.BuildDate = setting.Value
+			}
+		}
+	}
+	return info.withDefaults()
+}
+
+// withDefaults fills in "dev" and "unknown" for unset fields
+func (b buildInfo) withDefaults() buildInfo {
+	if b.Version == "" {
+		b.Version = "dev"
+	}
+	if b.Commit == "" {
+		b.Commit = "unknown"
+	}
+	if len(b.Commit) > 12 {
+		b.Commit = b.Commit[:12]
+	}
+	if b.BuildDate == "" {
+		b.BuildDate = "unknown"
+	}
+	return b
+}
+
+// printVersion writes the version line, or just the version with short
+func printVersion(w io.Writer, b buildInfo, short bool) {
+	if short {
+		fmt.Fprintln(w, b.Version)
+		return
+	}
+	fmt.Fprintf(w, "ghosted %s (commit %s, built %s)\n", b.Version, b.Commit, b.BuildDate)
+}
+
+// cmdVersion prints the build metadata
+func cmdVersion(args []string) {
+	short := false
+	for _, arg := range args {
+		switch arg {
+		case "--short":
+			short = true
+		default:
+			fmt.Fprintln(os.Stderr, "Usage: ghosted version [--short]")
+			os.Exit(1)
+		}
+	}
+	printVersion(os.Stdout, currentBuild(), short)
+}
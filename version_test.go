@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func setBuildVars(t *testing.T, version, commit, date string) {
+	t.Helper()
+	oldVersion, oldCommit, oldDate := Version, Commit, BuildDate
+	t.Cleanup(func() { Version, Commit, BuildDate = oldVersion, oldCommit, oldDate })
+	Version, Commit, BuildDate = version, commit, date
+}
+
+func TestPrintVersion_Defaults(t *testing.T) {
+	setBuildVars(t, "", "", "")
+
+	var buf bytes.Buffer
+	printVersion(&buf, currentBuild(), false)
+
+	want := "ghosted dev (commit unknown, built unknown)\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestPrintVersion_Injected(t *testing.T) {
+	setBuildVars(t, "v1.4.0", "abc1234", "2026-01-02T15:04:05Z")
+
+	var buf bytes.Buffer
+	printVersion(&buf, currentBuild(), false)
+
+	want := "ghosted v1.4.0 (commit abc1234, built 2026-01-02T15:04:05Z)\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	printVersion(&buf, currentBuild(), true)
+	if buf.String() != "v1.4.0\n" {
+		t.Errorf("short: got %q, want %q", buf.String(), "v1.4.0\n")
+	}
+}
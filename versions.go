@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	"github.com/celloopa/ghosted/internal/model"
+	"github.com/celloopa/ghosted/internal/store"
+)
+
+const versionsUsage = "Usage: ghosted versions <id>"
+
+// cmdVersions lists an application's archived resume versions and the
+// current resume
+func cmdVersions(s *store.Store, args []string) {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, versionsUsage)
+		os.Exit(1)
+	}
+
+	app := findAppByID(s, args[0])
+	if app == nil {
+		fmt.Fprintf(os.Stderr, "Error: application not found: %s\n", args[0])
+		os.Exit(1)
+	}
+
+	fmt.Printf("%s - %s\n", app.Company, app.Position)
+	if len(app.ResumeVersions) == 0 && app.ResumeVersion == "" {
+		fmt.Println("  No resume versions recorded. Run 'ghosted compile <id>' to create one.")
+		return
+	}
+	for i, path := range app.ResumeVersions {
+		fmt.Printf("  v%d  %s%s\n", i+1, path, missingMarker(path))
+	}
+	if app.ResumeVersion != "" {
+		fmt.Printf("  current  %s%s\n", app.ResumeVersion, missingMarker(app.ResumeVersion))
+	}
+}
+
+// missingMarker flags a recorded version whose file is gone
+func missingMarker(path string) string {
+	if fileExists(path) {
+		return ""
+	}
+	return "  (missing)"
+}
+
+// archiveResume keeps the resume.pdf in dir before it is recompiled, by
+// renaming it to the next {base}-resume-vN.pdf. It returns the archived
+// path, or "" if there was no previous PDF.
+func archiveResume(dir, base string) (string, error) {
+	current := filepath.Join(dir, "resume.pdf")
+	if !fileExists(current) {
+		return "", nil
+	}
+
+	version, err := nextResumeVersion(dir, base)
+	if err != nil {
+		return "", err
+	}
+	archived := filepath.Join(dir, fmt.Sprintf("%s-resume-v%d.pdf", base, version))
+	if err := os.Rename(current, archived); err != nil {
+		return "", fmt.Errorf("archiving previous resume: %w", err)
+	}
+	return archived, nil
+}
+
+// nextResumeVersion returns one more than the highest {base}-resume-vN.pdf
+// in dir, so numbering continues even if older versions were deleted
+func nextResumeVersion(dir, base string) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, err
+	}
+	versionRe := regexp.MustCompile(`^` + regexp.QuoteMeta(base) + `-resume-v(\d+)\.pdf$`)
+	highest := 0
+	for _, entry := range entries {
+		if m := versionRe.FindStringSubmatch(entry.Name()); m != nil {
+			if n, err := strconv.Atoi(m[1]); err == nil {
+				highest = max(highest, n)
+			}
+		}
+	}
+	return highest + 1, nil
+}
+
+// versionResume archives the resume.pdf in an application's folder ahead
+// of a recompile and records the archived copy on app, when there is one
+func versionResume(app *model.Application, dir string) (string, error) {
+	base := filepath.Base(filepath.Clean(dir))
+	if app != nil {
+		base = appBaseName(app)
+	}
+	archived, err := archiveResume(dir, base)
+	if err != nil || archived == "" {
+		return "", err
+	}
+	if app != nil {
+		app.ResumeVersions = append(app.ResumeVersions, archived)
+	}
+	return archived, nil
+}
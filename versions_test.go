@@ -0,0 +1,95 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+// fakeCompile stands in for typst, writing resume.pdf with the given content
+func fakeCompile(t *testing.T, dir, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, "resume.pdf"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVersionResume(t *testing.T) {
+	dir := t.TempDir()
+	app := &model.Application{Company: "Acme", Position: "Engineer"}
+	base := appBaseName(app)
+
+	// The first compile has nothing to keep
+	archived, err := versionResume(app, dir)
+	if err != nil || archived != "" {
+		t.Fatalf("versionResume() with no PDF = %q, %v", archived, err)
+	}
+	fakeCompile(t, dir, "first")
+
+	archived, err = versionResume(app, dir)
+	if err != nil {
+		t.Fatalf("versionResume() error = %v", err)
+	}
+	want := filepath.Join(dir, base+"-resume-v1.pdf")
+	if archived != want {
+		t.Errorf("archived = %q, want %q", archived, want)
+	}
+	if data, _ := os.ReadFile(want); string(data) != "first" {
+		t.Errorf("v1 content = %q, want the first compile", data)
+	}
+	if fileExists(filepath.Join(dir, "resume.pdf")) {
+		t.Error("resume.pdf should be moved aside for the recompile")
+	}
+	fakeCompile(t, dir, "second")
+
+	archived, err = versionResume(app, dir)
+	if err != nil {
+		t.Fatalf("versionResume() error = %v", err)
+	}
+	if want := filepath.Join(dir, base+"-resume-v2.pdf"); archived != want {
+		t.Errorf("archived = %q, want %q", archived, want)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, base+"-resume-v2.pdf")); string(data) != "second" {
+		t.Errorf("v2 content = %q, want the second compile", data)
+	}
+
+	want2 := []string{filepath.Join(dir, base+"-resume-v1.pdf"), filepath.Join(dir, base+"-resume-v2.pdf")}
+	if len(app.ResumeVersions) != 2 || app.ResumeVersions[0] != want2[0] || app.ResumeVersions[1] != want2[1] {
+		t.Errorf("ResumeVersions = %v, want %v", app.ResumeVersions, want2)
+	}
+}
+
+func TestNextResumeVersion_ContinuesFromHighest(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"acme-engineer-resume-v1.pdf", "acme-engineer-resume-v4.pdf", "other-resume-v9.pdf"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := nextResumeVersion(dir, "acme-engineer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != 5 {
+		t.Errorf("nextResumeVersion() = %d, want 5", got)
+	}
+}
+
+func TestVersionResume_WithoutTrackedApp(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "acme-engineer")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	fakeCompile(t, dir, "old")
+
+	// Compiling a folder with no matching application still keeps the PDF
+	archived, err := versionResume(nil, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(dir, "acme-engineer-resume-v1.pdf"); archived != want {
+		t.Errorf("archived = %q, want %q", archived, want)
+	}
+}
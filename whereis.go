@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/celloopa/ghosted/internal/model"
+	"github.com/celloopa/ghosted/internal/store"
+)
+
+const whereisUsage = "Usage: ghosted whereis <id> [--folder|--resume|--resume-typ|--cover|--cover-typ|--posting]"
+
+// appLocation is a file or folder belonging to an application
+type appLocation struct {
+	Key    string // Flag name without dashes, e.g. "resume"
+	Label  string
+	Path   string // Absolute path
+	Exists bool
+}
+
+// cmdWhereis prints where an application's documents and posting live.
+// With a field flag only that path is printed, for use in scripts.
+func cmdWhereis(s *store.Store, args []string) {
+	var id, field string
+	for _, arg := range args {
+		switch arg {
+		case "--folder", "--resume", "--resume-typ", "--cover", "--cover-typ", "--posting":
+			field = arg[2:]
+		default:
+			if id != "" || isFlag(arg) {
+				fmt.Fprintln(os.Stderr, whereisUsage)
+				os.Exit(1)
+			}
+			id = arg
+		}
+	}
+	if id == "" {
+		fmt.Fprintln(os.Stderr, whereisUsage)
+		os.Exit(1)
+	}
+
+	app := findAppByID(s, id)
+	if app == nil {
+		fmt.Fprintf(os.Stderr, "Application not found: %s\n", id)
+		os.Exit(1)
+	}
+
+	locations := appLocations(app, "local/applications")
+
+	if field == "" {
+		fmt.Printf("%s @ %s [%s]\n", app.Position, app.Company, shortID(app.ID))
+		printLocations(os.Stdout, locations)
+		return
+	}
+
+	for _, loc := range locations {
+		if loc.Key != field {
+			continue
+		}
+		if !loc.Exists {
+			fmt.Fprintf(os.Stderr, "Error: %s not found (expected %s)\n", loc.Label, loc.Path)
+			os.Exit(1)
+		}
+		fmt.Println(loc.Path)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Error: no %s linked to this application\n", field)
+	os.Exit(1)
+}
+
+// appLocations lists an application's folder, documents, and posting as
+// absolute paths. Documents recorded on the application are preferred; the
+// rest are the conventional names inside its folder under baseDir. The
+// posting is only listed when linked.
+func appLocations(app *model.Application, baseDir string) []appLocation {
+	folder := findAppFolderIn(baseDir, app)
+	if folder == "" {
+		// Where the folder would be; reported as missing
+		jobType := app.JobType
+		if jobType == "" {
+			jobType = model.InferJobType(app.Position)
+		}
+		folder = filepath.Join(baseDir, jobType, appBaseName(app))
+	}
+
+	resumePDF := existingPDF(app.ResumeVersion, folder, "resume.pdf")
+	if resumePDF == "" {
+		resumePDF = filepath.Join(folder, "resume.pdf")
+	}
+	coverPDF := existingPDF(app.CoverLetter, folder, "cover-letter.pdf")
+	if coverPDF == "" {
+		coverPDF = filepath.Join(folder, "cover-letter.pdf")
+	}
+
+	locations := []appLocation{
+		{Key: "folder", Label: "Folder", Path: folder},
+		{Key: "resume", Label: "Resume PDF", Path: resumePDF},
+		{Key: "resume-typ", Label: "Resume Typst", Path: filepath.Join(folder, "resume.typ")},
+		{Key: "cover", Label: "Cover PDF", Path: coverPDF},
+		{Key: "cover-typ", Label: "Cover Typst", Path: filepath.Join(folder, "cover-letter.typ")},
+	}
+	if app.PostingPath != "" {
+		locations = append(locations, appLocation{Key: "posting", Label: "Posting", Path: app.PostingPath})
+	}
+
+	for i := range locations {
+		loc := &locations[i]
+		if abs, err := filepath.Abs(loc.Path); err == nil {
+			loc.Path = abs
+		}
+		_, err := os.Stat(loc.Path)
+		loc.Exists = err == nil
+	}
+	return locations
+}
+
+// printLocations writes one aligned line per location, marking missing ones
+func printLocations(w io.Writer, locations []appLocation) {
+	for _, loc := range locations {
+		marker := ""
+		if !loc.Exists {
+			marker = "  (missing)"
+		}
+		fmt.Fprintf(w, "  %-13s %s%s\n", loc.Label+":", loc.Path, marker)
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/celloopa/ghosted/internal/model"
+)
+
+func TestAppLocations_AllPresent(t *testing.T) {
+	base := t.TempDir()
+	folder := filepath.Join(base, "swe", "acme-software-engineer")
+	if err := os.MkdirAll(folder, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"resume.pdf", "resume.typ", "cover-letter.pdf", "cover-letter.typ"} {
+		if err := os.WriteFile(filepath.Join(folder, name), []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	posting := filepath.Join(base, "acme-swe.md")
+	if err := os.WriteFile(posting, []byte("# Software Engineer"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	app := &model.Application{ID: "abc12345", Company: "Acme", Position: "Software Engineer", PostingPath: posting}
+	locations := appLocations(app, base)
+
+	want := map[string]string{
+		"folder":     folder,
+		"resume":     filepath.Join(folder, "resume.pdf"),
+		"resume-typ": filepath.Join(folder, "resume.typ"),
+		"cover":      filepath.Join(folder, "cover-letter.pdf"),
+		"cover-typ":  filepath.Join(folder, "cover-letter.typ"),
+		"posting":    posting,
+	}
+	if len(locations) != len(want) {
+		t.Fatalf("got %d locations, want %d", len(locations), len(want))
+	}
+	for _, loc := range locations {
+		if !filepath.IsAbs(loc.Path) {
+			t.Errorf("%s path %q is not absolute", loc.Key, loc.Path)
+		}
+		if loc.Path != want[loc.Key] {
+			t.Errorf("%s = %q, want %q", loc.Key, loc.Path, want[loc.Key])
+		}
+		if !loc.Exists {
+			t.Errorf("%s reported missing", loc.Key)
+		}
+	}
+
+	var out bytes.Buffer
+	printLocations(&out, locations)
+	if strings.Contains(out.String(), "(missing)") {
+		t.Errorf("output marks files missing:\n%s", out.String())
+	}
+}
+
+func TestAppLocations_MissingMarkers(t *testing.T) {
+	base := t.TempDir()
+	folder := filepath.Join(base, "fe-dev", "globex-frontend-engineer")
+	if err := os.MkdirAll(folder, 0755); err != nil {
+		t.Fatal(err)
+	}
+	// Only the resume source exists; nothing has been compiled
+	if err := os.WriteFile(filepath.Join(folder, "resume.typ"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	app := &model.Application{ID: "def67890", Company: "Globex", Position: "Frontend Engineer"}
+	locations := appLocations(app, base)
+
+	exists := make(map[string]bool)
+	for _, loc := range locations {
+		exists[loc.Key] = loc.Exists
+	}
+	if _, ok := exists["posting"]; ok {
+		t.Error("posting listed for an application without one")
+	}
+	if !exists["folder"] || !exists["resume-typ"] {
+		t.Errorf("folder and resume.typ should exist: %v", exists)
+	}
+	for _, key := range []string{"resume", "cover", "cover-typ"} {
+		if exists[key] {
+			t.Errorf("%s reported present", key)
+		}
+	}
+
+	var out bytes.Buffer
+	printLocations(&out, locations)
+	if got := strings.Count(out.String(), "(missing)"); got != 3 {
+		t.Errorf("got %d missing markers, want 3:\n%s", got, out.String())
+	}
+}
+
+func TestAppLocations_NoFolder(t *testing.T) {
+	base := t.TempDir()
+	app := &model.Application{ID: "fed09876", Company: "Initech", Position: "Product Designer"}
+
+	locations := appLocations(app, base)
+	want := filepath.Join(base, model.JobTypeProductDesign, "initech-product-designer")
+	if locations[0].Key != "folder" || locations[0].Path != want {
+		t.Errorf("folder = %q, want expected location %q", locations[0].Path, want)
+	}
+	if locations[0].Exists {
+		t.Error("missing folder reported present")
+	}
+}